@@ -0,0 +1,87 @@
+package httpclient
+
+import "time"
+
+// AdaptiveTimeoutConfig configures per-endpoint timeouts derived from
+// observed latency.
+//
+// The timeout is Multiplier times the Percentile latency of the endpoint's
+// recent successful requests, clamped to [Min, Max]. Until the tracker has
+// enough samples, Max is used.
+//
+// Example usage:
+//
+//	resp, err := client.Request("GetUser").
+//	    AdaptiveTimeout(httpclient.DefaultAdaptiveTimeoutConfig()).
+//	    Get(ctx, "/users/123")
+//
+// Requests cut off by the timeout are not recorded, so a Multiplier well
+// above 1 leaves room for the latency distribution to grow.
+type AdaptiveTimeoutConfig struct {
+	// Percentile is the latency percentile the timeout is based on (0-1).
+	//
+	// Default: 0.99 (P99)
+	Percentile float64
+
+	// Multiplier scales the percentile latency.
+	//
+	// Default: 2
+	Multiplier float64
+
+	// Min is the lower bound of the timeout. Zero means no lower bound.
+	Min time.Duration
+
+	// Max is the upper bound of the timeout, and the timeout used until
+	// enough samples exist. Zero means no upper bound and no timeout
+	// until enough samples exist.
+	Max time.Duration
+
+	// Tracker is the latency tracker to use. If nil, uses DefaultLatencyTracker().
+	Tracker *LatencyTracker
+}
+
+// DefaultAdaptiveTimeoutConfig returns reasonable defaults for adaptive
+// timeouts: twice the P99 latency, between 100ms and 30s.
+func DefaultAdaptiveTimeoutConfig() AdaptiveTimeoutConfig {
+	return AdaptiveTimeoutConfig{
+		Percentile: 0.99,
+		Multiplier: 2,
+		Min:        100 * time.Millisecond,
+		Max:        30 * time.Second,
+	}
+}
+
+// GetTracker returns the configured tracker or the default.
+func (c AdaptiveTimeoutConfig) GetTracker() *LatencyTracker {
+	if c.Tracker != nil {
+		return c.Tracker
+	}
+	return DefaultLatencyTracker()
+}
+
+// GetTimeout calculates the timeout for an endpoint. It returns Max if not
+// enough samples exist, and 0 for no timeout.
+func (c AdaptiveTimeoutConfig) GetTimeout(endpoint string) time.Duration {
+	percentile := c.Percentile
+	if percentile <= 0 {
+		percentile = 0.99
+	}
+	multiplier := c.Multiplier
+	if multiplier <= 0 {
+		multiplier = 2
+	}
+
+	latency, ok := c.GetTracker().Percentile(endpoint, percentile)
+	if !ok {
+		return c.Max
+	}
+
+	timeout := time.Duration(float64(latency) * multiplier)
+	if c.Min > 0 && timeout < c.Min {
+		timeout = c.Min
+	}
+	if c.Max > 0 && timeout > c.Max {
+		timeout = c.Max
+	}
+	return timeout
+}
@@ -0,0 +1,128 @@
+package httpclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdaptiveTimeoutConfig_GetTimeout(t *testing.T) {
+	tests := []struct {
+		name      string
+		cfg       AdaptiveTimeoutConfig
+		latencies []time.Duration
+		want      time.Duration
+	}{
+		{
+			name:      "given too few samples, then returns Max",
+			cfg:       AdaptiveTimeoutConfig{Percentile: 0.5, Multiplier: 2, Max: time.Second},
+			latencies: []time.Duration{10 * time.Millisecond},
+			want:      time.Second,
+		},
+		{
+			name: "given enough samples, then returns multiplier times percentile",
+			cfg:  AdaptiveTimeoutConfig{Percentile: 0.5, Multiplier: 3, Max: time.Second},
+			latencies: []time.Duration{
+				10 * time.Millisecond, 20 * time.Millisecond, 30 * time.Millisecond,
+			},
+			want: 60 * time.Millisecond,
+		},
+		{
+			name: "given timeout below Min, then returns Min",
+			cfg: AdaptiveTimeoutConfig{
+				Percentile: 0.5,
+				Multiplier: 2,
+				Min:        100 * time.Millisecond,
+				Max:        time.Second,
+			},
+			latencies: []time.Duration{
+				10 * time.Millisecond, 20 * time.Millisecond, 30 * time.Millisecond,
+			},
+			want: 100 * time.Millisecond,
+		},
+		{
+			name:      "given timeout above Max, then returns Max",
+			cfg:       AdaptiveTimeoutConfig{Percentile: 0.5, Multiplier: 2, Max: time.Second},
+			latencies: []time.Duration{time.Second, time.Second, time.Second},
+			want:      time.Second,
+		},
+		{
+			name: "given zero percentile and multiplier, then uses defaults",
+			cfg:  AdaptiveTimeoutConfig{},
+			latencies: []time.Duration{
+				10 * time.Millisecond, 10 * time.Millisecond, 10 * time.Millisecond,
+			},
+			want: 20 * time.Millisecond,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracker := NewLatencyTracker(100, 3)
+			for _, latency := range tt.latencies {
+				tracker.Record("GetUser", latency)
+			}
+			tt.cfg.Tracker = tracker
+
+			assert.Equal(t, tt.want, tt.cfg.GetTimeout("GetUser"))
+		})
+	}
+}
+
+func TestRequestBuilder_AdaptiveTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow" {
+			time.Sleep(200 * time.Millisecond)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := New(WithBaseURL(server.URL))
+
+	t.Run("given no samples, then uses Max and records latency", func(t *testing.T) {
+		tracker := NewLatencyTracker(100, 3)
+		cfg := AdaptiveTimeoutConfig{Max: 2 * time.Second, Tracker: tracker}
+
+		resp, err := client.Request("Slow").AdaptiveTimeout(cfg).Get(context.Background(), "/slow")
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, 1, tracker.Count("Slow"))
+	})
+
+	t.Run("given fast history, then times out slow request", func(t *testing.T) {
+		tracker := NewLatencyTracker(100, 3)
+		for range 3 {
+			tracker.Record("Slow", time.Millisecond)
+		}
+		cfg := AdaptiveTimeoutConfig{
+			Multiplier: 2,
+			Min:        20 * time.Millisecond,
+			Max:        2 * time.Second,
+			Tracker:    tracker,
+		}
+
+		_, err := client.Request("Slow").AdaptiveTimeout(cfg).Get(context.Background(), "/slow")
+		require.Error(t, err)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Equal(t, 3, tracker.Count("Slow"))
+	})
+
+	t.Run("given shared tracker with adaptive hedge, then records once", func(t *testing.T) {
+		tracker := NewLatencyTracker(100, 3)
+		hedge := DefaultAdaptiveHedgeConfig()
+		hedge.Tracker = tracker
+
+		_, err := client.Request("Fast").
+			AdaptiveHedge(hedge).
+			AdaptiveTimeout(AdaptiveTimeoutConfig{Max: time.Second, Tracker: tracker}).
+			Get(context.Background(), "/fast")
+		require.NoError(t, err)
+		assert.Equal(t, 1, tracker.Count("Fast"))
+	})
+}
@@ -0,0 +1,67 @@
+package httpclient
+
+import (
+	"context"
+	"unicode/utf8"
+
+	"go.opentelemetry.io/otel/baggage"
+)
+
+// baggageMember is a key-value pair added to the request baggage by
+// RequestBuilder.Baggage.
+type baggageMember struct {
+	key, value string
+}
+
+// droppedBaggageKey is the context key for the keys of baggage members
+// dropped because they were invalid.
+type droppedBaggageKey struct{}
+
+// withBaggageMembers returns a copy of ctx whose baggage also holds members,
+// and the keys of the members dropped because they were invalid.
+func withBaggageMembers(
+	ctx context.Context,
+	members []baggageMember,
+) (context.Context, []string) {
+	bag := baggage.FromContext(ctx)
+
+	var dropped []string
+	for _, m := range members {
+		if !isBaggageToken(m.key) || !utf8.ValidString(m.value) {
+			dropped = append(dropped, m.key)
+			continue
+		}
+		member, err := baggage.NewMemberRaw(m.key, m.value)
+		if err != nil {
+			dropped = append(dropped, m.key)
+			continue
+		}
+		next, err := bag.SetMember(member)
+		if err != nil {
+			dropped = append(dropped, m.key)
+			continue
+		}
+		bag = next
+	}
+
+	return baggage.ContextWithBaggage(ctx, bag), dropped
+}
+
+// isBaggageToken reports whether key is a token as defined in RFC 7230,
+// which the W3C Baggage specification requires for keys.
+func isBaggageToken(key string) bool {
+	if key == "" {
+		return false
+	}
+	for i := 0; i < len(key); i++ {
+		c := key[i]
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9':
+		case c == '!', c == '#', c == '$', c == '%', c == '&', c == '\'', c == '*',
+			c == '+', c == '-', c == '.', c == '^', c == '_', c == '`', c == '|', c == '~':
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,89 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+)
+
+// errNilRequest is returned by Client.Batch for nil request builders.
+var errNilRequest = errors.New("httpclient: nil request")
+
+// Batch sends independent requests with at most concurrency of them in
+// flight, and returns their responses and errors in the order of reqs.
+//
+// Each request is sent through its builder like Get or Post would: the
+// method is set with RequestBuilder.Method (GET by default) and the path
+// with RequestBuilder.Path. Every request goes through the client's rate
+// limiter, circuit breaker and retries individually, so one failure doesn't
+// stop the others. Requests not yet started when ctx is done fail with the
+// context error without being sent. If concurrency is less than 1, all
+// requests are sent at once.
+//
+// Example:
+//
+//	reqs := make([]*httpclient.RequestBuilder, len(userIDs))
+//	for i, id := range userIDs {
+//	    reqs[i] = client.Request("GetUser").
+//	        Path("/users/{id}").
+//	        PathParam("id", id).
+//	        Decode(&users[i])
+//	}
+//
+//	_, errs := client.Batch(ctx, reqs, 8)
+//	for i, err := range errs {
+//	    if err != nil {
+//	        log.Printf("user %s: %v", userIDs[i], err)
+//	    }
+//	}
+func (c *Client) Batch(
+	ctx context.Context,
+	reqs []*RequestBuilder,
+	concurrency int,
+) ([]*Response, []error) {
+	responses := make([]*Response, len(reqs))
+	errs := make([]error, len(reqs))
+	if len(reqs) == 0 {
+		return responses, errs
+	}
+	if concurrency < 1 || concurrency > len(reqs) {
+		concurrency = len(reqs)
+	}
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for range concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				responses[i], errs[i] = sendBatched(ctx, reqs[i])
+			}
+		}()
+	}
+
+	for i := range reqs {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	return responses, errs
+}
+
+// sendBatched sends one request of a batch unless ctx is already done.
+func sendBatched(ctx context.Context, rb *RequestBuilder) (*Response, error) {
+	if rb == nil {
+		return nil, errNilRequest
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	method := rb.method
+	if method == "" {
+		method = http.MethodGet
+	}
+	return rb.execute(ctx, method)
+}
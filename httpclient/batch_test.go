@@ -0,0 +1,116 @@
+package httpclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_Batch(t *testing.T) {
+	var inFlight, maxInFlight atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			m := maxInFlight.Load()
+			if n <= m || maxInFlight.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		_, _ = w.Write([]byte(r.Method + " " + r.URL.Path))
+	}))
+	defer server.Close()
+
+	client := New(WithBaseURL(server.URL), WithRetryDisabled())
+
+	tests := []struct {
+		name            string
+		requests        int
+		concurrency     int
+		wantMaxInFlight int32
+	}{
+		{
+			name:            "given concurrency 2, then runs at most 2 at once",
+			requests:        8,
+			concurrency:     2,
+			wantMaxInFlight: 2,
+		},
+		{
+			name:            "given concurrency 0, then runs all at once",
+			requests:        4,
+			concurrency:     0,
+			wantMaxInFlight: 4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			maxInFlight.Store(0)
+
+			reqs := make([]*RequestBuilder, tt.requests)
+			for i := range reqs {
+				reqs[i] = client.Request("GetItem").
+					Path("/items/{id}").
+					PathParam("id", strconv.Itoa(i))
+			}
+
+			responses, errs := client.Batch(context.Background(), reqs, tt.concurrency)
+
+			require.Len(t, responses, tt.requests)
+			require.Len(t, errs, tt.requests)
+			for i := range reqs {
+				require.NoError(t, errs[i])
+				body, err := responses[i].String()
+				require.NoError(t, err)
+				assert.Equal(t, "GET /items/"+strconv.Itoa(i), body)
+			}
+			assert.LessOrEqual(t, maxInFlight.Load(), tt.wantMaxInFlight)
+		})
+	}
+
+	t.Run("given method and nil request, then sends method and reports nil", func(t *testing.T) {
+		reqs := []*RequestBuilder{
+			client.Request("CreateItem").Method(http.MethodPost).Path("/items"),
+			nil,
+		}
+
+		responses, errs := client.Batch(context.Background(), reqs, 2)
+
+		require.NoError(t, errs[0])
+		body, err := responses[0].String()
+		require.NoError(t, err)
+		assert.Equal(t, "POST /items", body)
+		assert.ErrorIs(t, errs[1], errNilRequest)
+		assert.Nil(t, responses[1])
+	})
+
+	t.Run("given canceled context, then sends nothing", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		reqs := []*RequestBuilder{
+			client.Request("GetItem").Path("/items/1"),
+			client.Request("GetItem").Path("/items/2"),
+		}
+		responses, errs := client.Batch(ctx, reqs, 1)
+
+		for i := range reqs {
+			assert.Nil(t, responses[i])
+			assert.ErrorIs(t, errs[i], context.Canceled)
+		}
+	})
+
+	t.Run("given no requests, then returns empty results", func(t *testing.T) {
+		responses, errs := client.Batch(context.Background(), nil, 4)
+		assert.Empty(t, responses)
+		assert.Empty(t, errs)
+	})
+}
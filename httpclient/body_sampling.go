@@ -0,0 +1,127 @@
+package httpclient
+
+import (
+	"bytes"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// defaultBodySampleBytes is the body capture limit when WithBodySampling is
+// given no positive maxBytes.
+const defaultBodySampleBytes = 4096
+
+// bodySampling configures capturing request and response bodies on a
+// random fraction of spans.
+type bodySampling struct {
+	rate     float64
+	maxBytes int
+	redactor func([]byte) []byte
+}
+
+// sample reports whether the bodies of a request should be captured.
+func (s *bodySampling) sample() bool {
+	return s.rate >= 1 || rand.Float64() < s.rate
+}
+
+// attributes returns the span attributes for a captured body: its redacted
+// content under key, and key+".truncated" if it was cut at maxBytes.
+func (s *bodySampling) attributes(key string, body []byte) []attribute.KeyValue {
+	truncated := len(body) > s.maxBytes
+	if truncated {
+		body = body[:s.maxBytes]
+	}
+	if s.redactor != nil {
+		body = s.redactor(body)
+	}
+
+	attrs := []attribute.KeyValue{attribute.String(key, string(body))}
+	if truncated {
+		attrs = append(attrs, attribute.Bool(key+".truncated", true))
+	}
+	return attrs
+}
+
+// captureRequest records up to maxBytes of the request body on span,
+// leaving the body intact for the transport.
+func (s *bodySampling) captureRequest(span trace.Span, req *http.Request) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return
+	}
+
+	limit := int64(s.maxBytes) + 1
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return
+		}
+		defer body.Close()
+		prefix, err := io.ReadAll(io.LimitReader(body, limit))
+		if err == nil {
+			span.SetAttributes(s.attributes("http.request.body", prefix)...)
+		}
+		return
+	}
+
+	// The body can only be read once, so put the captured prefix back
+	prefix, body, err := peekBody(req.Body, limit)
+	req.Body = body
+	if err == nil {
+		span.SetAttributes(s.attributes("http.request.body", prefix)...)
+	}
+}
+
+// peekBody reads up to limit bytes of body. It returns them along with a
+// body that yields them again before the rest of body, so the reader still
+// sees the whole body.
+func peekBody(body io.ReadCloser, limit int64) ([]byte, io.ReadCloser, error) {
+	prefix, err := io.ReadAll(io.LimitReader(body, limit))
+	return prefix, struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(prefix), body), body}, err
+}
+
+// captureResponse wraps body so that up to maxBytes of what the caller
+// reads are kept. The returned function records them on span.
+func (s *bodySampling) captureResponse(
+	span trace.Span,
+	body io.ReadCloser,
+) (io.ReadCloser, func()) {
+	// Upgraded connections are not request/response bodies
+	if _, ok := body.(io.ReadWriteCloser); ok {
+		return body, func() {}
+	}
+
+	cb := &capturingBody{ReadCloser: body, limit: s.maxBytes + 1}
+	return cb, func() {
+		cb.mu.Lock()
+		defer cb.mu.Unlock()
+		span.SetAttributes(s.attributes("http.response.body", cb.buf.Bytes())...)
+	}
+}
+
+// capturingBody keeps the first limit bytes read from a response body.
+type capturingBody struct {
+	io.ReadCloser
+
+	mu    sync.Mutex
+	buf   bytes.Buffer
+	limit int
+}
+
+func (b *capturingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if n > 0 {
+		b.mu.Lock()
+		if room := b.limit - b.buf.Len(); room > 0 {
+			b.buf.Write(p[:min(n, room)])
+		}
+		b.mu.Unlock()
+	}
+	return n, err
+}
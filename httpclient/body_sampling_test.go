@@ -0,0 +1,109 @@
+package httpclient
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestWithBodySampling(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		_, _ = w.Write([]byte(`{"echo":` + string(body) + `}`))
+	}))
+	defer server.Close()
+
+	redactToken := func(b []byte) []byte {
+		return bytes.ReplaceAll(b, []byte("secret"), []byte("***"))
+	}
+
+	tests := []struct {
+		name         string
+		rate         float64
+		maxBytes     int
+		body         io.Reader
+		wantRequest  string
+		wantResponse string
+		wantTrunc    bool
+	}{
+		{
+			name:         "given rate 1, then records redacted bodies",
+			rate:         1,
+			maxBytes:     100,
+			body:         bytes.NewBufferString(`{"token":"secret"}`),
+			wantRequest:  `{"token":"***"}`,
+			wantResponse: `{"echo":{"token":"***"}}`,
+		},
+		{
+			name:     "given body over max bytes, then truncates and still sends all",
+			rate:     1,
+			maxBytes: 8,
+			// A plain reader has no GetBody, so the body is buffered
+			body:         io.MultiReader(strings.NewReader(`{"token":"secret"}`)),
+			wantRequest:  `{"token"`,
+			wantResponse: `{"echo":`,
+			wantTrunc:    true,
+		},
+		{
+			name: "given rate 0, then records nothing",
+			rate: 0,
+			body: bytes.NewBufferString(`{"token":"secret"}`),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exporter := tracetest.NewInMemoryExporter()
+			tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+			client := New(
+				WithTracerProvider(tp),
+				WithRetryDisabled(),
+				WithBodySampling(tt.rate, tt.maxBytes, redactToken),
+			)
+
+			req, err := http.NewRequestWithContext(context.Background(),
+				http.MethodPost, server.URL, tt.body)
+			require.NoError(t, err)
+			resp, err := client.HTTP().Do(req)
+			require.NoError(t, err)
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+			assert.Equal(t, `{"echo":{"token":"secret"}}`, string(body))
+
+			spans := exporter.GetSpans()
+			require.Len(t, spans, 1)
+			attrs := attribute.NewSet(spans[0].Attributes...)
+
+			reqBody, ok := attrs.Value("http.request.body")
+			assert.Equal(t, tt.wantRequest != "", ok)
+			assert.Equal(t, tt.wantRequest, reqBody.AsString())
+			respBody, ok := attrs.Value("http.response.body")
+			assert.Equal(t, tt.wantResponse != "", ok)
+			assert.Equal(t, tt.wantResponse, respBody.AsString())
+			assert.Equal(t, tt.wantTrunc, attrs.HasValue("http.request.body.truncated"))
+			assert.Equal(t, tt.wantTrunc, attrs.HasValue("http.response.body.truncated"))
+		})
+	}
+}
+
+func TestPeekBody(t *testing.T) {
+	prefix, body, err := peekBody(io.NopCloser(strings.NewReader("hello world")), 5)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("hello"), prefix)
+
+	all, err := io.ReadAll(body)
+	require.NoError(t, err)
+	assert.Equal(t, "hello world", string(all))
+}
@@ -1,6 +1,7 @@
 package httpclient
 
 import (
+	"context"
 	"errors"
 	"net"
 	"net/http"
@@ -12,15 +13,84 @@ import (
 	gobreakerredis "github.com/sony/gobreaker/v2/redis"
 )
 
+// RedisStoreConfig configures the store created by NewRedisStore.
+type RedisStoreConfig struct {
+	// KeyPrefix is prepended to every key the store uses, both for the
+	// circuit state and for its lock, e.g. "prod:payments:". Use it to keep
+	// services and environments sharing a Redis cluster apart.
+	// Default: "" (keys are "gobreaker:state:<name>" and "gobreaker:mutex:<name>")
+	KeyPrefix string
+
+	// StateTTL bounds how long circuit state is kept after its last update,
+	// so the state of retired breakers doesn't pile up. Keep it well above
+	// the breaker's Interval and Timeout: state that expires while in use
+	// resets the circuit to closed.
+	// If 0, state never expires.
+	StateTTL time.Duration
+}
+
 // NewRedisStore creates a SharedDataStore backed by Redis for distributed circuit breaking.
-// This uses the official sony/gobreaker/v2/redis implementation.
+// This uses the official sony/gobreaker/v2/redis implementation, with keys
+// and state lifetime configured by an optional RedisStoreConfig.
+//
+// Breakers with the same service name and key prefix share state, so a
+// prefix that is missing or reused across environments silently merges
+// their circuits. The client locks and initializes the shared state when it
+// is created; if that fails, e.g. because Redis is unreachable, it falls back
+// to a local (in-memory) breaker, which still protects this instance but no
+// longer shares state with the others.
 //
 // Usage:
 //
 //	rdb := redis.NewUniversalClient(&redis.UniversalOptions{Addrs: []string{"localhost:6379"}})
 //	store := httpclient.NewRedisStore(rdb)
-func NewRedisStore(client redis.UniversalClient) gobreaker.SharedDataStore {
-	return gobreakerredis.NewStoreFromClient(client)
+//
+// Usage - Namespaced by environment, with state expiring after a day:
+//
+//	store := httpclient.NewRedisStore(rdb, httpclient.RedisStoreConfig{
+//	    KeyPrefix: "staging:",
+//	    StateTTL:  24 * time.Hour,
+//	})
+func NewRedisStore(
+	client redis.UniversalClient,
+	cfg ...RedisStoreConfig,
+) gobreaker.SharedDataStore {
+	store := &redisStore{
+		SharedDataStore: gobreakerredis.NewStoreFromClient(client),
+		client:          client,
+	}
+	if len(cfg) > 0 {
+		store.cfg = cfg[0]
+	}
+	return store
+}
+
+// redisStore wraps the gobreaker Redis store to prefix its keys and expire
+// its state.
+type redisStore struct {
+	gobreaker.SharedDataStore
+	client redis.UniversalClient
+	cfg    RedisStoreConfig
+}
+
+// Lock implements gobreaker.SharedDataStore.
+func (s *redisStore) Lock(name string) error {
+	return s.SharedDataStore.Lock(s.cfg.KeyPrefix + name)
+}
+
+// Unlock implements gobreaker.SharedDataStore.
+func (s *redisStore) Unlock(name string) error {
+	return s.SharedDataStore.Unlock(s.cfg.KeyPrefix + name)
+}
+
+// GetData implements gobreaker.SharedDataStore.
+func (s *redisStore) GetData(name string) ([]byte, error) {
+	return s.SharedDataStore.GetData(s.cfg.KeyPrefix + name)
+}
+
+// SetData implements gobreaker.SharedDataStore.
+func (s *redisStore) SetData(name string, data []byte) error {
+	return s.client.Set(context.Background(), s.cfg.KeyPrefix+name, data, s.cfg.StateTTL).Err()
 }
 
 // CircuitBreaker is the interface used by circuit breaker transport.
@@ -33,6 +103,20 @@ type CircuitBreaker interface {
 // Returns true if the error/response indicates a system failure (e.g., 500, Network Error).
 type BreakerClassifier func(resp *http.Response, err error) bool
 
+// BreakerSuccessPredicate reports whether a request succeeded for circuit
+// breaker purposes. body holds up to the first 64 KiB of the response body,
+// and is nil when there is no response or the response is streamed.
+type BreakerSuccessPredicate func(resp *http.Response, body []byte, err error) bool
+
+// breakerPredicateMaxBody caps the response bytes buffered for a
+// BreakerSuccessPredicate.
+const breakerPredicateMaxBody = 64 << 10
+
+// streamedKey is the context key marking a request whose response is
+// streamed by DecodeStream. Its body is not buffered for a
+// BreakerSuccessPredicate.
+type streamedKey struct{}
+
 // BreakerConfig holds the configuration for the circuit breaker.
 //
 // Concepts:
@@ -67,6 +151,33 @@ type BreakerConfig struct {
 	// If 0, this rule is desabled.
 	ConsecutiveFailures uint32
 
+	// MinRequests is the minimum number of requests in the current Interval
+	// before FailureRatio is evaluated, so a single early failure can't trip
+	// the circuit at a 100% failure ratio. Unlike FailureThreshold, it does
+	// not delay the ConsecutiveFailures rule.
+	// If 0, the failure ratio is evaluated from the first request.
+	MinRequests uint32
+
+	// WarmupDuration is how long after the client is created the circuit
+	// stays closed regardless of failures, giving a freshly started service
+	// time to establish connections and warm caches.
+	// If 0, there is no warm-up.
+	WarmupDuration time.Duration
+
+	// CountCanceled counts requests whose context was canceled toward the
+	// circuit's counts. By default they are excluded, being neither a success
+	// nor a failure, so that callers giving up, and hedges canceled once
+	// another request won, can't trip the circuit. Deadline-exceeded
+	// requests are timeouts and always count as failures.
+	CountCanceled bool
+
+	// IndependentHedging keeps hedging enabled while the circuit is degraded.
+	// By default, hedged requests are sent without hedges while the circuit
+	// is open or half-open, or closed with a failure ratio of at least half
+	// of FailureRatio, so duplicate requests don't add load to a struggling
+	// downstream or hinder its recovery.
+	IndependentHedging bool
+
 	// Store is the shared data store for distributed circuit breaking.
 	// If nil, the circuit breaker is local (in-memory).
 	Store gobreaker.SharedDataStore
@@ -79,6 +190,101 @@ type BreakerConfig struct {
 	OnStateChange func(name string, from, to gobreaker.State)
 }
 
+// BreakerState is the state of a client's circuit breaker, as reported by
+// Client.BreakerState.
+type BreakerState int
+
+// Circuit breaker states.
+const (
+	// BreakerClosed means requests flow normally.
+	BreakerClosed BreakerState = iota
+
+	// BreakerHalfOpen means a limited number of trial requests are allowed
+	// to probe whether the downstream has recovered.
+	BreakerHalfOpen
+
+	// BreakerOpen means requests are rejected without being sent.
+	BreakerOpen
+
+	// BreakerDisabled means the client has no circuit breaker.
+	BreakerDisabled
+)
+
+// String returns the state name, e.g. "open".
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerHalfOpen:
+		return "half-open"
+	case BreakerOpen:
+		return "open"
+	case BreakerDisabled:
+		return "disabled"
+	default:
+		return "unknown"
+	}
+}
+
+// breakerStateOf converts a gobreaker state to a BreakerState.
+func breakerStateOf(s gobreaker.State) BreakerState {
+	switch s {
+	case gobreaker.StateHalfOpen:
+		return BreakerHalfOpen
+	case gobreaker.StateOpen:
+		return BreakerOpen
+	default:
+		return BreakerClosed
+	}
+}
+
+// BreakerState returns the current state of the client's circuit breaker,
+// or BreakerDisabled if it has none.
+//
+// A client has one breaker for the downstream it calls, so the state can
+// feed the application's own readiness check when that downstream is
+// critical. For a distributed breaker, the shared state is read from the
+// store, falling back to this instance's last known state if the store is
+// unavailable.
+//
+// Example:
+//
+//	health.AddReadinessCheck("payments", func(ctx context.Context) error {
+//	    if paymentsClient.BreakerState() == httpclient.BreakerOpen {
+//	        return errors.New("payments circuit breaker is open")
+//	    }
+//	    return nil
+//	})
+func (c *Client) BreakerState() BreakerState {
+	if c.config.breaker == nil {
+		return BreakerDisabled
+	}
+	return c.config.breaker.state()
+}
+
+// readyToTrip returns the gobreaker ReadyToTrip function for the config,
+// for a breaker created at started according to clock.
+func (c BreakerConfig) readyToTrip(clock Clock, started time.Time) func(gobreaker.Counts) bool {
+	return func(counts gobreaker.Counts) bool {
+		if c.WarmupDuration > 0 && clock.Now().Sub(started) < c.WarmupDuration {
+			return false
+		}
+		if c.FailureThreshold > 0 && counts.Requests < c.FailureThreshold {
+			return false
+		}
+		if c.ConsecutiveFailures > 0 && counts.ConsecutiveFailures >= c.ConsecutiveFailures {
+			return true
+		}
+		if c.FailureRatio > 0 && counts.TotalFailures > 0 && counts.Requests >= c.MinRequests {
+			ratio := float64(counts.TotalFailures) / float64(counts.Requests)
+			if ratio >= c.FailureRatio {
+				return true
+			}
+		}
+		return false
+	}
+}
+
 // DistributedBreakerConfig returns a configuration for a distributed circuit breaker backed by Redis.
 //
 // This configuration allows multiple service instances to share the same circuit breaker state.
@@ -1,8 +1,12 @@
 package httpclient
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"errors"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -61,6 +65,327 @@ func TestConfigFunctions(t *testing.T) {
 	})
 }
 
+func TestBreakerConfig_ReadyToTrip(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     BreakerConfig
+		started time.Time
+		counts  gobreaker.Counts
+		want    bool
+	}{
+		{
+			name:    "given first request failed and no minimum, then trips",
+			cfg:     BreakerConfig{FailureRatio: 0.5},
+			started: time.Now().Add(-time.Hour),
+			counts:  gobreaker.Counts{Requests: 1, TotalFailures: 1, ConsecutiveFailures: 1},
+			want:    true,
+		},
+		{
+			name:    "given failures below MinRequests, then stays closed",
+			cfg:     BreakerConfig{FailureRatio: 0.5, MinRequests: 10},
+			started: time.Now().Add(-time.Hour),
+			counts:  gobreaker.Counts{Requests: 1, TotalFailures: 1, ConsecutiveFailures: 1},
+			want:    false,
+		},
+		{
+			name:    "given failure ratio reached at MinRequests, then trips",
+			cfg:     BreakerConfig{FailureRatio: 0.5, MinRequests: 10},
+			started: time.Now().Add(-time.Hour),
+			counts:  gobreaker.Counts{Requests: 10, TotalFailures: 5},
+			want:    true,
+		},
+		{
+			name: "given consecutive failures below MinRequests, then trips",
+			cfg: BreakerConfig{
+				FailureRatio:        0.5,
+				MinRequests:         10,
+				ConsecutiveFailures: 3,
+			},
+			started: time.Now().Add(-time.Hour),
+			counts:  gobreaker.Counts{Requests: 3, TotalFailures: 3, ConsecutiveFailures: 3},
+			want:    true,
+		},
+		{
+			name: "given failures during warm-up, then stays closed",
+			cfg: BreakerConfig{
+				FailureRatio:        0.5,
+				ConsecutiveFailures: 3,
+				WarmupDuration:      time.Minute,
+			},
+			started: time.Now(),
+			counts:  gobreaker.Counts{Requests: 5, TotalFailures: 5, ConsecutiveFailures: 5},
+			want:    false,
+		},
+		{
+			name:    "given failures after warm-up, then trips",
+			cfg:     BreakerConfig{FailureRatio: 0.5, WarmupDuration: time.Minute},
+			started: time.Now().Add(-2 * time.Minute),
+			counts:  gobreaker.Counts{Requests: 5, TotalFailures: 5, ConsecutiveFailures: 5},
+			want:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.cfg.readyToTrip(realClock{}, tt.started)(tt.counts))
+		})
+	}
+}
+
+func TestBreakerTransport_Canceled(t *testing.T) {
+	tests := []struct {
+		name          string
+		countCanceled bool
+		wantErr       error
+	}{
+		{
+			name:    "given canceled request, then circuit stays closed",
+			wantErr: nil,
+		},
+		{
+			name:          "given canceled request and CountCanceled, then circuit opens",
+			countCanceled: true,
+			wantErr:       gobreaker.ErrOpenState,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(
+				http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(http.StatusOK)
+				}),
+			)
+			defer server.Close()
+
+			breakerCfg := DefaultBreakerConfig()
+			breakerCfg.FailureThreshold = 0
+			breakerCfg.ConsecutiveFailures = 1
+			breakerCfg.CountCanceled = tt.countCanceled
+			client := New(
+				WithBaseURL(server.URL),
+				WithRetryDisabled(),
+				WithBreakerConfig(breakerCfg),
+			)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			_, err := client.Request("Test").Get(ctx, "/test")
+			require.ErrorIs(t, err, context.Canceled)
+
+			_, err = client.Request("Test").Get(context.Background(), "/test")
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBreakerTransport_SuccessPredicate(t *testing.T) {
+	errorEnvelope := func(_ *http.Response, body []byte, err error) bool {
+		return err == nil && !bytes.Contains(body, []byte(`"error"`))
+	}
+
+	tests := []struct {
+		name      string
+		body      string
+		predicate BreakerSuccessPredicate
+		wantErr   error
+	}{
+		{
+			name:    "given 200 error envelope and no predicate, then circuit stays closed",
+			body:    `{"error":"quota exceeded"}`,
+			wantErr: nil,
+		},
+		{
+			name:      "given 200 error envelope and predicate, then circuit opens",
+			body:      `{"error":"quota exceeded"}`,
+			predicate: errorEnvelope,
+			wantErr:   gobreaker.ErrOpenState,
+		},
+		{
+			name:      "given 200 success and predicate, then circuit stays closed",
+			body:      `{"data":"ok"}`,
+			predicate: errorEnvelope,
+			wantErr:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(
+				http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					_, _ = w.Write([]byte(tt.body))
+				}),
+			)
+			defer server.Close()
+
+			breakerCfg := DefaultBreakerConfig()
+			breakerCfg.FailureThreshold = 0
+			breakerCfg.ConsecutiveFailures = 1
+			client := New(
+				WithBaseURL(server.URL),
+				WithRetryDisabled(),
+				WithBreakerConfig(breakerCfg),
+				WithBreakerSuccessPredicate(tt.predicate),
+			)
+
+			resp, err := client.Request("Test").Get(context.Background(), "/test")
+			require.NoError(t, err)
+			body, err := resp.String()
+			require.NoError(t, err)
+			assert.Equal(t, tt.body, body, "body must stay readable after the predicate")
+
+			_, err = client.Request("Test").Get(context.Background(), "/test")
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBreakerTransport_SuccessPredicate_Streamed(t *testing.T) {
+	t.Run("given streamed response, then predicate gets no body", func(t *testing.T) {
+		server := httptest.NewServer(
+			http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte(`[1,2,3]`))
+			}),
+		)
+		defer server.Close()
+
+		var gotBody []byte
+		client := New(
+			WithBaseURL(server.URL),
+			WithBreakerConfig(DefaultBreakerConfig()),
+			WithBreakerSuccessPredicate(func(_ *http.Response, body []byte, err error) bool {
+				gotBody = body
+				return err == nil
+			}),
+		)
+
+		var items int
+		_, err := client.Request("Test").DecodeStream(context.Background(), "/test",
+			func(json.RawMessage) error {
+				items++
+				return nil
+			})
+		require.NoError(t, err)
+		assert.Nil(t, gotBody)
+		assert.Equal(t, 3, items)
+	})
+}
+
+func TestClient_BreakerState(t *testing.T) {
+	tests := []struct {
+		name        string
+		breaker     bool
+		distributed bool
+		failures    int
+		want        BreakerState
+	}{
+		{
+			name: "given no breaker, then returns disabled",
+			want: BreakerDisabled,
+		},
+		{
+			name:    "given breaker without failures, then returns closed",
+			breaker: true,
+			want:    BreakerClosed,
+		},
+		{
+			name:     "given breaker tripped by failures, then returns open",
+			breaker:  true,
+			failures: 1,
+			want:     BreakerOpen,
+		},
+		{
+			name:        "given distributed breaker tripped by failures, then returns open",
+			breaker:     true,
+			distributed: true,
+			failures:    1,
+			want:        BreakerOpen,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(
+				http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(http.StatusInternalServerError)
+				}),
+			)
+			defer server.Close()
+
+			opts := []Option{WithBaseURL(server.URL), WithRetryDisabled()}
+			if tt.breaker {
+				breakerCfg := DefaultBreakerConfig()
+				if tt.distributed {
+					mr, err := miniredis.Run()
+					require.NoError(t, err)
+					defer mr.Close()
+					rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+					defer rdb.Close()
+					breakerCfg = DistributedBreakerConfig(NewRedisStore(rdb))
+				}
+				breakerCfg.FailureThreshold = 0
+				breakerCfg.ConsecutiveFailures = 1
+				opts = append(opts, WithBreakerConfig(breakerCfg))
+			}
+			client := New(opts...)
+
+			for range tt.failures {
+				_, _ = client.Request("Test").Get(context.Background(), "/test")
+			}
+
+			assert.Equal(t, tt.want, client.BreakerState())
+		})
+	}
+}
+
+func TestNewRedisStore(t *testing.T) {
+	tests := []struct {
+		name      string
+		cfg       []RedisStoreConfig
+		wantKey   string
+		wantTTL   time.Duration
+		wantLocal bool
+	}{
+		{
+			name:    "given no config, then uses unprefixed keys without TTL",
+			wantKey: "gobreaker:state:payments",
+		},
+		{
+			name:    "given key prefix and TTL, then prefixes keys and expires state",
+			cfg:     []RedisStoreConfig{{KeyPrefix: "staging:", StateTTL: time.Hour}},
+			wantKey: "staging:gobreaker:state:payments",
+			wantTTL: time.Hour,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mr, err := miniredis.Run()
+			require.NoError(t, err)
+			defer mr.Close()
+			rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+			defer rdb.Close()
+
+			store := NewRedisStore(rdb, tt.cfg...)
+			New(
+				WithServiceName("payments"),
+				WithBreakerConfig(DistributedBreakerConfig(store)),
+			)
+
+			assert.Equal(t, []string{tt.wantKey}, mr.Keys())
+			assert.Equal(t, tt.wantTTL, mr.TTL(tt.wantKey))
+		})
+	}
+}
+
 func TestBreakerTransport_RoundTrip(t *testing.T) {
 	type args struct {
 		resp *http.Response
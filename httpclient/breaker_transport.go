@@ -29,7 +29,13 @@ func (t *circuitBreakerTransport) RoundTrip(req *http.Request) (*http.Response,
 	res, err := t.breaker.Execute(func() (interface{}, error) {
 		resp, err := t.next.RoundTrip(req) //nolint:bodyclose
 
-		if t.classifier(resp, err) {
+		// Canceled requests bypass the classifier: IsExcluded leaves them out
+		// of the counts unless BreakerConfig.CountCanceled is set
+		if errors.Is(err, context.Canceled) {
+			return resp, err
+		}
+
+		if t.failed(req, resp, err) {
 			if err != nil {
 				return resp, err
 			}
@@ -40,9 +46,14 @@ func (t *circuitBreakerTransport) RoundTrip(req *http.Request) (*http.Response,
 	})
 	if err != nil {
 		// Differentiate between "Circuit Open" rejection and "Actual Failure"
-		if errors.Is(err, gobreaker.ErrOpenState) {
+		switch {
+		case errors.Is(err, gobreaker.ErrOpenState):
 			t.cfg.Metrics.recordBreakerRequest(ctx, t.name, "rejected")
-		} else {
+			t.cfg.counters.breakerRejections.Add(1)
+		case errors.Is(err, context.Canceled) && !t.cfg.BreakerConfig.CountCanceled:
+			// Excluded from the circuit's counts
+			t.cfg.Metrics.recordBreakerRequest(ctx, t.name, "canceled")
+		default:
 			// This is a failure that passed through the breaker but failed execution
 			t.cfg.Metrics.recordBreakerRequest(ctx, t.name, "failure")
 		}
@@ -66,6 +77,22 @@ func (t *circuitBreakerTransport) RoundTrip(req *http.Request) (*http.Response,
 	return nil, errors.New("circuit breaker returned unknown response type")
 }
 
+// failed reports whether a request counts as a breaker failure, using the
+// success predicate if one is set and the classifier otherwise.
+func (t *circuitBreakerTransport) failed(req *http.Request, resp *http.Response, err error) bool {
+	predicate := t.cfg.BreakerSuccessPredicate
+	if predicate == nil {
+		return t.classifier(resp, err)
+	}
+
+	var body []byte
+	streamed, _ := req.Context().Value(streamedKey{}).(bool)
+	if resp != nil && resp.Body != nil && resp.Body != http.NoBody && !streamed {
+		body, resp.Body, _ = peekBody(resp.Body, breakerPredicateMaxBody)
+	}
+	return !predicate(resp, body, err)
+}
+
 // newCircuitBreakerTransport creates a new circuit breaker transport.
 func newCircuitBreakerTransport(next http.RoundTripper, cfg *internalConfig) http.RoundTripper {
 	if cfg.BreakerConfig == nil {
@@ -84,22 +111,9 @@ func newCircuitBreakerTransport(next http.RoundTripper, cfg *internalConfig) htt
 		MaxRequests: cfg.BreakerConfig.MaxRequests,
 		Interval:    cfg.BreakerConfig.Interval,
 		Timeout:     cfg.BreakerConfig.Timeout,
-		ReadyToTrip: func(counts gobreaker.Counts) bool {
-			if cfg.BreakerConfig.FailureThreshold > 0 &&
-				counts.Requests < cfg.BreakerConfig.FailureThreshold {
-				return false
-			}
-			if cfg.BreakerConfig.ConsecutiveFailures > 0 &&
-				counts.ConsecutiveFailures >= cfg.BreakerConfig.ConsecutiveFailures {
-				return true
-			}
-			if cfg.BreakerConfig.FailureRatio > 0 && counts.TotalFailures > 0 {
-				ratio := float64(counts.TotalFailures) / float64(counts.Requests)
-				if ratio >= cfg.BreakerConfig.FailureRatio {
-					return true
-				}
-			}
-			return false
+		ReadyToTrip: cfg.BreakerConfig.readyToTrip(cfg.clock(), cfg.clock().Now()),
+		IsExcluded: func(err error) bool {
+			return !cfg.BreakerConfig.CountCanceled && errors.Is(err, context.Canceled)
 		},
 		OnStateChange: func(name string, from, to gobreaker.State) {
 			if cfg.Metrics != nil {
@@ -129,6 +143,10 @@ func newCircuitBreakerTransport(next http.RoundTripper, cfg *internalConfig) htt
 			// If creation fails, this instance will operate independently (Local mode), which may result in
 			// slightly higher total traffic to the failing service across all instances, but still provides
 			// process-level overload protection.
+			cfg.logger().Error("circuit breaker store unavailable, falling back to local breaker",
+				"breaker", name,
+				"error", err,
+			)
 			cb = gobreaker.NewCircuitBreaker[interface{}](st)
 		} else {
 			cb = dcb
@@ -137,11 +155,56 @@ func newCircuitBreakerTransport(next http.RoundTripper, cfg *internalConfig) htt
 		cb = gobreaker.NewCircuitBreaker[interface{}](st)
 	}
 
-	return &circuitBreakerTransport{
+	t := &circuitBreakerTransport{
 		breaker:    cb,
 		next:       next,
 		classifier: cfg.BreakerConfig.Classifier,
 		cfg:        cfg,
 		name:       name,
 	}
+	cfg.breaker = t
+	return t
+}
+
+// state returns the current state of the breaker.
+func (t *circuitBreakerTransport) state() BreakerState {
+	switch b := t.breaker.(type) {
+	case *gobreaker.CircuitBreaker[interface{}]:
+		return breakerStateOf(b.State())
+	case *gobreaker.DistributedCircuitBreaker[interface{}]:
+		if s, err := b.State(); err == nil {
+			return breakerStateOf(s)
+		}
+		return breakerStateOf(b.CircuitBreaker.State())
+	default:
+		return BreakerClosed
+	}
+}
+
+// degraded reports whether the circuit is open or half-open, or closed with
+// a failure ratio of at least half of FailureRatio.
+func (t *circuitBreakerTransport) degraded() bool {
+	var cb *gobreaker.CircuitBreaker[interface{}]
+	switch b := t.breaker.(type) {
+	case *gobreaker.CircuitBreaker[interface{}]:
+		cb = b
+	case *gobreaker.DistributedCircuitBreaker[interface{}]:
+		// The shared state as of this instance's last request, which avoids
+		// a store round trip per request.
+		cb = b.CircuitBreaker
+	default:
+		return false
+	}
+
+	if cb.State() != gobreaker.StateClosed {
+		return true
+	}
+
+	c := t.cfg.BreakerConfig
+	counts := cb.Counts()
+	if c.FailureRatio <= 0 || counts.TotalFailures == 0 || counts.Requests < c.MinRequests {
+		return false
+	}
+	ratio := float64(counts.TotalFailures) / float64(counts.Requests)
+	return ratio >= c.FailureRatio/2
 }
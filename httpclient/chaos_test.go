@@ -204,7 +204,7 @@ func TestChaosTransport_RoundTrip(t *testing.T) {
 			)
 			defer server.Close()
 
-			transport := newChaosTransport(http.DefaultTransport, tt.args.config)
+			transport := newChaosTransport(http.DefaultTransport, tt.args.config, realClock{})
 
 			ctx := context.Background()
 			if tt.args.contextTimeout > 0 {
@@ -245,7 +245,7 @@ func TestChaosTransport_ContextCancellation(t *testing.T) {
 	}))
 	defer server.Close()
 
-	transport := newChaosTransport(http.DefaultTransport, ChaosConfig{LatencyMs: 1000})
+	transport := newChaosTransport(http.DefaultTransport, ChaosConfig{LatencyMs: 1000}, realClock{})
 
 	ctx, cancel := context.WithCancel(context.Background())
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
@@ -4,7 +4,6 @@ import (
 	"errors"
 	"net"
 	"net/http"
-	"time"
 )
 
 // ErrChaosInjected is returned when chaos injection simulates a network error.
@@ -14,13 +13,15 @@ var ErrChaosInjected = errors.New("chaos: simulated network error")
 type chaosTransport struct {
 	next   http.RoundTripper
 	config ChaosConfig
+	clock  Clock
 }
 
 // newChaosTransport creates a new chaos transport wrapper.
-func newChaosTransport(next http.RoundTripper, cfg ChaosConfig) http.RoundTripper {
+func newChaosTransport(next http.RoundTripper, cfg ChaosConfig, clock Clock) http.RoundTripper {
 	return &chaosTransport{
 		next:   next,
 		config: cfg,
+		clock:  clock,
 	}
 }
 
@@ -48,7 +49,7 @@ func (t *chaosTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 	delay := t.config.Delay()
 	if delay > 0 {
 		select {
-		case <-time.After(delay):
+		case <-t.clock.After(delay):
 			// Delay completed
 		case <-ctx.Done():
 			return nil, ctx.Err()
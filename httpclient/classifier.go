@@ -8,6 +8,7 @@ import (
 	"net"
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
 	"syscall"
 )
@@ -45,6 +46,53 @@ import (
 //	)
 type RetryClassifier func(resp *http.Response, err error) bool
 
+// ClassifierWithReason is a RetryClassifier that also reports why a request
+// is retried. The reason is recorded as the "retry.reason" attribute of the
+// http.client.retry.attempts metric and of the http.retry span event, so
+// retries driven by throttling can be told apart from infrastructure
+// failures. It is ignored when retry is false.
+//
+// Example - Retry 500s with their own reason:
+//
+//	client := httpclient.New(
+//	    httpclient.WithRetryClassifierWithReason(
+//	        func(resp *http.Response, err error) (bool, string) {
+//	            if resp != nil && resp.StatusCode == http.StatusInternalServerError {
+//	                return true, "status_500"
+//	            }
+//	            return httpclient.DefaultClassifierWithReason(resp, err)
+//	        },
+//	    ),
+//	)
+type ClassifierWithReason func(resp *http.Response, err error) (retry bool, reason string)
+
+// Retry reasons reported by DefaultClassifierWithReason. Retryable status
+// codes are reported as "status_" followed by the code, e.g. "status_429".
+const (
+	RetryReasonNetworkTimeout    = "network_timeout"
+	RetryReasonConnectionRefused = "connection_refused"
+	RetryReasonDNS               = "dns"
+	RetryReasonNetwork           = "network_error"
+
+	// RetryReasonRetriable is reported for errors marked with Retriable.
+	RetryReasonRetriable = "retriable"
+
+	// RetryReasonCustom is reported for retries decided by a RetryClassifier,
+	// which gives no reason.
+	RetryReasonCustom = "custom"
+)
+
+// withCustomReason adapts a RetryClassifier to a ClassifierWithReason that
+// reports RetryReasonCustom.
+func withCustomReason(c RetryClassifier) ClassifierWithReason {
+	return func(resp *http.Response, err error) (bool, string) {
+		if c(resp, err) {
+			return true, RetryReasonCustom
+		}
+		return false, ""
+	}
+}
+
 // DefaultClassifier applies production-safe retry rules.
 //
 // Retries on:
@@ -59,44 +107,93 @@ type RetryClassifier func(resp *http.Response, err error) bool
 //   - 4xx Client errors (request is invalid, retry won't help)
 //   - Context cancellation (intentional cancellation by caller)
 //   - Permanent errors (TLS certificate errors, etc.)
+//   - Errors marked with Permanent
 //   - nil error with success response (no retry needed)
 //
+// Errors marked with Retriable are retried unless the context was cancelled.
+//
 // This classifier is designed for safety:
 //   - It avoids retrying requests that are unlikely to succeed
 //   - It respects intentional cancellation
 //   - It distinguishes transient errors from permanent failures
 func DefaultClassifier(resp *http.Response, err error) bool {
+	retry, _ := DefaultClassifierWithReason(resp, err)
+	return retry
+}
+
+// DefaultClassifierWithReason applies the same rules as DefaultClassifier
+// and reports the reason for a retry: "status_" followed by the status code
+// for retryable responses, or one of RetryReasonNetworkTimeout,
+// RetryReasonConnectionRefused, RetryReasonDNS and RetryReasonNetwork for
+// network errors, or RetryReasonRetriable for errors marked with Retriable.
+func DefaultClassifierWithReason(resp *http.Response, err error) (bool, string) {
 	// Success - no retry needed
 	if err == nil && resp != nil && resp.StatusCode < 400 {
-		return false
+		return false, ""
 	}
 
 	// Check for context cancellation - never retry intentional cancellation
 	if err != nil {
 		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
-			return false
+			return false, ""
 		}
 
-		// Check for permanent errors that should not be retried (TLS, DNS NXDOMAIN, etc.)
-		if isPermanentError(err) {
-			return false
+		// Explicit retry intent from Retriable or Permanent takes precedence
+		if retriable, ok := retryIntent(err); ok {
+			if retriable {
+				return true, RetryReasonRetriable
+			}
+			return false, ""
 		}
 
-		// Check for retryable network errors
-		if isRetryableNetworkError(err) {
-			return true
+		// Check for permanent errors that should not be retried (TLS, DNS NXDOMAIN, etc.)
+		if isPermanentError(err) {
+			return false, ""
 		}
 
-		// Unknown error - default to retry for network-level errors
-		return true
+		// Retryable network errors, and unknown errors, which default to
+		// retry as network-level errors
+		return true, networkErrorReason(err)
 	}
 
 	// Response received - check status code
-	if resp != nil {
-		return isRetryableStatusCode(resp.StatusCode)
+	if resp != nil && isRetryableStatusCode(resp.StatusCode) {
+		return true, "status_" + strconv.Itoa(resp.StatusCode)
 	}
 
-	return false
+	return false, ""
+}
+
+// networkErrorReason returns the retry reason for a network error.
+func networkErrorReason(err error) string {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return RetryReasonDNS
+	}
+
+	if errors.Is(err, syscall.ECONNREFUSED) {
+		return RetryReasonConnectionRefused
+	}
+
+	var netErr net.Error
+	if (errors.As(err, &netErr) && netErr.Timeout()) ||
+		errors.Is(err, syscall.ETIMEDOUT) ||
+		errors.Is(err, os.ErrDeadlineExceeded) {
+		return RetryReasonNetworkTimeout
+	}
+
+	// Fallback for errors that lost their type, as in containsTransientPattern
+	errStr := strings.ToLower(err.Error())
+	switch {
+	case strings.Contains(errStr, "connection refused"):
+		return RetryReasonConnectionRefused
+	case strings.Contains(errStr, "no such host"):
+		return RetryReasonDNS
+	case strings.Contains(errStr, "i/o timeout"):
+		return RetryReasonNetworkTimeout
+	default:
+		return RetryReasonNetwork
+	}
 }
 
 // isRetryableStatusCode returns true for status codes that indicate
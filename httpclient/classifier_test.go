@@ -5,6 +5,7 @@ import (
 	"errors"
 	"net"
 	"net/http"
+	"syscall"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -177,6 +178,96 @@ func TestDefaultClassifier(t *testing.T) {
 	}
 }
 
+func TestDefaultClassifierWithReason(t *testing.T) {
+	type args struct {
+		resp *http.Response
+		err  error
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantRetry  bool
+		wantReason string
+	}{
+		{
+			name:      "given 200 response, then returns no reason",
+			args:      args{resp: &http.Response{StatusCode: http.StatusOK}},
+			wantRetry: false,
+		},
+		{
+			name:       "given 429 response, then returns status_429",
+			args:       args{resp: &http.Response{StatusCode: http.StatusTooManyRequests}},
+			wantRetry:  true,
+			wantReason: "status_429",
+		},
+		{
+			name:       "given 503 response, then returns status_503",
+			args:       args{resp: &http.Response{StatusCode: http.StatusServiceUnavailable}},
+			wantRetry:  true,
+			wantReason: "status_503",
+		},
+		{
+			name:      "given 500 response, then returns no reason",
+			args:      args{resp: &http.Response{StatusCode: http.StatusInternalServerError}},
+			wantRetry: false,
+		},
+		{
+			name:       "given timeout error, then returns network_timeout",
+			args:       args{err: &timeoutError{}},
+			wantRetry:  true,
+			wantReason: RetryReasonNetworkTimeout,
+		},
+		{
+			name:       "given connection refused, then returns connection_refused",
+			args:       args{err: &net.OpError{Op: "dial", Err: syscall.ECONNREFUSED}},
+			wantRetry:  true,
+			wantReason: RetryReasonConnectionRefused,
+		},
+		{
+			name:       "given temporary DNS error, then returns dns",
+			args:       args{err: &net.DNSError{Err: "server misbehaving", IsTemporary: true}},
+			wantRetry:  true,
+			wantReason: RetryReasonDNS,
+		},
+		{
+			name:       "given unknown error, then returns network_error",
+			args:       args{err: errors.New("some unknown error")},
+			wantRetry:  true,
+			wantReason: RetryReasonNetwork,
+		},
+		{
+			name:      "given context canceled, then returns no reason",
+			args:      args{err: context.Canceled},
+			wantRetry: false,
+		},
+		{
+			name:       "given error marked retriable, then returns retriable",
+			args:       args{err: Retriable(errors.New("x509: certificate has expired"))},
+			wantRetry:  true,
+			wantReason: RetryReasonRetriable,
+		},
+		{
+			name:      "given timeout error marked permanent, then returns no reason",
+			args:      args{err: Permanent(&timeoutError{})},
+			wantRetry: false,
+		},
+		{
+			name:      "given canceled error marked retriable, then returns no reason",
+			args:      args{err: Retriable(context.Canceled)},
+			wantRetry: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			retry, reason := DefaultClassifierWithReason(tt.args.resp, tt.args.err)
+			assert.Equal(t, tt.wantRetry, retry)
+			assert.Equal(t, tt.wantReason, reason)
+			assert.Equal(t, tt.wantRetry, DefaultClassifier(tt.args.resp, tt.args.err))
+		})
+	}
+}
+
 // timeoutError implements net.Error with Timeout() returning true.
 type timeoutError struct{}
 
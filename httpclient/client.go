@@ -110,22 +110,30 @@ func New(opts ...Option) *Client {
 	if cfg.MockTransport != nil {
 		chain = cfg.MockTransport
 	} else {
-		transport := cfg.buildTransport()
+		var transport http.RoundTripper = cfg.BaseTransport
+		if transport == nil {
+			transport = cfg.buildTransport()
+		}
 
-		// Build transport chain: OTel -> Breaker -> RateLimit -> Retry -> Chaos -> http.Transport
+		// Build transport chain:
+		// OTel -> Breaker -> RateLimit -> Retry -> Deadline -> Chaos -> http.Transport
 		// Order matters:
 		// - OTel: outermost to trace everything including retries
 		// - Breaker: fail fast before wasting rate limit tokens
 		// - RateLimit: throttle before retry attempts consume quota
 		// - Retry: retry transient failures from inner layers
+		// - Deadline: inside retry so each attempt sends the budget left
 		// - Chaos: innermost so other layers see simulated failures
 		chain = transport
 		if cfg.ChaosConfig != nil {
-			chain = newChaosTransport(chain, *cfg.ChaosConfig)
+			chain = newChaosTransport(chain, *cfg.ChaosConfig, cfg.clock())
+		}
+		if cfg.DeadlineHeader != "" {
+			chain = newDeadlineTransport(chain, cfg.DeadlineHeader)
 		}
 		chain = newRetryTransport(chain, cfg)
 		if cfg.RateLimitConfig != nil && cfg.RateLimitConfig.RequestsPerSecond > 0 {
-			chain = newRateLimitTransport(chain, *cfg.RateLimitConfig)
+			chain = newRateLimitTransport(chain, *cfg.RateLimitConfig, cfg.clock())
 		}
 		chain = newCircuitBreakerTransport(chain, cfg)
 		chain = newOtelTransport(chain, cfg)
@@ -5,10 +5,13 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/kroma-labs/sentinel-go/httpclient/mocks"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
@@ -211,6 +214,56 @@ func TestNewWithTransport(t *testing.T) {
 	}
 }
 
+func TestNew_WithBaseTransport(t *testing.T) {
+	rt := mocks.NewRoundTripper(t)
+	rt.EXPECT().
+		RoundTrip(mock.Anything).
+		Return(&http.Response{
+			StatusCode: http.StatusServiceUnavailable,
+			Body:       io.NopCloser(strings.NewReader("")),
+		}, nil).Once()
+	rt.EXPECT().
+		RoundTrip(mock.Anything).
+		Return(&http.Response{
+			StatusCode: http.StatusOK,
+			Proto:      "HTTP/3.0",
+			Body:       io.NopCloser(strings.NewReader("OK")),
+		}, nil).Once()
+
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	defer tp.Shutdown(context.Background())
+
+	client := New(
+		WithBaseTransport(rt),
+		WithTracerProvider(tp),
+		WithRetryConfig(RetryConfig{
+			MaxRetries:      1,
+			InitialInterval: time.Millisecond,
+			MaxInterval:     time.Millisecond,
+			Multiplier:      1,
+		}),
+	)
+
+	resp, err := client.Request("GetData").Get(context.Background(), "https://example.com/data")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// The span ends when the body is closed
+	_, err = resp.Body()
+	require.NoError(t, err)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	var version string
+	for _, attr := range spans[0].Attributes {
+		if attr.Key == "network.protocol.version" {
+			version = attr.Value.AsString()
+		}
+	}
+	assert.Equal(t, "3", version)
+}
+
 func TestWrapClient(t *testing.T) {
 	type args struct {
 		timeout      time.Duration
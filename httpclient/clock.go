@@ -0,0 +1,235 @@
+package httpclient
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Clock is the source of time for the client's time-based features: retry
+// backoff and MaxElapsedTime, rate limiting, hedge delays, adaptive hedging
+// latencies, circuit breaker warm-up and chaos latency.
+//
+// The default uses the system clock. Tests can pass a FakeClock with
+// WithClock to drive these features deterministically, without sleeping.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+
+	// After returns a channel that receives the current time once d has
+	// elapsed.
+	After(d time.Duration) <-chan time.Time
+
+	// NewTimer returns a Timer that fires once d has elapsed.
+	NewTimer(d time.Duration) Timer
+}
+
+// Timer is a single-shot timer created by a Clock, like time.Timer.
+type Timer interface {
+	// C returns the channel the time is sent on when the timer fires.
+	C() <-chan time.Time
+
+	// Stop prevents the timer from firing. It returns false if the timer
+	// has already fired or been stopped.
+	Stop() bool
+
+	// Reset changes the timer to fire once d has elapsed. It returns true
+	// if the timer had been active.
+	Reset(d time.Duration) bool
+}
+
+// realClock is the Clock backed by the time package.
+type realClock struct{}
+
+func (realClock) Now() time.Time                         { return time.Now() }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+func (realClock) NewTimer(d time.Duration) Timer         { return &realTimer{time.NewTimer(d)} }
+
+// realTimer adapts time.Timer to Timer.
+type realTimer struct {
+	timer *time.Timer
+}
+
+func (t *realTimer) C() <-chan time.Time        { return t.timer.C }
+func (t *realTimer) Stop() bool                 { return t.timer.Stop() }
+func (t *realTimer) Reset(d time.Duration) bool { return t.timer.Reset(d) }
+
+// clock returns the configured Clock, or the system clock.
+func (cfg *internalConfig) clock() Clock {
+	if cfg.Clock == nil {
+		return realClock{}
+	}
+	return cfg.Clock
+}
+
+// afterFunc calls f in its own goroutine once d has elapsed on c, and
+// returns a function that cancels the call, like time.AfterFunc.
+func afterFunc(c Clock, d time.Duration, f func()) (stop func() bool) {
+	if _, ok := c.(realClock); ok {
+		return time.AfterFunc(d, f).Stop
+	}
+
+	timer := c.NewTimer(d)
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-timer.C():
+			f()
+		case <-done:
+		}
+	}()
+
+	var once sync.Once
+	return func() bool {
+		stopped := timer.Stop()
+		once.Do(func() { close(done) })
+		return stopped
+	}
+}
+
+// FakeClock is a Clock for tests whose time only moves when Advance or Set
+// is called. Timers fire, in deadline order, once the clock reaches their
+// deadline.
+//
+// Example:
+//
+//	clock := httpclient.NewFakeClock(time.Now())
+//	client := httpclient.New(
+//	    httpclient.WithClock(clock),
+//	    httpclient.WithRetryConfig(httpclient.DefaultRetryConfig()),
+//	)
+//
+//	go func() {
+//	    clock.BlockUntil(1)        // wait for the first backoff
+//	    clock.Advance(time.Second) // skip it
+//	}()
+//	resp, err := client.Request("GetUser").Get(ctx, "/users/1")
+type FakeClock struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	now     time.Time
+	waiters []*fakeTimer
+}
+
+// NewFakeClock returns a FakeClock set to now.
+func NewFakeClock(now time.Time) *FakeClock {
+	c := &FakeClock{now: now}
+	c.cond = sync.NewCond(&c.mu)
+	return c
+}
+
+// Now returns the fake current time.
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// After returns a channel that receives the fake time once the clock has
+// advanced by d.
+func (c *FakeClock) After(d time.Duration) <-chan time.Time {
+	return c.NewTimer(d).C()
+}
+
+// NewTimer returns a Timer that fires once the clock has advanced by d.
+func (c *FakeClock) NewTimer(d time.Duration) Timer {
+	t := &fakeTimer{clock: c, ch: make(chan time.Time, 1)}
+	t.Reset(d)
+	return t
+}
+
+// Advance moves the clock forward by d, firing every timer whose deadline
+// is reached.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	c.setLocked(c.now.Add(d))
+	c.mu.Unlock()
+}
+
+// Set moves the clock to t, firing every timer whose deadline is reached.
+// Setting it backwards fires nothing.
+func (c *FakeClock) Set(t time.Time) {
+	c.mu.Lock()
+	c.setLocked(t)
+	c.mu.Unlock()
+}
+
+// BlockUntil blocks until at least n timers are waiting to fire. It lets a
+// test wait for the code under test to start waiting before advancing the
+// clock.
+func (c *FakeClock) BlockUntil(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for len(c.waiters) < n {
+		c.cond.Wait()
+	}
+}
+
+// setLocked moves the clock to t and fires due timers. c.mu must be held.
+func (c *FakeClock) setLocked(t time.Time) {
+	if t.After(c.now) {
+		c.now = t
+	}
+
+	sort.SliceStable(c.waiters, func(i, j int) bool {
+		return c.waiters[i].deadline.Before(c.waiters[j].deadline)
+	})
+	remaining := c.waiters[:0]
+	for _, w := range c.waiters {
+		if w.deadline.After(c.now) {
+			remaining = append(remaining, w)
+			continue
+		}
+		select {
+		case w.ch <- c.now:
+		default:
+		}
+	}
+	c.waiters = remaining
+}
+
+// removeLocked removes t from the waiting timers and reports whether it was
+// waiting. c.mu must be held.
+func (c *FakeClock) removeLocked(t *fakeTimer) bool {
+	for i, w := range c.waiters {
+		if w == t {
+			c.waiters = append(c.waiters[:i], c.waiters[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// fakeTimer is a Timer driven by a FakeClock.
+type fakeTimer struct {
+	clock    *FakeClock
+	ch       chan time.Time
+	deadline time.Time
+}
+
+func (t *fakeTimer) C() <-chan time.Time { return t.ch }
+
+func (t *fakeTimer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	return t.clock.removeLocked(t)
+}
+
+func (t *fakeTimer) Reset(d time.Duration) bool {
+	c := t.clock
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	active := c.removeLocked(t)
+	t.deadline = c.now.Add(d)
+	if d <= 0 {
+		select {
+		case t.ch <- c.now:
+		default:
+		}
+		return active
+	}
+	c.waiters = append(c.waiters, t)
+	c.cond.Broadcast()
+	return active
+}
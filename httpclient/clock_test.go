@@ -0,0 +1,197 @@
+package httpclient
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kroma-labs/sentinel-go/httpclient/mocks"
+	"github.com/sony/gobreaker/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+func TestFakeClock(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("given timers, when advanced, then fires due timers only", func(t *testing.T) {
+		clock := NewFakeClock(start)
+		short := clock.NewTimer(time.Second)
+		long := clock.After(time.Minute)
+
+		clock.Advance(time.Second)
+
+		assert.Equal(t, start.Add(time.Second), clock.Now())
+		assert.Equal(t, start.Add(time.Second), <-short.C())
+		select {
+		case <-long:
+			t.Fatal("long timer fired early")
+		default:
+		}
+
+		clock.Set(start.Add(time.Minute))
+		assert.Equal(t, start.Add(time.Minute), <-long)
+	})
+
+	t.Run("given stopped timer, when advanced, then does not fire", func(t *testing.T) {
+		clock := NewFakeClock(start)
+		timer := clock.NewTimer(time.Second)
+
+		assert.True(t, timer.Stop())
+		assert.False(t, timer.Stop())
+		clock.Advance(time.Second)
+
+		select {
+		case <-timer.C():
+			t.Fatal("stopped timer fired")
+		default:
+		}
+	})
+
+	t.Run("given reset timer, when advanced, then fires at new deadline", func(t *testing.T) {
+		clock := NewFakeClock(start)
+		timer := clock.NewTimer(time.Second)
+
+		assert.True(t, timer.Reset(time.Minute))
+		clock.Advance(time.Second)
+		select {
+		case <-timer.C():
+			t.Fatal("reset timer fired at old deadline")
+		default:
+		}
+
+		clock.Advance(time.Minute)
+		assert.Equal(t, start.Add(time.Minute+time.Second), <-timer.C())
+	})
+
+	t.Run("given waiting goroutine, then BlockUntil returns", func(t *testing.T) {
+		clock := NewFakeClock(start)
+		done := make(chan struct{})
+		go func() {
+			<-clock.After(time.Second)
+			close(done)
+		}()
+
+		clock.BlockUntil(1)
+		clock.Advance(time.Second)
+		<-done
+	})
+}
+
+func TestAfterFunc(t *testing.T) {
+	t.Run("given fake clock, when advanced, then calls f", func(t *testing.T) {
+		clock := NewFakeClock(time.Now())
+		called := make(chan struct{})
+		afterFunc(clock, time.Second, func() { close(called) })
+
+		clock.Advance(time.Second)
+		<-called
+	})
+
+	t.Run("given fake clock, when stopped, then does not call f", func(t *testing.T) {
+		clock := NewFakeClock(time.Now())
+		stop := afterFunc(clock, time.Second, func() { t.Error("f called after stop") })
+
+		assert.True(t, stop())
+		assert.False(t, stop())
+		clock.Advance(time.Second)
+	})
+}
+
+func TestWithClock_Retry(t *testing.T) {
+	clock := NewFakeClock(time.Now())
+
+	mockRT := mocks.NewRoundTripper(t)
+	mockRT.EXPECT().
+		RoundTrip(mock.Anything).
+		Return(&http.Response{
+			StatusCode: http.StatusServiceUnavailable,
+			Body:       io.NopCloser(bytes.NewBufferString("")),
+		}, nil).Once()
+	mockRT.EXPECT().
+		RoundTrip(mock.Anything).
+		Return(&http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewBufferString("OK")),
+		}, nil).Once()
+
+	rt := newRetryTransport(mockRT, newConfig(
+		WithClock(clock),
+		WithRetryConfig(RetryConfig{
+			MaxRetries:      1,
+			InitialInterval: time.Hour,
+			MaxInterval:     time.Hour,
+			Multiplier:      1,
+			MaxElapsedTime:  2 * time.Hour,
+		}),
+	))
+
+	type result struct {
+		resp *http.Response
+		err  error
+	}
+	done := make(chan result, 1)
+	go func() {
+		req := httptest.NewRequest(http.MethodGet, "http://example.com", nil)
+		resp, err := rt.RoundTrip(req)
+		done <- result{resp, err}
+	}()
+
+	// The backoff is about an hour, jitter included; skipping two is
+	// enough without waiting in real time
+	clock.BlockUntil(1)
+	clock.Advance(2 * time.Hour)
+
+	res := <-done
+	require.NoError(t, res.err)
+	defer res.resp.Body.Close()
+	assert.Equal(t, http.StatusOK, res.resp.StatusCode)
+}
+
+func TestWithClock_RateLimit(t *testing.T) {
+	clock := NewFakeClock(time.Now())
+	limiter := rate.NewLimiter(1, 1)
+
+	require.NoError(t, waitForToken(context.Background(), limiter, clock))
+
+	done := make(chan error, 1)
+	go func() {
+		done <- waitForToken(context.Background(), limiter, clock)
+	}()
+
+	clock.BlockUntil(1)
+	select {
+	case <-done:
+		t.Fatal("token granted before the clock advanced")
+	default:
+	}
+
+	clock.Advance(time.Second)
+	require.NoError(t, <-done)
+
+	t.Run("given canceled context, then returns context error", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		go func() {
+			clock.BlockUntil(1)
+			cancel()
+		}()
+		assert.ErrorIs(t, waitForToken(ctx, limiter, clock), context.Canceled)
+	})
+}
+
+func TestWithClock_BreakerWarmup(t *testing.T) {
+	clock := NewFakeClock(time.Now())
+	cfg := BreakerConfig{ConsecutiveFailures: 1, WarmupDuration: time.Minute}
+	readyToTrip := cfg.readyToTrip(clock, clock.Now())
+	counts := gobreaker.Counts{Requests: 1, TotalFailures: 1, ConsecutiveFailures: 1}
+
+	assert.False(t, readyToTrip(counts))
+	clock.Advance(time.Minute)
+	assert.True(t, readyToTrip(counts))
+}
@@ -0,0 +1,71 @@
+package httpclient
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Deadline propagation headers understood by httpserver.DeadlinePropagation.
+const (
+	// RequestTimeoutHeader carries the remaining time budget as a Go
+	// duration in milliseconds, e.g. "2500ms".
+	RequestTimeoutHeader = "X-Request-Timeout"
+
+	// GRPCTimeoutHeader carries the remaining time budget in the gRPC
+	// format, e.g. "2500m".
+	GRPCTimeoutHeader = "grpc-timeout"
+)
+
+// deadlineTransport sets a header with the time left until the request
+// context's deadline on every attempt.
+type deadlineTransport struct {
+	next   http.RoundTripper
+	header string
+	format func(time.Duration) string
+}
+
+// newDeadlineTransport creates a transport that propagates the remaining
+// deadline in header.
+func newDeadlineTransport(next http.RoundTripper, header string) http.RoundTripper {
+	format := formatRequestTimeout
+	if strings.EqualFold(header, GRPCTimeoutHeader) {
+		format = formatGRPCTimeout
+	}
+	return &deadlineTransport{
+		next:   next,
+		header: header,
+		format: format,
+	}
+}
+
+// RoundTrip implements http.RoundTripper. The budget is computed here, below
+// the retry transport, so it excludes time spent on earlier attempts and
+// backoff.
+func (t *deadlineTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	deadline, ok := req.Context().Deadline()
+	if !ok {
+		return t.next.RoundTrip(req)
+	}
+
+	remaining := max(time.Until(deadline), 0)
+	req = req.Clone(req.Context())
+	req.Header.Set(t.header, t.format(remaining))
+	return t.next.RoundTrip(req)
+}
+
+// formatRequestTimeout formats d as whole milliseconds, e.g. "2500ms".
+func formatRequestTimeout(d time.Duration) string {
+	return strconv.FormatInt(d.Milliseconds(), 10) + "ms"
+}
+
+// formatGRPCTimeout formats d in the gRPC timeout format, which allows at
+// most 8 digits: milliseconds when they fit, seconds otherwise.
+func formatGRPCTimeout(d time.Duration) string {
+	const maxValue = 99_999_999
+	if ms := d.Milliseconds(); ms <= maxValue {
+		return strconv.FormatInt(ms, 10) + "m"
+	}
+	return strconv.FormatInt(min(int64(d/time.Second), maxValue), 10) + "S"
+}
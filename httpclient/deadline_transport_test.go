@@ -0,0 +1,142 @@
+package httpclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithPropagateDeadline(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		headers []http.Header
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		headers = append(headers, r.Header.Clone())
+		attempt := len(headers)
+		mu.Unlock()
+		if attempt == 1 && r.URL.Path == "/flaky" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	retry := WithRetryConfig(RetryConfig{
+		MaxRetries:      1,
+		InitialInterval: 50 * time.Millisecond,
+		MaxInterval:     50 * time.Millisecond,
+		Multiplier:      1,
+	})
+
+	tests := []struct {
+		name     string
+		header   string
+		path     string
+		timeout  time.Duration
+		parse    func(string) (time.Duration, error)
+		attempts int
+	}{
+		{
+			name:     "given timeout, then sends remaining budget in milliseconds",
+			header:   RequestTimeoutHeader,
+			path:     "/ok",
+			timeout:  2 * time.Second,
+			parse:    time.ParseDuration,
+			attempts: 1,
+		},
+		{
+			name:    "given retry, then sends the budget left after backoff",
+			header:  GRPCTimeoutHeader,
+			path:    "/flaky",
+			timeout: 2 * time.Second,
+			parse: func(v string) (time.Duration, error) {
+				return time.ParseDuration(v + "s")
+			},
+			attempts: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mu.Lock()
+			headers = nil
+			mu.Unlock()
+
+			client := New(WithBaseURL(server.URL), WithPropagateDeadline(tt.header), retry)
+			resp, err := client.Request("Get").
+				Timeout(tt.timeout).
+				Get(context.Background(), tt.path)
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+			mu.Lock()
+			defer mu.Unlock()
+			require.Len(t, headers, tt.attempts)
+			last := tt.timeout
+			for i, h := range headers {
+				budget, err := tt.parse(h.Get(tt.header))
+				require.NoError(t, err, "attempt %d: %q", i, h.Get(tt.header))
+				assert.Positive(t, budget, "attempt %d", i)
+				if i == 0 {
+					assert.LessOrEqual(t, budget, last)
+				} else {
+					// Backoff with jitter waits at least 25ms
+					assert.LessOrEqual(t, budget, last-25*time.Millisecond, "attempt %d", i)
+				}
+				last = budget
+			}
+		})
+	}
+
+	t.Run("given no deadline, then sends no header", func(t *testing.T) {
+		mu.Lock()
+		headers = nil
+		mu.Unlock()
+
+		client := &http.Client{
+			Transport: newDeadlineTransport(http.DefaultTransport, RequestTimeoutHeader),
+		}
+		resp, err := client.Get(server.URL + "/ok")
+		require.NoError(t, err)
+		resp.Body.Close()
+
+		mu.Lock()
+		defer mu.Unlock()
+		require.Len(t, headers, 1)
+		assert.Empty(t, headers[0].Get(RequestTimeoutHeader))
+	})
+}
+
+func TestFormatGRPCTimeout(t *testing.T) {
+	tests := []struct {
+		name string
+		d    time.Duration
+		want string
+	}{
+		{
+			name: "given short duration, then formats milliseconds",
+			d:    2500 * time.Millisecond,
+			want: "2500m",
+		},
+		{
+			name: "given duration over 8 digits of milliseconds, then formats seconds",
+			d:    48 * time.Hour,
+			want: "172800S",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, formatGRPCTimeout(tt.d))
+		})
+	}
+}
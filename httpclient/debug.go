@@ -5,17 +5,11 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptrace"
-	"os"
 	"sort"
 	"strings"
 	"time"
-
-	"github.com/rs/zerolog"
 )
 
-// debugLogger is the package-level zerolog logger for debug output.
-var debugLogger = zerolog.New(os.Stdout).With().Timestamp().Logger()
-
 // generateCurlCommand creates a cURL command equivalent for the given request.
 //
 // The generated command can be used to reproduce the request from the command line.
@@ -32,8 +26,12 @@ func generateCurlCommand(req *http.Request, body []byte) string {
 
 	parts = append(parts, "curl")
 
-	// Method
-	if req.Method != http.MethodGet {
+	// Method; curl -X HEAD would wait for a body that never comes, so use -I
+	switch req.Method {
+	case http.MethodGet:
+	case http.MethodHead:
+		parts = append(parts, "-I")
+	default:
 		parts = append(parts, "-X", req.Method)
 	}
 
@@ -150,21 +148,21 @@ func (t *requestTracer) toTraceInfo() *TraceInfo {
 	return info
 }
 
-// logRequest logs the request details using zerolog.
-func logRequest(logger zerolog.Logger, req *http.Request) {
-	logger.Debug().
-		Str("method", req.Method).
-		Str("url", req.URL.String()).
-		Str("host", req.Host).
-		Msg("HTTP request")
+// logRequest logs the request details.
+func logRequest(logger Logger, req *http.Request) {
+	logger.Debug("HTTP request",
+		"method", req.Method,
+		"url", req.URL.String(),
+		"host", req.Host,
+	)
 }
 
-// logResponse logs the response details using zerolog.
-func logResponse(logger zerolog.Logger, resp *http.Response, duration time.Duration) {
-	logger.Debug().
-		Int("status", resp.StatusCode).
-		Str("status_text", resp.Status).
-		Dur("duration_ms", duration).
-		Int64("content_length", resp.ContentLength).
-		Msg("HTTP response")
+// logResponse logs the response details.
+func logResponse(logger Logger, resp *http.Response, duration time.Duration) {
+	logger.Debug("HTTP response",
+		"status", resp.StatusCode,
+		"status_text", resp.Status,
+		"duration_ms", duration,
+		"content_length", resp.ContentLength,
+	)
 }
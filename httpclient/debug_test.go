@@ -1,10 +1,16 @@
 package httpclient
 
 import (
+	"bytes"
+	"context"
+	"log/slog"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGenerateCurlCommand(t *testing.T) {
@@ -56,6 +62,16 @@ func TestGenerateCurlCommand(t *testing.T) {
 				"-H", "'Authorization: Bearer token123'",
 			},
 		},
+		{
+			name:    "given HEAD request, then uses -I",
+			method:  http.MethodHead,
+			url:     "https://api.example.com/users",
+			headers: nil,
+			body:    nil,
+			wantContains: []string{
+				"curl -I 'https://api.example.com/users'",
+			},
+		},
 		{
 			name:    "given body with single quotes, then escapes them",
 			method:  http.MethodPost,
@@ -94,3 +110,68 @@ func TestRequestTracer_ToTraceInfo(t *testing.T) {
 		assert.Equal(t, "0s", info.TotalTime)
 	})
 }
+
+// recordingLogger is a Logger that records the messages it receives.
+type recordingLogger struct {
+	messages []string
+}
+
+func (l *recordingLogger) Debug(msg string, _ ...any) { l.messages = append(l.messages, msg) }
+func (l *recordingLogger) Info(msg string, _ ...any)  { l.messages = append(l.messages, msg) }
+func (l *recordingLogger) Error(msg string, _ ...any) { l.messages = append(l.messages, msg) }
+
+func TestWithLogger(t *testing.T) {
+	// log/slog satisfies Logger without an adapter
+	var _ Logger = slog.Default()
+
+	tests := []struct {
+		name         string
+		debug        bool
+		wantMessages []string
+	}{
+		{
+			name:         "given debug enabled, then logs request and response to the logger",
+			debug:        true,
+			wantMessages: []string{"HTTP request", "HTTP response"},
+		},
+		{
+			name: "given debug disabled, then logs nothing",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(
+				func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) },
+			))
+			defer server.Close()
+
+			logger := &recordingLogger{}
+			client := New(
+				WithBaseURL(server.URL),
+				WithRetryDisabled(),
+				WithLogger(logger),
+				WithDebug(tt.debug),
+			)
+
+			_, err := client.Request("Ping").Get(context.Background(), "/")
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantMessages, logger.messages)
+		})
+	}
+}
+
+func TestZerologLogger(t *testing.T) {
+	t.Run("given fields, then writes them as key-value pairs", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := NewZerologLogger(zerolog.New(&buf))
+
+		logger.Error("breaker fallback", "breaker", "payments", "attempt", 2)
+
+		assert.JSONEq(t,
+			`{"level":"error","breaker":"payments","attempt":2,"message":"breaker fallback"}`,
+			buf.String(),
+		)
+	})
+}
@@ -207,6 +207,21 @@
 // The tracker records latencies per-endpoint (using operation name).
 // Until MinSamples is reached, FallbackDelay is used.
 //
+// Samples live in memory. Persist them across restarts with
+// LatencyTracker.Export and Import, and clear an endpoint whose backend
+// changed with LatencyTracker.ResetEndpoint.
+//
+// # Adaptive Timeouts
+//
+// The same tracker can size per-request timeouts, so fast and slow
+// endpoints don't share one hard-coded value:
+//
+//	// Twice the endpoint's P99, between 100ms and 30s; 30s until
+//	// enough samples exist
+//	resp, err := client.Request("GetUser").
+//	    AdaptiveTimeout(httpclient.DefaultAdaptiveTimeoutConfig()).
+//	    Get(ctx, "/users/123")
+//
 // # Request Coalescing
 //
 // Deduplicate simultaneous identical requests using singleflight:
@@ -247,6 +262,13 @@
 //
 // This means Timeout() can only REDUCE the timeout, never extend it.
 //
+// WithPropagateDeadline sends the budget left on each attempt to the
+// downstream service, which can honor it with httpserver.DeadlinePropagation:
+//
+//	client := httpclient.New(
+//	    httpclient.WithPropagateDeadline(httpclient.RequestTimeoutHeader),
+//	)
+//
 // # Rate Limiting
 //
 // Proactively respect API rate limits to prevent 429 errors.
@@ -311,6 +333,12 @@
 //
 // Execution order: Client interceptors → Per-request interceptors → Send
 //
+// A failing interceptor stops the request and returns an *InterceptorError
+// naming it. The failure is counted with error.type "interceptor" rather than
+// as a network error, and recorded as an "http.interceptor.error" span event.
+// Name interceptors with WithNamedRequestInterceptor and
+// WithNamedResponseInterceptor to tell them apart in dashboards.
+//
 // # Mock Transport (Testing)
 //
 // Test HTTP clients without network calls using MockTransport:
@@ -357,6 +385,7 @@
 //   - Spans for each request with method, URL, status code
 //   - Retry events with attempt number and delay
 //   - Network timing events (DNS, TLS, connect)
+//   - Redacted request/response bodies on a sample of spans (WithBodySampling)
 //
 // # Transport Wrapping
 //
@@ -406,10 +435,13 @@
 // Enable debug logging and cURL command generation:
 //
 //	client := httpclient.New(
-//	    httpclient.WithDebug(true),       // Logs requests/responses with zerolog
+//	    httpclient.WithDebug(true),       // Logs requests/responses
 //	    httpclient.WithGenerateCurl(true), // Generates cURL commands
 //	)
 //
+// Logs go to zerolog on stdout unless WithLogger sets another Logger, such
+// as a *slog.Logger.
+//
 //	resp, err := client.Request("Test").
 //	    EnableTrace().  // Capture timing info
 //	    Get(ctx, "/api")
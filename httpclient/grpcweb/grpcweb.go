@@ -0,0 +1,277 @@
+// Package grpcweb reads unary gRPC-Web responses with httpclient, without a
+// full gRPC-Web client.
+//
+// It is a separate package so that clients which don't call gRPC-Web
+// endpoints don't depend on protobuf and gRPC. Requests are sent with the
+// regular request builder; Encode frames the request message and Decode
+// parses the framed response message and trailers.
+//
+// Both the binary (application/grpc-web+proto) and the base64 text
+// (application/grpc-web-text+proto) encodings are read. Compressed messages
+// are not supported.
+//
+// Example:
+//
+//	body, err := grpcweb.Encode(&pb.GetUserRequest{Id: id})
+//	if err != nil {
+//	    return err
+//	}
+//
+//	resp, err := client.Request("GetUser").
+//	    Header("Content-Type", grpcweb.ContentType).
+//	    Header("X-Grpc-Web", "1").
+//	    Body(bytes.NewReader(body)).
+//	    Post(ctx, "/users.v1.UserService/GetUser")
+//	if err != nil {
+//	    return err
+//	}
+//
+//	var user pb.User
+//	if err := grpcweb.Decode(resp, &user); err != nil {
+//	    if status.Code(err) == codes.NotFound {
+//	        return ErrUserNotFound
+//	    }
+//	    return err
+//	}
+package grpcweb
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/textproto"
+	"strconv"
+	"strings"
+
+	"github.com/kroma-labs/sentinel-go/httpclient"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// Content types of gRPC-Web requests and responses.
+const (
+	ContentType     = "application/grpc-web+proto"
+	ContentTypeText = "application/grpc-web-text+proto"
+)
+
+// Frame flags, as defined by the gRPC-Web protocol.
+const (
+	flagCompressed = 0x01
+	flagTrailer    = 0x80
+)
+
+// frameHeaderLen is the length of the flag byte and the big-endian uint32
+// payload length that precede each frame.
+const frameHeaderLen = 5
+
+// ErrMalformedResponse is returned by Decode when the body is not valid
+// gRPC-Web framing.
+var ErrMalformedResponse = errors.New("grpcweb: malformed response")
+
+// Encode marshals msg into a single gRPC-Web data frame, for use as the
+// request body.
+func Encode(msg proto.Message) ([]byte, error) {
+	data, err := proto.Marshal(msg)
+	if err != nil {
+		return nil, fmt.Errorf("grpcweb: marshal request: %w", err)
+	}
+	frame := make([]byte, frameHeaderLen+len(data))
+	binary.BigEndian.PutUint32(frame[1:frameHeaderLen], uint32(len(data)))
+	copy(frame[frameHeaderLen:], data)
+	return frame, nil
+}
+
+// Decode parses a unary gRPC-Web response into msg.
+//
+// The gRPC status is read from the trailer frame, or from the response
+// headers for trailers-only responses. A non-OK status is returned as a
+// gRPC status error, so status.Code and status.FromError work on it; the
+// details from grpc-status-details-bin are included when present. msg is
+// left unchanged in that case.
+//
+// A response without any gRPC status, such as a 502 from a proxy, returns
+// an error describing the HTTP status.
+func Decode(resp *httpclient.Response, msg proto.Message) error {
+	body, err := resp.Body()
+	if err != nil {
+		return fmt.Errorf("grpcweb: read response: %w", err)
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if strings.HasPrefix(mediaType, "application/grpc-web-text") {
+		body, err = decodeText(body)
+		if err != nil {
+			return err
+		}
+	}
+
+	message, trailer, err := parseFrames(body)
+	if err != nil {
+		return err
+	}
+
+	if err := statusError(resp.StatusCode, resp.Header, trailer); err != nil {
+		return err
+	}
+
+	if message == nil {
+		message = []byte{}
+	}
+	if err := proto.Unmarshal(message, msg); err != nil {
+		return fmt.Errorf("grpcweb: unmarshal response: %w", err)
+	}
+	return nil
+}
+
+// decodeText decodes a grpc-web-text body. Each frame may be base64
+// encoded separately, so padded chunks are decoded one at a time.
+func decodeText(body []byte) ([]byte, error) {
+	body = bytes.TrimSpace(body)
+	var out []byte
+	for len(body) > 0 {
+		end := len(body)
+		if i := bytes.IndexByte(body, '='); i >= 0 {
+			end = i + 1
+			for end < len(body) && body[end] == '=' {
+				end++
+			}
+		}
+		chunk := make([]byte, base64.StdEncoding.DecodedLen(end))
+		n, err := base64.StdEncoding.Decode(chunk, body[:end])
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrMalformedResponse, err)
+		}
+		out = append(out, chunk[:n]...)
+		body = body[end:]
+	}
+	return out, nil
+}
+
+// parseFrames splits body into the payload of its data frame and the
+// headers of its trailer frame. Either may be nil when absent.
+func parseFrames(body []byte) (message []byte, trailer http.Header, err error) {
+	for len(body) > 0 {
+		if len(body) < frameHeaderLen {
+			return nil, nil, fmt.Errorf("%w: truncated frame header", ErrMalformedResponse)
+		}
+		flags := body[0]
+		size := binary.BigEndian.Uint32(body[1:frameHeaderLen])
+		body = body[frameHeaderLen:]
+		if uint64(size) > uint64(len(body)) {
+			return nil, nil, fmt.Errorf("%w: truncated frame", ErrMalformedResponse)
+		}
+		payload := body[:size]
+		body = body[size:]
+
+		switch {
+		case flags&flagTrailer != 0:
+			trailer, err = parseTrailer(payload)
+			if err != nil {
+				return nil, nil, err
+			}
+		case flags&flagCompressed != 0:
+			return nil, nil, fmt.Errorf("%w: compressed messages are not supported",
+				ErrMalformedResponse)
+		case message != nil:
+			return nil, nil, fmt.Errorf("%w: more than one message", ErrMalformedResponse)
+		default:
+			message = payload
+		}
+	}
+	return message, trailer, nil
+}
+
+// parseTrailer parses a trailer frame, which holds HTTP/1 style header
+// lines.
+func parseTrailer(payload []byte) (http.Header, error) {
+	trailer := make(http.Header)
+	for _, line := range strings.Split(string(payload), "\r\n") {
+		if line == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			return nil, fmt.Errorf("%w: invalid trailer %q", ErrMalformedResponse, line)
+		}
+		trailer.Add(textproto.CanonicalMIMEHeaderKey(strings.TrimSpace(key)),
+			strings.TrimSpace(value))
+	}
+	return trailer, nil
+}
+
+// statusError returns the gRPC status error for the response, or nil if the
+// status is OK. The trailer takes precedence over the response headers.
+func statusError(httpStatus int, header, trailer http.Header) error {
+	source := trailer
+	if source.Get("Grpc-Status") == "" {
+		source = header
+	}
+
+	raw := source.Get("Grpc-Status")
+	if raw == "" {
+		if httpStatus != http.StatusOK {
+			return fmt.Errorf("grpcweb: unexpected HTTP status %d", httpStatus)
+		}
+		return fmt.Errorf("%w: missing grpc-status", ErrMalformedResponse)
+	}
+	code, err := strconv.ParseUint(raw, 10, 32)
+	if err != nil {
+		return fmt.Errorf("%w: invalid grpc-status %q", ErrMalformedResponse, raw)
+	}
+	if codes.Code(code) == codes.OK {
+		return nil
+	}
+
+	if details := source.Get("Grpc-Status-Details-Bin"); details != "" {
+		if st, ok := decodeStatusDetails(details); ok {
+			return status.ErrorProto(st)
+		}
+	}
+
+	message := source.Get("Grpc-Message")
+	if unescaped, err := decodeGRPCMessage(message); err == nil {
+		message = unescaped
+	}
+	return status.Error(codes.Code(code), message)
+}
+
+// decodeStatusDetails decodes a grpc-status-details-bin value, which is a
+// base64 encoded google.rpc.Status, padded or not.
+func decodeStatusDetails(value string) (*spb.Status, bool) {
+	data, err := base64.RawStdEncoding.DecodeString(strings.TrimRight(value, "="))
+	if err != nil {
+		return nil, false
+	}
+	st := &spb.Status{}
+	if err := proto.Unmarshal(data, st); err != nil {
+		return nil, false
+	}
+	return st, true
+}
+
+// decodeGRPCMessage reverses the percent-encoding applied to grpc-message.
+func decodeGRPCMessage(msg string) (string, error) {
+	if !strings.Contains(msg, "%") {
+		return msg, nil
+	}
+	var b strings.Builder
+	for i := 0; i < len(msg); i++ {
+		if msg[i] == '%' && i+2 < len(msg) {
+			v, err := strconv.ParseUint(msg[i+1:i+3], 16, 8)
+			if err != nil {
+				return "", err
+			}
+			b.WriteByte(byte(v))
+			i += 2
+			continue
+		}
+		b.WriteByte(msg[i])
+	}
+	return b.String(), nil
+}
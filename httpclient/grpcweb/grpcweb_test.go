@@ -0,0 +1,194 @@
+package grpcweb
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kroma-labs/sentinel-go/httpclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestDecode(t *testing.T) {
+	message, err := Encode(wrapperspb.String("hello"))
+	require.NoError(t, err)
+
+	details, err := proto.Marshal(&spb.Status{
+		Code:    int32(codes.NotFound),
+		Message: "user 42 not found",
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		contentType string
+		header      http.Header
+		status      int
+		body        []byte
+		wantValue   string
+		wantCode    codes.Code
+		wantMessage string
+		wantErr     error
+	}{
+		{
+			name:      "given message and OK trailer, then decodes message",
+			body:      concat(message, trailerFrame("grpc-status: 0\r\ngrpc-message: \r\n")),
+			wantValue: "hello",
+		},
+		{
+			name:        "given text encoding, then decodes message",
+			contentType: ContentTypeText,
+			body: []byte(base64.StdEncoding.EncodeToString(message) +
+				base64.StdEncoding.EncodeToString(trailerFrame("grpc-status: 0\r\n"))),
+			wantValue: "hello",
+		},
+		{
+			name: "given non-zero status in trailer, then returns status error",
+			body: trailerFrame(
+				"grpc-status: 5\r\ngrpc-message: user%2042%20not%20found\r\n"),
+			wantCode:    codes.NotFound,
+			wantMessage: "user 42 not found",
+		},
+		{
+			name: "given status details, then returns status from details",
+			body: trailerFrame("grpc-status: 5\r\ngrpc-status-details-bin: " +
+				base64.RawStdEncoding.EncodeToString(details) + "\r\n"),
+			wantCode:    codes.NotFound,
+			wantMessage: "user 42 not found",
+		},
+		{
+			name: "given trailers-only response, then returns status from headers",
+			header: http.Header{
+				"Grpc-Status":  {"16"},
+				"Grpc-Message": {"token expired"},
+			},
+			wantCode:    codes.Unauthenticated,
+			wantMessage: "token expired",
+		},
+		{
+			name:    "given truncated frame, then returns malformed error",
+			body:    message[:len(message)-1],
+			wantErr: ErrMalformedResponse,
+		},
+		{
+			name:    "given message without status, then returns malformed error",
+			body:    message,
+			wantErr: ErrMalformedResponse,
+		},
+		{
+			name:   "given non-200 without status, then returns HTTP status error",
+			status: http.StatusNotFound,
+			body:   []byte("404 page not found"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := func(w http.ResponseWriter, _ *http.Request) {
+				contentType := tt.contentType
+				if contentType == "" {
+					contentType = ContentType
+				}
+				w.Header().Set("Content-Type", contentType)
+				for k, v := range tt.header {
+					w.Header()[k] = v
+				}
+				if tt.status != 0 {
+					w.WriteHeader(tt.status)
+				}
+				_, _ = w.Write(tt.body)
+			}
+			server := httptest.NewServer(http.HandlerFunc(handler))
+			defer server.Close()
+
+			client := httpclient.New(httpclient.WithBaseURL(server.URL))
+			resp, err := client.Request("GetGreeting").Post(context.Background(), "/greeter")
+			require.NoError(t, err)
+
+			var got wrapperspb.StringValue
+			err = Decode(resp, &got)
+
+			switch {
+			case tt.wantValue != "":
+				require.NoError(t, err)
+				assert.Equal(t, tt.wantValue, got.GetValue())
+			case tt.wantCode != codes.OK:
+				st, ok := status.FromError(err)
+				require.True(t, ok, "expected status error, got %v", err)
+				assert.Equal(t, tt.wantCode, st.Code())
+				assert.Equal(t, tt.wantMessage, st.Message())
+			case tt.wantErr != nil:
+				assert.ErrorIs(t, err, tt.wantErr)
+			default:
+				assert.Error(t, err)
+				assert.Equal(t, codes.Unknown, status.Code(err))
+			}
+		})
+	}
+}
+
+func TestEncode(t *testing.T) {
+	frame, err := Encode(wrapperspb.String("hello"))
+	require.NoError(t, err)
+
+	require.GreaterOrEqual(t, len(frame), frameHeaderLen)
+	assert.Equal(t, byte(0), frame[0])
+	assert.Equal(t, uint32(len(frame)-frameHeaderLen), binary.BigEndian.Uint32(frame[1:5]))
+
+	var got wrapperspb.StringValue
+	require.NoError(t, proto.Unmarshal(frame[frameHeaderLen:], &got))
+	assert.Equal(t, "hello", got.GetValue())
+}
+
+func TestDecode_RequestRoundTrip(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, ContentType, r.Header.Get("Content-Type"))
+		body, _ := io.ReadAll(r.Body)
+
+		var in wrapperspb.StringValue
+		require.NoError(t, proto.Unmarshal(body[frameHeaderLen:], &in))
+		out, _ := Encode(wrapperspb.String("hello " + in.GetValue()))
+
+		w.Header().Set("Content-Type", ContentType)
+		_, _ = w.Write(concat(out, trailerFrame("grpc-status: 0\r\n")))
+	}))
+	defer server.Close()
+
+	body, err := Encode(wrapperspb.String("world"))
+	require.NoError(t, err)
+
+	client := httpclient.New(httpclient.WithBaseURL(server.URL))
+	resp, err := client.Request("Greet").
+		Header("Content-Type", ContentType).
+		Body(bytes.NewReader(body)).
+		Post(context.Background(), "/greeter.v1.Greeter/Greet")
+	require.NoError(t, err)
+
+	var got wrapperspb.StringValue
+	require.NoError(t, Decode(resp, &got))
+	assert.Equal(t, "hello world", got.GetValue())
+}
+
+// trailerFrame returns a trailer frame holding the given header lines.
+func trailerFrame(lines string) []byte {
+	frame := make([]byte, frameHeaderLen+len(lines))
+	frame[0] = flagTrailer
+	binary.BigEndian.PutUint32(frame[1:frameHeaderLen], uint32(len(lines)))
+	copy(frame[frameHeaderLen:], lines)
+	return frame
+}
+
+func concat(frames ...[]byte) []byte {
+	return bytes.Join(frames, nil)
+}
@@ -1,6 +1,15 @@
 package httpclient
 
-import "time"
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
 
 // HedgeConfig configures hedged requests for tail latency optimization.
 //
@@ -58,3 +67,63 @@ type HedgeConfig struct {
 func (c HedgeConfig) Enabled() bool {
 	return c.Delay > 0 && c.MaxHedges > 0
 }
+
+// hedgeSuppressed reports whether hedges should not be sent because the
+// circuit breaker is degraded. See BreakerConfig.IndependentHedging.
+func (cfg *internalConfig) hedgeSuppressed() bool {
+	return cfg.breaker != nil &&
+		!cfg.BreakerConfig.IndependentHedging &&
+		cfg.breaker.degraded()
+}
+
+// replayDisabledKey is the context key for the replayDisabled of a request
+// whose body was too large to buffer for hedging or coalescing.
+type replayDisabledKey struct{}
+
+// replayDisabled records that hedging and coalescing were skipped for a
+// request because its body could not be buffered.
+type replayDisabled struct {
+	hedge           bool
+	coalesce        bool
+	maxBufferedBody int64
+}
+
+// record adds an http.hedge.disabled and an http.coalesce.disabled span
+// event for the features that were requested but skipped.
+func (d replayDisabled) record(span trace.Span) {
+	if !span.IsRecording() {
+		return
+	}
+
+	if d.hedge {
+		span.AddEvent("http.hedge.disabled", trace.WithAttributes(
+			attribute.String("hedge.disabled.reason", "body_too_large"),
+			attribute.Int64("hedge.max_buffered_body", d.maxBufferedBody),
+		))
+	}
+	if d.coalesce {
+		span.AddEvent("http.coalesce.disabled", trace.WithAttributes(
+			attribute.String("coalesce.disabled.reason", "body_too_large"),
+			attribute.Int64("coalesce.max_buffered_body", d.maxBufferedBody),
+		))
+	}
+}
+
+// isRewindable reports whether http.NewRequest can rewind body on its own,
+// so buffering it costs no more than the copy already held in memory.
+func isRewindable(body io.Reader) bool {
+	switch body.(type) {
+	case *bytes.Buffer, *bytes.Reader, *strings.Reader:
+		return true
+	default:
+		return false
+	}
+}
+
+// recordHedgeSuppressed records a hedge that was not sent as a
+// "hedge.suppressed" event on the span in ctx and in metrics.
+func (cfg *internalConfig) recordHedgeSuppressed(ctx context.Context) {
+	attr := attribute.String("breaker.name", cfg.breaker.name)
+	trace.SpanFromContext(ctx).AddEvent("hedge.suppressed", trace.WithAttributes(attr))
+	cfg.Metrics.recordHedgeSuppressed(ctx, cfg.breaker.name)
+}
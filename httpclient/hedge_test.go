@@ -2,14 +2,21 @@ package httpclient
 
 import (
 	"context"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 )
 
 func TestHedgeConfig_Enabled(t *testing.T) {
@@ -420,3 +427,195 @@ func TestHedge_DoesNotBreakNormalFlow(t *testing.T) {
 		})
 	}
 }
+
+func TestRequestBuilder_HedgeSuppressedByBreaker(t *testing.T) {
+	type args struct {
+		failures           int
+		independentHedging bool
+	}
+
+	tests := []struct {
+		name           string
+		args           args
+		wantRequests   int32
+		wantSuppressed bool
+	}{
+		{
+			name:         "given healthy circuit, then hedge is sent",
+			args:         args{failures: 0},
+			wantRequests: 2,
+		},
+		{
+			name:           "given high failure ratio, then hedge is suppressed",
+			args:           args{failures: 1},
+			wantRequests:   1,
+			wantSuppressed: true,
+		},
+		{
+			name: "given high failure ratio and independent hedging, then hedge is sent",
+			args: args{
+				failures:           1,
+				independentHedging: true,
+			},
+			wantRequests: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requestCount atomic.Int32
+
+			server := httptest.NewServer(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					if r.URL.Path == "/fail" {
+						w.WriteHeader(http.StatusInternalServerError)
+						return
+					}
+					if requestCount.Add(1) == 1 {
+						time.Sleep(100 * time.Millisecond)
+					}
+					w.WriteHeader(http.StatusOK)
+				}),
+			)
+			defer server.Close()
+
+			exporter := tracetest.NewInMemoryExporter()
+			tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+			defer tp.Shutdown(context.Background())
+			reader := sdkmetric.NewManualReader()
+			mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+			defer mp.Shutdown(context.Background())
+
+			breakerCfg := DefaultBreakerConfig()
+			breakerCfg.IndependentHedging = tt.args.independentHedging
+			client := New(
+				WithBaseURL(server.URL),
+				WithRetryDisabled(),
+				WithMeterProvider(mp),
+				WithBreakerConfig(breakerCfg),
+			)
+
+			for range tt.args.failures {
+				_, err := client.Request("Fail").Get(context.Background(), "/fail")
+				require.NoError(t, err)
+			}
+
+			ctx, span := tp.Tracer("test").Start(context.Background(), "parent")
+			resp, err := client.Request("Test").
+				Hedge(20*time.Millisecond).
+				Get(ctx, "/test")
+			span.End()
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+			// Wait for the slow original request to finish
+			time.Sleep(150 * time.Millisecond)
+			assert.Equal(t, tt.wantRequests, requestCount.Load())
+
+			spans := exporter.GetSpans()
+			require.Len(t, spans, 1)
+			var suppressed bool
+			for _, event := range spans[0].Events {
+				if event.Name == "hedge.suppressed" {
+					suppressed = true
+				}
+			}
+			assert.Equal(t, tt.wantSuppressed, suppressed)
+
+			var rm metricdata.ResourceMetrics
+			require.NoError(t, reader.Collect(context.Background(), &rm))
+			var count int64
+			for _, sm := range rm.ScopeMetrics {
+				for _, m := range sm.Metrics {
+					if m.Name != "http.client.hedge.suppressed" {
+						continue
+					}
+					for _, dp := range m.Data.(metricdata.Sum[int64]).DataPoints {
+						count += dp.Value
+					}
+				}
+			}
+			assert.Equal(t, tt.wantSuppressed, count > 0)
+		})
+	}
+}
+
+func TestRequestBuilder_HedgeBodyReplay(t *testing.T) {
+	tests := []struct {
+		name            string
+		maxBufferedBody int64
+		wantBodies      []string
+		wantEvent       bool
+	}{
+		{
+			name:       "given one-shot body within cap, then hedge resends it",
+			wantBodies: []string{"payload", "payload"},
+		},
+		{
+			name:            "given one-shot body over cap, then sends it once without hedging",
+			maxBufferedBody: 4,
+			wantBodies:      []string{"payload"},
+			wantEvent:       true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				mu     sync.Mutex
+				bodies []string
+			)
+			server := httptest.NewServer(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					body, _ := io.ReadAll(r.Body)
+					mu.Lock()
+					bodies = append(bodies, string(body))
+					first := len(bodies) == 1
+					mu.Unlock()
+					if first {
+						time.Sleep(100 * time.Millisecond)
+					}
+					w.WriteHeader(http.StatusOK)
+				}),
+			)
+			defer server.Close()
+
+			exporter := tracetest.NewInMemoryExporter()
+			tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+			defer tp.Shutdown(context.Background())
+
+			client := New(
+				WithBaseURL(server.URL),
+				WithTracerProvider(tp),
+				WithRetryConfig(RetryConfig{MaxBufferedBody: tt.maxBufferedBody}),
+			)
+
+			// MultiReader hides the strings.Reader, so the body can't be rewound
+			resp, err := client.Request("Test").
+				Hedge(20*time.Millisecond).
+				Body(io.MultiReader(strings.NewReader("payload"))).
+				Post(context.Background(), "/test")
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			// Reading the body ends the client span
+			_, err = resp.Body()
+			require.NoError(t, err)
+
+			// Wait for the slow original request to finish
+			time.Sleep(150 * time.Millisecond)
+			mu.Lock()
+			assert.Equal(t, tt.wantBodies, bodies)
+			mu.Unlock()
+
+			var disabled bool
+			for _, span := range exporter.GetSpans() {
+				for _, event := range span.Events {
+					if event.Name == "http.hedge.disabled" {
+						disabled = true
+					}
+				}
+			}
+			assert.Equal(t, tt.wantEvent, disabled)
+		})
+	}
+}
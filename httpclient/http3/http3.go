@@ -0,0 +1,57 @@
+// Package http3 adds HTTP/3 support to httpclient using quic-go.
+//
+// It is a separate package so that clients which don't use HTTP/3 don't
+// depend on quic-go. The HTTP/3 transport replaces only the http.Transport
+// at the bottom of the client's transport chain: tracing, metrics, retries
+// and the other client layers still wrap it.
+//
+// Network tracing works as for TCP, with the QUIC handshake, which also
+// performs the TLS handshake, reported as both the connect and the TLS
+// timings. Connections are never reported as idle, since the transport
+// doesn't track it.
+//
+// Example:
+//
+//	client := sentinelhttpclient.New(
+//	    sentinelhttpclient.WithBaseURL("https://api.example.com"),
+//	    http3.WithHTTP3(),
+//	)
+package http3
+
+import (
+	"github.com/kroma-labs/sentinel-go/httpclient"
+	"github.com/quic-go/quic-go/http3"
+)
+
+// WithHTTP3 sends requests over HTTP/3 with a default quic-go transport.
+//
+// The server must support HTTP/3; there is no fallback to HTTP/1.1 or
+// HTTP/2. Transport options such as WithTLSConfig and WithProxyURL don't
+// apply to the HTTP/3 transport; use WithTransport to configure TLS and
+// QUIC instead.
+//
+// Example:
+//
+//	client := sentinelhttpclient.New(
+//	    sentinelhttpclient.WithBaseURL("https://api.example.com"),
+//	    http3.WithHTTP3(),
+//	)
+func WithHTTP3() httpclient.Option {
+	return WithTransport(&http3.Transport{})
+}
+
+// WithTransport sends requests over HTTP/3 with the given quic-go transport,
+// for custom TLS or QUIC configuration.
+//
+// Example:
+//
+//	client := sentinelhttpclient.New(
+//	    sentinelhttpclient.WithBaseURL("https://api.example.com"),
+//	    http3.WithTransport(&quichttp3.Transport{
+//	        TLSClientConfig: &tls.Config{RootCAs: pool},
+//	        QUICConfig:      &quic.Config{MaxIdleTimeout: time.Minute},
+//	    }),
+//	)
+func WithTransport(t *http3.Transport) httpclient.Option {
+	return httpclient.WithBaseTransport(t)
+}
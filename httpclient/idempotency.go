@@ -0,0 +1,32 @@
+package httpclient
+
+import "context"
+
+// IdempotencyKeyHeader is the header carrying the idempotency key of a
+// request.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// idempotencyKeyKey is the context key for the idempotency key.
+type idempotencyKeyKey struct{}
+
+// ContextWithIdempotencyKey returns a copy of ctx carrying an idempotency
+// key, for RequestBuilder.IdempotencyFromContext to send downstream.
+//
+// Call it once where the operation enters the system, e.g. in a gateway, so
+// every service along the call chain deduplicates on the same key.
+//
+// Example:
+//
+//	ctx = httpclient.ContextWithIdempotencyKey(ctx, r.Header.Get("Idempotency-Key"))
+func ContextWithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyKey{}, key)
+}
+
+// IdempotencyKeyFromContext returns the idempotency key stored in ctx by
+// ContextWithIdempotencyKey, or an empty string if there is none.
+func IdempotencyKeyFromContext(ctx context.Context) string {
+	if key, ok := ctx.Value(idempotencyKeyKey{}).(string); ok {
+		return key
+	}
+	return ""
+}
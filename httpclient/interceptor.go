@@ -1,6 +1,7 @@
 package httpclient
 
 import (
+	"fmt"
 	"net/http"
 )
 
@@ -24,10 +25,52 @@ type RequestInterceptor func(req *http.Request) error
 //   - Custom error handling
 type ResponseInterceptor func(resp *http.Response, req *http.Request) error
 
+// Interceptor phases reported in InterceptorError.Phase.
+const (
+	InterceptorPhaseRequest  = "request"
+	InterceptorPhaseResponse = "response"
+)
+
+// InterceptorError is returned when a request or response interceptor fails.
+//
+// Interceptor failures are recorded with error.type "interceptor" in the
+// http.client.request.error metric, separately from network errors, and
+// as an "http.interceptor.error" event on the span in the request context.
+// Use errors.As to find out which interceptor failed:
+//
+//	var ierr *httpclient.InterceptorError
+//	if errors.As(err, &ierr) {
+//	    log.Printf("%s interceptor %q failed: %v", ierr.Phase, ierr.Name, ierr.Err)
+//	}
+type InterceptorError struct {
+	// Name is the name the interceptor was added with, or a generated name
+	// for unnamed interceptors: "request_interceptor_0" for client-level
+	// ones and "per_request_interceptor_0" for those added with Intercept.
+	Name string
+
+	// Phase is InterceptorPhaseRequest or InterceptorPhaseResponse.
+	Phase string
+
+	// Err is the error returned by the interceptor.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *InterceptorError) Error() string {
+	return fmt.Sprintf("httpclient: %s interceptor %q: %v", e.Phase, e.Name, e.Err)
+}
+
+// Unwrap returns the error returned by the interceptor.
+func (e *InterceptorError) Unwrap() error {
+	return e.Err
+}
+
 // InterceptorChain manages request and response interceptors.
 type InterceptorChain struct {
 	requestInterceptors  []RequestInterceptor
 	responseInterceptors []ResponseInterceptor
+	requestNames         []string
+	responseNames        []string
 }
 
 // NewInterceptorChain creates an empty interceptor chain.
@@ -37,31 +80,59 @@ func NewInterceptorChain() *InterceptorChain {
 
 // AddRequestInterceptor adds a request interceptor to the chain.
 func (c *InterceptorChain) AddRequestInterceptor(i RequestInterceptor) {
+	c.AddNamedRequestInterceptor("", i)
+}
+
+// AddNamedRequestInterceptor adds a request interceptor to the chain under
+// a name that identifies it in errors, metrics and traces.
+func (c *InterceptorChain) AddNamedRequestInterceptor(name string, i RequestInterceptor) {
+	if name == "" {
+		name = fmt.Sprintf("request_interceptor_%d", len(c.requestInterceptors))
+	}
 	c.requestInterceptors = append(c.requestInterceptors, i)
+	c.requestNames = append(c.requestNames, name)
 }
 
 // AddResponseInterceptor adds a response interceptor to the chain.
 func (c *InterceptorChain) AddResponseInterceptor(i ResponseInterceptor) {
+	c.AddNamedResponseInterceptor("", i)
+}
+
+// AddNamedResponseInterceptor adds a response interceptor to the chain under
+// a name that identifies it in errors, metrics and traces.
+func (c *InterceptorChain) AddNamedResponseInterceptor(name string, i ResponseInterceptor) {
+	if name == "" {
+		name = fmt.Sprintf("response_interceptor_%d", len(c.responseInterceptors))
+	}
 	c.responseInterceptors = append(c.responseInterceptors, i)
+	c.responseNames = append(c.responseNames, name)
 }
 
 // ApplyRequestInterceptors runs all request interceptors in order.
-// Returns an error if any interceptor fails.
+// Returns an *InterceptorError if any interceptor fails.
 func (c *InterceptorChain) ApplyRequestInterceptors(req *http.Request) error {
-	for _, interceptor := range c.requestInterceptors {
+	for idx, interceptor := range c.requestInterceptors {
 		if err := interceptor(req); err != nil {
-			return err
+			return &InterceptorError{
+				Name:  c.requestNames[idx],
+				Phase: InterceptorPhaseRequest,
+				Err:   err,
+			}
 		}
 	}
 	return nil
 }
 
 // ApplyResponseInterceptors runs all response interceptors in order.
-// Returns an error if any interceptor fails.
+// Returns an *InterceptorError if any interceptor fails.
 func (c *InterceptorChain) ApplyResponseInterceptors(resp *http.Response, req *http.Request) error {
-	for _, interceptor := range c.responseInterceptors {
+	for idx, interceptor := range c.responseInterceptors {
 		if err := interceptor(resp, req); err != nil {
-			return err
+			return &InterceptorError{
+				Name:  c.responseNames[idx],
+				Phase: InterceptorPhaseResponse,
+				Err:   err,
+			}
 		}
 	}
 	return nil
@@ -10,6 +10,11 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 )
 
 func TestAuthBearerInterceptor(t *testing.T) {
@@ -297,3 +302,118 @@ func TestCorrelationIDInterceptor(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "corr-id-2", capturedCorrelationID)
 }
+
+func TestInterceptor_ErrorRecorded(t *testing.T) {
+	t.Parallel()
+
+	errInterceptor := errors.New("token unavailable")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	failRequest := func(_ *http.Request) error { return errInterceptor }
+	failResponse := func(_ *http.Response, _ *http.Request) error { return errInterceptor }
+
+	tests := []struct {
+		name      string
+		opts      []Option
+		intercept RequestInterceptor
+		wantName  string
+		wantPhase string
+	}{
+		{
+			name:      "given named request interceptor, then reports its name",
+			opts:      []Option{WithNamedRequestInterceptor("auth", failRequest)},
+			wantName:  "auth",
+			wantPhase: InterceptorPhaseRequest,
+		},
+		{
+			name: "given unnamed request interceptor, then reports generated name",
+			opts: []Option{
+				WithRequestInterceptor(UserAgentInterceptor("test")),
+				WithRequestInterceptor(failRequest),
+			},
+			wantName:  "request_interceptor_1",
+			wantPhase: InterceptorPhaseRequest,
+		},
+		{
+			name:      "given named response interceptor, then reports response phase",
+			opts:      []Option{WithNamedResponseInterceptor("audit", failResponse)},
+			wantName:  "audit",
+			wantPhase: InterceptorPhaseResponse,
+		},
+		{
+			name:      "given per-request interceptor, then reports its index",
+			intercept: failRequest,
+			wantName:  "per_request_interceptor_0",
+			wantPhase: InterceptorPhaseRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exporter := tracetest.NewInMemoryExporter()
+			tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+			defer tp.Shutdown(context.Background())
+			reader := sdkmetric.NewManualReader()
+			mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+			defer mp.Shutdown(context.Background())
+
+			opts := append([]Option{WithBaseURL(server.URL), WithMeterProvider(mp)}, tt.opts...)
+			client := New(opts...)
+
+			rb := client.Request("Test")
+			if tt.intercept != nil {
+				rb = rb.Intercept(tt.intercept)
+			}
+
+			ctx, span := tp.Tracer("test").Start(context.Background(), "parent")
+			_, err := rb.Get(ctx, "/test")
+			span.End()
+
+			require.ErrorIs(t, err, errInterceptor)
+			var interceptorErr *InterceptorError
+			require.ErrorAs(t, err, &interceptorErr)
+			assert.Equal(t, tt.wantName, interceptorErr.Name)
+			assert.Equal(t, tt.wantPhase, interceptorErr.Phase)
+			assert.Equal(t, ErrorTypeInterceptor, classifyError(err))
+
+			spans := exporter.GetSpans()
+			require.Len(t, spans, 1)
+			var eventName attribute.Value
+			for _, event := range spans[0].Events {
+				if event.Name != "http.interceptor.error" {
+					continue
+				}
+				for _, attr := range event.Attributes {
+					if attr.Key == "interceptor.name" {
+						eventName = attr.Value
+					}
+				}
+			}
+			assert.Equal(t, tt.wantName, eventName.AsString())
+
+			var rm metricdata.ResourceMetrics
+			require.NoError(t, reader.Collect(context.Background(), &rm))
+			var count int64
+			for _, sm := range rm.ScopeMetrics {
+				for _, m := range sm.Metrics {
+					if m.Name != "http.client.request.error" {
+						continue
+					}
+					for _, dp := range m.Data.(metricdata.Sum[int64]).DataPoints {
+						errorType, _ := dp.Attributes.Value("error.type")
+						name, _ := dp.Attributes.Value("interceptor.name")
+						if errorType.AsString() == ErrorTypeInterceptor &&
+							name.AsString() == tt.wantName {
+							count += dp.Value
+						}
+					}
+				}
+			}
+			assert.Equal(t, int64(1), count)
+		})
+	}
+}
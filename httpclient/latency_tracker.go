@@ -68,6 +68,17 @@ func (t *LatencyTracker) Record(endpoint string, latency time.Duration) {
 //
 // p should be between 0 and 1 (e.g., 0.95 for P95).
 // Returns false if insufficient samples are available.
+//
+// Example - report the P95 adaptive hedging uses as a gauge:
+//
+//	meter.Float64ObservableGauge("users.latency.p95",
+//	    metric.WithFloat64Callback(func(_ context.Context, o metric.Float64Observer) error {
+//	        if p95, ok := tracker.Percentile("GetUser", 0.95); ok {
+//	            o.Observe(p95.Seconds())
+//	        }
+//	        return nil
+//	    }),
+//	)
 func (t *LatencyTracker) Percentile(endpoint string, p float64) (time.Duration, bool) {
 	t.mu.RLock()
 	defer t.mu.RUnlock()
@@ -112,6 +123,76 @@ func (t *LatencyTracker) Reset() {
 	t.endpoints = make(map[string]*latencyWindow)
 }
 
+// ResetEndpoint clears the samples for one endpoint, e.g. after a known
+// backend change made its latency history stale. Hedging for the endpoint
+// falls back to FallbackDelay until MinSamples new samples are recorded.
+func (t *LatencyTracker) ResetEndpoint(endpoint string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.endpoints, endpoint)
+}
+
+// LatencySnapshot holds a tracker's latency samples per endpoint, oldest
+// first. It marshals to JSON, with samples in nanoseconds.
+type LatencySnapshot map[string][]time.Duration
+
+// Export returns a copy of the tracked samples, to be persisted and loaded
+// with Import after a restart so adaptive hedging doesn't fall back to
+// FallbackDelay while samples rebuild.
+//
+// Example:
+//
+//	// On shutdown
+//	data, _ := json.Marshal(httpclient.DefaultLatencyTracker().Export())
+//	os.WriteFile("latency.json", data, 0o600)
+//
+//	// On startup
+//	var snapshot httpclient.LatencySnapshot
+//	if data, err := os.ReadFile("latency.json"); err == nil {
+//	    if json.Unmarshal(data, &snapshot) == nil {
+//	        httpclient.DefaultLatencyTracker().Import(snapshot)
+//	    }
+//	}
+func (t *LatencyTracker) Export() LatencySnapshot {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	snapshot := make(LatencySnapshot, len(t.endpoints))
+	for endpoint, window := range t.endpoints {
+		samples := make([]time.Duration, 0, window.count)
+		start := window.head - window.count
+		if start < 0 {
+			start += t.windowSize
+		}
+		for i := range window.count {
+			samples = append(samples, window.samples[(start+i)%t.windowSize])
+		}
+		snapshot[endpoint] = samples
+	}
+	return snapshot
+}
+
+// Import replaces the samples of each endpoint in snapshot. Endpoints not in
+// the snapshot keep their samples. Only the newest samples that fit the
+// tracker's window are kept.
+func (t *LatencyTracker) Import(snapshot LatencySnapshot) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for endpoint, samples := range snapshot {
+		if len(samples) > t.windowSize {
+			samples = samples[len(samples)-t.windowSize:]
+		}
+		window := &latencyWindow{
+			samples: make([]time.Duration, t.windowSize),
+			count:   len(samples),
+		}
+		copy(window.samples, samples)
+		window.head = len(samples) % t.windowSize
+		t.endpoints[endpoint] = window
+	}
+}
+
 // defaultLatencyTracker is the global tracker used when not explicitly provided.
 var defaultLatencyTracker = NewLatencyTracker(100, 10)
 
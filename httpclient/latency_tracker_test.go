@@ -199,3 +199,66 @@ func TestLatencyTracker_PerEndpoint(t *testing.T) {
 	assert.Equal(t, 20*time.Millisecond, usersP50)
 	assert.Equal(t, 200*time.Millisecond, postsP50)
 }
+
+func TestLatencyTracker_ResetEndpoint(t *testing.T) {
+	tracker := NewLatencyTracker(100, 1)
+	tracker.Record("/users", 10*time.Millisecond)
+	tracker.Record("/posts", 20*time.Millisecond)
+
+	tracker.ResetEndpoint("/users")
+
+	assert.Equal(t, 0, tracker.Count("/users"))
+	assert.Equal(t, 1, tracker.Count("/posts"))
+}
+
+func TestLatencyTracker_ExportImport(t *testing.T) {
+	tests := []struct {
+		name       string
+		windowSize int
+		latencies  []time.Duration
+		importSize int
+		want       []time.Duration
+	}{
+		{
+			name:       "given partial window, then round-trips samples in order",
+			windowSize: 5,
+			latencies:  []time.Duration{1, 2, 3},
+			importSize: 5,
+			want:       []time.Duration{1, 2, 3},
+		},
+		{
+			name:       "given wrapped window, then exports oldest first",
+			windowSize: 3,
+			latencies:  []time.Duration{1, 2, 3, 4, 5},
+			importSize: 3,
+			want:       []time.Duration{3, 4, 5},
+		},
+		{
+			name:       "given smaller importing window, then keeps newest samples",
+			windowSize: 5,
+			latencies:  []time.Duration{1, 2, 3, 4},
+			importSize: 2,
+			want:       []time.Duration{3, 4},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracker := NewLatencyTracker(tt.windowSize, 1)
+			for _, latency := range tt.latencies {
+				tracker.Record("/users", latency)
+			}
+
+			restored := NewLatencyTracker(tt.importSize, 1)
+			restored.Import(tracker.Export())
+
+			assert.Equal(t, LatencySnapshot{"/users": tt.want}, restored.Export())
+
+			// New samples keep evicting the oldest after an import
+			restored.Record("/users", 100)
+			got := restored.Export()["/users"]
+			assert.Equal(t, time.Duration(100), got[len(got)-1])
+			assert.LessOrEqual(t, len(got), tt.importSize)
+		})
+	}
+}
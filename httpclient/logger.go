@@ -0,0 +1,64 @@
+package httpclient
+
+import (
+	"os"
+
+	"github.com/rs/zerolog"
+)
+
+// Logger receives the client's debug request/response logs and internal
+// warnings, such as a circuit breaker falling back to local mode.
+//
+// Fields are alternating key-value pairs, as in log/slog, so a *slog.Logger
+// can be passed as is. Other logging stacks need a small adapter.
+//
+// Example:
+//
+//	client := httpclient.New(
+//	    httpclient.WithLogger(slog.Default()),
+//	    httpclient.WithDebug(true),
+//	)
+type Logger interface {
+	Debug(msg string, fields ...any)
+	Info(msg string, fields ...any)
+	Error(msg string, fields ...any)
+}
+
+// defaultLogger is the Logger used unless WithLogger is set.
+var defaultLogger = NewZerologLogger(zerolog.New(os.Stdout).With().Timestamp().Logger())
+
+// zerologLogger adapts a zerolog.Logger to Logger.
+type zerologLogger struct {
+	logger zerolog.Logger
+}
+
+// NewZerologLogger returns a Logger that writes to a zerolog.Logger.
+//
+// Example:
+//
+//	client := httpclient.New(
+//	    httpclient.WithLogger(httpclient.NewZerologLogger(log.Logger)),
+//	)
+func NewZerologLogger(logger zerolog.Logger) Logger {
+	return zerologLogger{logger: logger}
+}
+
+func (l zerologLogger) Debug(msg string, fields ...any) {
+	l.logger.Debug().Fields(fields).Msg(msg)
+}
+
+func (l zerologLogger) Info(msg string, fields ...any) {
+	l.logger.Info().Fields(fields).Msg(msg)
+}
+
+func (l zerologLogger) Error(msg string, fields ...any) {
+	l.logger.Error().Fields(fields).Msg(msg)
+}
+
+// logger returns the configured Logger, or the default zerolog logger.
+func (cfg *internalConfig) logger() Logger {
+	if cfg.Logger == nil {
+		return defaultLogger
+	}
+	return cfg.Logger
+}
@@ -6,6 +6,7 @@ import (
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // metrics holds the metric instruments for HTTP client operations.
@@ -75,8 +76,18 @@ type metrics struct {
 	breakerState metric.Int64Gauge
 
 	// breakerRequests counts circuit breaker requests by result.
-	// result tag: success, failure, rejected
+	// result tag: success, failure, rejected, canceled
 	breakerRequests metric.Int64Counter
+
+	// === Hedging Metrics ===
+
+	// hedgeSuppressed counts hedges not sent because the circuit breaker
+	// was degraded.
+	hedgeSuppressed metric.Int64Counter
+
+	// noExemplars hides the span in ctx from request duration
+	// measurements, so the SDK attaches no exemplar.
+	noExemplars bool
 }
 
 // newMetrics creates and registers metric instruments.
@@ -231,7 +242,7 @@ func newMetrics(meter metric.Meter) (*metrics, error) {
 	// Retry exhausted counter
 	m.retryExhausted, err = meter.Int64Counter(
 		"http.client.retry.exhausted",
-		metric.WithDescription("Number of requests that exhausted all retries"),
+		metric.WithDescription("Number of requests that gave up retrying, by reason"),
 		metric.WithUnit("{request}"),
 	)
 	if err != nil {
@@ -272,10 +283,23 @@ func newMetrics(meter metric.Meter) (*metrics, error) {
 		return nil, err
 	}
 
+	// Hedge suppressed counter
+	m.hedgeSuppressed, err = meter.Int64Counter(
+		"http.client.hedge.suppressed",
+		metric.WithDescription(
+			"Number of hedges not sent because the circuit breaker was degraded",
+		),
+		metric.WithUnit("{hedge}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	return m, nil
 }
 
-// recordRequestDuration records the duration of an HTTP request.
+// recordRequestDuration records the duration of an HTTP request, with the
+// span in ctx as an exemplar unless exemplars are disabled.
 func (m *metrics) recordRequestDuration(
 	ctx context.Context,
 	duration time.Duration,
@@ -284,6 +308,10 @@ func (m *metrics) recordRequestDuration(
 	if m == nil || m.requestDuration == nil {
 		return
 	}
+	if m.noExemplars {
+		// The SDK samples exemplars from the span in ctx, so hide it
+		ctx = trace.ContextWithSpanContext(ctx, trace.SpanContext{})
+	}
 	m.requestDuration.Record(ctx, duration.Seconds(), metric.WithAttributes(attrs...))
 }
 
@@ -402,23 +430,39 @@ func (m *metrics) recordError(ctx context.Context, errorType string, attrs []att
 	m.requestErrors.Add(ctx, 1, metric.WithAttributes(allAttrs...))
 }
 
-// recordRetryAttempt records a retry attempt.
-func (m *metrics) recordRetryAttempt(ctx context.Context, attrs []attribute.KeyValue, attempt int) {
+// recordRetryAttempt records a retry attempt and the classifier's reason for it.
+func (m *metrics) recordRetryAttempt(
+	ctx context.Context,
+	attrs []attribute.KeyValue,
+	attempt int,
+	reason string,
+) {
 	if m == nil || m.retryAttempts == nil {
 		return
 	}
-	allAttrs := make([]attribute.KeyValue, 0, len(attrs)+1)
+	allAttrs := make([]attribute.KeyValue, 0, len(attrs)+2)
 	allAttrs = append(allAttrs, attrs...)
-	allAttrs = append(allAttrs, attribute.Int("retry.attempt", attempt))
+	allAttrs = append(allAttrs,
+		attribute.Int("retry.attempt", attempt),
+		attribute.String("retry.reason", reason),
+	)
 	m.retryAttempts.Add(ctx, 1, metric.WithAttributes(allAttrs...))
 }
 
-// recordRetryExhausted records when all retries have been exhausted.
-func (m *metrics) recordRetryExhausted(ctx context.Context, attrs []attribute.KeyValue) {
+// recordRetryExhausted records a retry loop that gave up, with the reason
+// it stopped: "max_retries", "elapsed" or "backoff_stop".
+func (m *metrics) recordRetryExhausted(
+	ctx context.Context,
+	attrs []attribute.KeyValue,
+	reason string,
+) {
 	if m == nil || m.retryExhausted == nil {
 		return
 	}
-	m.retryExhausted.Add(ctx, 1, metric.WithAttributes(attrs...))
+	allAttrs := make([]attribute.KeyValue, 0, len(attrs)+1)
+	allAttrs = append(allAttrs, attrs...)
+	allAttrs = append(allAttrs, attribute.String("retry.exhausted.reason", reason))
+	m.retryExhausted.Add(ctx, 1, metric.WithAttributes(allAttrs...))
 }
 
 // recordRetryDuration records the total time spent in a retry loop.
@@ -453,3 +497,13 @@ func (m *metrics) recordBreakerRequest(ctx context.Context, name string, result
 		attribute.String("breaker.result", result),
 	))
 }
+
+// recordHedgeSuppressed records a hedge suppressed by the circuit breaker.
+func (m *metrics) recordHedgeSuppressed(ctx context.Context, name string) {
+	if m == nil || m.hedgeSuppressed == nil {
+		return
+	}
+	m.hedgeSuppressed.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("breaker.name", name),
+	))
+}
@@ -2,6 +2,8 @@ package httpclient
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -9,7 +11,9 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/otel/attribute"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/exemplar"
 	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 )
 
 func TestNewMetrics(t *testing.T) {
@@ -364,3 +368,81 @@ func TestMetricsNilHistogramSafety(t *testing.T) {
 		})
 	})
 }
+
+func TestMetricsExemplars(t *testing.T) {
+	tests := []struct {
+		name          string
+		filter        exemplar.Filter
+		opts          []Option
+		wantExemplars bool
+	}{
+		{
+			name:          "given default exemplar filter, then links duration to trace",
+			filter:        exemplar.TraceBasedFilter,
+			wantExemplars: true,
+		},
+		{
+			name:   "given exemplars disabled, then records no exemplars",
+			filter: exemplar.TraceBasedFilter,
+			opts:   []Option{WithExemplars(false)},
+		},
+		{
+			name:   "given exemplars turned off in the SDK, then records no exemplars",
+			filter: exemplar.AlwaysOffFilter,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}
+			server := httptest.NewServer(http.HandlerFunc(handler))
+			defer server.Close()
+
+			reader := sdkmetric.NewManualReader()
+			mp := sdkmetric.NewMeterProvider(
+				sdkmetric.WithReader(reader),
+				sdkmetric.WithExemplarFilter(tt.filter),
+			)
+			defer mp.Shutdown(context.Background())
+			tp := sdktrace.NewTracerProvider(sdktrace.WithSampler(sdktrace.AlwaysSample()))
+			defer tp.Shutdown(context.Background())
+
+			opts := append([]Option{
+				WithBaseURL(server.URL),
+				WithMeterProvider(mp),
+				WithTracerProvider(tp),
+			}, tt.opts...)
+			client := New(opts...)
+
+			_, err := client.Request("GetOrder").Get(context.Background(), "/orders/1")
+			require.NoError(t, err)
+
+			var rm metricdata.ResourceMetrics
+			require.NoError(t, reader.Collect(context.Background(), &rm))
+
+			var exemplars []metricdata.Exemplar[float64]
+			for _, sm := range rm.ScopeMetrics {
+				for _, m := range sm.Metrics {
+					if m.Name != "http.client.request.duration" {
+						continue
+					}
+					hist, ok := m.Data.(metricdata.Histogram[float64])
+					require.True(t, ok)
+					for _, dp := range hist.DataPoints {
+						exemplars = append(exemplars, dp.Exemplars...)
+					}
+				}
+			}
+
+			if !tt.wantExemplars {
+				assert.Empty(t, exemplars)
+				return
+			}
+			require.Len(t, exemplars, 1)
+			assert.Len(t, exemplars[0].TraceID, 16)
+			assert.Len(t, exemplars[0].SpanID, 8)
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/http/httptrace"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/cenkalti/backoff/v5"
@@ -473,6 +474,13 @@ type internalConfig struct {
 	// Metrics holds the metric instruments.
 	Metrics *metrics
 
+	// DisableExemplars hides the request's span from request duration
+	// measurements, so they carry no exemplar.
+	DisableExemplars bool
+
+	// counters holds the counters behind Client.MetricsSnapshot.
+	counters clientCounters
+
 	// === Service Identification ===
 
 	// ServiceName identifies the HTTP client for tracing purposes.
@@ -495,6 +503,11 @@ type internalConfig struct {
 	// If nil and ProxyFromEnvironment is true, uses environment variables.
 	ProxyURL *url.URL
 
+	// BaseTransport replaces the http.Transport built from the configuration.
+	// If nil, the http.Transport is built from Config, TLSConfig and the
+	// proxy settings.
+	BaseTransport http.RoundTripper
+
 	// ProxyFromEnvironment uses HTTP_PROXY, HTTPS_PROXY and NO_PROXY
 	// environment variables. Default: true
 	ProxyFromEnvironment bool
@@ -518,6 +531,10 @@ type internalConfig struct {
 	// MetricAttributesFn adds dynamic attributes to metrics based on request.
 	MetricAttributesFn func(*http.Request) []attribute.KeyValue
 
+	// ResponseSpanAttributesFn adds attributes derived from the response
+	// to the client span.
+	ResponseSpanAttributesFn func(*http.Response) []attribute.KeyValue
+
 	// === Context Propagation ===
 
 	// Propagators configures the context propagators.
@@ -539,6 +556,12 @@ type internalConfig struct {
 	// Default: DefaultClassifier
 	RetryClassifier RetryClassifier
 
+	// RetryReasonClassifier determines which errors/responses should trigger
+	// retries, and why. If set, it is used instead of RetryClassifier.
+	// Default: DefaultClassifierWithReason, or RetryClassifier with
+	// RetryReasonCustom if only that is set
+	RetryReasonClassifier ClassifierWithReason
+
 	// RetryBackOff allows providing a custom backoff strategy.
 	// If nil, uses ExponentialBackOff based on RetryConfig.
 	RetryBackOff backoff.BackOff
@@ -549,6 +572,14 @@ type internalConfig struct {
 	// If nil, the circuit breaker is disabled.
 	BreakerConfig *BreakerConfig
 
+	// BreakerSuccessPredicate decides breaker failures from the response
+	// and its body. If set, it is used instead of BreakerConfig.Classifier.
+	BreakerSuccessPredicate BreakerSuccessPredicate
+
+	// breaker is the circuit breaker transport built from BreakerConfig,
+	// used to suppress hedging while the circuit is degraded.
+	breaker *circuitBreakerTransport
+
 	// === Chaos Injection Configuration ===
 
 	// ChaosConfig holds the chaos injection configuration for testing.
@@ -561,6 +592,18 @@ type internalConfig struct {
 	// If nil or RequestsPerSecond <= 0, rate limiting is disabled.
 	RateLimitConfig *RateLimitConfig
 
+	// === Body Sampling Configuration ===
+
+	// bodySampling captures the bodies of a fraction of requests on their
+	// spans. If nil, bodies are never captured.
+	bodySampling *bodySampling
+
+	// === Deadline Propagation Configuration ===
+
+	// DeadlineHeader is the header carrying the remaining deadline budget.
+	// If empty, deadlines are not propagated.
+	DeadlineHeader string
+
 	// === Interceptor Configuration ===
 
 	// Interceptors holds the client-level interceptor chain.
@@ -576,15 +619,28 @@ type internalConfig struct {
 	// DefaultHeaders are applied to all requests.
 	DefaultHeaders http.Header
 
-	// Debug enables request/response logging to zerolog.
+	// Debug enables request/response logging to Logger.
 	Debug bool
 
+	// Logger receives debug logs and internal warnings.
+	// Default: zerolog to stdout
+	Logger Logger
+
+	// Clock is the time source for retries, rate limiting, hedging and
+	// the breaker warm-up.
+	// Default: the system clock
+	Clock Clock
+
 	// GenerateCurl enables cURL command generation for debugging.
 	GenerateCurl bool
 
 	// EnableTrace enables timing trace info collection.
 	EnableTrace bool
 
+	// Decoders maps media types to the decoders used for response bodies.
+	// Keys are lowercase media types without parameters.
+	Decoders map[string]Decoder
+
 	// === Testing Configuration ===
 
 	// MockTransport is an optional mock transport for testing.
@@ -614,6 +670,9 @@ func newConfig(opts ...Option) *internalConfig {
 
 	// Initialize metrics (ignore errors, will just be nil if fails)
 	cfg.Metrics, _ = newMetrics(cfg.Meter)
+	if cfg.Metrics != nil {
+		cfg.Metrics.noExemplars = cfg.DisableExemplars
+	}
 
 	// Initialize retry defaults if not explicitly configured.
 	// We check if RetryConfig is still the zero value (not configured).
@@ -624,8 +683,13 @@ func newConfig(opts ...Option) *internalConfig {
 	if cfg.RetryConfig == zeroConfig {
 		cfg.RetryConfig = DefaultRetryConfig()
 	}
-	if cfg.RetryClassifier == nil {
-		cfg.RetryClassifier = DefaultClassifier
+	if cfg.RetryReasonClassifier == nil {
+		if cfg.RetryClassifier == nil {
+			cfg.RetryClassifier = DefaultClassifier
+			cfg.RetryReasonClassifier = DefaultClassifierWithReason
+		} else {
+			cfg.RetryReasonClassifier = withCustomReason(cfg.RetryClassifier)
+		}
 	}
 
 	return cfg
@@ -810,6 +874,26 @@ func WithMeterProvider(mp metric.MeterProvider) Option {
 	}
 }
 
+// WithExemplars controls whether http.client.request.duration measurements
+// carry exemplars with the trace and span ID of the request, so a dashboard
+// can jump from a latency spike to the trace behind it.
+//
+// Exemplars are recorded by default, following the OTel SDK's exemplar
+// filter, which keeps them for sampled spans. Pass false for backends that
+// reject exemplars.
+//
+// Example:
+//
+//	client := sentinelhttpclient.New(
+//	    sentinelhttpclient.WithServiceName("my-service"),
+//	    sentinelhttpclient.WithExemplars(false),
+//	)
+func WithExemplars(enabled bool) Option {
+	return func(cfg *internalConfig) {
+		cfg.DisableExemplars = !enabled
+	}
+}
+
 // WithTLSConfig sets a custom TLS configuration.
 // Use this for custom certificate verification, client certificates (mTLS),
 // or specific TLS version requirements.
@@ -836,6 +920,26 @@ func WithTLSConfig(tlsCfg *tls.Config) Option {
 	}
 }
 
+// WithBaseTransport replaces the http.Transport at the bottom of the
+// transport chain with rt. Tracing, metrics, the circuit breaker, rate
+// limiting, retries and chaos injection still wrap rt, but options that
+// configure the http.Transport, such as WithTLSConfig and WithProxyURL,
+// no longer apply and must be set on rt instead.
+//
+// Network tracing relies on rt calling the httptrace hooks in the request
+// context; timings for hooks it never calls are omitted.
+//
+// Example - HTTP/3 transport (see the httpclient/http3 package):
+//
+//	client := sentinelhttpclient.New(
+//	    sentinelhttpclient.WithBaseTransport(&http3.Transport{}),
+//	)
+func WithBaseTransport(rt http.RoundTripper) Option {
+	return func(cfg *internalConfig) {
+		cfg.BaseTransport = rt
+	}
+}
+
 // WithProxyURL sets a specific proxy URL for all requests.
 // When set, this takes precedence over environment variables.
 //
@@ -871,6 +975,11 @@ func WithProxyFromEnvironment(enabled bool) Option {
 // WithDisableNetworkTrace disables the httptrace integration that provides
 // detailed network-level timing (DNS lookup, TLS handshake, connection time).
 //
+// When enabled, each network phase is recorded as a timestamped span event
+// (dns.start, dns.done, connect.start, connect.done, tls.start, tls.done,
+// got_conn, wrote_request, got_first_response_byte), for every attempt and
+// including phases that failed, so the span shows the full waterfall.
+//
 // You might disable this to:
 //   - Reduce tracing overhead in extremely high-throughput scenarios
 //   - Simplify trace output when network timing is not needed
@@ -981,6 +1090,33 @@ func WithMetricAttributesFn(f func(*http.Request) []attribute.KeyValue) Option {
 	}
 }
 
+// WithResponseSpanAttributesFn sets a function to add attributes derived
+// from the response to the client span, such as the upstream's request ID,
+// cache status, or rate-limit headers. This lets a trace be correlated with
+// the upstream's own logs.
+//
+// The function is called once per response, before the span ends, with the
+// response headers available and the body unread. It is not called when the
+// request fails without a response.
+//
+// Example:
+//
+//	client := sentinelhttpclient.New(
+//	    sentinelhttpclient.WithResponseSpanAttributesFn(
+//	        func(resp *http.Response) []attribute.KeyValue {
+//	            return []attribute.KeyValue{
+//	                attribute.String("upstream.request_id", resp.Header.Get("X-Request-ID")),
+//	                attribute.String("upstream.cache", resp.Header.Get("X-Cache")),
+//	            }
+//	        },
+//	    ),
+//	)
+func WithResponseSpanAttributesFn(f func(*http.Response) []attribute.KeyValue) Option {
+	return func(cfg *internalConfig) {
+		cfg.ResponseSpanAttributesFn = f
+	}
+}
+
 // WithPropagators sets custom context propagators for trace context injection.
 // By default, W3C TraceContext and Baggage propagators are used.
 //
@@ -1087,9 +1223,39 @@ func WithRetryDisabled() Option {
 //	        return sentinelhttpclient.DefaultClassifier(resp, err)
 //	    }),
 //	)
+//
+// Retries decided by a custom classifier are recorded with the reason
+// RetryReasonCustom; use WithRetryClassifierWithReason to report reasons.
 func WithRetryClassifier(c RetryClassifier) Option {
 	return func(cfg *internalConfig) {
 		cfg.RetryClassifier = c
+		cfg.RetryReasonClassifier = nil
+	}
+}
+
+// WithRetryClassifierWithReason sets a custom function to determine if a
+// request should be retried and why. The reason is recorded on retry metrics
+// and span events; see ClassifierWithReason.
+//
+// Example - Retry 500s, keeping the default rules and reasons otherwise:
+//
+//	client := sentinelhttpclient.New(
+//	    sentinelhttpclient.WithRetryClassifierWithReason(
+//	        func(resp *http.Response, err error) (bool, string) {
+//	            if resp != nil && resp.StatusCode == http.StatusInternalServerError {
+//	                return true, "status_500"
+//	            }
+//	            return sentinelhttpclient.DefaultClassifierWithReason(resp, err)
+//	        },
+//	    ),
+//	)
+func WithRetryClassifierWithReason(c ClassifierWithReason) Option {
+	return func(cfg *internalConfig) {
+		cfg.RetryReasonClassifier = c
+		cfg.RetryClassifier = func(resp *http.Response, err error) bool {
+			retry, _ := c(resp, err)
+			return retry
+		}
 	}
 }
 
@@ -1175,6 +1341,35 @@ func WithBreakerConfig(c BreakerConfig) Option {
 	}
 }
 
+// WithBreakerSuccessPredicate sets a function that decides whether a request
+// succeeded for circuit breaker purposes, from the response and its body.
+// It is used instead of BreakerConfig.Classifier, for APIs that report
+// failures as 200 responses with an error envelope.
+//
+// The predicate receives up to the first 64 KiB of the body, which stays
+// readable by the caller. Reading it delays the response until those bytes
+// arrive, so streamed responses are passed with a nil body. It has no
+// effect unless a breaker is configured with WithBreakerConfig.
+//
+// Example:
+//
+//	client := httpclient.New(
+//	    httpclient.WithBreakerConfig(httpclient.DefaultBreakerConfig()),
+//	    httpclient.WithBreakerSuccessPredicate(
+//	        func(resp *http.Response, body []byte, err error) bool {
+//	            if httpclient.DefaultBreakerClassifier(resp, err) {
+//	                return false
+//	            }
+//	            return !bytes.Contains(body, []byte(`"status":"error"`))
+//	        },
+//	    ),
+//	)
+func WithBreakerSuccessPredicate(p BreakerSuccessPredicate) Option {
+	return func(cfg *internalConfig) {
+		cfg.BreakerSuccessPredicate = p
+	}
+}
+
 // WithChaos enables chaos injection for testing resilience patterns.
 //
 // Chaos injection allows you to simulate failures in development/testing
@@ -1207,6 +1402,71 @@ func WithChaos(c ChaosConfig) Option {
 	}
 }
 
+// WithBodySampling records the request and response bodies of a random
+// fraction of requests on their spans, as the "http.request.body" and
+// "http.response.body" attributes.
+//
+// rate is the fraction of requests sampled, from 0 to 1. Each body is cut
+// to maxBytes (4 KiB if maxBytes <= 0), marked with a ".truncated"
+// attribute when cut, and then passed to redactor, which should mask
+// credentials and personal data before the body leaves the process. A nil
+// redactor records bodies as is.
+//
+// Request bodies are read before sending; without GetBody, the read part
+// is buffered in memory. Response bodies are captured as the caller reads
+// them, so only the part actually read is recorded. Use a low rate: bodies
+// make spans larger and more expensive to export.
+//
+// Example:
+//
+//	client := httpclient.New(
+//	    httpclient.WithBodySampling(0.01, 2048, func(body []byte) []byte {
+//	        return passwordPattern.ReplaceAll(body, []byte(`"password":"***"`))
+//	    }),
+//	)
+func WithBodySampling(rate float64, maxBytes int, redactor func([]byte) []byte) Option {
+	return func(cfg *internalConfig) {
+		if rate <= 0 {
+			cfg.bodySampling = nil
+			return
+		}
+		if maxBytes <= 0 {
+			maxBytes = defaultBodySampleBytes
+		}
+		cfg.bodySampling = &bodySampling{
+			rate:     rate,
+			maxBytes: maxBytes,
+			redactor: redactor,
+		}
+	}
+}
+
+// WithPropagateDeadline sends the time left until the request context's
+// deadline in the given header, so the downstream service can stop work it
+// can't finish in time.
+//
+// The budget is computed for every attempt, after earlier attempts and
+// retry backoff have used part of it. Requests without a deadline are sent
+// without the header. RequestTimeoutHeader values are whole milliseconds
+// ("2500ms"); the GRPCTimeoutHeader uses the gRPC format ("2500m"). Both are
+// understood by httpserver.DeadlinePropagation.
+//
+// Example:
+//
+//	client := httpclient.New(
+//	    httpclient.WithPropagateDeadline(httpclient.RequestTimeoutHeader),
+//	)
+//
+//	// Sends X-Request-Timeout: 2000ms, or less on retries
+//	resp, err := client.Request("GetUser").
+//	    Timeout(2*time.Second).
+//	    Get(ctx, "/users/1")
+func WithPropagateDeadline(header string) Option {
+	return func(cfg *internalConfig) {
+		cfg.DeadlineHeader = header
+	}
+}
+
 // =============================================================================
 // Request Builder Options
 // =============================================================================
@@ -1264,12 +1524,14 @@ func WithDefaultHeader(key, value string) Option {
 	}
 }
 
-// WithDebug enables request/response logging using zerolog.
+// WithDebug enables request/response logging at debug level.
 //
 // When enabled, the client logs:
 //   - Request method and URL
 //   - Response status and duration
 //
+// Logs go to the Logger set with WithLogger, zerolog to stdout by default.
+//
 // Example:
 //
 //	client := httpclient.New(
@@ -1281,6 +1543,57 @@ func WithDebug(enabled bool) Option {
 	}
 }
 
+// WithLogger sets the Logger for debug request/response logs and internal
+// warnings, so they go to the application's logging stack.
+//
+// Example (log/slog):
+//
+//	client := httpclient.New(
+//	    httpclient.WithLogger(slog.Default()),
+//	    httpclient.WithDebug(true),
+//	)
+//
+// Example (zap):
+//
+//	type zapLogger struct{ s *zap.SugaredLogger }
+//
+//	func (l zapLogger) Debug(msg string, fields ...any) { l.s.Debugw(msg, fields...) }
+//	func (l zapLogger) Info(msg string, fields ...any)  { l.s.Infow(msg, fields...) }
+//	func (l zapLogger) Error(msg string, fields ...any) { l.s.Errorw(msg, fields...) }
+//
+//	client := httpclient.New(httpclient.WithLogger(zapLogger{zap.S()}))
+func WithLogger(logger Logger) Option {
+	return func(cfg *internalConfig) {
+		cfg.Logger = logger
+	}
+}
+
+// WithClock sets the time source for retry backoff and MaxElapsedTime,
+// rate limiting, hedge delays, adaptive hedging latencies, the circuit
+// breaker warm-up and chaos latency.
+//
+// It is meant for tests: with a FakeClock, time-dependent behavior can be
+// driven by advancing the clock instead of sleeping. The breaker's open
+// state Timeout and Interval are managed by gobreaker and always use the
+// system clock.
+//
+// Example:
+//
+//	clock := httpclient.NewFakeClock(time.Now())
+//	client := httpclient.New(
+//	    httpclient.WithClock(clock),
+//	    httpclient.WithRateLimit(httpclient.RateLimitConfig{
+//	        RequestsPerSecond: 1,
+//	        Burst:             1,
+//	        WaitOnLimit:       true,
+//	    }),
+//	)
+func WithClock(clock Clock) Option {
+	return func(cfg *internalConfig) {
+		cfg.Clock = clock
+	}
+}
+
 // WithGenerateCurl enables cURL command generation for debugging.
 //
 // When enabled, each response will have a CurlCommand() method that
@@ -1302,6 +1615,36 @@ func WithGenerateCurl(enabled bool) Option {
 	}
 }
 
+// WithDecoders sets the decoders used by Decode and DecodeError, by the
+// media type of the response Content-Type. They are added to the built-in
+// decoders for application/json, application/xml and text/xml, and replace
+// them for the same media type.
+//
+// Media types are matched case-insensitively, ignoring parameters such as
+// charset. Types with a +json or +xml suffix, like application/problem+json,
+// use the built-in decoders. Bodies of any other content type are decoded
+// as JSON.
+//
+// Example:
+//
+//	client := httpclient.New(
+//	    httpclient.WithDecoders(map[string]httpclient.Decoder{
+//	        "application/x-msgpack": func(body []byte, target any) error {
+//	            return msgpack.Unmarshal(body, target)
+//	        },
+//	    }),
+//	)
+func WithDecoders(decoders map[string]Decoder) Option {
+	return func(cfg *internalConfig) {
+		if cfg.Decoders == nil {
+			cfg.Decoders = make(map[string]Decoder, len(decoders))
+		}
+		for mediaType, decoder := range decoders {
+			cfg.Decoders[strings.ToLower(mediaType)] = decoder
+		}
+	}
+}
+
 // WithRateLimit configures client-level rate limiting.
 //
 // All requests made by this client will be subject to the rate limit.
@@ -1406,3 +1749,40 @@ func WithResponseInterceptor(i ResponseInterceptor) Option {
 		c.Interceptors.AddResponseInterceptor(i)
 	}
 }
+
+// WithNamedRequestInterceptor adds a request interceptor like
+// WithRequestInterceptor, under a name that identifies it when it fails.
+//
+// A failing interceptor's error is returned as an *InterceptorError with
+// this name, counted with error.type "interceptor" and an interceptor.name
+// attribute in the request errors metric, and recorded as an
+// "http.interceptor.error" span event. Unnamed interceptors get generated
+// names such as "request_interceptor_0".
+//
+// Example:
+//
+//	client := httpclient.New(
+//	    httpclient.WithNamedRequestInterceptor("auth",
+//	        httpclient.AuthBearerFuncInterceptor(tokenManager.GetToken)),
+//	)
+func WithNamedRequestInterceptor(name string, i RequestInterceptor) Option {
+	return func(c *internalConfig) {
+		if c.Interceptors == nil {
+			c.Interceptors = NewInterceptorChain()
+		}
+		c.Interceptors.AddNamedRequestInterceptor(name, i)
+	}
+}
+
+// WithNamedResponseInterceptor adds a response interceptor like
+// WithResponseInterceptor, under a name that identifies it when it fails.
+//
+// See WithNamedRequestInterceptor for how failures are reported.
+func WithNamedResponseInterceptor(name string, i ResponseInterceptor) Option {
+	return func(c *internalConfig) {
+		if c.Interceptors == nil {
+			c.Interceptors = NewInterceptorChain()
+		}
+		c.Interceptors.AddNamedResponseInterceptor(name, i)
+	}
+}
@@ -43,10 +43,15 @@ type rateLimitTransport struct {
 	next    http.RoundTripper
 	limiter *rate.Limiter
 	wait    bool
+	clock   Clock
 }
 
 // newRateLimitTransport creates a rate-limited transport wrapper.
-func newRateLimitTransport(next http.RoundTripper, cfg RateLimitConfig) http.RoundTripper {
+func newRateLimitTransport(
+	next http.RoundTripper,
+	cfg RateLimitConfig,
+	clock Clock,
+) http.RoundTripper {
 	if cfg.RequestsPerSecond <= 0 {
 		return next // No rate limiting
 	}
@@ -57,6 +62,7 @@ func newRateLimitTransport(next http.RoundTripper, cfg RateLimitConfig) http.Rou
 		next:    next,
 		limiter: limiter,
 		wait:    cfg.WaitOnLimit,
+		clock:   clock,
 	}
 }
 
@@ -66,15 +72,12 @@ func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error
 
 	if t.wait {
 		// Wait for token, respecting context deadline
-		if err := t.limiter.Wait(ctx); err != nil {
-			if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
-				return nil, err
-			}
-			return nil, ErrRateLimited
+		if err := waitForToken(ctx, t.limiter, t.clock); err != nil {
+			return nil, err
 		}
 	} else {
 		// Fail fast if no token available
-		if !t.limiter.Allow() {
+		if !t.limiter.AllowN(t.clock.Now(), 1) {
 			return nil, ErrRateLimited
 		}
 	}
@@ -82,6 +85,37 @@ func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error
 	return t.next.RoundTrip(req)
 }
 
+// waitForToken waits on clock until limiter allows an event. Like
+// rate.Limiter.Wait, it returns ErrRateLimited without waiting if the token
+// would only become available after the context deadline, and the context
+// error if the context is done first.
+func waitForToken(ctx context.Context, limiter *rate.Limiter, clock Clock) error {
+	now := clock.Now()
+	r := limiter.ReserveN(now, 1)
+	if !r.OK() {
+		return ErrRateLimited
+	}
+
+	delay := r.DelayFrom(now)
+	if delay == 0 {
+		return nil
+	}
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+		r.CancelAt(now)
+		return ErrRateLimited
+	}
+
+	timer := clock.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C():
+		return nil
+	case <-ctx.Done():
+		r.CancelAt(clock.Now())
+		return ctx.Err()
+	}
+}
+
 // requestRateLimiter manages per-endpoint rate limiters.
 type requestRateLimiter struct {
 	mu       sync.RWMutex
@@ -128,7 +162,12 @@ type RequestRateLimitConfig struct {
 
 // applyRequestRateLimit checks and applies per-request rate limiting.
 // Returns an error if rate limit is exceeded and WaitOnLimit is false.
-func applyRequestRateLimit(ctx context.Context, key string, cfg RequestRateLimitConfig) error {
+func applyRequestRateLimit(
+	ctx context.Context,
+	key string,
+	cfg RequestRateLimitConfig,
+	clock Clock,
+) error {
 	if cfg.RequestsPerSecond <= 0 {
 		return nil // No rate limiting
 	}
@@ -141,10 +180,10 @@ func applyRequestRateLimit(ctx context.Context, key string, cfg RequestRateLimit
 	limiter := globalRequestLimiter.getOrCreate(key, cfg.RequestsPerSecond, burst)
 
 	if cfg.WaitOnLimit {
-		return limiter.Wait(ctx)
+		return waitForToken(ctx, limiter, clock)
 	}
 
-	if !limiter.Allow() {
+	if !limiter.AllowN(clock.Now(), 1) {
 		return ErrRateLimited
 	}
 	return nil
@@ -195,7 +234,7 @@ func (t *rateLimitTransport) GetStats() RateLimiterStats {
 // ReserveN attempts to reserve n tokens without blocking.
 // Returns the duration to wait before the reservation is valid.
 func (t *rateLimitTransport) ReserveN(n int) time.Duration {
-	r := t.limiter.ReserveN(time.Now(), n)
+	r := t.limiter.ReserveN(t.clock.Now(), n)
 	if !r.OK() {
 		return -1 // Cannot satisfy request
 	}
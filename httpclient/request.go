@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"context"
 	"encoding/xml"
+	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptrace"
@@ -12,6 +14,8 @@ import (
 	"time"
 
 	json "github.com/goccy/go-json"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // RequestBuilder provides a fluent API for constructing HTTP requests.
@@ -26,6 +30,7 @@ type RequestBuilder struct {
 	client              *Client
 	operationName       string
 	path                string
+	method              string
 	pathParams          map[string]string
 	queryParams         url.Values
 	headers             http.Header
@@ -38,14 +43,27 @@ type RequestBuilder struct {
 	adaptiveHedgeConfig *AdaptiveHedgeConfig
 	coalesce            bool
 	timeout             time.Duration
+	adaptiveTimeout     *AdaptiveTimeoutConfig
+	retries             *uint
+	deadline            time.Time
+	values              []contextValue
+	metricAttrs         []attribute.KeyValue
+	baggage             []baggageMember
+	idempotencyFromCtx  bool
 	rateLimitRPS        float64
 	requestInterceptors []RequestInterceptor
+	stream              func(item json.RawMessage) error
 
 	// Multipart upload fields
 	fileUploads []FileUpload
 	formFields  map[string]string
 }
 
+// contextValue is a key-value pair added to the request context by WithValue.
+type contextValue struct {
+	key, val any
+}
+
 // bodyEncodingError is an io.Reader that returns an error.
 type bodyEncodingError struct {
 	err error
@@ -71,6 +89,22 @@ func (rb *RequestBuilder) Path(path string) *RequestBuilder {
 	return rb
 }
 
+// Method sets the HTTP method used when the request is sent by
+// Client.Batch. Default: GET
+//
+// Get, Post and the other method-named functions ignore it.
+//
+// Example:
+//
+//	req := client.Request("CreateUser").
+//	    Method(http.MethodPost).
+//	    Path("/users").
+//	    Body(user)
+func (rb *RequestBuilder) Method(method string) *RequestBuilder {
+	rb.method = method
+	return rb
+}
+
 // PathParam sets a path parameter value.
 //
 // Path parameters are replaced in the path string using {name} syntax.
@@ -583,6 +617,172 @@ func (rb *RequestBuilder) Timeout(d time.Duration) *RequestBuilder {
 	return rb
 }
 
+// AdaptiveTimeout sets the per-request timeout from the endpoint's observed
+// latency: Multiplier times the Percentile latency, clamped to [Min, Max],
+// or Max until enough samples exist. Latency is tracked per endpoint like
+// AdaptiveHedge, by operation name, or by path if the name is empty.
+//
+// Like Timeout, it can only reduce the effective timeout. When both are
+// set, the shorter wins.
+//
+// Example:
+//
+//	resp, err := client.Request("GetUser").
+//	    AdaptiveTimeout(httpclient.AdaptiveTimeoutConfig{
+//	        Percentile: 0.99,
+//	        Multiplier: 3,
+//	        Min:        200 * time.Millisecond,
+//	        Max:        5 * time.Second,
+//	    }).
+//	    Get(ctx, "/users/123")
+func (rb *RequestBuilder) AdaptiveTimeout(cfg AdaptiveTimeoutConfig) *RequestBuilder {
+	rb.adaptiveTimeout = &cfg
+	return rb
+}
+
+// Retries overrides the client's RetryConfig.MaxRetries for this request.
+//
+// It takes precedence over the client's RetryConfig, in both directions, but
+// the backoff between attempts and the retry classifier still come from the
+// client. It has no effect on a client with retries disabled, which has no
+// retry layer. A value of 0 or less disables retries, like NoRetry().
+// Timeout and Deadline bound the whole call, retries included.
+//
+// Example:
+//
+//	// Critical read - retry harder than the client default
+//	resp, err := client.Request("GetBalance").
+//	    Retries(5).
+//	    Get(ctx, "/balance")
+func (rb *RequestBuilder) Retries(n int) *RequestBuilder {
+	retries := uint(max(n, 0))
+	rb.retries = &retries
+	return rb
+}
+
+// NoRetry disables retries for this request, regardless of the client's
+// RetryConfig. Use it for non-idempotent requests that must not be sent twice.
+//
+// Example:
+//
+//	resp, err := client.Request("CreatePayment").
+//	    NoRetry().
+//	    Body(payment).
+//	    Post(ctx, "/payments")
+func (rb *RequestBuilder) NoRetry() *RequestBuilder {
+	return rb.Retries(0)
+}
+
+// IdempotencyFromContext sets the Idempotency-Key header to the key stored
+// in the request context by ContextWithIdempotencyKey, so a key generated
+// once upstream is reused by every downstream call and the operation is
+// deduplicated end to end. The header is left unset if the context has no
+// key, and a key set explicitly with Header takes precedence.
+//
+// Example:
+//
+//	// Gateway
+//	ctx = httpclient.ContextWithIdempotencyKey(ctx, uuid.NewString())
+//
+//	// Any service along the call chain
+//	resp, err := client.Request("CreatePayment").
+//	    IdempotencyFromContext().
+//	    Body(payment).
+//	    Post(ctx, "/payments")
+func (rb *RequestBuilder) IdempotencyFromContext() *RequestBuilder {
+	rb.idempotencyFromCtx = true
+	return rb
+}
+
+// Deadline sets an absolute deadline for this request.
+//
+// Like Timeout, a deadline can only tighten the request's time budget, never
+// extend it: if the context passed to the execute method already has an
+// earlier deadline, or Timeout yields an earlier one, that deadline wins.
+//
+// Example:
+//
+//	// Finish before the batch window closes
+//	resp, err := client.Request("SyncInventory").
+//	    Deadline(windowEnd).
+//	    Post(ctx, "/inventory/sync")
+func (rb *RequestBuilder) Deadline(t time.Time) *RequestBuilder {
+	rb.deadline = t
+	return rb
+}
+
+// WithValue adds a value to the context the request is executed with, as
+// context.WithValue would. The value is visible to interceptors through
+// req.Context(), as well as to the transport. Later calls with the same key
+// shadow earlier ones.
+//
+// Example:
+//
+//	resp, err := client.Request("GetUser").
+//	    WithValue(tenantKey{}, tenantID).
+//	    Get(ctx, "/users/123")
+func (rb *RequestBuilder) WithValue(key, val any) *RequestBuilder {
+	rb.values = append(rb.values, contextValue{key: key, val: val})
+	return rb
+}
+
+// Baggage adds a W3C baggage member to the context the request is executed
+// with, so business context such as a tenant or campaign ID travels to the
+// downstream service and everything it calls. Members already in the
+// context's baggage are kept; a member with the same key is replaced.
+//
+// The key must be a token as defined by the W3C Baggage specification and
+// the value valid UTF-8; the value is percent-encoded as needed. Invalid
+// members are dropped and reported as a "baggage.dropped" span event rather
+// than failing the request.
+//
+// Example:
+//
+//	resp, err := client.Request("CreateOrder").
+//	    Baggage("tenant.id", tenantID).
+//	    Baggage("campaign", "black friday").
+//	    Body(order).
+//	    Post(ctx, "/orders")
+func (rb *RequestBuilder) Baggage(key, value string) *RequestBuilder {
+	rb.baggage = append(rb.baggage, baggageMember{key: key, value: value})
+	return rb
+}
+
+// MetricAttr adds an attribute to the metrics recorded for this request,
+// for a custom dimension that only some requests need.
+//
+// Per-request attributes are added after those from WithMetricAttributesFn
+// and take precedence when both set the same key.
+//
+// Example:
+//
+//	resp, err := client.Request("CreateOrders").
+//	    MetricAttr("operation_variant", "bulk").
+//	    Body(orders).
+//	    Post(ctx, "/orders/bulk")
+func (rb *RequestBuilder) MetricAttr(key, value string) *RequestBuilder {
+	rb.metricAttrs = append(rb.metricAttrs, attribute.String(key, value))
+	return rb
+}
+
+// MetricAttrs adds multiple attributes to the metrics recorded for this
+// request. See MetricAttr.
+//
+// Example:
+//
+//	resp, err := client.Request("CreateOrders").
+//	    MetricAttrs(map[string]string{
+//	        "operation_variant": "bulk",
+//	        "region":            "eu",
+//	    }).
+//	    Post(ctx, "/orders/bulk")
+func (rb *RequestBuilder) MetricAttrs(attrs map[string]string) *RequestBuilder {
+	for k, v := range attrs {
+		rb.MetricAttr(k, v)
+	}
+	return rb
+}
+
 // RateLimit sets a per-request rate limit for this specific operation.
 //
 // This is useful when different endpoints have different throughput limits.
@@ -747,6 +947,83 @@ func (rb *RequestBuilder) Delete(ctx context.Context, path ...string) (*Response
 	return rb.execute(ctx, http.MethodDelete)
 }
 
+// Head executes a HEAD request.
+//
+// HEAD fetches only a resource's headers, e.g. to check that it exists or
+// read its size without downloading it. The response has no body: use
+// Response.ContentLength and Response.Header instead. Since HEAD is
+// idempotent, it is safe to combine with Hedge() and AdaptiveHedge().
+//
+// Example:
+//
+//	resp, err := client.Request("CheckExport").
+//	    PathParam("id", exportID).
+//	    Head(ctx, "/exports/{id}")
+//	if err != nil {
+//	    return err
+//	}
+//	if resp.IsSuccess() {
+//	    log.Printf("export is %d bytes", resp.ContentLength)
+//	}
+func (rb *RequestBuilder) Head(ctx context.Context, path ...string) (*Response, error) {
+	if len(path) > 0 {
+		rb.path = path[0]
+	}
+	return rb.execute(ctx, http.MethodHead)
+}
+
+// Options executes an OPTIONS request.
+//
+// OPTIONS asks the server which methods and features a resource supports,
+// typically reported in the Allow header.
+//
+// Example:
+//
+//	resp, err := client.Request("DescribeUsers").Options(ctx, "/users")
+//	if err != nil {
+//	    return err
+//	}
+//	allowed := resp.Header.Get("Allow")
+func (rb *RequestBuilder) Options(ctx context.Context, path ...string) (*Response, error) {
+	if len(path) > 0 {
+		rb.path = path[0]
+	}
+	return rb.execute(ctx, http.MethodOptions)
+}
+
+// DecodeStream sends a GET request and decodes the response, which must be a
+// JSON array, one element at a time: fn is called with each element as it
+// is read from the body, so large arrays are never buffered whole.
+//
+// Decoding stops at the first error returned by fn, which DecodeStream
+// returns, and when ctx is done. Retries are disabled for the request,
+// since a partially consumed stream can't be safely replayed. Error
+// responses are not streamed; they are decoded into the DecodeError target,
+// if any, and their body remains available from the Response.
+//
+// Example:
+//
+//	resp, err := client.Request("ExportEvents").
+//	    DecodeStream(ctx, "/events/export", func(item json.RawMessage) error {
+//	        var event Event
+//	        if err := json.Unmarshal(item, &event); err != nil {
+//	            return err
+//	        }
+//	        return sink.Write(event)
+//	    })
+//	if err != nil {
+//	    return err
+//	}
+func (rb *RequestBuilder) DecodeStream(
+	ctx context.Context,
+	path string,
+	fn func(item json.RawMessage) error,
+) (*Response, error) {
+	rb.path = path
+	rb.stream = fn
+	return rb.execute(ctx, http.MethodGet)
+}
+
 // execute builds and sends the HTTP request.
 func (rb *RequestBuilder) execute(ctx context.Context, method string) (*Response, error) {
 	// Apply per-request timeout if set (shortest wins)
@@ -756,6 +1033,43 @@ func (rb *RequestBuilder) execute(ctx context.Context, method string) (*Response
 		defer cancel()
 	}
 
+	// Apply per-request deadline if set (earliest wins)
+	if !rb.deadline.IsZero() {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, rb.deadline)
+		defer cancel()
+	}
+
+	// Apply per-request context values
+	for _, v := range rb.values {
+		ctx = context.WithValue(ctx, v.key, v.val)
+	}
+
+	// Pass per-request metric attributes to the transport
+	if len(rb.metricAttrs) > 0 {
+		ctx = context.WithValue(ctx, metricAttrsKey{}, rb.metricAttrs)
+	}
+
+	// Add per-request baggage, reporting invalid members to the transport
+	if len(rb.baggage) > 0 {
+		var dropped []string
+		ctx, dropped = withBaggageMembers(ctx, rb.baggage)
+		if len(dropped) > 0 {
+			ctx = context.WithValue(ctx, droppedBaggageKey{}, dropped)
+		}
+	}
+
+	// Override the client's retries for this request
+	if rb.retries != nil {
+		ctx = context.WithValue(ctx, maxRetriesKey{}, *rb.retries)
+	}
+
+	// A streamed response can't be replayed once partially consumed
+	if rb.stream != nil {
+		ctx = context.WithValue(ctx, noRetryKey{}, true)
+		ctx = context.WithValue(ctx, streamedKey{}, true)
+	}
+
 	// Apply per-request rate limit if set
 	if rb.rateLimitRPS > 0 {
 		key := rb.operationName
@@ -766,7 +1080,7 @@ func (rb *RequestBuilder) execute(ctx context.Context, method string) (*Response
 			RequestsPerSecond: rb.rateLimitRPS,
 			Burst:             1,
 			WaitOnLimit:       true,
-		}); err != nil {
+		}, rb.client.config.clock()); err != nil {
 			return nil, err
 		}
 	}
@@ -794,16 +1108,36 @@ func (rb *RequestBuilder) execute(ctx context.Context, method string) (*Response
 		rb.contentType = contentType
 	}
 
-	// Read body for potential replay (needed for hedging or coalescing)
-	needsBodyReplay := (rb.hedgeConfig != nil && rb.hedgeConfig.Enabled()) ||
-		(rb.adaptiveHedgeConfig != nil && rb.adaptiveHedgeConfig.Enabled()) ||
-		rb.coalesce
-	if reqBody != nil && needsBodyReplay {
-		bodyBytes, err = io.ReadAll(reqBody)
+	// Read body for potential replay (needed for hedging or coalescing).
+	// Bodies that can't be rewound are buffered only up to the retry
+	// MaxBufferedBody; a larger body is sent once, without hedging or
+	// coalescing.
+	hedging := (rb.hedgeConfig != nil && rb.hedgeConfig.Enabled()) ||
+		(rb.adaptiveHedgeConfig != nil && rb.adaptiveHedgeConfig.Enabled())
+	coalesce := rb.coalesce
+	if reqBody != nil && (hedging || coalesce) {
+		rewindable := isRewindable(reqBody)
+		limit := rb.client.config.RetryConfig.maxBufferedBody()
+		if rewindable {
+			bodyBytes, err = io.ReadAll(reqBody)
+		} else {
+			bodyBytes, err = io.ReadAll(io.LimitReader(reqBody, limit+1))
+		}
 		if err != nil {
 			return nil, err
 		}
-		reqBody = bytes.NewReader(bodyBytes)
+		if rewindable || int64(len(bodyBytes)) <= limit {
+			reqBody = bytes.NewReader(bodyBytes)
+		} else {
+			reqBody = io.MultiReader(bytes.NewReader(bodyBytes), reqBody)
+			bodyBytes = nil
+			ctx = context.WithValue(ctx, replayDisabledKey{}, replayDisabled{
+				hedge:           hedging,
+				coalesce:        coalesce,
+				maxBufferedBody: limit,
+			})
+			hedging, coalesce = false, false
+		}
 	}
 
 	// Create request
@@ -824,6 +1158,13 @@ func (rb *RequestBuilder) execute(ctx context.Context, method string) (*Response
 		req.Header[k] = v
 	}
 
+	// Reuse the upstream idempotency key unless one was set explicitly
+	if rb.idempotencyFromCtx && req.Header.Get(IdempotencyKeyHeader) == "" {
+		if key := IdempotencyKeyFromContext(ctx); key != "" {
+			req.Header.Set(IdempotencyKeyHeader, key)
+		}
+	}
+
 	// Set content type if body was set
 	if rb.contentType != "" && req.Header.Get("Content-Type") == "" {
 		req.Header.Set("Content-Type", rb.contentType)
@@ -832,13 +1173,20 @@ func (rb *RequestBuilder) execute(ctx context.Context, method string) (*Response
 	// Apply client-level request interceptors
 	if rb.client.config.Interceptors != nil {
 		if err := rb.client.config.Interceptors.ApplyRequestInterceptors(req); err != nil {
+			rb.recordInterceptorError(ctx, req, err)
 			return nil, err
 		}
 	}
 
 	// Apply per-request interceptors
-	for _, interceptor := range rb.requestInterceptors {
+	for idx, interceptor := range rb.requestInterceptors {
 		if err := interceptor(req); err != nil {
+			err = &InterceptorError{
+				Name:  fmt.Sprintf("per_request_interceptor_%d", idx),
+				Phase: InterceptorPhaseRequest,
+				Err:   err,
+			}
+			rb.recordInterceptorError(ctx, req, err)
 			return nil, err
 		}
 	}
@@ -853,10 +1201,11 @@ func (rb *RequestBuilder) execute(ctx context.Context, method string) (*Response
 
 	// Debug logging
 	if rb.client.debug {
-		logRequest(debugLogger, req)
+		logRequest(rb.client.config.logger(), req)
 	}
 
-	startTime := time.Now()
+	clock := rb.client.config.clock()
+	startTime := clock.Now()
 
 	// Determine endpoint key for latency tracking
 	endpoint := rb.operationName
@@ -864,13 +1213,23 @@ func (rb *RequestBuilder) execute(ctx context.Context, method string) (*Response
 		endpoint = req.URL.Path
 	}
 
+	// Apply the adaptive timeout for the endpoint (shortest wins)
+	if rb.adaptiveTimeout != nil {
+		if timeout := rb.adaptiveTimeout.GetTimeout(endpoint); timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+			req = req.WithContext(ctx)
+		}
+	}
+
 	// Execute request (with or without hedging/coalescing)
 	var httpResp *http.Response
 
 	// Define the actual request execution function
 	doRequest := func() (*http.Response, error) {
 		switch {
-		case rb.adaptiveHedgeConfig != nil && rb.adaptiveHedgeConfig.Enabled():
+		case hedging && rb.adaptiveHedgeConfig != nil && rb.adaptiveHedgeConfig.Enabled():
 			// Adaptive hedging: calculate delay from historical data
 			delay := rb.adaptiveHedgeConfig.GetDelay(endpoint)
 			hedgeCfg := &HedgeConfig{
@@ -879,7 +1238,7 @@ func (rb *RequestBuilder) execute(ctx context.Context, method string) (*Response
 			}
 
 			return rb.executeWithHedgingConfig(ctx, req, bodyBytes, hedgeCfg)
-		case rb.hedgeConfig != nil && rb.hedgeConfig.Enabled():
+		case hedging && rb.hedgeConfig != nil && rb.hedgeConfig.Enabled():
 
 			return rb.executeWithHedging(ctx, req, bodyBytes)
 		default:
@@ -889,7 +1248,7 @@ func (rb *RequestBuilder) execute(ctx context.Context, method string) (*Response
 	}
 
 	// Execute with or without coalescing
-	if rb.coalesce {
+	if coalesce {
 		// Generate coalesce key
 		coalesceKey := GenerateCoalesceKey(method, targetURL, bodyBytes)
 
@@ -901,14 +1260,19 @@ func (rb *RequestBuilder) execute(ctx context.Context, method string) (*Response
 		group := clientCoalesceGroups.getOrCreateGroup(clientID)
 
 		// Execute via singleflight
+		var executed bool
 		//nolint:bodyclose // Response body is closed by caller via Response wrapper
 		result, err, _ := group.Do(coalesceKey, func() (any, error) {
+			executed = true
 			resp, err := doRequest()
 			if err != nil {
 				return nil, err
 			}
 			return resp, nil
 		})
+		if !executed {
+			rb.client.config.counters.coalesceHits.Add(1)
+		}
 
 		if err != nil {
 			return nil, err
@@ -919,25 +1283,35 @@ func (rb *RequestBuilder) execute(ctx context.Context, method string) (*Response
 		httpResp, err = doRequest()
 	}
 
-	duration := time.Since(startTime)
+	duration := clock.Now().Sub(startTime)
 
 	// Record latency for adaptive hedging (only on success)
 	if httpResp != nil && rb.adaptiveHedgeConfig != nil {
 		rb.adaptiveHedgeConfig.GetTracker().Record(endpoint, duration)
 	}
 
+	// Record latency for the adaptive timeout, unless already recorded in
+	// the same tracker for adaptive hedging
+	if httpResp != nil && rb.adaptiveTimeout != nil {
+		tracker := rb.adaptiveTimeout.GetTracker()
+		if rb.adaptiveHedgeConfig == nil || rb.adaptiveHedgeConfig.GetTracker() != tracker {
+			tracker.Record(endpoint, duration)
+		}
+	}
+
 	if err != nil {
 		return nil, err
 	}
 
 	// Debug logging for response
 	if rb.client.debug {
-		logResponse(debugLogger, httpResp, duration)
+		logResponse(rb.client.config.logger(), httpResp, duration)
 	}
 
 	// Apply client-level response interceptors
 	if rb.client.config.Interceptors != nil {
 		if err := rb.client.config.Interceptors.ApplyResponseInterceptors(httpResp, req); err != nil {
+			rb.recordInterceptorError(ctx, req, err)
 			return nil, err
 		}
 	}
@@ -948,6 +1322,7 @@ func (rb *RequestBuilder) execute(ctx context.Context, method string) (*Response
 		request:     req,
 		result:      rb.result,
 		errorResult: rb.errorResult,
+		decoders:    rb.client.config.Decoders,
 	}
 
 	// Generate cURL command if enabled
@@ -966,6 +1341,14 @@ func (rb *RequestBuilder) execute(ctx context.Context, method string) (*Response
 		resp.traceInfo = tracer.toTraceInfo()
 	}
 
+	// Stream the body if DecodeStream was used
+	if rb.stream != nil {
+		if err := resp.decodeStream(ctx, rb.stream); err != nil {
+			return resp, err
+		}
+		return resp, nil
+	}
+
 	// Read and decode body if targets are set
 	if rb.result != nil || rb.errorResult != nil {
 		if err := resp.decode(); err != nil {
@@ -976,6 +1359,33 @@ func (rb *RequestBuilder) execute(ctx context.Context, method string) (*Response
 	return resp, nil
 }
 
+// recordInterceptorError records an interceptor failure with error.type
+// "interceptor" and adds an event naming the interceptor to the span in ctx.
+func (rb *RequestBuilder) recordInterceptorError(
+	ctx context.Context,
+	req *http.Request,
+	err error,
+) {
+	var interceptorErr *InterceptorError
+	if !errors.As(err, &interceptorErr) {
+		return
+	}
+
+	cfg := rb.client.config
+	attrs := append(cfg.baseAttributes(),
+		attribute.String("http.request.method", req.Method),
+		attribute.String("interceptor.name", interceptorErr.Name),
+		attribute.String("interceptor.phase", interceptorErr.Phase),
+	)
+	cfg.Metrics.recordError(ctx, ErrorTypeInterceptor, attrs)
+
+	trace.SpanFromContext(ctx).AddEvent("http.interceptor.error", trace.WithAttributes(
+		attribute.String("interceptor.name", interceptorErr.Name),
+		attribute.String("interceptor.phase", interceptorErr.Phase),
+		attribute.String("exception.message", interceptorErr.Err.Error()),
+	))
+}
+
 // executeWithHedging executes the request with hedging support using the RequestBuilder's config.
 func (rb *RequestBuilder) executeWithHedging(
 	ctx context.Context,
@@ -1022,10 +1432,19 @@ func (rb *RequestBuilder) executeWithHedgingConfig(
 	go doRequest()
 
 	// Set up timers for hedge requests
-	hedgeTimers := make([]*time.Timer, cfg.MaxHedges)
+	clock := rb.client.config.clock()
+	stopHedges := make([]func() bool, cfg.MaxHedges)
 	for i := range cfg.MaxHedges {
 		delay := cfg.Delay * time.Duration(i+1)
-		hedgeTimers[i] = time.AfterFunc(delay, doRequest)
+		stopHedges[i] = afterFunc(clock, delay, func() {
+			// Don't add load to a downstream the circuit breaker sees as
+			// struggling
+			if rb.client.config.hedgeSuppressed() {
+				rb.client.config.recordHedgeSuppressed(ctx)
+				return
+			}
+			doRequest()
+		})
 	}
 
 	// Wait for first result
@@ -1033,8 +1452,8 @@ func (rb *RequestBuilder) executeWithHedgingConfig(
 
 	// Cancel remaining and stop timers
 	cancel()
-	for _, timer := range hedgeTimers {
-		timer.Stop()
+	for _, stop := range stopHedges {
+		stop()
 	}
 
 	// Drain remaining results in background
@@ -3,15 +3,25 @@ package httpclient
 import (
 	"bytes"
 	"context"
+	"errors"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	json "github.com/goccy/go-json"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 )
 
 func TestRequestBuilder_Path(t *testing.T) {
@@ -290,6 +300,152 @@ func TestRequestBuilder_Decode(t *testing.T) {
 	assert.Equal(t, "John", user.Name)
 }
 
+func TestRequestBuilder_Decode_WithDecoders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/x-csv")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("john,jane"))
+	}))
+	defer server.Close()
+
+	client := New(
+		WithBaseURL(server.URL),
+		WithDecoders(map[string]Decoder{
+			"Application/X-CSV": func(body []byte, target any) error {
+				*target.(*[]string) = strings.Split(string(body), ",")
+				return nil
+			},
+		}),
+	)
+
+	var names []string
+	resp, err := client.Request("ListNames").
+		Decode(&names).
+		Get(context.Background(), "/names")
+
+	require.NoError(t, err)
+	assert.True(t, resp.IsSuccess())
+	assert.Equal(t, []string{"john", "jane"}, names)
+}
+
+func TestRequestBuilder_DecodeStream(t *testing.T) {
+	errStop := errors.New("stop")
+
+	tests := []struct {
+		name         string
+		status       int
+		body         string
+		fn           func(cancel context.CancelFunc, items []string) error
+		wantErr      assert.ErrorAssertionFunc
+		wantItems    []string
+		wantAttempts int32
+	}{
+		{
+			name:         "given JSON array, then calls fn per element",
+			status:       http.StatusOK,
+			body:         `[{"id":1}, {"id":2}, 3]`,
+			wantErr:      assert.NoError,
+			wantItems:    []string{`{"id":1}`, `{"id":2}`, `3`},
+			wantAttempts: 1,
+		},
+		{
+			name:         "given empty array, then never calls fn",
+			status:       http.StatusOK,
+			body:         `[]`,
+			wantErr:      assert.NoError,
+			wantAttempts: 1,
+		},
+		{
+			name:         "given JSON object, then returns error",
+			status:       http.StatusOK,
+			body:         `{"id":1}`,
+			wantErr:      assert.Error,
+			wantAttempts: 1,
+		},
+		{
+			name:   "given fn error, then stops and returns it",
+			status: http.StatusOK,
+			body:   `[1, 2, 3]`,
+			fn: func(_ context.CancelFunc, items []string) error {
+				if len(items) == 2 {
+					return errStop
+				}
+				return nil
+			},
+			wantErr: func(t assert.TestingT, err error, _ ...any) bool {
+				return assert.ErrorIs(t, err, errStop)
+			},
+			wantItems:    []string{`1`, `2`},
+			wantAttempts: 1,
+		},
+		{
+			name:   "given cancelled context, then stops and returns context error",
+			status: http.StatusOK,
+			body:   `[1, 2, 3]`,
+			fn: func(cancel context.CancelFunc, _ []string) error {
+				cancel()
+				return nil
+			},
+			wantErr: func(t assert.TestingT, err error, _ ...any) bool {
+				return assert.ErrorIs(t, err, context.Canceled)
+			},
+			wantItems:    []string{`1`},
+			wantAttempts: 1,
+		},
+		{
+			name:         "given retryable status, then does not retry",
+			status:       http.StatusServiceUnavailable,
+			body:         `[]`,
+			wantErr:      assert.NoError,
+			wantAttempts: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts atomic.Int32
+			server := httptest.NewServer(
+				http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					attempts.Add(1)
+					w.Header().Set("Content-Type", "application/json")
+					w.WriteHeader(tt.status)
+					_, _ = w.Write([]byte(tt.body))
+				}),
+			)
+			defer server.Close()
+
+			client := New(
+				WithBaseURL(server.URL),
+				WithRetryConfig(RetryConfig{
+					MaxRetries:      2,
+					InitialInterval: time.Millisecond,
+					MaxInterval:     time.Millisecond,
+					Multiplier:      1,
+				}),
+			)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var items []string
+			resp, err := client.Request("ExportEvents").
+				DecodeStream(ctx, "/events", func(item json.RawMessage) error {
+					items = append(items, string(item))
+					if tt.fn != nil {
+						return tt.fn(cancel, items)
+					}
+					return nil
+				})
+
+			tt.wantErr(t, err)
+			require.NotNil(t, resp)
+			assert.Equal(t, tt.status, resp.StatusCode)
+			assert.Equal(t, tt.wantItems, items)
+			assert.Equal(t, tt.wantAttempts, attempts.Load())
+		})
+	}
+}
+
 func TestRequestBuilder_HTTPMethods(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -337,6 +493,22 @@ func TestRequestBuilder_HTTPMethods(t *testing.T) {
 			},
 			wantMethod: http.MethodDelete,
 		},
+		{
+			name:   "Head",
+			method: http.MethodHead,
+			execFunc: func(rb *RequestBuilder, ctx context.Context) (*Response, error) {
+				return rb.Head(ctx, "/test")
+			},
+			wantMethod: http.MethodHead,
+		},
+		{
+			name:   "Options",
+			method: http.MethodOptions,
+			execFunc: func(rb *RequestBuilder, ctx context.Context) (*Response, error) {
+				return rb.Options(ctx, "/test")
+			},
+			wantMethod: http.MethodOptions,
+		},
 	}
 
 	for _, tt := range tests {
@@ -357,6 +529,59 @@ func TestRequestBuilder_HTTPMethods(t *testing.T) {
 	}
 }
 
+func TestRequestBuilder_Head(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func(rb *RequestBuilder) *RequestBuilder
+	}{
+		{
+			name:  "given plain request, then exposes headers without body",
+			build: func(rb *RequestBuilder) *RequestBuilder { return rb },
+		},
+		{
+			name: "given decode target, then skips decoding",
+			build: func(rb *RequestBuilder) *RequestBuilder {
+				var result map[string]any
+				return rb.Decode(&result)
+			},
+		},
+		{
+			name: "given hedging, then succeeds",
+			build: func(rb *RequestBuilder) *RequestBuilder {
+				return rb.Hedge(time.Millisecond)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(
+				http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					w.Header().Set("Content-Type", "application/json")
+					w.Header().Set("Content-Length", "1024")
+					w.Header().Set("ETag", `"v1"`)
+					w.WriteHeader(http.StatusOK)
+				}),
+			)
+			defer server.Close()
+
+			client := New(WithBaseURL(server.URL))
+
+			resp, err := tt.build(client.Request("CheckExport")).
+				Head(context.Background(), "/exports/1")
+
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			assert.EqualValues(t, 1024, resp.ContentLength)
+			assert.Equal(t, `"v1"`, resp.Header.Get("ETag"))
+
+			body, err := resp.Body()
+			require.NoError(t, err)
+			assert.Empty(t, body)
+		})
+	}
+}
+
 func TestRequestBuilder_DebugWithCurl(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
 		w.WriteHeader(http.StatusOK)
@@ -400,6 +625,364 @@ func TestRequestBuilder_EnableTrace(t *testing.T) {
 	assert.Contains(t, str, "Total Time")
 }
 
+type testContextKey struct{}
+
+func TestRequestBuilder_WithValue(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := New(WithBaseURL(server.URL))
+
+	var got any
+	_, err := client.Request("test").
+		WithValue(testContextKey{}, "first").
+		WithValue(testContextKey{}, "second").
+		Intercept(func(req *http.Request) error {
+			got = req.Context().Value(testContextKey{})
+			return nil
+		}).
+		Get(context.Background(), "/api")
+
+	require.NoError(t, err)
+	assert.Equal(t, "second", got)
+}
+
+func TestRequestBuilder_Retries(t *testing.T) {
+	type args struct {
+		clientRetries uint
+		build         func(rb *RequestBuilder) *RequestBuilder
+		timeout       time.Duration
+	}
+
+	tests := []struct {
+		name         string
+		args         args
+		wantRequests int32
+	}{
+		{
+			name: "given no override, then uses client retries",
+			args: args{
+				clientRetries: 2,
+				build:         func(rb *RequestBuilder) *RequestBuilder { return rb },
+			},
+			wantRequests: 3,
+		},
+		{
+			name: "given NoRetry, then sends a single request",
+			args: args{
+				clientRetries: 2,
+				build:         (*RequestBuilder).NoRetry,
+			},
+			wantRequests: 1,
+		},
+		{
+			name: "given Retries above client retries, then retries more",
+			args: args{
+				clientRetries: 1,
+				build:         func(rb *RequestBuilder) *RequestBuilder { return rb.Retries(3) },
+			},
+			wantRequests: 4,
+		},
+		{
+			name: "given negative Retries, then sends a single request",
+			args: args{
+				clientRetries: 2,
+				build:         func(rb *RequestBuilder) *RequestBuilder { return rb.Retries(-1) },
+			},
+			wantRequests: 1,
+		},
+		{
+			name: "given Retries with Timeout, then timeout bounds all attempts",
+			args: args{
+				clientRetries: 1,
+				build:         func(rb *RequestBuilder) *RequestBuilder { return rb.Retries(100) },
+				timeout:       50 * time.Millisecond,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requestCount atomic.Int32
+			server := httptest.NewServer(
+				http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					requestCount.Add(1)
+					w.WriteHeader(http.StatusServiceUnavailable)
+				}),
+			)
+			defer server.Close()
+
+			client := New(
+				WithBaseURL(server.URL),
+				WithRetryConfig(RetryConfig{
+					MaxRetries:      tt.args.clientRetries,
+					InitialInterval: 5 * time.Millisecond,
+					MaxInterval:     5 * time.Millisecond,
+					Multiplier:      1,
+				}),
+			)
+
+			rb := tt.args.build(client.Request("Test"))
+			if tt.args.timeout > 0 {
+				rb = rb.Timeout(tt.args.timeout)
+			}
+			_, err := rb.Get(context.Background(), "/test")
+
+			if tt.args.timeout > 0 {
+				require.ErrorIs(t, err, context.DeadlineExceeded)
+				assert.Less(t, requestCount.Load(), int32(100))
+				return
+			}
+			assert.Equal(t, tt.wantRequests, requestCount.Load())
+		})
+	}
+}
+
+func TestRequestBuilder_MetricAttr(t *testing.T) {
+	clientAttrs := func(*http.Request) []attribute.KeyValue {
+		return []attribute.KeyValue{
+			attribute.String("operation_variant", "single"),
+			attribute.String("tenant", "acme"),
+		}
+	}
+
+	tests := []struct {
+		name      string
+		build     func(rb *RequestBuilder) *RequestBuilder
+		wantAttrs map[string]string
+	}{
+		{
+			name:  "given no request attributes, then uses client attributes",
+			build: func(rb *RequestBuilder) *RequestBuilder { return rb },
+			wantAttrs: map[string]string{
+				"operation_variant": "single",
+				"tenant":            "acme",
+			},
+		},
+		{
+			name: "given MetricAttr, then request attribute wins on conflict",
+			build: func(rb *RequestBuilder) *RequestBuilder {
+				return rb.MetricAttr("operation_variant", "bulk")
+			},
+			wantAttrs: map[string]string{
+				"operation_variant": "bulk",
+				"tenant":            "acme",
+			},
+		},
+		{
+			name: "given MetricAttrs, then adds all request attributes",
+			build: func(rb *RequestBuilder) *RequestBuilder {
+				return rb.MetricAttrs(map[string]string{
+					"operation_variant": "bulk",
+					"region":            "eu",
+				})
+			},
+			wantAttrs: map[string]string{
+				"operation_variant": "bulk",
+				"tenant":            "acme",
+				"region":            "eu",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(
+				http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(http.StatusOK)
+				}),
+			)
+			defer server.Close()
+
+			reader := sdkmetric.NewManualReader()
+			mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+			defer mp.Shutdown(context.Background())
+
+			client := New(
+				WithBaseURL(server.URL),
+				WithMeterProvider(mp),
+				WithMetricAttributesFn(clientAttrs),
+			)
+
+			resp, err := tt.build(client.Request("CreateOrders")).
+				Post(context.Background(), "/orders")
+			require.NoError(t, err)
+			_, err = resp.Body()
+			require.NoError(t, err)
+
+			var rm metricdata.ResourceMetrics
+			require.NoError(t, reader.Collect(context.Background(), &rm))
+
+			var histogram metricdata.Histogram[float64]
+			for _, sm := range rm.ScopeMetrics {
+				for _, m := range sm.Metrics {
+					if m.Name == "http.client.request.duration" {
+						histogram, _ = m.Data.(metricdata.Histogram[float64])
+					}
+				}
+			}
+			require.Len(t, histogram.DataPoints, 1)
+
+			attrs := histogram.DataPoints[0].Attributes
+			for key, want := range tt.wantAttrs {
+				got, ok := attrs.Value(attribute.Key(key))
+				require.True(t, ok, key)
+				assert.Equal(t, want, got.AsString(), key)
+			}
+		})
+	}
+}
+
+func TestRequestBuilder_Baggage(t *testing.T) {
+	tests := []struct {
+		name        string
+		build       func(rb *RequestBuilder) *RequestBuilder
+		wantBaggage map[string]string
+		wantDropped []string
+	}{
+		{
+			name: "given members, then propagates them with the context's baggage",
+			build: func(rb *RequestBuilder) *RequestBuilder {
+				return rb.Baggage("tenant.id", "acme").Baggage("campaign", "black friday")
+			},
+			wantBaggage: map[string]string{
+				"user.id":   "42",
+				"tenant.id": "acme",
+				"campaign":  "black friday",
+			},
+		},
+		{
+			name: "given member with existing key, then replaces it",
+			build: func(rb *RequestBuilder) *RequestBuilder {
+				return rb.Baggage("user.id", "7")
+			},
+			wantBaggage: map[string]string{"user.id": "7"},
+		},
+		{
+			name: "given invalid members, then drops them and records span events",
+			build: func(rb *RequestBuilder) *RequestBuilder {
+				return rb.Baggage("bad key", "v").
+					Baggage("", "v").
+					Baggage("region", "\xff").
+					Baggage("tenant.id", "acme")
+			},
+			wantBaggage: map[string]string{"user.id": "42", "tenant.id": "acme"},
+			wantDropped: []string{"bad key", "", "region"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var header string
+			handler := func(w http.ResponseWriter, r *http.Request) {
+				header = r.Header.Get("baggage")
+				w.WriteHeader(http.StatusOK)
+			}
+			server := httptest.NewServer(http.HandlerFunc(handler))
+			defer server.Close()
+
+			exporter := tracetest.NewInMemoryExporter()
+			tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+			client := New(WithBaseURL(server.URL), WithTracerProvider(tp))
+
+			member, err := baggage.NewMemberRaw("user.id", "42")
+			require.NoError(t, err)
+			bag, err := baggage.New(member)
+			require.NoError(t, err)
+			ctx := baggage.ContextWithBaggage(context.Background(), bag)
+
+			resp, err := tt.build(client.Request("CreateOrder")).Get(ctx, "/orders")
+			require.NoError(t, err)
+			require.Equal(t, http.StatusOK, resp.StatusCode)
+			_, err = resp.Body() // ends the client span
+			require.NoError(t, err)
+
+			got, err := baggage.Parse(header)
+			require.NoError(t, err)
+			gotBaggage := map[string]string{}
+			for _, m := range got.Members() {
+				gotBaggage[m.Key()] = m.Value()
+			}
+			assert.Equal(t, tt.wantBaggage, gotBaggage)
+
+			var gotDropped []string
+			for _, span := range exporter.GetSpans() {
+				for _, e := range span.Events {
+					if e.Name != "baggage.dropped" {
+						continue
+					}
+					for _, attr := range e.Attributes {
+						if attr.Key == "baggage.key" {
+							gotDropped = append(gotDropped, attr.Value.AsString())
+						}
+					}
+				}
+			}
+			assert.Equal(t, tt.wantDropped, gotDropped)
+		})
+	}
+}
+
+func TestRequestBuilder_IdempotencyFromContext(t *testing.T) {
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		build   func(rb *RequestBuilder) *RequestBuilder
+		wantKey string
+	}{
+		{
+			name: "given key in context, then sets header",
+			ctx:  ContextWithIdempotencyKey(context.Background(), "key-123"),
+			build: func(rb *RequestBuilder) *RequestBuilder {
+				return rb.IdempotencyFromContext()
+			},
+			wantKey: "key-123",
+		},
+		{
+			name: "given no key in context, then leaves header unset",
+			ctx:  context.Background(),
+			build: func(rb *RequestBuilder) *RequestBuilder {
+				return rb.IdempotencyFromContext()
+			},
+		},
+		{
+			name: "given explicit header, then keeps it",
+			ctx:  ContextWithIdempotencyKey(context.Background(), "key-123"),
+			build: func(rb *RequestBuilder) *RequestBuilder {
+				return rb.Header(IdempotencyKeyHeader, "explicit").IdempotencyFromContext()
+			},
+			wantKey: "explicit",
+		},
+		{
+			name: "given key in context, when not requested, then leaves header unset",
+			ctx:  ContextWithIdempotencyKey(context.Background(), "key-123"),
+			build: func(rb *RequestBuilder) *RequestBuilder {
+				return rb
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotKey string
+			handler := func(w http.ResponseWriter, r *http.Request) {
+				gotKey = r.Header.Get(IdempotencyKeyHeader)
+				w.WriteHeader(http.StatusOK)
+			}
+			server := httptest.NewServer(http.HandlerFunc(handler))
+			defer server.Close()
+
+			client := New(WithBaseURL(server.URL))
+
+			_, err := tt.build(client.Request("CreatePayment")).Post(tt.ctx, "/payments")
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantKey, gotKey)
+		})
+	}
+}
+
 func TestRequestBuilder_DefaultHeaders(t *testing.T) {
 	var receivedHeaders http.Header
 
@@ -0,0 +1,131 @@
+package httpclient
+
+// ResolvedConfig is a snapshot of a client's effective configuration, after
+// presets and options have been applied in order.
+//
+// Log it at startup, or inspect it when diagnosing why requests time out or
+// retry, instead of working out the option-application order by hand.
+// Per-request settings such as RequestBuilder.Timeout and
+// RequestBuilder.Retries apply on top of it.
+//
+// Fields holding configuration structs are copies; changing them does not
+// affect the client.
+type ResolvedConfig struct {
+	// ServiceName identifies the client in spans, metrics and the breaker.
+	ServiceName string
+
+	// BaseURL is prepended to request builder paths.
+	BaseURL string
+
+	// Transport holds the timeouts and connection pool settings.
+	Transport Config
+
+	// Retry is the retry configuration. RetryEnabled reports whether
+	// requests are retried at all.
+	Retry RetryConfig
+
+	// RetryEnabled is false when retries are disabled.
+	RetryEnabled bool
+
+	// Breaker is the circuit breaker configuration, or nil if disabled.
+	Breaker *BreakerConfig
+
+	// DistributedBreaker is true when the breaker state is shared through
+	// a store, e.g. Redis.
+	DistributedBreaker bool
+
+	// RateLimit is the client-wide rate limit, or nil if disabled.
+	RateLimit *RateLimitConfig
+
+	// Chaos is the fault injection configuration, or nil if disabled.
+	Chaos *ChaosConfig
+
+	// BodySampleRate is the fraction of requests whose bodies are recorded
+	// on their spans, or 0 if body sampling is disabled.
+	BodySampleRate float64
+
+	// DeadlineHeader is the header carrying the remaining deadline budget,
+	// or empty if deadlines are not propagated.
+	DeadlineHeader string
+
+	// Proxy is the proxy URL, "environment" when the proxy is read from
+	// HTTP_PROXY and related variables, or empty for direct connections.
+	Proxy string
+
+	// CustomTransport is true when WithBaseTransport replaced the base
+	// transport, so Transport does not describe the connection pool.
+	CustomTransport bool
+
+	// MockTransport is true when a mock replaced the whole transport chain.
+	MockTransport bool
+
+	// Debug enables request and response logging.
+	Debug bool
+
+	// GenerateCurl enables cURL command generation.
+	GenerateCurl bool
+
+	// EnableTrace enables timing trace info collection.
+	EnableTrace bool
+
+	// NetworkTrace enables DNS, connect and TLS span events and metrics.
+	NetworkTrace bool
+
+	// Exemplars links request duration measurements to traces.
+	Exemplars bool
+}
+
+// Config returns a snapshot of the client's effective configuration.
+//
+// Example:
+//
+//	client := httpclient.New(
+//	    httpclient.WithConfig(httpclient.HighThroughputConfig()),
+//	    httpclient.WithRetryConfig(httpclient.AggressiveRetryConfig()),
+//	)
+//	logger.Info().Interface("httpclient", client.Config()).Msg("starting")
+func (c *Client) Config() ResolvedConfig {
+	cfg := c.config
+
+	resolved := ResolvedConfig{
+		ServiceName:     cfg.ServiceName,
+		BaseURL:         cfg.BaseURL,
+		Transport:       cfg.httpConfig,
+		Retry:           cfg.RetryConfig,
+		RetryEnabled:    cfg.RetryConfig.IsEnabled(),
+		CustomTransport: cfg.BaseTransport != nil,
+		MockTransport:   cfg.MockTransport != nil,
+		Debug:           cfg.Debug,
+		GenerateCurl:    cfg.GenerateCurl,
+		EnableTrace:     cfg.EnableTrace,
+		NetworkTrace:    cfg.EnableNetworkTrace,
+		Exemplars:       !cfg.DisableExemplars,
+		DeadlineHeader:  cfg.DeadlineHeader,
+	}
+
+	if cfg.BreakerConfig != nil {
+		breaker := *cfg.BreakerConfig
+		resolved.Breaker = &breaker
+		resolved.DistributedBreaker = breaker.Store != nil
+	}
+	if cfg.RateLimitConfig != nil && cfg.RateLimitConfig.RequestsPerSecond > 0 {
+		rateLimit := *cfg.RateLimitConfig
+		resolved.RateLimit = &rateLimit
+	}
+	if cfg.ChaosConfig != nil {
+		chaos := *cfg.ChaosConfig
+		resolved.Chaos = &chaos
+	}
+	if cfg.bodySampling != nil {
+		resolved.BodySampleRate = cfg.bodySampling.rate
+	}
+
+	switch {
+	case cfg.ProxyURL != nil:
+		resolved.Proxy = cfg.ProxyURL.Redacted()
+	case cfg.ProxyFromEnvironment:
+		resolved.Proxy = "environment"
+	}
+
+	return resolved
+}
@@ -1,10 +1,14 @@
 package httpclient
 
 import (
+	"context"
 	"encoding/xml"
+	"errors"
 	"fmt"
 	"io"
+	"mime"
 	"net/http"
+	"strconv"
 	"strings"
 
 	json "github.com/goccy/go-json"
@@ -72,8 +76,21 @@ type Response struct {
 	// traceInfo contains request timing information.
 	// Only populated if EnableTrace() was called on the request.
 	traceInfo *TraceInfo
+
+	// decoders maps media types to the decoders configured with
+	// WithDecoders. They take precedence over the built-in decoders.
+	decoders map[string]Decoder
+
+	// json is the body parsed as generic JSON, cached by JSONField.
+	json       any
+	jsonErr    error
+	jsonParsed bool
 }
 
+// ErrJSONFieldNotFound is returned by Response.JSONField when no field
+// matches the path.
+var ErrJSONFieldNotFound = errors.New("json field not found")
+
 // Body returns the response body as bytes.
 //
 // The body is read and cached on first access. Subsequent calls
@@ -119,6 +136,71 @@ func (r *Response) Error() any {
 	return r.errorResult
 }
 
+// JSONField returns the value at path in the JSON response body, for
+// probes and tooling where defining a struct is overkill.
+//
+// The path is a dot-separated list of object keys and array indices, e.g.
+// "data.items.0.id"; an empty path returns the whole document. Values are
+// decoded as by encoding/json into an any: objects as map[string]any,
+// arrays as []any and numbers as float64. The body is parsed on the first
+// call and the result is cached, so repeated calls are cheap.
+//
+// Example:
+//
+//	resp, err := client.Request("Health").Get(ctx, "/health")
+//	if err != nil {
+//	    return err
+//	}
+//	status, err := resp.JSONField("checks.database.status")
+//	if errors.Is(err, httpclient.ErrJSONFieldNotFound) {
+//	    status = "unknown"
+//	}
+func (r *Response) JSONField(path string) (any, error) {
+	if !r.jsonParsed {
+		r.json, r.jsonErr = r.parseJSON()
+		r.jsonParsed = true
+	}
+	if r.jsonErr != nil {
+		return nil, r.jsonErr
+	}
+	if path == "" {
+		return r.json, nil
+	}
+
+	node := r.json
+	for _, key := range strings.Split(path, ".") {
+		var ok bool
+		switch v := node.(type) {
+		case map[string]any:
+			node, ok = v[key]
+		case []any:
+			i, err := strconv.Atoi(key)
+			ok = err == nil && i >= 0 && i < len(v)
+			if ok {
+				node = v[i]
+			}
+		}
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", ErrJSONFieldNotFound, path)
+		}
+	}
+	return node, nil
+}
+
+// parseJSON reads the body and parses it as generic JSON.
+func (r *Response) parseJSON() (any, error) {
+	body, err := r.Body()
+	if err != nil {
+		return nil, err
+	}
+
+	var v any
+	if err := json.Unmarshal(body, &v); err != nil {
+		return nil, fmt.Errorf("parse response body as JSON: %w", err)
+	}
+	return v, nil
+}
+
 // IsSuccess returns true if the response status code is 2xx.
 func (r *Response) IsSuccess() bool {
 	return r.StatusCode >= 200 && r.StatusCode < 300
@@ -158,28 +240,113 @@ func (r *Response) decode() error {
 	contentType := r.Header.Get("Content-Type")
 
 	if r.IsSuccess() && r.result != nil {
-		return decodeBody(body, contentType, r.result)
+		return decodeBody(body, contentType, r.result, r.decoders)
 	}
 
 	if r.IsError() && r.errorResult != nil {
-		return decodeBody(body, contentType, r.errorResult)
+		return decodeBody(body, contentType, r.errorResult, r.decoders)
 	}
 
 	return nil
 }
 
-// decodeBody decodes the body based on content type.
-func decodeBody(body []byte, contentType string, target any) error {
-	if strings.Contains(contentType, "application/json") {
-		return json.Unmarshal(body, target)
+// decodeStream decodes a successful response body as a JSON array, calling
+// fn with each element as it is read, so the array is never held in memory
+// whole. It stops at the first error from fn or from reading the body, and
+// when ctx is done. Error responses are decoded as by decode.
+func (r *Response) decodeStream(ctx context.Context, fn func(item json.RawMessage) error) error {
+	if !r.IsSuccess() {
+		return r.decode()
 	}
-	isXML := strings.Contains(contentType, "application/xml") ||
-		strings.Contains(contentType, "text/xml")
-	if isXML {
-		return xml.Unmarshal(body, target)
+
+	defer r.Response.Body.Close()
+	r.bodyRead = true
+
+	dec := json.NewDecoder(r.Response.Body)
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok != json.Delim('[') {
+		return fmt.Errorf("expected JSON array, got %v", tok)
+	}
+
+	for dec.More() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		var item json.RawMessage
+		if err := dec.Decode(&item); err != nil {
+			return err
+		}
+		if err := fn(item); err != nil {
+			return err
+		}
+	}
+
+	_, err = dec.Token()
+	return err
+}
+
+// Decoder decodes a response body into target.
+//
+// Example:
+//
+//	var msgpackDecoder httpclient.Decoder = func(body []byte, target any) error {
+//	    return msgpack.Unmarshal(body, target)
+//	}
+type Decoder func(body []byte, target any) error
+
+// defaultDecoders are the built-in decoders, by media type.
+var defaultDecoders = map[string]Decoder{
+	"application/json": json.Unmarshal,
+	"application/xml":  xml.Unmarshal,
+	"text/xml":         xml.Unmarshal,
+}
+
+// decodeBody decodes the body with the decoder for its content type.
+//
+// The decoder is looked up by media type, ignoring parameters such as
+// charset: first in decoders, then in the built-in JSON and XML decoders,
+// then by a +json or +xml structured syntax suffix. A body whose content
+// type matches no decoder is decoded as JSON, and the error says so.
+func decodeBody(body []byte, contentType string, target any, decoders map[string]Decoder) error {
+	if decoder := findDecoder(contentType, decoders); decoder != nil {
+		return decoder(body, target)
+	}
+
+	if err := json.Unmarshal(body, target); err != nil {
+		if contentType == "" {
+			return err
+		}
+		return fmt.Errorf("no decoder for content type %q, decoding as JSON: %w", contentType, err)
+	}
+	return nil
+}
+
+// findDecoder returns the decoder for contentType, or nil if none matches.
+func findDecoder(contentType string, decoders map[string]Decoder) Decoder {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil
+	}
+
+	if decoder, ok := decoders[mediaType]; ok {
+		return decoder
+	}
+	if decoder, ok := defaultDecoders[mediaType]; ok {
+		return decoder
+	}
+
+	switch {
+	case strings.HasSuffix(mediaType, "+json"):
+		return json.Unmarshal
+	case strings.HasSuffix(mediaType, "+xml"):
+		return xml.Unmarshal
+	default:
+		return nil
 	}
-	// Default to JSON
-	return json.Unmarshal(body, target)
 }
 
 // TraceInfo contains timing information for an HTTP request.
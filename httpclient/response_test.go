@@ -93,6 +93,103 @@ func TestResponse_String(t *testing.T) {
 	assert.Equal(t, bodyContent, str)
 }
 
+func TestResponse_JSONField(t *testing.T) {
+	body := `{"status":"ok","data":{"items":[{"id":1},{"id":2}],"total":2}}`
+
+	tests := []struct {
+		name    string
+		body    string
+		path    string
+		want    any
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "given top-level key, then returns value",
+			body:    body,
+			path:    "status",
+			want:    "ok",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "given nested path with array index, then returns value",
+			body:    body,
+			path:    "data.items.1.id",
+			want:    float64(2),
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "given empty path, then returns whole document",
+			body:    `[1,2]`,
+			path:    "",
+			want:    []any{float64(1), float64(2)},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "given missing key, then returns ErrJSONFieldNotFound",
+			body: body,
+			path: "data.missing",
+			wantErr: func(t assert.TestingT, err error, _ ...any) bool {
+				return assert.ErrorIs(t, err, ErrJSONFieldNotFound)
+			},
+		},
+		{
+			name: "given out of range index, then returns ErrJSONFieldNotFound",
+			body: body,
+			path: "data.items.5",
+			wantErr: func(t assert.TestingT, err error, _ ...any) bool {
+				return assert.ErrorIs(t, err, ErrJSONFieldNotFound)
+			},
+		},
+		{
+			name: "given path through scalar, then returns ErrJSONFieldNotFound",
+			body: body,
+			path: "status.code",
+			wantErr: func(t assert.TestingT, err error, _ ...any) bool {
+				return assert.ErrorIs(t, err, ErrJSONFieldNotFound)
+			},
+		},
+		{
+			name:    "given invalid JSON, then returns error",
+			body:    `not json`,
+			path:    "status",
+			wantErr: assert.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &Response{
+				Response: &http.Response{
+					Body: io.NopCloser(strings.NewReader(tt.body)),
+				},
+			}
+
+			got, err := resp.JSONField(tt.path)
+			if !tt.wantErr(t, err) {
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestResponse_JSONField_CachesParsedBody(t *testing.T) {
+	resp := &Response{
+		Response: &http.Response{
+			Body: io.NopCloser(strings.NewReader(`{"a":{"b":1}}`)),
+		},
+	}
+
+	_, err := resp.JSONField("a.b")
+	require.NoError(t, err)
+
+	// Replacing the cached body has no effect once the tree is parsed
+	resp.body = []byte(`{"a":{"b":2}}`)
+	got, err := resp.JSONField("a.b")
+	require.NoError(t, err)
+	assert.Equal(t, float64(1), got)
+}
+
 func TestResponse_CurlCommand(t *testing.T) {
 	resp := &Response{
 		curlCommand: "curl -X GET 'https://api.example.com/users'",
@@ -145,15 +242,22 @@ func TestTraceInfo_String(t *testing.T) {
 
 func TestDecodeBody(t *testing.T) {
 	type User struct {
-		ID   int    `json:"id"`
-		Name string `json:"name"`
+		ID   int    `json:"id" xml:"id"`
+		Name string `json:"name" xml:"name"`
+	}
+
+	lineDecoder := func(body []byte, target any) error {
+		target.(*User).Name = strings.TrimSpace(string(body))
+		return nil
 	}
 
 	tests := []struct {
 		name        string
 		body        []byte
 		contentType string
+		decoders    map[string]Decoder
 		wantName    string
+		wantErr     string
 	}{
 		{
 			name:        "given JSON content-type, then decodes as JSON",
@@ -173,13 +277,49 @@ func TestDecodeBody(t *testing.T) {
 			contentType: "",
 			wantName:    "Default",
 		},
+		{
+			name:        "given XML content-type, then decodes as XML",
+			body:        []byte(`<User><id>1</id><name>Xavier</name></User>`),
+			contentType: "text/xml; charset=utf-8",
+			wantName:    "Xavier",
+		},
+		{
+			name:        "given structured JSON suffix, then decodes as JSON",
+			body:        []byte(`{"id":1,"name":"Problem"}`),
+			contentType: "application/problem+json",
+			wantName:    "Problem",
+		},
+		{
+			name:        "given custom decoder for content-type, then uses it",
+			body:        []byte("Custom\n"),
+			contentType: "Text/Plain; charset=utf-8",
+			decoders:    map[string]Decoder{"text/plain": lineDecoder},
+			wantName:    "Custom",
+		},
+		{
+			name:        "given custom decoder for JSON, then replaces built-in decoder",
+			body:        []byte("Override"),
+			contentType: "application/json",
+			decoders:    map[string]Decoder{"application/json": lineDecoder},
+			wantName:    "Override",
+		},
+		{
+			name:        "given unknown content-type with non-JSON body, then returns clear error",
+			body:        []byte("\x81\xa4name"),
+			contentType: "application/x-msgpack",
+			wantErr:     `no decoder for content type "application/x-msgpack"`,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			var user User
-			err := decodeBody(tt.body, tt.contentType, &user)
+			err := decodeBody(tt.body, tt.contentType, &user, tt.decoders)
 
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
 			require.NoError(t, err)
 			assert.Equal(t, tt.wantName, user.Name)
 		})
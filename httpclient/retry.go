@@ -50,8 +50,11 @@ type RetryConfig struct {
 	// Default: 30s
 	MaxInterval time.Duration
 
-	// MaxElapsedTime is the total time budget for the entire retry sequence.
-	// Once this time has passed since the first attempt, no more retries occur.
+	// MaxElapsedTime is the total time budget for the entire retry sequence,
+	// backoff sleeps included, independent of the request context.
+	// Once this time has passed since the first attempt, no more retries occur,
+	// and the http.client.retry.exhausted counter and http.retry.exhausted
+	// span event record the reason "elapsed".
 	// Set to 0 for no time limit (only MaxRetries applies).
 	// Default: 2m
 	//
@@ -78,6 +81,15 @@ type RetryConfig struct {
 	// Example with JitterFactor=0.5 and interval=1s:
 	// Actual wait time will be random between 0.5s and 1.5s.
 	JitterFactor float64
+
+	// MaxBufferedBody caps the bytes of a request body buffered so it can
+	// be resent on retry, by hedges, or to coalescing callers. It applies to
+	// bodies that can't be rewound, i.e. io.Reader bodies other than bytes
+	// and strings readers. A larger body is sent once, without retries,
+	// hedging or coalescing, and an http.retry.disabled,
+	// http.hedge.disabled or http.coalesce.disabled span event is recorded.
+	// Default: 0, meaning DefaultMaxBufferedBody
+	MaxBufferedBody int64
 }
 
 // Default values for RetryConfig.
@@ -100,6 +112,10 @@ const (
 	// DefaultJitterFactor is the default randomization factor.
 	// 0.5 means ±50% randomization, which is recommended for most use cases.
 	DefaultJitterFactor = 0.5
+
+	// DefaultMaxBufferedBody is the default cap on request bodies buffered
+	// for retries.
+	DefaultMaxBufferedBody = 4 << 20
 )
 
 // DefaultRetryConfig returns balanced defaults for general use.
@@ -192,6 +208,14 @@ func NoRetryConfig() RetryConfig {
 	}
 }
 
+// maxBufferedBody returns MaxBufferedBody, or its default if unset.
+func (c RetryConfig) maxBufferedBody() int64 {
+	if c.MaxBufferedBody <= 0 {
+		return DefaultMaxBufferedBody
+	}
+	return c.MaxBufferedBody
+}
+
 // IsEnabled returns true if retries are enabled.
 func (c RetryConfig) IsEnabled() bool {
 	return c.MaxRetries > 0
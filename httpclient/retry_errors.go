@@ -0,0 +1,91 @@
+package httpclient
+
+import "errors"
+
+// retriableError marks an error as worth retrying.
+type retriableError struct {
+	err error
+}
+
+func (e *retriableError) Error() string { return e.err.Error() }
+func (e *retriableError) Unwrap() error { return e.err }
+
+// permanentError marks an error as not worth retrying.
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Retriable marks err as transient, so DefaultClassifier retries it even
+// when it would otherwise be treated as permanent. It returns nil if err
+// is nil. The wrapped error is still reachable with errors.Is and errors.As.
+//
+// Use it in a RoundTripper passed to WithBaseTransport, or in a custom
+// classifier, to give retry decisions the same vocabulary everywhere.
+//
+// Example:
+//
+//	if resp.Header.Get("X-Shard-Moving") != "" {
+//	    return nil, httpclient.Retriable(errShardMoving)
+//	}
+func Retriable(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &retriableError{err: err}
+}
+
+// Permanent marks err as final, so DefaultClassifier does not retry it even
+// when it looks like a transient network error. It returns nil if err is
+// nil. The wrapped error is still reachable with errors.Is and errors.As.
+//
+// Example:
+//
+//	if errors.Is(err, errQuotaExhausted) {
+//	    return nil, httpclient.Permanent(err)
+//	}
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// IsRetriable reports whether err, or any error it wraps, was marked with
+// Retriable. When both wrappers are present, the outermost one wins.
+func IsRetriable(err error) bool {
+	retriable, ok := retryIntent(err)
+	return ok && retriable
+}
+
+// IsPermanent reports whether err, or any error it wraps, was marked with
+// Permanent. When both wrappers are present, the outermost one wins.
+func IsPermanent(err error) bool {
+	retriable, ok := retryIntent(err)
+	return ok && !retriable
+}
+
+// retryIntent returns the retry decision of the outermost Retriable or
+// Permanent wrapper in err's chain, and false if there is none.
+func retryIntent(err error) (retriable bool, ok bool) {
+	for err != nil {
+		switch err.(type) {
+		case *retriableError:
+			return true, true
+		case *permanentError:
+			return false, true
+		}
+		if joined, isJoined := err.(interface{ Unwrap() []error }); isJoined {
+			for _, e := range joined.Unwrap() {
+				if retriable, ok := retryIntent(e); ok {
+					return retriable, true
+				}
+			}
+			return false, false
+		}
+		err = errors.Unwrap(err)
+	}
+	return false, false
+}
@@ -0,0 +1,136 @@
+package httpclient
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kroma-labs/sentinel-go/httpclient/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryIntent(t *testing.T) {
+	errBase := errors.New("base")
+
+	tests := []struct {
+		name          string
+		err           error
+		wantRetriable bool
+		wantPermanent bool
+	}{
+		{
+			name: "given nil error, then is neither",
+			err:  nil,
+		},
+		{
+			name: "given plain error, then is neither",
+			err:  errBase,
+		},
+		{
+			name:          "given retriable error, then is retriable",
+			err:           Retriable(errBase),
+			wantRetriable: true,
+		},
+		{
+			name:          "given permanent error, then is permanent",
+			err:           Permanent(errBase),
+			wantPermanent: true,
+		},
+		{
+			name:          "given wrapped retriable error, then is retriable",
+			err:           fmt.Errorf("fetch: %w", Retriable(errBase)),
+			wantRetriable: true,
+		},
+		{
+			name:          "given permanent wrapping retriable, then outermost wins",
+			err:           Permanent(Retriable(errBase)),
+			wantPermanent: true,
+		},
+		{
+			name:          "given joined errors, then first marked error wins",
+			err:           errors.Join(errBase, Retriable(errBase), Permanent(errBase)),
+			wantRetriable: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantRetriable, IsRetriable(tt.err))
+			assert.Equal(t, tt.wantPermanent, IsPermanent(tt.err))
+		})
+	}
+}
+
+func TestRetriable(t *testing.T) {
+	errBase := errors.New("base")
+
+	t.Run("given nil error, then returns nil", func(t *testing.T) {
+		assert.NoError(t, Retriable(nil))
+		assert.NoError(t, Permanent(nil))
+	})
+
+	t.Run("given wrapped error, then keeps message and chain", func(t *testing.T) {
+		for _, err := range []error{Retriable(errBase), Permanent(errBase)} {
+			assert.EqualError(t, err, "base")
+			assert.ErrorIs(t, err, errBase)
+		}
+	})
+}
+
+func TestRetryTransport_RetryIntent(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		wantRequests int
+	}{
+		{
+			name:         "given error marked retriable, then retries",
+			err:          Retriable(errors.New("x509: certificate has expired")),
+			wantRequests: 2,
+		},
+		{
+			name:         "given network error marked permanent, then does not retry",
+			err:          Permanent(&timeoutError{}),
+			wantRequests: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRT := mocks.NewRoundTripper(t)
+			mockRT.EXPECT().RoundTrip(mock.Anything).Return(nil, tt.err).Once()
+			if tt.wantRequests > 1 {
+				mockRT.EXPECT().
+					RoundTrip(mock.Anything).
+					Return(&http.Response{
+						StatusCode: http.StatusOK,
+						Body:       io.NopCloser(bytes.NewBufferString("OK")),
+					}, nil).Once()
+			}
+
+			rt := newRetryTransport(mockRT, newConfig(WithRetryConfig(RetryConfig{
+				MaxRetries:      1,
+				InitialInterval: time.Millisecond,
+				MaxInterval:     time.Millisecond,
+				Multiplier:      1,
+			})))
+
+			req := httptest.NewRequest(http.MethodGet, "http://example.com", nil)
+			resp, err := rt.RoundTrip(req)
+			if tt.wantRequests > 1 {
+				require.NoError(t, err)
+				resp.Body.Close()
+				return
+			}
+			require.Error(t, err)
+			assert.True(t, IsPermanent(err))
+		})
+	}
+}
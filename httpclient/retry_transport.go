@@ -2,6 +2,7 @@ package httpclient
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"io"
 	"net/http"
@@ -16,13 +17,21 @@ import (
 // errRetryableStatus is a sentinel error for retryable HTTP status codes.
 var errRetryableStatus = errors.New("retryable status code")
 
+// noRetryKey is the context key that disables retries for a request, set
+// for requests whose response is streamed.
+type noRetryKey struct{}
+
+// maxRetriesKey is the context key that overrides RetryConfig.MaxRetries for
+// a request, set by RequestBuilder.Retries and RequestBuilder.NoRetry.
+type maxRetriesKey struct{}
+
 // retryTransport wraps an http.RoundTripper with retry logic.
 // It uses the provided backoff strategy and classifier to determine
 // when and how to retry failed requests.
 type retryTransport struct {
 	base       http.RoundTripper
 	cfg        *internalConfig
-	classifier RetryClassifier
+	classifier ClassifierWithReason
 }
 
 // newRetryTransport creates a new retry transport wrapper.
@@ -32,9 +41,9 @@ func newRetryTransport(base http.RoundTripper, cfg *internalConfig) http.RoundTr
 		return base
 	}
 
-	classifier := cfg.RetryClassifier
+	classifier := cfg.RetryReasonClassifier
 	if classifier == nil {
-		classifier = DefaultClassifier
+		classifier = DefaultClassifierWithReason
 	}
 
 	return &retryTransport{
@@ -49,95 +58,148 @@ func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 	ctx := req.Context()
 	cfg := t.cfg.RetryConfig
 
-	// Capture request body for potential retries
+	if maxRetries, ok := ctx.Value(maxRetriesKey{}).(uint); ok {
+		cfg.MaxRetries = maxRetries
+	}
+	if noRetry, _ := ctx.Value(noRetryKey{}).(bool); noRetry || !cfg.IsEnabled() {
+		return t.base.RoundTrip(req)
+	}
+
+	// Get or create span for retry events
+	span := trace.SpanFromContext(ctx)
+
+	// Capture request body for potential retries, unless it is too large to
+	// hold in memory, in which case it can only be sent once
 	var bodyBytes []byte
 	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		limit := cfg.maxBufferedBody()
 		var err error
-		bodyBytes, err = io.ReadAll(req.Body)
+		bodyBytes, err = io.ReadAll(io.LimitReader(req.Body, limit+1))
 		if err != nil {
 			return nil, err
 		}
+		if int64(len(bodyBytes)) > limit {
+			req.Body = struct {
+				io.Reader
+				io.Closer
+			}{io.MultiReader(bytes.NewReader(bodyBytes), req.Body), req.Body}
+			t.recordRetryDisabled(span, "body_too_large", limit)
+			return t.base.RoundTrip(req)
+		}
 		req.Body.Close()
 		req.Body = io.NopCloser(bytes.NewReader(bodyBytes))
 	}
 
-	// Get or create span for retry events
-	span := trace.SpanFromContext(ctx)
-
 	// Create backoff strategy
 	b := t.getBackoff()
+	b.Reset()
 
 	var (
 		resp      *http.Response
 		lastErr   error
 		attempt   int
-		startTime = time.Now()
+		tries     uint
+		reason    string
+		exhausted string
+		clock     = t.cfg.clock()
+		startTime = clock.Now()
 	)
 
-	// Use cenkalti/backoff retry with context
-	retryOpts := []backoff.RetryOption{
-		backoff.WithBackOff(b),
-		backoff.WithMaxTries(cfg.MaxRetries + 1), // +1 because initial attempt is counted
-	}
-
-	if cfg.MaxElapsedTime > 0 {
-		retryOpts = append(retryOpts, backoff.WithMaxElapsedTime(cfg.MaxElapsedTime))
-	}
-
-	// Add notify callback for retry events
-	retryOpts = append(retryOpts, backoff.WithNotify(func(err error, next time.Duration) {
-		attempt++
-		t.recordRetryEvent(span, attempt, err, next)
-		t.cfg.Metrics.recordRetryAttempt(ctx, t.cfg.baseAttributes(), attempt)
-	}))
-
-	resp, lastErr = backoff.Retry(ctx, func() (*http.Response, error) {
+	// The loop mirrors backoff.Retry, with the waits and MaxElapsedTime
+	// measured on the configured clock
+	for {
 		// Clone request with fresh body for each attempt
 		reqClone := t.cloneRequest(req, bodyBytes)
 
 		// Execute request
-		resp, err := t.base.RoundTrip(reqClone)
+		tries++
+		resp, lastErr = t.base.RoundTrip(reqClone)
 
 		// Check if we should retry
-		if t.classifier(resp, err) {
-			// Close response body before retry to prevent leaks
-			if resp != nil && resp.Body != nil {
-				io.Copy(io.Discard, resp.Body)
-				resp.Body.Close()
-			}
-			// If err is nil (retryable status code), create a synthetic error
-			// so backoff.Retry knows to retry
-			if err == nil && resp != nil {
-				err = errRetryableStatus
+		var retryable bool
+		if retryable, reason = t.classifier(resp, lastErr); !retryable {
+			// Not retryable - return the response or error as is
+			if lastErr != nil {
+				resp = nil
 			}
-			return nil, err
+			break
 		}
 
-		// Not retryable - return as permanent error to stop retrying
-		if err != nil {
-			return nil, backoff.Permanent(err)
+		// Close response body before retry to prevent leaks
+		if resp != nil && resp.Body != nil {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		// If err is nil (retryable status code), report a synthetic error
+		// should this be the last attempt
+		if lastErr == nil && resp != nil {
+			lastErr = errRetryableStatus
 		}
+		resp = nil
 
-		return resp, nil
-	}, retryOpts...)
+		if tries > cfg.MaxRetries {
+			exhausted = "max_retries"
+			break
+		}
+		if cerr := context.Cause(ctx); cerr != nil {
+			lastErr = cerr
+			break
+		}
+
+		next := b.NextBackOff()
+		if next == backoff.Stop {
+			exhausted = "backoff_stop"
+			break
+		}
+		if cfg.MaxElapsedTime > 0 && clock.Now().Sub(startTime)+next > cfg.MaxElapsedTime {
+			exhausted = "elapsed"
+			break
+		}
+
+		attempt++
+		t.cfg.counters.retries.Add(1)
+		t.recordRetryEvent(span, attempt, reason, lastErr, next)
+		t.cfg.Metrics.recordRetryAttempt(ctx, t.cfg.baseAttributes(), attempt, reason)
+
+		if !t.wait(ctx, clock, next) {
+			lastErr = context.Cause(ctx)
+			break
+		}
+	}
 
 	// Record final retry metrics
-	totalDuration := time.Since(startTime)
+	totalDuration := clock.Now().Sub(startTime)
 	if attempt > 0 {
 		span.SetAttributes(
 			attribute.Int("http.retry_count", attempt),
 			attribute.Bool("http.retry_success", lastErr == nil),
 		)
+	}
 
-		if lastErr != nil {
-			t.cfg.Metrics.recordRetryExhausted(ctx, t.cfg.baseAttributes())
-		}
+	// The loop gave up on a retryable failure: either every attempt was
+	// used, or the next backoff would have exceeded the time budget
+	if exhausted != "" {
+		t.recordRetryExhausted(span, exhausted, tries, totalDuration)
+		t.cfg.Metrics.recordRetryExhausted(ctx, t.cfg.baseAttributes(), exhausted)
 	}
 	t.cfg.Metrics.recordRetryDuration(ctx, t.cfg.baseAttributes(), totalDuration)
 
 	return resp, lastErr
 }
 
+// wait sleeps for d on clock and reports whether it did so before the
+// context was done.
+func (t *retryTransport) wait(ctx context.Context, clock Clock, d time.Duration) bool {
+	timer := clock.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C():
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
 // cloneRequest creates a copy of the request with a fresh body.
 func (t *retryTransport) cloneRequest(req *http.Request, bodyBytes []byte) *http.Request {
 	// Clone the request
@@ -176,6 +238,7 @@ func (t *retryTransport) getBackoff() backoff.BackOff {
 func (t *retryTransport) recordRetryEvent(
 	span trace.Span,
 	attempt int,
+	reason string,
 	err error,
 	nextDelay time.Duration,
 ) {
@@ -186,24 +249,10 @@ func (t *retryTransport) recordRetryEvent(
 	attrs := []attribute.KeyValue{
 		attribute.Int("retry.attempt", attempt),
 		attribute.Int64("retry.delay_ms", nextDelay.Milliseconds()),
+		attribute.String("retry.reason", reason),
 	}
 
 	if err != nil {
-		// Classify the retry reason
-		reason := "unknown"
-		errStr := err.Error()
-
-		if isRetryableNetworkError(err) {
-			reason = "network_error"
-		} else if len(errStr) > 0 {
-			reason = errStr
-			if len(reason) > 50 {
-				reason = reason[:50] + "..."
-			}
-		}
-
-		attrs = append(attrs, attribute.String("retry.reason", reason))
-
 		// Record error on span
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
@@ -211,3 +260,39 @@ func (t *retryTransport) recordRetryEvent(
 
 	span.AddEvent("http.retry", trace.WithAttributes(attrs...))
 }
+
+// recordRetryExhausted adds a span event for a retry loop that stopped
+// before the request succeeded.
+func (t *retryTransport) recordRetryExhausted(
+	span trace.Span,
+	reason string,
+	tries uint,
+	elapsed time.Duration,
+) {
+	if !span.IsRecording() {
+		return
+	}
+
+	span.AddEvent("http.retry.exhausted", trace.WithAttributes(
+		attribute.String("retry.exhausted.reason", reason),
+		attribute.Int("retry.tries", int(tries)),
+		attribute.Int64("retry.elapsed_ms", elapsed.Milliseconds()),
+	))
+}
+
+// recordRetryDisabled adds a span event for a request sent without retries
+// although retries are enabled.
+func (t *retryTransport) recordRetryDisabled(
+	span trace.Span,
+	reason string,
+	maxBufferedBody int64,
+) {
+	if !span.IsRecording() {
+		return
+	}
+
+	span.AddEvent("http.retry.disabled", trace.WithAttributes(
+		attribute.String("retry.disabled.reason", reason),
+		attribute.Int64("retry.max_buffered_body", maxBufferedBody),
+	))
+}
@@ -7,6 +7,8 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
@@ -14,6 +16,10 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 )
 
 func TestRetryTransport_RoundTrip(t *testing.T) {
@@ -262,3 +268,266 @@ func TestRetryTransport_Disabled(t *testing.T) {
 		assert.Equal(t, mockRT, rt)
 	})
 }
+
+func TestRetryTransport_RetryReason(t *testing.T) {
+	retryConfig := RetryConfig{
+		MaxRetries:      1,
+		InitialInterval: time.Millisecond,
+		MaxInterval:     time.Millisecond,
+		Multiplier:      1,
+	}
+
+	tests := []struct {
+		name       string
+		status     int
+		opts       []Option
+		wantReason string
+	}{
+		{
+			name:       "given default classifier and 429, then records status_429",
+			status:     http.StatusTooManyRequests,
+			wantReason: "status_429",
+		},
+		{
+			name:   "given bool classifier, then records custom",
+			status: http.StatusInternalServerError,
+			opts: []Option{
+				WithRetryClassifier(StatusCodeClassifier(http.StatusInternalServerError)),
+			},
+			wantReason: RetryReasonCustom,
+		},
+		{
+			name:   "given classifier with reason, then records its reason",
+			status: http.StatusInternalServerError,
+			opts: []Option{
+				WithRetryClassifierWithReason(func(resp *http.Response, _ error) (bool, string) {
+					return resp.StatusCode >= 500, "server_error"
+				}),
+			},
+			wantReason: "server_error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRT := mocks.NewRoundTripper(t)
+			mockRT.EXPECT().
+				RoundTrip(mock.Anything).
+				Return(&http.Response{
+					StatusCode: tt.status,
+					Body:       io.NopCloser(bytes.NewBufferString("")),
+				}, nil).Once()
+			mockRT.EXPECT().
+				RoundTrip(mock.Anything).
+				Return(&http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(bytes.NewBufferString("OK")),
+				}, nil).Once()
+
+			reader := sdkmetric.NewManualReader()
+			mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+			defer mp.Shutdown(context.Background())
+
+			opts := append([]Option{
+				WithMeterProvider(mp),
+				WithRetryConfig(retryConfig),
+			}, tt.opts...)
+			rt := newRetryTransport(mockRT, newConfig(opts...))
+
+			req := httptest.NewRequest(http.MethodGet, "http://example.com", nil)
+			resp, err := rt.RoundTrip(req)
+			require.NoError(t, err)
+			resp.Body.Close()
+
+			var rm metricdata.ResourceMetrics
+			require.NoError(t, reader.Collect(context.Background(), &rm))
+
+			var reasons []string
+			for _, sm := range rm.ScopeMetrics {
+				for _, m := range sm.Metrics {
+					if m.Name != "http.client.retry.attempts" {
+						continue
+					}
+					sum, ok := m.Data.(metricdata.Sum[int64])
+					require.True(t, ok)
+					for _, dp := range sum.DataPoints {
+						reason, _ := dp.Attributes.Value("retry.reason")
+						reasons = append(reasons, reason.AsString())
+					}
+				}
+			}
+			assert.Equal(t, []string{tt.wantReason}, reasons)
+		})
+	}
+}
+
+func TestRetryTransport_BodyReplay(t *testing.T) {
+	tests := []struct {
+		name            string
+		body            string
+		maxBufferedBody int64
+		wantBodies      []string
+		wantStatus      int
+		wantEvent       bool
+	}{
+		{
+			name:       "given one-shot body within cap, then resends it on retry",
+			body:       "payload",
+			wantBodies: []string{"payload", "payload"},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:            "given one-shot body over cap, then sends it once without retries",
+			body:            "payload",
+			maxBufferedBody: 4,
+			wantBodies:      []string{"payload"},
+			wantStatus:      http.StatusServiceUnavailable,
+			wantEvent:       true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var bodies []string
+			handler := func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				bodies = append(bodies, string(body))
+				if len(bodies) == 1 {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			}
+			server := httptest.NewServer(http.HandlerFunc(handler))
+			defer server.Close()
+
+			exporter := tracetest.NewInMemoryExporter()
+			tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+			ctx, span := tp.Tracer("test").Start(context.Background(), "request")
+
+			rt := newRetryTransport(http.DefaultTransport, newConfig(WithRetryConfig(RetryConfig{
+				MaxRetries:      1,
+				InitialInterval: time.Millisecond,
+				MaxInterval:     time.Millisecond,
+				Multiplier:      1,
+				MaxBufferedBody: tt.maxBufferedBody,
+			})))
+
+			// MultiReader hides the strings.Reader, so the body can't be rewound
+			body := io.MultiReader(strings.NewReader(tt.body))
+			req, err := http.NewRequestWithContext(ctx, http.MethodPost, server.URL, body)
+			require.NoError(t, err)
+			require.Nil(t, req.GetBody)
+
+			resp, err := rt.RoundTrip(req)
+			require.NoError(t, err)
+			resp.Body.Close()
+			span.End()
+
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+			assert.Equal(t, tt.wantBodies, bodies)
+
+			var events []string
+			for _, e := range exporter.GetSpans()[0].Events {
+				events = append(events, e.Name)
+			}
+			assert.Equal(t, tt.wantEvent, slices.Contains(events, "http.retry.disabled"))
+		})
+	}
+}
+
+func TestRetryTransport_Exhausted(t *testing.T) {
+	tests := []struct {
+		name         string
+		cfg          RetryConfig
+		wantReason   string
+		wantRequests int
+	}{
+		{
+			name: "given failures past max retries, then records max_retries",
+			cfg: RetryConfig{
+				MaxRetries:      2,
+				InitialInterval: time.Millisecond,
+				MaxInterval:     time.Millisecond,
+				Multiplier:      1,
+			},
+			wantReason:   "max_retries",
+			wantRequests: 3,
+		},
+		{
+			name: "given backoff past max elapsed time, then stops early and records elapsed",
+			cfg: RetryConfig{
+				MaxRetries:      10,
+				InitialInterval: 40 * time.Millisecond,
+				MaxInterval:     40 * time.Millisecond,
+				MaxElapsedTime:  60 * time.Millisecond,
+				Multiplier:      1,
+				JitterFactor:    0.01,
+			},
+			wantReason:   "elapsed",
+			wantRequests: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int
+			handler := func(w http.ResponseWriter, _ *http.Request) {
+				requests++
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}
+			server := httptest.NewServer(http.HandlerFunc(handler))
+			defer server.Close()
+
+			exporter := tracetest.NewInMemoryExporter()
+			tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+			ctx, span := tp.Tracer("test").Start(context.Background(), "request")
+			reader := sdkmetric.NewManualReader()
+			mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+			rt := newRetryTransport(http.DefaultTransport, newConfig(
+				WithRetryConfig(tt.cfg),
+				WithMeterProvider(mp),
+			))
+
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+			require.NoError(t, err)
+
+			_, err = rt.RoundTrip(req)
+			require.Error(t, err)
+			span.End()
+
+			assert.Equal(t, tt.wantRequests, requests)
+
+			var gotReasons []string
+			for _, e := range exporter.GetSpans()[0].Events {
+				if e.Name != "http.retry.exhausted" {
+					continue
+				}
+				for _, attr := range e.Attributes {
+					if attr.Key == "retry.exhausted.reason" {
+						gotReasons = append(gotReasons, attr.Value.AsString())
+					}
+				}
+			}
+			assert.Equal(t, []string{tt.wantReason}, gotReasons)
+
+			var rm metricdata.ResourceMetrics
+			require.NoError(t, reader.Collect(context.Background(), &rm))
+			var gotMetric string
+			for _, sm := range rm.ScopeMetrics {
+				for _, m := range sm.Metrics {
+					if m.Name != "http.client.retry.exhausted" {
+						continue
+					}
+					sum, ok := m.Data.(metricdata.Sum[int64])
+					require.True(t, ok)
+					require.Len(t, sum.DataPoints, 1)
+					reason, _ := sum.DataPoints[0].Attributes.Value("retry.exhausted.reason")
+					gotMetric = reason.AsString()
+				}
+			}
+			assert.Equal(t, tt.wantReason, gotMetric)
+		})
+	}
+}
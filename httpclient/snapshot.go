@@ -0,0 +1,75 @@
+package httpclient
+
+import (
+	"net/http"
+	"sync/atomic"
+
+	"github.com/goccy/go-json"
+)
+
+// ClientMetricsSnapshot is a point-in-time copy of a client's counters.
+//
+// The counters are kept by the client itself, next to the OpenTelemetry
+// metrics, so tests can assert on them without setting up a metric reader.
+// They count from the client's creation and are never reset.
+type ClientMetricsSnapshot struct {
+	// Requests is the number of requests sent through the client's transport
+	// chain. Each hedge counts as a request; retries of a request don't.
+	Requests int64 `json:"requests"`
+
+	// Retries is the number of retry attempts, excluding first attempts.
+	Retries int64 `json:"retries"`
+
+	// BreakerRejections is the number of requests rejected by the open
+	// circuit breaker without being sent.
+	BreakerRejections int64 `json:"breaker_rejections"`
+
+	// CoalesceHits is the number of coalesced requests that shared the
+	// response of an identical in-flight request instead of being sent.
+	CoalesceHits int64 `json:"coalesce_hits"`
+}
+
+// clientCounters holds the counters behind ClientMetricsSnapshot.
+type clientCounters struct {
+	requests          atomic.Int64
+	retries           atomic.Int64
+	breakerRejections atomic.Int64
+	coalesceHits      atomic.Int64
+}
+
+// snapshot returns the current value of the counters.
+func (c *clientCounters) snapshot() ClientMetricsSnapshot {
+	return ClientMetricsSnapshot{
+		Requests:          c.requests.Load(),
+		Retries:           c.retries.Load(),
+		BreakerRejections: c.breakerRejections.Load(),
+		CoalesceHits:      c.coalesceHits.Load(),
+	}
+}
+
+// MetricsSnapshot returns the client's counters.
+//
+// A MockTransport replaces the transport chain, so with one configured only
+// CoalesceHits is counted.
+//
+// Example:
+//
+//	before := client.MetricsSnapshot()
+//	_, err := client.Request("GetUser").Get(ctx, "/users/1")
+//	retries := client.MetricsSnapshot().Retries - before.Retries
+func (c *Client) MetricsSnapshot() ClientMetricsSnapshot {
+	return c.config.counters.snapshot()
+}
+
+// DebugHandler returns an http.Handler that serves the client's
+// MetricsSnapshot as JSON, for mounting on an internal debug endpoint.
+//
+// Example:
+//
+//	mux.Handle("/debug/httpclient/payments", paymentsClient.DebugHandler())
+func (c *Client) DebugHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(c.MetricsSnapshot())
+	})
+}
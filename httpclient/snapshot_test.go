@@ -0,0 +1,121 @@
+package httpclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_MetricsSnapshot(t *testing.T) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, serverURL string) *Client
+		want ClientMetricsSnapshot
+	}{
+		{
+			name: "given retried request, then counts one request and its retries",
+			run: func(t *testing.T, serverURL string) *Client {
+				client := New(
+					WithBaseURL(serverURL),
+					WithRetryConfig(RetryConfig{
+						MaxRetries:      2,
+						InitialInterval: time.Millisecond,
+						MaxInterval:     time.Millisecond,
+						Multiplier:      1,
+					}),
+				)
+				_, err := client.Request("Test").Get(context.Background(), "/unavailable")
+				require.Error(t, err)
+				return client
+			},
+			want: ClientMetricsSnapshot{Requests: 1, Retries: 2},
+		},
+		{
+			name: "given open circuit, then counts breaker rejection",
+			run: func(t *testing.T, serverURL string) *Client {
+				breakerCfg := DefaultBreakerConfig()
+				breakerCfg.FailureThreshold = 0
+				breakerCfg.ConsecutiveFailures = 1
+				client := New(
+					WithBaseURL(serverURL),
+					WithRetryDisabled(),
+					WithBreakerConfig(breakerCfg),
+				)
+				_, err := client.Request("Test").Get(context.Background(), "/unavailable")
+				require.NoError(t, err)
+				_, err = client.Request("Test").Get(context.Background(), "/unavailable")
+				require.Error(t, err)
+				return client
+			},
+			want: ClientMetricsSnapshot{Requests: 2, BreakerRejections: 1},
+		},
+		{
+			name: "given concurrent coalesced requests, then counts coalesce hits",
+			run: func(t *testing.T, serverURL string) *Client {
+				client := New(WithBaseURL(serverURL), WithRetryDisabled())
+				var wg sync.WaitGroup
+				for range 3 {
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+						_, err := client.Request("Snapshot").
+							Coalesce().
+							Get(context.Background(), "/slow")
+						assert.NoError(t, err)
+					}()
+				}
+				wg.Wait()
+				return client
+			},
+			want: ClientMetricsSnapshot{Requests: 1, CoalesceHits: 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					if r.URL.Path == "/slow" {
+						time.Sleep(100 * time.Millisecond)
+					}
+					if r.URL.Path == "/unavailable" {
+						w.WriteHeader(http.StatusServiceUnavailable)
+						return
+					}
+					w.WriteHeader(http.StatusOK)
+				}),
+			)
+			defer server.Close()
+
+			client := tt.run(t, server.URL)
+
+			assert.Equal(t, tt.want, client.MetricsSnapshot())
+		})
+	}
+}
+
+func TestClient_DebugHandler(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := New(WithBaseURL(server.URL))
+	_, err := client.Request("Test").Get(context.Background(), "/test")
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	client.DebugHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug", nil))
+
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	var got ClientMetricsSnapshot
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+	assert.Equal(t, ClientMetricsSnapshot{Requests: 1}, got)
+}
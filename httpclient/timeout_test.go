@@ -104,3 +104,71 @@ func TestTimeout_NoTimeoutSetSucceeds(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, http.StatusOK, resp.StatusCode)
 }
+
+func TestTimeout_DeadlineOnlyTightens(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	soon := time.Now().Add(time.Minute)
+	later := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name     string
+		ctx      func() (context.Context, context.CancelFunc)
+		deadline time.Time
+		timeout  time.Duration
+		want     time.Time
+	}{
+		{
+			name: "given_deadline_only,_then_applies_it",
+			ctx: func() (context.Context, context.CancelFunc) {
+				return context.WithCancel(context.Background())
+			},
+			deadline: soon,
+			want:     soon,
+		},
+		{
+			name: "given_earlier_context_deadline,_then_keeps_context_deadline",
+			ctx: func() (context.Context, context.CancelFunc) {
+				return context.WithDeadline(context.Background(), soon)
+			},
+			deadline: later,
+			want:     soon,
+		},
+		{
+			name: "given_shorter_timeout,_then_keeps_timeout_deadline",
+			ctx: func() (context.Context, context.CancelFunc) {
+				return context.WithCancel(context.Background())
+			},
+			deadline: later,
+			timeout:  time.Minute,
+			want:     soon,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := tt.ctx()
+			defer cancel()
+
+			client := New(WithBaseURL(server.URL))
+
+			var got time.Time
+			_, err := client.Request("test").
+				Deadline(tt.deadline).
+				Timeout(tt.timeout).
+				Intercept(func(req *http.Request) error {
+					got, _ = req.Context().Deadline()
+					return nil
+				}).
+				Get(ctx, "/api")
+
+			require.NoError(t, err)
+			assert.WithinDuration(t, tt.want, got, time.Second)
+		})
+	}
+}
@@ -24,10 +24,14 @@ const (
 	ErrorTypeConnectionRefused = "connection_refused"
 	ErrorTypeDNSError          = "dns_error"
 	ErrorTypeTLSError          = "tls_error"
-	ErrorTypeCancelled         = "cancelled"
+	ErrorTypeCanceled          = "canceled"
 	ErrorTypeConnectionReset   = "connection_reset"
 	ErrorTypeEOF               = "eof"
+	ErrorTypeInterceptor       = "interceptor"
 	ErrorTypeUnknown           = "unknown"
+
+	// Deprecated: Use ErrorTypeCanceled.
+	ErrorTypeCancelled = ErrorTypeCanceled
 )
 
 // networkTrace holds timing data collected from httptrace.ClientTrace.
@@ -61,6 +65,26 @@ type networkTrace struct {
 
 	// DNS info
 	dnsAddrs []string
+
+	// dialStarts holds the start of each in-flight dial by address, since
+	// the dialer may race connections to several addresses
+	dialStarts map[string]time.Time
+
+	// events is the timeline of network phases, in the order they happened,
+	// across every attempt made with this trace
+	events []networkEvent
+}
+
+// networkEvent is a network phase recorded as a span event.
+type networkEvent struct {
+	name  string
+	at    time.Time
+	attrs []attribute.KeyValue
+}
+
+// addEvent appends a phase to the timeline. The caller must hold nt.mu.
+func (nt *networkTrace) addEvent(name string, at time.Time, attrs ...attribute.KeyValue) {
+	nt.events = append(nt.events, networkEvent{name: name, at: at, attrs: attrs})
 }
 
 // createClientTrace creates an httptrace.ClientTrace that populates networkTrace.
@@ -85,11 +109,17 @@ func createClientTrace(nt *networkTrace) *httptrace.ClientTrace {
 					nt.connLocal = addr.String()
 				}
 			}
+			nt.addEvent("got_conn", nt.gotConnTime,
+				attribute.Bool("connection.reused", nt.connReused),
+				attribute.Bool("connection.was_idle", nt.connIdle),
+				attribute.String("network.peer.address", nt.connRemote),
+			)
 		},
-		DNSStart: func(_ httptrace.DNSStartInfo) {
+		DNSStart: func(info httptrace.DNSStartInfo) {
 			nt.mu.Lock()
 			defer nt.mu.Unlock()
 			nt.dnsStart = time.Now()
+			nt.addEvent("dns.start", nt.dnsStart, attribute.String("dns.host", info.Host))
 		},
 		DNSDone: func(info httptrace.DNSDoneInfo) {
 			nt.mu.Lock()
@@ -101,110 +131,111 @@ func createClientTrace(nt *networkTrace) *httptrace.ClientTrace {
 					nt.dnsAddrs = append(nt.dnsAddrs, addr.String())
 				}
 			}
+			attrs := []attribute.KeyValue{
+				attribute.Float64("dns.duration_ms", durationMs(nt.dnsDone.Sub(nt.dnsStart))),
+				attribute.StringSlice("dns.addresses", nt.dnsAddrs),
+			}
+			if info.Err != nil {
+				attrs = append(attrs, attribute.String("dns.error", info.Err.Error()))
+			}
+			nt.addEvent("dns.done", nt.dnsDone, attrs...)
 		},
-		ConnectStart: func(_, _ string) {
+		ConnectStart: func(network, addr string) {
 			nt.mu.Lock()
 			defer nt.mu.Unlock()
 			nt.connectStart = time.Now()
+			if nt.dialStarts == nil {
+				nt.dialStarts = make(map[string]time.Time)
+			}
+			nt.dialStarts[addr] = nt.connectStart
+			nt.addEvent("connect.start", nt.connectStart,
+				attribute.String("network.transport", network),
+				attribute.String("network.peer.address", addr),
+			)
 		},
-		ConnectDone: func(_, _ string, _ error) {
+		ConnectDone: func(network, addr string, err error) {
 			nt.mu.Lock()
 			defer nt.mu.Unlock()
 			nt.connectDone = time.Now()
+			start, ok := nt.dialStarts[addr]
+			if !ok {
+				start = nt.connectStart
+			}
+			delete(nt.dialStarts, addr)
+			attrs := []attribute.KeyValue{
+				attribute.Float64("connect.duration_ms", durationMs(nt.connectDone.Sub(start))),
+				attribute.String("network.transport", network),
+				attribute.String("network.peer.address", addr),
+			}
+			if err != nil {
+				attrs = append(attrs, attribute.String("connect.error", err.Error()))
+			}
+			nt.addEvent("connect.done", nt.connectDone, attrs...)
 		},
 		TLSHandshakeStart: func() {
 			nt.mu.Lock()
 			defer nt.mu.Unlock()
 			nt.tlsStart = time.Now()
+			nt.addEvent("tls.start", nt.tlsStart)
 		},
-		TLSHandshakeDone: func(state tls.ConnectionState, _ error) {
+		TLSHandshakeDone: func(state tls.ConnectionState, err error) {
 			nt.mu.Lock()
 			defer nt.mu.Unlock()
 			nt.tlsDone = time.Now()
 			nt.protocolVer = state.NegotiatedProtocol
+			attrs := []attribute.KeyValue{
+				attribute.Float64("tls.duration_ms", durationMs(nt.tlsDone.Sub(nt.tlsStart))),
+				attribute.String("tls.protocol", nt.protocolVer),
+			}
+			if err != nil {
+				attrs = append(attrs, attribute.String("tls.error", err.Error()))
+			}
+			nt.addEvent("tls.done", nt.tlsDone, attrs...)
 		},
-		WroteRequest: func(_ httptrace.WroteRequestInfo) {
+		WroteRequest: func(info httptrace.WroteRequestInfo) {
 			nt.mu.Lock()
 			defer nt.mu.Unlock()
 			nt.wroteRequestTime = time.Now()
+			var attrs []attribute.KeyValue
+			if info.Err != nil {
+				attrs = append(attrs, attribute.String("wrote_request.error", info.Err.Error()))
+			}
+			nt.addEvent("wrote_request", nt.wroteRequestTime, attrs...)
 		},
 		GotFirstResponseByte: func() {
 			nt.mu.Lock()
 			defer nt.mu.Unlock()
 			nt.firstResponseTime = time.Now()
+			var ttfbMs float64
+			if !nt.wroteRequestTime.IsZero() {
+				ttfbMs = durationMs(nt.firstResponseTime.Sub(nt.wroteRequestTime))
+			}
+			nt.addEvent("got_first_response_byte", nt.firstResponseTime,
+				attribute.Float64("ttfb_ms", ttfbMs),
+			)
 		},
 	}
 }
 
-// addTraceEvents adds span events for network timing.
+// addTraceEvents adds a span event for each network phase, with the time it
+// happened, so the span shows the DNS, connect and TLS waterfall of every
+// attempt, including phases that failed.
 func (nt *networkTrace) addTraceEvents(span trace.Span) {
-	nt.mu.Lock()
-	defer nt.mu.Unlock()
-
-	// DNS events
-	if !nt.dnsStart.IsZero() && !nt.dnsDone.IsZero() {
-		span.AddEvent("dns.start", trace.WithTimestamp(nt.dnsStart))
-		span.AddEvent("dns.done", trace.WithTimestamp(nt.dnsDone),
-			trace.WithAttributes(
-				attribute.Float64(
-					"dns.duration_ms",
-					float64(nt.dnsDone.Sub(nt.dnsStart).Milliseconds()),
-				),
-				attribute.StringSlice("dns.addresses", nt.dnsAddrs),
-			))
-	}
-
-	// Connect events
-	if !nt.connectStart.IsZero() && !nt.connectDone.IsZero() {
-		span.AddEvent("connect.start", trace.WithTimestamp(nt.connectStart))
-		span.AddEvent("connect.done", trace.WithTimestamp(nt.connectDone),
-			trace.WithAttributes(
-				attribute.Float64(
-					"connect.duration_ms",
-					float64(nt.connectDone.Sub(nt.connectStart).Milliseconds()),
-				),
-			))
-	}
-
-	// TLS events
-	if !nt.tlsStart.IsZero() && !nt.tlsDone.IsZero() {
-		span.AddEvent("tls.start", trace.WithTimestamp(nt.tlsStart))
-		span.AddEvent("tls.done", trace.WithTimestamp(nt.tlsDone),
-			trace.WithAttributes(
-				attribute.Float64(
-					"tls.duration_ms",
-					float64(nt.tlsDone.Sub(nt.tlsStart).Milliseconds()),
-				),
-				attribute.String("tls.protocol", nt.protocolVer),
-			))
+	if !span.IsRecording() {
+		return
 	}
 
-	// Connection acquired event
-	if !nt.gotConnTime.IsZero() {
-		span.AddEvent("got_conn", trace.WithTimestamp(nt.gotConnTime),
-			trace.WithAttributes(
-				attribute.Bool("connection.reused", nt.connReused),
-				attribute.Bool("connection.was_idle", nt.connIdle),
-				attribute.String("network.peer.address", nt.connRemote),
-			))
-	}
+	nt.mu.Lock()
+	defer nt.mu.Unlock()
 
-	// Request written event
-	if !nt.wroteRequestTime.IsZero() {
-		span.AddEvent("wrote_request", trace.WithTimestamp(nt.wroteRequestTime))
+	for _, e := range nt.events {
+		span.AddEvent(e.name, trace.WithTimestamp(e.at), trace.WithAttributes(e.attrs...))
 	}
+}
 
-	// First response byte event
-	if !nt.firstResponseTime.IsZero() {
-		var ttfbMs float64
-		if !nt.wroteRequestTime.IsZero() {
-			ttfbMs = float64(nt.firstResponseTime.Sub(nt.wroteRequestTime).Milliseconds())
-		}
-		span.AddEvent("got_first_response_byte", trace.WithTimestamp(nt.firstResponseTime),
-			trace.WithAttributes(
-				attribute.Float64("ttfb_ms", ttfbMs),
-			))
-	}
+// durationMs returns d in milliseconds, with microsecond precision.
+func durationMs(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
 }
 
 // recordTimingMetrics records network timing metrics.
@@ -252,9 +283,16 @@ func classifyError(err error) string {
 		return ""
 	}
 
-	// Check for context cancellation
+	// Interceptor failures happen before or after the network round trip
+	var interceptorErr *InterceptorError
+	if errors.As(err, &interceptorErr) {
+		return ErrorTypeInterceptor
+	}
+
+	// Check for context cancellation, usually by the caller rather than a
+	// fault of the server
 	if errors.Is(err, context.Canceled) {
-		return ErrorTypeCancelled
+		return ErrorTypeCanceled
 	}
 
 	// Check for deadline exceeded (timeout)
@@ -4,7 +4,11 @@ import (
 	"context"
 	"crypto/tls"
 	"errors"
+	"io"
 	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httptrace"
 	"testing"
 	"time"
 
@@ -33,7 +37,7 @@ func TestClassifyError(t *testing.T) {
 		{
 			name:    "given context cancelled, then returns cancelled",
 			args:    args{err: context.Canceled},
-			wantVal: ErrorTypeCancelled,
+			wantVal: ErrorTypeCanceled,
 		},
 		{
 			name:    "given context deadline exceeded, then returns timeout",
@@ -43,7 +47,7 @@ func TestClassifyError(t *testing.T) {
 		{
 			name:    "given wrapped context cancelled, then returns cancelled",
 			args:    args{err: errors.Join(errors.New("request failed"), context.Canceled)},
-			wantVal: ErrorTypeCancelled,
+			wantVal: ErrorTypeCanceled,
 		},
 		{
 			name:    "given timeout in message, then returns timeout",
@@ -166,35 +170,53 @@ func TestErrorTypeFromStatusCode(t *testing.T) {
 }
 
 func TestNetworkTrace_AddTraceEvents(t *testing.T) {
-	type args struct {
-		dnsStart  time.Time
-		dnsDone   time.Time
-		dnsAddrs  []string
-		connReuse bool
-	}
+	dialErr := errors.New("connection refused")
 
 	tests := []struct {
-		name           string
-		args           args
-		wantEventCount int
+		name       string
+		run        func(ct *httptrace.ClientTrace)
+		wantEvents []string
+		wantAttrs  map[string]string
 	}{
 		{
-			name: "given full trace, then adds all events",
-			args: func() args {
-				now := time.Now()
-				return args{
-					dnsStart:  now,
-					dnsDone:   now.Add(10 * time.Millisecond),
-					dnsAddrs:  []string{"192.168.1.1"},
-					connReuse: false,
-				}
-			}(),
-			wantEventCount: 2, // dns.start, dns.done
+			name: "given full trace, then adds an event per phase in order",
+			run: func(ct *httptrace.ClientTrace) {
+				ct.DNSStart(httptrace.DNSStartInfo{Host: "api.example.com"})
+				ct.DNSDone(httptrace.DNSDoneInfo{})
+				ct.ConnectStart("tcp", "192.168.1.1:443")
+				ct.ConnectDone("tcp", "192.168.1.1:443", nil)
+				ct.TLSHandshakeStart()
+				ct.TLSHandshakeDone(tls.ConnectionState{NegotiatedProtocol: "h2"}, nil)
+				ct.GotConn(httptrace.GotConnInfo{})
+				ct.WroteRequest(httptrace.WroteRequestInfo{})
+				ct.GotFirstResponseByte()
+			},
+			wantEvents: []string{
+				"dns.start", "dns.done", "connect.start", "connect.done", "tls.start",
+				"tls.done", "got_conn", "wrote_request", "got_first_response_byte",
+			},
+			wantAttrs: map[string]string{
+				"dns.host":     "api.example.com",
+				"tls.protocol": "h2",
+			},
+		},
+		{
+			name: "given failed dial then retry, then records both attempts and the error",
+			run: func(ct *httptrace.ClientTrace) {
+				ct.ConnectStart("tcp", "192.168.1.1:443")
+				ct.ConnectDone("tcp", "192.168.1.1:443", dialErr)
+				ct.ConnectStart("tcp", "192.168.1.2:443")
+				ct.ConnectDone("tcp", "192.168.1.2:443", nil)
+			},
+			wantEvents: []string{"connect.start", "connect.done", "connect.start", "connect.done"},
+			wantAttrs: map[string]string{
+				"connect.error": "connection refused",
+			},
 		},
 		{
-			name:           "given empty trace, then adds no events",
-			args:           args{},
-			wantEventCount: 0,
+			name:       "given empty trace, then adds no events",
+			run:        func(*httptrace.ClientTrace) {},
+			wantEvents: nil,
 		},
 	}
 
@@ -207,11 +229,8 @@ func TestNetworkTrace_AddTraceEvents(t *testing.T) {
 			tracer := tp.Tracer("test")
 			_, span := tracer.Start(context.Background(), "test-span")
 
-			nt := &networkTrace{
-				dnsStart: tt.args.dnsStart,
-				dnsDone:  tt.args.dnsDone,
-				dnsAddrs: tt.args.dnsAddrs,
-			}
+			nt := &networkTrace{}
+			tt.run(createClientTrace(nt))
 
 			nt.addTraceEvents(span)
 			span.End()
@@ -219,15 +238,63 @@ func TestNetworkTrace_AddTraceEvents(t *testing.T) {
 			spans := exporter.GetSpans()
 			require.Len(t, spans, 1)
 
-			if tt.wantEventCount > 0 {
-				assert.GreaterOrEqual(t, len(spans[0].Events), tt.wantEventCount)
-			} else {
-				assert.Empty(t, spans[0].Events)
+			var gotEvents []string
+			gotAttrs := map[string]string{}
+			for i, e := range spans[0].Events {
+				gotEvents = append(gotEvents, e.Name)
+				if i > 0 {
+					assert.False(t, e.Time.Before(spans[0].Events[i-1].Time))
+				}
+				for _, attr := range e.Attributes {
+					if _, ok := tt.wantAttrs[string(attr.Key)]; ok {
+						gotAttrs[string(attr.Key)] = attr.Value.AsString()
+					}
+				}
+			}
+			assert.Equal(t, tt.wantEvents, gotEvents)
+			for k, v := range tt.wantAttrs {
+				assert.Equal(t, v, gotAttrs[k], k)
 			}
 		})
 	}
 }
 
+func TestOtelTransport_NetworkEvents(t *testing.T) {
+	handler := func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}
+	server := httptest.NewTLSServer(http.HandlerFunc(handler))
+	defer server.Close()
+
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	defer tp.Shutdown(context.Background())
+
+	client := New(
+		WithTracerProvider(tp),
+		WithBaseTransport(server.Client().Transport),
+	)
+
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+	resp, err := client.HTTP().Do(req)
+	require.NoError(t, err)
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+
+	var got []string
+	for _, e := range spans[0].Events {
+		got = append(got, e.Name)
+	}
+	assert.Equal(t, []string{
+		"connect.start", "connect.done", "tls.start", "tls.done",
+		"got_conn", "wrote_request", "got_first_response_byte",
+	}, got)
+}
+
 func TestNetworkTrace_RecordTimingMetrics(t *testing.T) {
 	type args struct {
 		dnsStart time.Time
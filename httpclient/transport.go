@@ -17,6 +17,10 @@ import (
 // Compile-time interface check.
 var _ http.RoundTripper = (*otelTransport)(nil)
 
+// metricAttrsKey is the context key for the metric attributes set on a
+// request with MetricAttr and MetricAttrs.
+type metricAttrsKey struct{}
+
 // otelTransport wraps an http.RoundTripper with OpenTelemetry instrumentation.
 type otelTransport struct {
 	base       http.RoundTripper
@@ -44,6 +48,8 @@ func newOtelTransport(base http.RoundTripper, cfg *internalConfig) *otelTranspor
 
 // RoundTrip implements http.RoundTripper with full tracing and metrics.
 func (t *otelTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.cfg.counters.requests.Add(1)
+
 	// Check filters - skip tracing if any filter returns false
 	for _, f := range t.cfg.Filters {
 		if !f(req) {
@@ -75,6 +81,20 @@ func (t *otelTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 	// 1. Transport error occurs (immediately)
 	// 2. Response body is closed or EOF is reached (via wrappedBody)
 
+	// Report baggage members dropped by RequestBuilder.Baggage
+	if dropped, ok := ctx.Value(droppedBaggageKey{}).([]string); ok {
+		for _, key := range dropped {
+			span.AddEvent("baggage.dropped", trace.WithAttributes(
+				attribute.String("baggage.key", key),
+			))
+		}
+	}
+
+	// Report hedging and coalescing skipped because the body was too large
+	if disabled, ok := ctx.Value(replayDisabledKey{}).(replayDisabled); ok {
+		disabled.record(span)
+	}
+
 	// Inject trace context into request headers
 	t.propagator.Inject(ctx, propagation.HeaderCarrier(req.Header))
 
@@ -103,6 +123,15 @@ func (t *otelTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 	// Update request with new context
 	req = req.WithContext(ctx)
 
+	// Capture bodies on a sample of recorded spans
+	sampling := t.cfg.bodySampling
+	if sampling != nil && !(span.IsRecording() && sampling.sample()) {
+		sampling = nil
+	}
+	if sampling != nil {
+		sampling.captureRequest(span, req)
+	}
+
 	// Perform the actual request
 	resp, err := t.base.RoundTrip(req)
 
@@ -135,6 +164,9 @@ func (t *otelTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 
 	// Record response attributes
 	span.SetAttributes(t.responseAttributes(resp)...)
+	if t.cfg.ResponseSpanAttributesFn != nil {
+		span.SetAttributes(t.cfg.ResponseSpanAttributesFn(resp)...)
+	}
 
 	// Set span status based on response code
 	if resp.StatusCode >= 400 {
@@ -157,7 +189,14 @@ func (t *otelTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 		// Capture whether this was a new connection for closure tracking
 		wasNewConnection := nt != nil && !nt.connReused && !nt.connectStart.IsZero()
 
+		recordBody := func() {}
+		if sampling != nil {
+			resp.Body, recordBody = sampling.captureResponse(span, resp.Body)
+		}
+
 		resp.Body = newWrappedBody(span, resp.Body, func(bytesRead int64) {
+			recordBody()
+
 			// Record actual response body size if it differs from Content-Length
 			if resp.ContentLength <= 0 && bytesRead > 0 {
 				t.cfg.Metrics.recordResponseBodySize(ctx, bytesRead, baseAttrs)
@@ -245,13 +284,13 @@ func (t *otelTransport) responseAttributes(resp *http.Response) []attribute.KeyV
 
 	// Protocol version
 	if resp.Proto != "" {
-		// Convert "HTTP/1.1" to "1.1", "HTTP/2.0" to "2"
+		// Convert "HTTP/1.1" to "1.1", "HTTP/2.0" to "2", "HTTP/3.0" to "3"
 		version := resp.Proto
 		if len(version) > 5 && version[:5] == "HTTP/" {
 			version = version[5:]
 		}
-		if version == "2.0" {
-			version = "2"
+		if version == "2.0" || version == "3.0" {
+			version = version[:1]
 		}
 		attrs = append(attrs, attribute.String("network.protocol.version", version))
 	}
@@ -304,6 +343,20 @@ func (t *otelTransport) metricsAttributes(
 		}
 	}
 
+	return append(attrs, t.customAttributes(req)...)
+}
+
+// customAttributes returns the attributes from WithMetricAttributesFn
+// followed by those set on the request with MetricAttr. When a key appears
+// twice the last value wins, so per-request attributes take precedence.
+func (t *otelTransport) customAttributes(req *http.Request) []attribute.KeyValue {
+	var attrs []attribute.KeyValue
+	if t.cfg.MetricAttributesFn != nil {
+		attrs = append(attrs, t.cfg.MetricAttributesFn(req)...)
+	}
+	if reqAttrs, ok := req.Context().Value(metricAttrsKey{}).([]attribute.KeyValue); ok {
+		attrs = append(attrs, reqAttrs...)
+	}
 	return attrs
 }
 
@@ -337,5 +390,5 @@ func (t *otelTransport) errorAttributes(req *http.Request, errorType string) []a
 		attrs = append(attrs, attribute.String("error.type", errorType))
 	}
 
-	return attrs
+	return append(attrs, t.customAttributes(req)...)
 }
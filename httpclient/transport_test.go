@@ -11,6 +11,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
@@ -177,7 +178,7 @@ func TestOtelTransport_RoundTrip_ErrorHandling(t *testing.T) {
 		{
 			name:         "given context cancelled, then records cancelled",
 			args:         args{transportErr: context.Canceled},
-			wantErrType:  ErrorTypeCancelled,
+			wantErrType:  ErrorTypeCanceled,
 			wantSpanAttr: true,
 		},
 	}
@@ -330,6 +331,15 @@ func TestOtelTransport_ResponseAttributes(t *testing.T) {
 			wantStatusCode: 200,
 			wantVersion:    "2",
 		},
+		{
+			name: "given HTTP/3 response, then extracts version as '3'",
+			args: args{
+				statusCode: http.StatusOK,
+				proto:      "HTTP/3.0",
+			},
+			wantStatusCode: 200,
+			wantVersion:    "3",
+		},
 		{
 			name: "given HTTP/1.1 response, then extracts version as '1.1'",
 			args: args{
@@ -363,6 +373,69 @@ func TestOtelTransport_ResponseAttributes(t *testing.T) {
 	}
 }
 
+func TestOtelTransport_ResponseSpanAttributesFn(t *testing.T) {
+	tests := []struct {
+		name      string
+		fn        func(*http.Response) []attribute.KeyValue
+		wantAttrs map[string]string
+	}{
+		{
+			name: "given attributes fn, then adds response-derived attributes to span",
+			fn: func(resp *http.Response) []attribute.KeyValue {
+				return []attribute.KeyValue{
+					attribute.String("upstream.request_id", resp.Header.Get("X-Request-ID")),
+				}
+			},
+			wantAttrs: map[string]string{"upstream.request_id": "req-123"},
+		},
+		{
+			name:      "given no attributes fn, then adds no custom attributes",
+			wantAttrs: map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("X-Request-ID", "req-123")
+				w.WriteHeader(http.StatusOK)
+			}
+			server := httptest.NewServer(http.HandlerFunc(handler))
+			defer server.Close()
+
+			exporter := tracetest.NewInMemoryExporter()
+			tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+			defer tp.Shutdown(context.Background())
+
+			cfg := &internalConfig{
+				httpConfig:               DefaultConfig(),
+				TracerProvider:           tp,
+				ResponseSpanAttributesFn: tt.fn,
+			}
+			cfg.Tracer = tp.Tracer(scope)
+
+			transport := newOtelTransport(http.DefaultTransport, cfg)
+
+			req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+			resp, err := transport.RoundTrip(req)
+			require.NoError(t, err)
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+
+			spans := exporter.GetSpans()
+			require.Len(t, spans, 1)
+
+			gotAttrs := map[string]string{}
+			for _, attr := range spans[0].Attributes {
+				if strings.HasPrefix(string(attr.Key), "upstream.") {
+					gotAttrs[string(attr.Key)] = attr.Value.AsString()
+				}
+			}
+			assert.Equal(t, tt.wantAttrs, gotAttrs)
+		})
+	}
+}
+
 func TestOtelTransport_MetricsRecording(t *testing.T) {
 	t.Run("given successful request, then records duration metric", func(t *testing.T) {
 		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
@@ -0,0 +1,131 @@
+// Package chi provides middleware adapters for the chi router.
+//
+// chi uses standard net/http middleware, so every httpserver middleware works
+// with it directly. This package adds route-pattern awareness: metrics and
+// spans are labeled with the chi pattern ("/users/{id}") instead of the
+// concrete path ("/users/123").
+//
+// # Quick Start
+//
+//	r := chi.NewRouter()
+//
+//	metrics, _ := httpserver.NewMetrics(httpserver.DefaultMetricsConfig())
+//	tracing := httpserver.DefaultTracingConfig()
+//
+//	// Install route labeling, tracing, metrics, and recovery in one call
+//	r.Use(chisentinel.Middleware(r, chisentinel.Config{
+//	    Logger:  &logger,
+//	    Tracer:  &tracing,
+//	    Metrics: metrics,
+//	}))
+//
+//	r.Get("/users/{id}", getUser)
+//
+// # Using with httpserver.Server
+//
+// When the chi router is served by httpserver.Server, pass RouteTagger to
+// WithRouteTagger so the server's own tracing and metrics use chi patterns:
+//
+//	server := httpserver.New(
+//	    httpserver.WithRouteTagger(chisentinel.RouteTagger(r)),
+//	    httpserver.WithMetrics(httpserver.DefaultMetricsConfig()),
+//	    httpserver.WithHandler(r),
+//	)
+package chi
+
+import (
+	"net/http"
+
+	chilib "github.com/go-chi/chi/v5"
+	"github.com/kroma-labs/sentinel-go/httpserver"
+	"github.com/rs/zerolog"
+)
+
+// RouteTagger returns an httpserver.RouteTagger that resolves the chi route
+// pattern matching the request.
+//
+// The pattern is looked up in routes without executing any handler, so it is
+// available before chi has routed the request. Requests that match no route
+// return an empty string, which httpserver reports as
+// httpserver.UnknownRoute.
+//
+//	httpserver.WithRouteTagger(chisentinel.RouteTagger(r))
+func RouteTagger(routes chilib.Routes) httpserver.RouteTagger {
+	return func(r *http.Request) string {
+		path := r.URL.RawPath
+		if path == "" {
+			path = r.URL.Path
+		}
+		return routes.Find(chilib.NewRouteContext(), r.Method, path)
+	}
+}
+
+// RoutePattern returns middleware that stores the matched chi route pattern
+// in the request context (see httpserver.SetRoute).
+//
+// Install it before tracing or metrics middleware so they label requests by
+// pattern:
+//
+//	r.Use(chisentinel.RoutePattern(r))
+//	r.Use(httpserver.Tracing(httpserver.DefaultTracingConfig()))
+func RoutePattern(routes chilib.Routes) httpserver.Middleware {
+	tagger := RouteTagger(routes)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if route := tagger(r); route != "" {
+				r = httpserver.SetRoute(r, route)
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// Config holds configuration for Middleware.
+type Config struct {
+	// Logger enables Recovery middleware.
+	Logger *zerolog.Logger
+
+	// Tracer enables OpenTelemetry tracing.
+	Tracer *httpserver.TracingConfig
+
+	// Metrics enables OpenTelemetry metrics.
+	Metrics *httpserver.Metrics
+}
+
+// Middleware returns pattern-aware observability middleware for a chi router.
+//
+// Applies middleware in the following order:
+//  1. RoutePattern
+//  2. Tracing (if Tracer provided)
+//  3. Metrics (if Metrics provided)
+//  4. Recovery (if Logger provided)
+//
+// Recovery runs innermost so recovered panics are recorded as 500 responses
+// by tracing and metrics.
+//
+// Example:
+//
+//	r := chi.NewRouter()
+//	r.Use(chisentinel.Middleware(r, chisentinel.Config{
+//	    Logger:  &logger,
+//	    Tracer:  &tracing,
+//	    Metrics: metrics,
+//	}))
+func Middleware(routes chilib.Routes, cfg Config) func(http.Handler) http.Handler {
+	middlewares := []httpserver.Middleware{RoutePattern(routes)}
+
+	if cfg.Tracer != nil {
+		middlewares = append(middlewares, httpserver.Tracing(*cfg.Tracer))
+	}
+
+	if cfg.Metrics != nil {
+		middlewares = append(middlewares, cfg.Metrics.Middleware())
+	}
+
+	if cfg.Logger != nil {
+		middlewares = append(middlewares, httpserver.Recovery(*cfg.Logger))
+	}
+
+	return httpserver.Chain(middlewares...)
+}
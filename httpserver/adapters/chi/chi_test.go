@@ -0,0 +1,162 @@
+package chi_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	chilib "github.com/go-chi/chi/v5"
+	"github.com/kroma-labs/sentinel-go/httpserver"
+	chisentinel "github.com/kroma-labs/sentinel-go/httpserver/adapters/chi"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestRouteTagger(t *testing.T) {
+	t.Parallel()
+
+	r := chilib.NewRouter()
+	r.Get("/users/{id}", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	r.Route("/orgs/{org}", func(sub chilib.Router) {
+		sub.Get("/members/{member}", func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})
+	})
+
+	tests := []struct {
+		name      string
+		method    string
+		path      string
+		wantRoute string
+	}{
+		{
+			name:      "given matching route, then returns pattern",
+			method:    http.MethodGet,
+			path:      "/users/123",
+			wantRoute: "/users/{id}",
+		},
+		{
+			name:      "given sub-router route, then returns full pattern",
+			method:    http.MethodGet,
+			path:      "/orgs/acme/members/42",
+			wantRoute: "/orgs/{org}/members/{member}",
+		},
+		{
+			name:      "given unmatched path, then returns empty",
+			method:    http.MethodGet,
+			path:      "/nope",
+			wantRoute: "",
+		},
+		{
+			name:      "given unmatched method, then returns empty",
+			method:    http.MethodPost,
+			path:      "/users/123",
+			wantRoute: "",
+		},
+	}
+
+	tagger := chisentinel.RouteTagger(r)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			assert.Equal(t, tt.wantRoute, tagger(req))
+		})
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	t.Parallel()
+
+	t.Run("given chi route, then labels metrics and span by pattern", func(t *testing.T) {
+		exporter := tracetest.NewInMemoryExporter()
+		tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+		reader := sdkmetric.NewManualReader()
+		mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+		metrics, err := httpserver.NewMetrics(httpserver.MetricsConfig{MeterProvider: mp})
+		require.NoError(t, err)
+		tracing := httpserver.TracingConfig{TracerProvider: tp}
+
+		r := chilib.NewRouter()
+		r.Use(chisentinel.Middleware(r, chisentinel.Config{
+			Tracer:  &tracing,
+			Metrics: metrics,
+		}))
+		r.Get("/users/{id}", func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/users/123", nil)
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		spans := exporter.GetSpans()
+		require.Len(t, spans, 1)
+		assert.Equal(t, "HTTP GET /users/{id}", spans[0].Name)
+
+		assert.Equal(t, []string{"/users/{id}"}, recordedRoutes(t, reader))
+	})
+
+	t.Run("given handler panics, when logger set, then records 500", func(t *testing.T) {
+		reader := sdkmetric.NewManualReader()
+		mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+		metrics, err := httpserver.NewMetrics(httpserver.MetricsConfig{MeterProvider: mp})
+		require.NoError(t, err)
+		logger := zerolog.Nop()
+
+		r := chilib.NewRouter()
+		r.Use(chisentinel.Middleware(r, chisentinel.Config{
+			Logger:  &logger,
+			Metrics: metrics,
+		}))
+		r.Get("/users/{id}", func(_ http.ResponseWriter, _ *http.Request) {
+			panic("boom")
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/users/123", nil)
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.Equal(t, []string{"/users/{id}"}, recordedRoutes(t, reader))
+	})
+}
+
+// recordedRoutes returns the http.route values recorded on
+// http.server.request.total.
+func recordedRoutes(t *testing.T, reader *sdkmetric.ManualReader) []string {
+	t.Helper()
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+
+	var routes []string
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != "http.server.request.total" {
+				continue
+			}
+			sum, ok := m.Data.(metricdata.Sum[int64])
+			require.True(t, ok)
+			for _, dp := range sum.DataPoints {
+				route, _ := dp.Attributes.Value("http.route")
+				routes = append(routes, route.AsString())
+			}
+		}
+	}
+	return routes
+}
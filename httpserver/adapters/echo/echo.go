@@ -20,6 +20,12 @@
 //	// Register health endpoints
 //	echosentinel.RegisterHealth(e, healthHandler)
 //
+// # Using a Server's Middleware
+//
+// To reuse the middleware configured on an httpserver.Server:
+//
+//	e.Use(echosentinel.Middlewares(server)...)
+//
 // # Available Middleware
 //
 //   - RequestID: Generates/forwards X-Request-ID header
@@ -35,6 +41,7 @@
 // # Service Endpoints
 //
 //   - RegisterHealth: /ping, /livez, /readyz
+//   - RegisterHealthGroup: /ping, /livez, /readyz on an Echo group
 //   - RegisterPprof: /debug/pprof/*
 //   - RegisterPrometheus: /metrics
 package echo
@@ -45,6 +52,7 @@ import (
 
 	"github.com/kroma-labs/sentinel-go/httpserver"
 	echolib "github.com/labstack/echo/v4"
+	"github.com/redis/go-redis/v9"
 	"github.com/rs/zerolog"
 	"golang.org/x/time/rate"
 )
@@ -54,6 +62,9 @@ import (
 // Use this to wrap any httpserver.Middleware for use with Echo:
 //
 //	e.Use(echo.WrapMiddleware(myCustomMiddleware))
+//
+// The matched route (c.Path()) is stored in the request context, so metrics
+// and spans are labeled "/users/:id" rather than "/users/123".
 func WrapMiddleware(m httpserver.Middleware) echolib.MiddlewareFunc {
 	return func(next echolib.HandlerFunc) echolib.HandlerFunc {
 		return func(c echolib.Context) error {
@@ -62,12 +73,39 @@ func WrapMiddleware(m httpserver.Middleware) echolib.MiddlewareFunc {
 				c.SetRequest(r)
 				err = next(c)
 			}))
-			handler.ServeHTTP(c.Response(), c.Request())
+			req := c.Request()
+			if route := c.Path(); route != "" {
+				req = httpserver.SetRoute(req, route)
+			}
+			handler.ServeHTTP(c.Response(), req)
 			return err
 		}
 	}
 }
 
+// Middlewares returns the server's middleware stack as Echo middleware.
+//
+// This wires the tracing, metrics, logging, rate limiting, and custom
+// middleware (e.g. Recovery, RequestID) configured on the server into Echo,
+// in the same order the server applies them. Build the server without
+// WithHandler so the stack is not applied twice.
+//
+//	server := httpserver.New(
+//	    httpserver.WithServiceName("my-api"),
+//	    httpserver.WithTracing(httpserver.DefaultTracingConfig()),
+//	    httpserver.WithMetrics(httpserver.DefaultMetricsConfig()),
+//	    httpserver.WithMiddleware(httpserver.Recovery(logger), httpserver.RequestID()),
+//	)
+//	e.Use(echosentinel.Middlewares(server)...)
+func Middlewares(s *httpserver.Server) []echolib.MiddlewareFunc {
+	ms := s.Middlewares()
+	wrapped := make([]echolib.MiddlewareFunc, len(ms))
+	for i, m := range ms {
+		wrapped[i] = WrapMiddleware(m)
+	}
+	return wrapped
+}
+
 // Recovery returns Echo middleware that recovers from panics.
 //
 // On panic, logs the stack trace and returns 500 Internal Server Error.
@@ -165,6 +203,18 @@ func RateLimitByIP(limit rate.Limit, burst int) echolib.MiddlewareFunc {
 	return WrapMiddleware(httpserver.RateLimitByIP(limit, burst))
 }
 
+// RateLimitByIPRedis returns Echo middleware that rate limits per client IP
+// using Redis, sharing limits across all instances.
+//
+//	e.Use(echosentinel.RateLimitByIPRedis(redisClient, 100, 200))
+func RateLimitByIPRedis(
+	rdb redis.UniversalClient,
+	limit rate.Limit,
+	burst int,
+) echolib.MiddlewareFunc {
+	return WrapMiddleware(httpserver.RateLimitByIPRedis(rdb, limit, burst))
+}
+
 // ServiceAuth returns Echo middleware for service-to-service auth.
 //
 // Validates Client-ID and Pass-Key headers against provided validator.
@@ -197,6 +247,19 @@ func RegisterHealth(e *echolib.Echo, h *httpserver.HealthHandler) {
 	e.GET("/readyz", echolib.WrapHandler(h.ReadyHandler()))
 }
 
+// RegisterHealthGroup registers health endpoints on an Echo group.
+//
+// Registers GET {prefix}/ping, {prefix}/livez, and {prefix}/readyz, so the
+// endpoints can share the group's prefix and middleware.
+//
+//	internal := e.Group("/internal")
+//	echosentinel.RegisterHealthGroup(internal, health)
+func RegisterHealthGroup(g *echolib.Group, h *httpserver.HealthHandler) {
+	g.GET("/ping", echolib.WrapHandler(h.PingHandler()))
+	g.GET("/livez", echolib.WrapHandler(h.LiveHandler()))
+	g.GET("/readyz", echolib.WrapHandler(h.ReadyHandler()))
+}
+
 // RegisterPprof registers pprof endpoints on an Echo instance.
 //
 // Registers /debug/pprof/* endpoints for profiling.
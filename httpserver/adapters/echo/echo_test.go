@@ -6,11 +6,16 @@ import (
 	"testing"
 	"time"
 
+	"github.com/alicebob/miniredis/v2"
 	"github.com/kroma-labs/sentinel-go/httpserver"
 	echosentinel "github.com/kroma-labs/sentinel-go/httpserver/adapters/echo"
 	echolib "github.com/labstack/echo/v4"
+	"github.com/redis/go-redis/v9"
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 )
 
 func TestWrapMiddleware(t *testing.T) {
@@ -40,6 +45,30 @@ func TestWrapMiddleware(t *testing.T) {
 		assert.Equal(t, "test-value", rec.Header().Get("X-Custom"))
 		assert.Equal(t, "hello", rec.Body.String())
 	})
+
+	t.Run("given matched route, when wrapped, then stores route in context", func(t *testing.T) {
+		e := echolib.New()
+
+		var route string
+		middleware := func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				route = httpserver.RouteFromContext(r.Context())
+				next.ServeHTTP(w, r)
+			})
+		}
+
+		e.Use(echosentinel.WrapMiddleware(middleware))
+		e.GET("/users/:id", func(c echolib.Context) error {
+			return c.String(http.StatusOK, "ok")
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/users/123", nil)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "/users/:id", route)
+	})
 }
 
 func TestRequestID(t *testing.T) {
@@ -211,6 +240,68 @@ func TestRateLimitByIP(t *testing.T) {
 	})
 }
 
+func TestRateLimitByIPRedis(t *testing.T) {
+	t.Parallel()
+
+	t.Run("given redis per-IP limit, when burst exhausted, then returns 429", func(t *testing.T) {
+		mr := miniredis.RunT(t)
+		rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+		defer rdb.Close()
+
+		e := echolib.New()
+		e.Use(echosentinel.RateLimitByIPRedis(rdb, 1, 1))
+		e.GET("/test", func(c echolib.Context) error {
+			return c.String(http.StatusOK, "ok")
+		})
+
+		req1 := httptest.NewRequest(http.MethodGet, "/test", nil)
+		req1.RemoteAddr = "10.0.0.1:1234"
+		rec1 := httptest.NewRecorder()
+		e.ServeHTTP(rec1, req1)
+		assert.Equal(t, http.StatusOK, rec1.Code)
+
+		req2 := httptest.NewRequest(http.MethodGet, "/test", nil)
+		req2.RemoteAddr = "10.0.0.1:1234"
+		rec2 := httptest.NewRecorder()
+		e.ServeHTTP(rec2, req2)
+		assert.Equal(t, http.StatusTooManyRequests, rec2.Code)
+	})
+}
+
+func TestMiddlewares(t *testing.T) {
+	t.Parallel()
+
+	t.Run("given server middleware, when used with Echo, then applies stack", func(t *testing.T) {
+		exporter := tracetest.NewInMemoryExporter()
+		tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+		server := httpserver.New(
+			httpserver.WithTracing(httpserver.TracingConfig{TracerProvider: tp}),
+			httpserver.WithMiddleware(
+				httpserver.Recovery(zerolog.Nop()),
+				httpserver.RequestID(),
+			),
+		)
+
+		e := echolib.New()
+		e.Use(echosentinel.Middlewares(server)...)
+		e.GET("/users/:id", func(_ echolib.Context) error {
+			panic("boom")
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/users/123", nil)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.NotEmpty(t, rec.Header().Get("X-Request-ID"))
+
+		spans := exporter.GetSpans()
+		require.Len(t, spans, 1)
+		assert.Equal(t, "HTTP GET /users/:id", spans[0].Name)
+	})
+}
+
 func TestServiceAuth(t *testing.T) {
 	t.Parallel()
 
@@ -286,6 +377,23 @@ func TestRegisterHealth(t *testing.T) {
 	})
 }
 
+func TestRegisterHealthGroup(t *testing.T) {
+	t.Parallel()
+
+	t.Run("given echo group, when registered, then uses group prefix", func(t *testing.T) {
+		e := echolib.New()
+		health := httpserver.NewHealthHandler(httpserver.WithVersion("1.0.0"))
+		echosentinel.RegisterHealthGroup(e.Group("/internal"), health)
+
+		for _, path := range []string{"/internal/ping", "/internal/livez", "/internal/readyz"} {
+			req := httptest.NewRequest(http.MethodGet, path, nil)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+			assert.Equal(t, http.StatusOK, rec.Code, path)
+		}
+	})
+}
+
 func TestRegisterPrometheus(t *testing.T) {
 	t.Parallel()
 
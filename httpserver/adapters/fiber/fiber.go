@@ -8,6 +8,10 @@
 // the gap. While there may be minor performance overhead, it allows using all
 // sentinel-go middleware consistently across frameworks.
 //
+// For lower overhead, Instrument provides request IDs, tracing, and metrics
+// natively on the fasthttp request, without the adaptor. See its
+// documentation for limitations.
+//
 // # Quick Start
 //
 //	app := fiber.New()
@@ -28,6 +32,7 @@
 //
 // # Available Middleware
 //
+//   - Instrument: Native request ID, tracing, and metrics (no adaptor)
 //   - RequestID: Generates/forwards X-Request-ID header
 //   - Recovery: Panic recovery with structured logging
 //   - Logger: Structured request/response logging
@@ -190,7 +195,11 @@ func ServiceAuth(cfg httpserver.ServiceAuthConfig) fiber.Handler {
 //
 //     health := httpserver.NewHealthHandler(httpserver.WithVersion("1.0.0"))
 //     fibersentinel.RegisterHealth(app, health)
-func RegisterHealth(app *fiber.App, h *httpserver.HealthHandler) {
+//
+// Any fiber.Router is accepted, so the endpoints can be mounted on a group:
+//
+//	fibersentinel.RegisterHealth(app.Group("/internal"), health)
+func RegisterHealth(app fiber.Router, h *httpserver.HealthHandler) {
 	app.Get("/ping", adaptor.HTTPHandler(h.PingHandler()))
 	app.Get("/livez", adaptor.HTTPHandler(h.LiveHandler()))
 	app.Get("/readyz", adaptor.HTTPHandler(h.ReadyHandler()))
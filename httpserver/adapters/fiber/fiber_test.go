@@ -1,15 +1,24 @@
 package fiber_test
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/recover"
 	"github.com/kroma-labs/sentinel-go/httpserver"
 	fibersentinel "github.com/kroma-labs/sentinel-go/httpserver/adapters/fiber"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 )
 
 func TestWrapMiddleware(t *testing.T) {
@@ -74,6 +83,144 @@ func TestRequestID(t *testing.T) {
 	)
 }
 
+func TestInstrument(t *testing.T) {
+	t.Run("given traced request, then continues trace and records route", func(t *testing.T) {
+		exporter := tracetest.NewInMemoryExporter()
+		tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+		reader := sdkmetric.NewManualReader()
+		mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+		metrics, err := httpserver.NewMetrics(httpserver.MetricsConfig{MeterProvider: mp})
+		require.NoError(t, err)
+
+		app := fiber.New()
+		app.Use(fibersentinel.Instrument(fibersentinel.InstrumentConfig{
+			ServiceName:    "test-api",
+			TracerProvider: tp,
+			Propagator:     propagation.TraceContext{},
+			Metrics:        metrics,
+		}))
+
+		var ctxID, localsID string
+		app.Get("/users/:id", func(c *fiber.Ctx) error {
+			ctxID = httpserver.RequestIDFromContext(c.UserContext())
+			localsID = fibersentinel.RequestIDFromCtx(c)
+			return c.SendString("ok")
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/users/123", nil)
+		req.Header.Set("Traceparent", "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01")
+		req.Header.Set("X-Request-ID", "req-123")
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, "req-123", resp.Header.Get("X-Request-ID"))
+		require.Equal(t, "req-123", ctxID)
+		require.Equal(t, "req-123", localsID)
+
+		spans := exporter.GetSpans()
+		require.Len(t, spans, 1)
+		require.Equal(t, "HTTP GET /users/:id", spans[0].Name)
+		require.Equal(t,
+			"0af7651916cd43dd8448eb211c80319c", spans[0].SpanContext.TraceID().String())
+		require.Equal(t, "b7ad6b7169203331", spans[0].Parent.SpanID().String())
+
+		var rm metricdata.ResourceMetrics
+		require.NoError(t, reader.Collect(context.Background(), &rm))
+
+		var routes []string
+		var active int64
+		for _, sm := range rm.ScopeMetrics {
+			for _, m := range sm.Metrics {
+				switch m.Name {
+				case "http.server.request.total":
+					sum, ok := m.Data.(metricdata.Sum[int64])
+					require.True(t, ok)
+					for _, dp := range sum.DataPoints {
+						route, _ := dp.Attributes.Value("http.route")
+						status, _ := dp.Attributes.Value("http.response.status_code")
+						require.Equal(t, int64(http.StatusOK), status.AsInt64())
+						routes = append(routes, route.AsString())
+					}
+				case "http.server.active_requests":
+					sum, ok := m.Data.(metricdata.Sum[int64])
+					require.True(t, ok)
+					for _, dp := range sum.DataPoints {
+						active += dp.Value
+					}
+				}
+			}
+		}
+		require.Equal(t, []string{"/users/:id"}, routes)
+		require.Zero(t, active)
+	})
+
+	t.Run("given handler error, when instrumented, then records error status", func(t *testing.T) {
+		exporter := tracetest.NewInMemoryExporter()
+		tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+		app := fiber.New()
+		app.Use(fibersentinel.Instrument(fibersentinel.InstrumentConfig{TracerProvider: tp}))
+		app.Get("/fail", func(_ *fiber.Ctx) error {
+			return errors.New("boom")
+		})
+
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/fail", nil))
+		require.NoError(t, err)
+		require.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		require.NotEmpty(t, resp.Header.Get("X-Request-ID"))
+
+		spans := exporter.GetSpans()
+		require.Len(t, spans, 1)
+		require.Equal(t, codes.Error, spans[0].Status.Code)
+	})
+
+	t.Run("given panicking handler, then completes active request", func(t *testing.T) {
+		reader := sdkmetric.NewManualReader()
+		mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+		metrics, err := httpserver.NewMetrics(httpserver.MetricsConfig{MeterProvider: mp})
+		require.NoError(t, err)
+
+		app := fiber.New()
+		app.Use(recover.New())
+		app.Use(fibersentinel.Instrument(fibersentinel.InstrumentConfig{Metrics: metrics}))
+		app.Get("/panic", func(_ *fiber.Ctx) error {
+			panic("boom")
+		})
+
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/panic", nil))
+		require.NoError(t, err)
+		require.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+
+		var rm metricdata.ResourceMetrics
+		require.NoError(t, reader.Collect(context.Background(), &rm))
+
+		var statuses []int64
+		var active int64
+		for _, sm := range rm.ScopeMetrics {
+			for _, m := range sm.Metrics {
+				switch m.Name {
+				case "http.server.request.total":
+					sum, ok := m.Data.(metricdata.Sum[int64])
+					require.True(t, ok)
+					for _, dp := range sum.DataPoints {
+						status, _ := dp.Attributes.Value("http.response.status_code")
+						statuses = append(statuses, status.AsInt64())
+					}
+				case "http.server.active_requests":
+					sum, ok := m.Data.(metricdata.Sum[int64])
+					require.True(t, ok)
+					for _, dp := range sum.DataPoints {
+						active += dp.Value
+					}
+				}
+			}
+		}
+		require.Equal(t, []int64{http.StatusInternalServerError}, statuses)
+		require.Zero(t, active)
+	})
+}
+
 func TestRecovery(t *testing.T) {
 	// Note: Fiber's adaptor doesn't bridge panics from net/http middleware to fasthttp.
 	// The httpserver.Recovery middleware works, but the panic happens in fasthttp layer
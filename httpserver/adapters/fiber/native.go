@@ -0,0 +1,209 @@
+package fiber
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/kroma-labs/sentinel-go/httpserver"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// requestIDLocalsKey is the fiber.Ctx locals key holding the request ID.
+const requestIDLocalsKey = "request_id"
+
+// InstrumentConfig configures the native Fiber instrumentation middleware.
+type InstrumentConfig struct {
+	// ServiceName is recorded as the service.name span attribute.
+	ServiceName string
+
+	// TracerProvider is the OTel tracer provider.
+	// If nil, uses otel.GetTracerProvider().
+	TracerProvider trace.TracerProvider
+
+	// Propagator extracts the trace context from request headers.
+	// If nil, uses otel.GetTextMapPropagator().
+	Propagator propagation.TextMapPropagator
+
+	// Metrics records request metrics. If nil, metrics are not recorded.
+	Metrics *httpserver.Metrics
+
+	// RequestIDGenerator creates a request ID when the request has none.
+	// Default: UUID v4
+	RequestIDGenerator func() string
+
+	// SkipPaths are paths that should not be traced or recorded.
+	SkipPaths []string
+}
+
+// Instrument returns native Fiber middleware for request IDs, tracing, and
+// metrics that works directly on the fasthttp request, without the
+// net/http adaptor.
+//
+// For each request it:
+//   - Forwards or generates X-Request-ID and stores it in the user context
+//     (httpserver.RequestIDFromContext) and in c.Locals("request_id")
+//   - Extracts W3C trace context from the fasthttp headers and starts a
+//     server span, available via c.UserContext()
+//   - Records the httpserver metrics (duration, sizes, status) labeled by the
+//     matched Fiber route, e.g. "/users/:id"
+//
+// # Limitations
+//
+//   - No *http.Request is built, so net/http middleware and handlers cannot
+//     see the span or request ID; use c.UserContext() instead
+//   - The span and metrics use the route of the last handler run, so they
+//     are named after the Use path if a middleware ends the request early
+//   - The active request gauge is labeled by method only, since the route
+//     is not known until the handler has run
+//
+// # Conversion Cost
+//
+// Only the headers needed for propagation are read, and no request or
+// response bodies are copied. This is considerably cheaper than the
+// adaptor-based Tracing, Metrics, and RequestID middleware, which convert
+// every request to net/http and back.
+//
+// Example:
+//
+//	metrics, _ := httpserver.NewMetrics(httpserver.DefaultMetricsConfig())
+//	app.Use(fibersentinel.Instrument(fibersentinel.InstrumentConfig{
+//	    ServiceName: "my-api",
+//	    Metrics:     metrics,
+//	}))
+func Instrument(cfg InstrumentConfig) fiber.Handler {
+	if cfg.TracerProvider == nil {
+		cfg.TracerProvider = otel.GetTracerProvider()
+	}
+	if cfg.Propagator == nil {
+		cfg.Propagator = otel.GetTextMapPropagator()
+	}
+	if cfg.RequestIDGenerator == nil {
+		cfg.RequestIDGenerator = func() string { return uuid.New().String() }
+	}
+
+	tracer := cfg.TracerProvider.Tracer(
+		"github.com/kroma-labs/sentinel-go/httpserver/adapters/fiber",
+		trace.WithInstrumentationVersion("1.0.0"),
+	)
+
+	skipPaths := make(map[string]bool)
+	for _, path := range cfg.SkipPaths {
+		skipPaths[path] = true
+	}
+
+	return func(c *fiber.Ctx) error {
+		// Values read from fasthttp are only valid for the lifetime of the
+		// request, so anything that outlives it (span attributes, context)
+		// is copied with strings.Clone.
+
+		// Request ID
+		id := strings.Clone(c.Get(httpserver.RequestIDHeader))
+		if id == "" {
+			id = cfg.RequestIDGenerator()
+		}
+		c.Set(httpserver.RequestIDHeader, id)
+		c.Locals(requestIDLocalsKey, id)
+		ctx := httpserver.ContextWithRequestID(c.UserContext(), id)
+
+		if skipPaths[c.Path()] {
+			c.SetUserContext(ctx)
+			return c.Next()
+		}
+
+		// Extract trace context from fasthttp headers and start server span
+		method := strings.Clone(c.Method())
+		ctx = cfg.Propagator.Extract(ctx, headerCarrier{c: c})
+		ctx, span := tracer.Start(ctx, "HTTP "+method,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.ServiceName(cfg.ServiceName),
+				semconv.HTTPRequestMethodKey.String(method),
+				semconv.URLPath(strings.Clone(c.Path())),
+				semconv.URLScheme(strings.Clone(c.Protocol())),
+				semconv.ServerAddress(strings.Clone(c.Hostname())),
+				semconv.UserAgentOriginal(strings.Clone(c.Get(fiber.HeaderUserAgent))),
+				semconv.ClientAddress(strings.Clone(c.IP())),
+				attribute.String("request.id", id),
+			),
+		)
+		defer span.End()
+		c.SetUserContext(ctx)
+
+		// A handler that panics never sets status, so it is recorded as a
+		// server error.
+		status := fiber.StatusInternalServerError
+		route := httpserver.UnknownRoute
+		if cfg.Metrics != nil {
+			requestSize := int64(c.Request().Header.ContentLength())
+			done := cfg.Metrics.TrackRequest(ctx, method, requestSize)
+			defer func() {
+				done(route, status, int64(len(c.Response().Body())))
+			}()
+		}
+
+		err := c.Next()
+		if err != nil {
+			// Let Fiber's error handler write the response so the recorded
+			// status matches what the client receives.
+			if handlerErr := c.App().ErrorHandler(c, err); handlerErr != nil {
+				_ = c.SendStatus(fiber.StatusInternalServerError)
+			}
+			span.RecordError(err)
+		}
+
+		status = c.Response().StatusCode()
+		if r := c.Route(); r != nil && r.Path != "" {
+			route = r.Path
+		}
+
+		span.SetName("HTTP " + method + " " + route)
+		span.SetAttributes(
+			semconv.HTTPRoute(route),
+			semconv.HTTPResponseStatusCode(status),
+		)
+		if status >= 500 {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+
+		return nil
+	}
+}
+
+// RequestIDFromCtx returns the request ID stored by Instrument.
+//
+// Returns an empty string if Instrument has not run for this request.
+func RequestIDFromCtx(c *fiber.Ctx) string {
+	if id, ok := c.Locals(requestIDLocalsKey).(string); ok {
+		return id
+	}
+	return ""
+}
+
+// headerCarrier adapts fasthttp request headers to propagation.TextMapCarrier.
+type headerCarrier struct {
+	c *fiber.Ctx
+}
+
+func (hc headerCarrier) Get(key string) string {
+	return strings.Clone(hc.c.Get(key))
+}
+
+func (hc headerCarrier) Set(key, value string) {
+	hc.c.Request().Header.Set(key, value)
+}
+
+func (hc headerCarrier) Keys() []string {
+	headers := hc.c.GetReqHeaders()
+	keys := make([]string, 0, len(headers))
+	for k := range headers {
+		keys = append(keys, k)
+	}
+	return keys
+}
@@ -24,6 +24,7 @@
 //
 //   - RequestID: Generates/forwards X-Request-ID header
 //   - Recovery: Panic recovery with structured logging
+//   - Errors: c.Error → JSON error body and span errors
 //   - Logger: Structured request/response logging
 //   - Tracing: OpenTelemetry distributed tracing
 //   - Metrics: OpenTelemetry request metrics
@@ -41,29 +42,55 @@ package gin
 
 import (
 	"net/http"
+	"strings"
 	"time"
 
 	ginlib "github.com/gin-gonic/gin"
 	"github.com/kroma-labs/sentinel-go/httpserver"
 	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/time/rate"
 )
 
+// RequestIDKey is the gin.Context key under which WrapMiddleware stores the
+// request ID set by httpserver.RequestID.
+//
+//	id := c.GetString(ginsentinel.RequestIDKey)
+const RequestIDKey = "request_id"
+
 // WrapMiddleware adapts httpserver middleware to Gin middleware.
 //
 // Use this to wrap any httpserver.Middleware for use with Gin:
 //
 //	r.Use(ginsentinel.WrapMiddleware(myCustomMiddleware))
+//
+// The matched route (c.FullPath()) is stored in the request context, so
+// metrics and spans are labeled "/users/:id" rather than "/users/123".
+//
+// The request context produced by the middleware (trace span, request ID)
+// replaces c.Request for the remaining handlers, and the request ID is also
+// available via c.GetString(RequestIDKey). If the middleware does not call
+// the next handler (e.g. rate limited) or recovers from a panic, the
+// remaining gin handlers are aborted.
 func WrapMiddleware(m httpserver.Middleware) ginlib.HandlerFunc {
 	return func(c *ginlib.Context) {
-		var aborted bool
+		var aborted, completed bool
 		handler := m(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
 			c.Request = r
+			if id := httpserver.RequestIDFromContext(r.Context()); id != "" {
+				c.Set(RequestIDKey, id)
+			}
 			c.Next()
 			aborted = c.IsAborted()
+			completed = true
 		}))
-		handler.ServeHTTP(c.Writer, c.Request)
-		if aborted {
+		req := c.Request
+		if route := c.FullPath(); route != "" {
+			req = httpserver.SetRoute(req, route)
+		}
+		handler.ServeHTTP(c.Writer, req)
+		if aborted || !completed {
 			c.Abort()
 		}
 	}
@@ -71,11 +98,90 @@ func WrapMiddleware(m httpserver.Middleware) ginlib.HandlerFunc {
 
 // Recovery returns Gin middleware that recovers from panics.
 //
-// On panic, logs the stack trace and returns 500 Internal Server Error.
+// Matches httpserver.RecoveryWithConfig with RecordToSpan set: on panic,
+// logs the stack trace, records the panic on the active span, and returns a
+// 500 JSON error. Remaining handlers are aborted.
 //
 //	r.Use(ginsentinel.Recovery(logger))
 func Recovery(logger zerolog.Logger) ginlib.HandlerFunc {
-	return WrapMiddleware(httpserver.Recovery(logger))
+	cfg := httpserver.DefaultRecoveryConfig()
+	cfg.Logger = logger
+	cfg.RecordToSpan = true
+	return WrapMiddleware(httpserver.RecoveryWithConfig(cfg))
+}
+
+// Errors returns Gin middleware that converts errors attached with c.Error
+// into the httpserver JSON error shape and records them on the active span.
+//
+// After the remaining handlers run, if c.Errors is non-empty:
+//   - Each error is recorded on the span, and the span status is set to error
+//   - If no response body was written, a JSON error is written with the
+//     status set by the handler (or 500 if it is below 400)
+//
+// Only gin.ErrorTypePublic messages are exposed to clients; other errors are
+// reported with a generic message to avoid leaking internals.
+//
+//	r.Use(ginsentinel.Tracing(httpserver.DefaultTracingConfig()))
+//	r.Use(ginsentinel.Errors())
+//
+//	r.GET("/users/:id", func(c *gin.Context) {
+//	    if err := validate(c); err != nil {
+//	        _ = c.Error(err).SetType(gin.ErrorTypePublic).SetMeta("id")
+//	        c.Status(http.StatusBadRequest)
+//	        return
+//	    }
+//	})
+func Errors() ginlib.HandlerFunc {
+	return func(c *ginlib.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 {
+			return
+		}
+
+		span := trace.SpanFromContext(c.Request.Context())
+		for _, e := range c.Errors {
+			span.RecordError(e.Err)
+		}
+		span.SetStatus(codes.Error, c.Errors.Last().Error())
+
+		if c.Writer.Written() {
+			return
+		}
+
+		status := c.Writer.Status()
+		if status < http.StatusBadRequest {
+			status = http.StatusInternalServerError
+		}
+
+		errs := make([]httpserver.Error, 0, len(c.Errors))
+		for _, e := range c.Errors {
+			errs = append(errs, ginError(e, status))
+		}
+
+		message := strings.ToLower(http.StatusText(status))
+		httpserver.WriteCodedError(c.Writer, c.Request, status,
+			strings.ReplaceAll(message, " ", "_"), message, errs...)
+		c.Abort()
+	}
+}
+
+// ginError converts a gin error into an httpserver.Error.
+//
+// The field comes from the error's Meta when it is a string. Non-public
+// errors use the status text instead of the error message.
+func ginError(e *ginlib.Error, status int) httpserver.Error {
+	field := "error"
+	if meta, ok := e.Meta.(string); ok && meta != "" {
+		field = meta
+	}
+
+	message := strings.ToLower(http.StatusText(status))
+	if e.IsType(ginlib.ErrorTypePublic) {
+		message = e.Error()
+	}
+
+	return httpserver.Error{Field: field, Message: message}
 }
 
 // RequestID returns Gin middleware that generates/forwards X-Request-ID.
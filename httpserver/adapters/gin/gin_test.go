@@ -1,8 +1,10 @@
 package gin_test
 
 import (
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -11,6 +13,10 @@ import (
 	ginsentinel "github.com/kroma-labs/sentinel-go/httpserver/adapters/gin"
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 )
 
 func init() {
@@ -44,6 +50,30 @@ func TestWrapMiddleware(t *testing.T) {
 		assert.Equal(t, "test-value", rec.Header().Get("X-Custom"))
 		assert.Equal(t, "hello", rec.Body.String())
 	})
+
+	t.Run("given matched route, when wrapped, then stores route in context", func(t *testing.T) {
+		r := ginlib.New()
+
+		var route string
+		middleware := func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				route = httpserver.RouteFromContext(req.Context())
+				next.ServeHTTP(w, req)
+			})
+		}
+
+		r.Use(ginsentinel.WrapMiddleware(middleware))
+		r.GET("/users/:id", func(c *ginlib.Context) {
+			c.String(http.StatusOK, "ok")
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/users/123", nil)
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "/users/:id", route)
+	})
 }
 
 func TestRequestID(t *testing.T) {
@@ -109,6 +139,148 @@ func TestRecovery(t *testing.T) {
 			assert.Equal(t, http.StatusInternalServerError, rec.Code)
 		},
 	)
+
+	t.Run(
+		"given middleware panics, when Recovery applied, then aborts and records span error",
+		func(t *testing.T) {
+			exporter := tracetest.NewInMemoryExporter()
+			tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+			r := ginlib.New()
+			r.Use(ginsentinel.Tracing(httpserver.TracingConfig{TracerProvider: tp}))
+			r.Use(ginsentinel.Recovery(zerolog.Nop()))
+
+			reached := false
+			r.GET("/panic", func(_ *ginlib.Context) {
+				panic("test panic")
+			}, func(_ *ginlib.Context) {
+				reached = true
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/panic", nil)
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusInternalServerError, rec.Code)
+			assert.Contains(t, rec.Body.String(), "internal server error")
+			assert.False(t, reached)
+
+			spans := exporter.GetSpans()
+			require.Len(t, spans, 1)
+			assert.Equal(t, codes.Error, spans[0].Status.Code)
+			require.NotEmpty(t, spans[0].Events)
+			assert.Equal(t, "exception", spans[0].Events[0].Name)
+		},
+	)
+}
+
+func TestErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		handler    ginlib.HandlerFunc
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name: "given public error with status, then writes JSON with message",
+			handler: func(c *ginlib.Context) {
+				_ = c.Error(errors.New("must be numeric")).
+					SetType(ginlib.ErrorTypePublic).
+					SetMeta("id")
+				c.Status(http.StatusBadRequest)
+			},
+			wantStatus: http.StatusBadRequest,
+			wantBody: `{"errors":[{"field":"id","message":"must be numeric"}],` +
+				`"message":"bad request"}`,
+		},
+		{
+			name: "given private error without status, then writes generic 500",
+			handler: func(c *ginlib.Context) {
+				_ = c.Error(errors.New("db: connection refused"))
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantBody: `{"errors":[{"field":"error","message":"internal server error"}],` +
+				`"message":"internal server error"}`,
+		},
+		{
+			name: "given error after response written, then keeps response",
+			handler: func(c *ginlib.Context) {
+				c.String(http.StatusOK, "ok")
+				_ = c.Error(errors.New("late failure"))
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   "ok",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			exporter := tracetest.NewInMemoryExporter()
+			tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+			r := ginlib.New()
+			r.Use(ginsentinel.Tracing(httpserver.TracingConfig{TracerProvider: tp}))
+			r.Use(ginsentinel.Errors())
+			r.GET("/users/:id", tt.handler)
+
+			req := httptest.NewRequest(http.MethodGet, "/users/abc", nil)
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Equal(t, tt.wantBody, strings.TrimSpace(rec.Body.String()))
+
+			spans := exporter.GetSpans()
+			require.Len(t, spans, 1)
+			assert.Equal(t, codes.Error, spans[0].Status.Code)
+			require.NotEmpty(t, spans[0].Events)
+			assert.Equal(t, "exception", spans[0].Events[0].Name)
+		})
+	}
+}
+
+func TestWrapMiddleware_Context(t *testing.T) {
+	t.Parallel()
+
+	t.Run("given RequestID, then ID is available to gin handlers", func(t *testing.T) {
+		r := ginlib.New()
+		r.Use(ginsentinel.RequestID())
+
+		var fromKey, fromCtx string
+		r.GET("/test", func(c *ginlib.Context) {
+			fromKey = c.GetString(ginsentinel.RequestIDKey)
+			fromCtx = httpserver.RequestIDFromContext(c.Request.Context())
+			c.Status(http.StatusOK)
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+		req.Header.Set("X-Request-ID", "req-123")
+		r.ServeHTTP(httptest.NewRecorder(), req)
+
+		assert.Equal(t, "req-123", fromKey)
+		assert.Equal(t, "req-123", fromCtx)
+	})
+
+	t.Run("given middleware short-circuits, then aborts remaining handlers", func(t *testing.T) {
+		r := ginlib.New()
+		r.Use(ginsentinel.RateLimit(httpserver.RateLimitConfig{Limit: 1, Burst: 1}))
+
+		calls := 0
+		r.GET("/test", func(c *ginlib.Context) {
+			calls++
+			c.Status(http.StatusOK)
+		})
+
+		for range 2 {
+			r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/test", nil))
+		}
+
+		assert.Equal(t, 1, calls)
+	})
 }
 
 func TestCORS(t *testing.T) {
@@ -31,6 +31,14 @@
 //	    RateLimit: &httpserver.RateLimitConfig{Limit: 100, Burst: 200},
 //	})
 //
+// # Errors and Trace Propagation
+//
+// Use ServeMuxOptions when creating the gateway mux to write gRPC errors in
+// the httpserver JSON error shape and to propagate the gateway span into
+// outgoing gRPC metadata:
+//
+//	gwmux := runtime.NewServeMux(grpcgateway.ServeMuxOptions(grpcgateway.ServeMuxConfig{})...)
+//
 // # Combining with HTTP Endpoints
 //
 // To serve both gRPC-Gateway and regular HTTP from the same port:
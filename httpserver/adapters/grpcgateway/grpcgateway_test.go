@@ -1,6 +1,9 @@
 package grpcgateway_test
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -10,6 +13,12 @@ import (
 	"github.com/kroma-labs/sentinel-go/httpserver/adapters/grpcgateway"
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func TestWrapWithMiddleware(t *testing.T) {
@@ -327,3 +336,127 @@ func TestNewHandler(t *testing.T) {
 		assert.NotEqual(t, http.StatusUnauthorized, rec.Code)
 	})
 }
+
+func TestErrorHandler(t *testing.T) {
+	t.Parallel()
+
+	badRequest, err := status.New(codes.InvalidArgument, "invalid request").
+		WithDetails(&errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{
+				{Field: "email", Description: "must be a valid email"},
+			},
+		}, &errdetails.ErrorInfo{Reason: "VALIDATION_FAILED"})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		mapper     grpcgateway.StatusMapper
+		err        error
+		wantStatus int
+		wantBody   httpserver.Response[any]
+	}{
+		{
+			name:       "given grpc status with details, then maps code and surfaces details",
+			err:        badRequest.Err(),
+			wantStatus: http.StatusBadRequest,
+			wantBody: httpserver.Response[any]{
+				Message: "invalid request",
+				Errors: []httpserver.Error{
+					{Field: "email", Message: "must be a valid email"},
+					{Field: "reason", Message: "VALIDATION_FAILED"},
+				},
+			},
+		},
+		{
+			name: "given custom mapper, then uses mapped status",
+			mapper: func(c codes.Code) int {
+				if c == codes.NotFound {
+					return http.StatusGone
+				}
+				return grpcgateway.DefaultStatusMapper(c)
+			},
+			err:        status.Error(codes.NotFound, "user deleted"),
+			wantStatus: http.StatusGone,
+			wantBody:   httpserver.Response[any]{Message: "user deleted"},
+		},
+		{
+			name: "given routing error, then keeps http status",
+			err: &runtime.HTTPStatusError{
+				HTTPStatus: http.StatusMethodNotAllowed,
+				Err:        status.Error(codes.Unimplemented, "Method Not Allowed"),
+			},
+			wantStatus: http.StatusMethodNotAllowed,
+			wantBody:   httpserver.Response[any]{Message: "Method Not Allowed"},
+		},
+		{
+			name:       "given non-grpc error, then returns 500",
+			err:        errors.New("boom"),
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   httpserver.Response[any]{Message: "boom"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			handler := grpcgateway.ErrorHandler(tt.mapper)
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			rec := httptest.NewRecorder()
+			handler(req.Context(), nil, nil, rec, req, tt.err)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+			var body httpserver.Response[any]
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			assert.Equal(t, tt.wantBody, body)
+		})
+	}
+}
+
+func TestTraceMetadata(t *testing.T) {
+	t.Parallel()
+
+	t.Run("given active span, then injects traceparent into metadata", func(t *testing.T) {
+		tp := sdktrace.NewTracerProvider()
+		ctx, span := tp.Tracer("test").Start(context.Background(), "gateway")
+		defer span.End()
+
+		annotate := grpcgateway.TraceMetadata(propagation.TraceContext{})
+		md := annotate(ctx, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		values := md.Get("traceparent")
+		require.Len(t, values, 1)
+		assert.Contains(t, values[0], span.SpanContext().TraceID().String())
+		assert.Contains(t, values[0], span.SpanContext().SpanID().String())
+	})
+
+	t.Run("given no span, then returns empty metadata", func(t *testing.T) {
+		annotate := grpcgateway.TraceMetadata(propagation.TraceContext{})
+		md := annotate(context.Background(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+		assert.Empty(t, md)
+	})
+}
+
+func TestServeMuxOptions(t *testing.T) {
+	t.Parallel()
+
+	t.Run("given unregistered route, then returns JSON error", func(t *testing.T) {
+		gwmux := runtime.NewServeMux(
+			grpcgateway.ServeMuxOptions(grpcgateway.ServeMuxConfig{})...,
+		)
+
+		req := httptest.NewRequest(http.MethodGet, "/v1/unknown", nil)
+		rec := httptest.NewRecorder()
+		gwmux.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+
+		var body httpserver.Response[any]
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.NotEmpty(t, body.Message)
+	})
+}
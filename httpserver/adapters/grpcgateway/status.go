@@ -0,0 +1,208 @@
+package grpcgateway
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"unicode"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/kroma-labs/sentinel-go/httpserver"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// StatusMapper maps a gRPC status code to an HTTP status code.
+type StatusMapper func(code codes.Code) int
+
+// DefaultStatusMapper maps gRPC codes to HTTP statuses using grpc-gateway's
+// standard table (e.g. NotFound → 404, InvalidArgument → 400,
+// Unavailable → 503).
+func DefaultStatusMapper(code codes.Code) int {
+	return runtime.HTTPStatusFromCode(code)
+}
+
+// ServeMuxConfig configures the ServeMux options returned by ServeMuxOptions.
+type ServeMuxConfig struct {
+	// StatusMapper maps gRPC status codes to HTTP status codes.
+	// If nil, DefaultStatusMapper is used.
+	StatusMapper StatusMapper
+
+	// Propagator injects the active trace context into outgoing gRPC metadata.
+	// If nil, uses otel.GetTextMapPropagator().
+	Propagator propagation.TextMapPropagator
+}
+
+// ServeMuxOptions returns grpc-gateway ServeMux options that close the
+// observability gap across the HTTP→gRPC boundary:
+//
+//   - Errors are written in the httpserver JSON error shape, with the HTTP
+//     status chosen by StatusMapper and gRPC error details surfaced as errors
+//   - The trace context of the gateway request is injected into outgoing gRPC
+//     metadata, so the gRPC server span becomes a child of the gateway span
+//
+// Wrap the mux with httpserver.Tracing so a gateway span exists to propagate.
+//
+// Example:
+//
+//	gwmux := runtime.NewServeMux(grpcgateway.ServeMuxOptions(grpcgateway.ServeMuxConfig{})...)
+//	handler := grpcgateway.WithTracing(gwmux, httpserver.DefaultTracingConfig())
+func ServeMuxOptions(cfg ServeMuxConfig) []runtime.ServeMuxOption {
+	return []runtime.ServeMuxOption{
+		runtime.WithErrorHandler(ErrorHandler(cfg.StatusMapper)),
+		runtime.WithMetadata(TraceMetadata(cfg.Propagator)),
+	}
+}
+
+// ErrorHandler returns a grpc-gateway error handler that writes errors using
+// httpserver.WriteError.
+//
+// The response status comes from mapper (DefaultStatusMapper if nil), the
+// message is the gRPC status message, and supported error details are
+// converted to entries in the "errors" array:
+//
+//   - BadRequest: one entry per field violation
+//   - PreconditionFailure: one entry per violation, keyed by subject
+//   - ErrorInfo: {"field": "reason", "message": <reason>}
+//   - LocalizedMessage: {"field": "message", "message": <message>}
+//   - Any other detail: keyed by its proto type name, with a JSON message
+//
+// Example:
+//
+//	gwmux := runtime.NewServeMux(
+//	    runtime.WithErrorHandler(grpcgateway.ErrorHandler(func(c codes.Code) int {
+//	        if c == codes.NotFound {
+//	            return http.StatusGone
+//	        }
+//	        return grpcgateway.DefaultStatusMapper(c)
+//	    })),
+//	)
+func ErrorHandler(mapper StatusMapper) runtime.ErrorHandlerFunc {
+	if mapper == nil {
+		mapper = DefaultStatusMapper
+	}
+
+	return func(
+		_ context.Context,
+		_ *runtime.ServeMux,
+		_ runtime.Marshaler,
+		w http.ResponseWriter,
+		r *http.Request,
+		err error,
+	) {
+		// Routing errors from the mux carry an explicit HTTP status
+		statusCode := 0
+		var httpErr *runtime.HTTPStatusError
+		if errors.As(err, &httpErr) {
+			statusCode = httpErr.HTTPStatus
+			err = httpErr.Err
+		}
+
+		s := status.Convert(err)
+		if statusCode == 0 {
+			statusCode = mapper(s.Code())
+		}
+
+		WriteStatusError(w, r, statusCode, s)
+	}
+}
+
+// WriteStatusError writes a gRPC status as an httpserver JSON error response.
+// With an error envelope set on the server, the error code is the gRPC code
+// in snake case, e.g. "not_found" for codes.NotFound.
+//
+// See ErrorHandler for how error details are converted.
+func WriteStatusError(w http.ResponseWriter, r *http.Request, statusCode int, s *status.Status) {
+	httpserver.WriteCodedError(w, r, statusCode,
+		statusCodeName(s.Code()), s.Message(), statusDetails(s)...)
+}
+
+// statusCodeName returns the snake case name of a gRPC code, e.g.
+// "invalid_argument" for codes.InvalidArgument.
+func statusCodeName(c codes.Code) string {
+	var b strings.Builder
+	for i, ch := range c.String() {
+		if unicode.IsUpper(ch) {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			ch = unicode.ToLower(ch)
+		}
+		b.WriteRune(ch)
+	}
+	return b.String()
+}
+
+// statusDetails converts gRPC error details into httpserver errors.
+func statusDetails(s *status.Status) []httpserver.Error {
+	var errs []httpserver.Error
+
+	for _, detail := range s.Details() {
+		switch d := detail.(type) {
+		case *errdetails.BadRequest:
+			for _, v := range d.GetFieldViolations() {
+				errs = append(errs, httpserver.Error{
+					Field:   v.GetField(),
+					Message: v.GetDescription(),
+				})
+			}
+		case *errdetails.PreconditionFailure:
+			for _, v := range d.GetViolations() {
+				errs = append(errs, httpserver.Error{
+					Field:   v.GetSubject(),
+					Message: v.GetDescription(),
+				})
+			}
+		case *errdetails.ErrorInfo:
+			errs = append(errs, httpserver.Error{Field: "reason", Message: d.GetReason()})
+		case *errdetails.LocalizedMessage:
+			errs = append(errs, httpserver.Error{Field: "message", Message: d.GetMessage()})
+		case proto.Message:
+			b, err := protojson.Marshal(d)
+			if err != nil {
+				continue
+			}
+			errs = append(errs, httpserver.Error{
+				Field:   string(proto.MessageName(d)),
+				Message: string(b),
+			})
+		}
+	}
+
+	return errs
+}
+
+// TraceMetadata returns a grpc-gateway metadata annotator that injects the
+// trace context of the incoming request into outgoing gRPC metadata.
+//
+// If propagator is nil, otel.GetTextMapPropagator() is used.
+//
+// Example:
+//
+//	gwmux := runtime.NewServeMux(runtime.WithMetadata(grpcgateway.TraceMetadata(nil)))
+func TraceMetadata(
+	propagator propagation.TextMapPropagator,
+) func(context.Context, *http.Request) metadata.MD {
+	return func(ctx context.Context, _ *http.Request) metadata.MD {
+		p := propagator
+		if p == nil {
+			p = otel.GetTextMapPropagator()
+		}
+
+		carrier := propagation.MapCarrier{}
+		p.Inject(ctx, carrier)
+
+		md := metadata.MD{}
+		for k, v := range carrier {
+			md.Set(k, v)
+		}
+		return md
+	}
+}
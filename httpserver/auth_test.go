@@ -172,6 +172,90 @@ func TestServiceAuthMiddleware(t *testing.T) {
 	)
 }
 
+func TestBasicAuth(t *testing.T) {
+	t.Parallel()
+
+	validator := httpserver.NewStaticBasicAuthValidator(map[string]string{
+		"admin": "secret",
+		"ops":   "hunter2",
+	})
+
+	tests := []struct {
+		name          string
+		cfg           httpserver.BasicAuthConfig
+		setAuth       bool
+		user          string
+		pass          string
+		wantStatus    int
+		wantChallenge string
+	}{
+		{
+			name:       "given valid credentials, when request made, then proceeds",
+			cfg:        httpserver.BasicAuthConfig{Validator: validator},
+			setAuth:    true,
+			user:       "ops",
+			pass:       "hunter2",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:          "given missing header, when request made, then returns 401 challenge",
+			cfg:           httpserver.BasicAuthConfig{Validator: validator},
+			wantStatus:    http.StatusUnauthorized,
+			wantChallenge: `Basic realm="Restricted", charset="UTF-8"`,
+		},
+		{
+			name:          "given wrong password, when request made, then returns 401",
+			cfg:           httpserver.BasicAuthConfig{Validator: validator, Realm: "admin"},
+			setAuth:       true,
+			user:          "admin",
+			pass:          "hunter2",
+			wantStatus:    http.StatusUnauthorized,
+			wantChallenge: `Basic realm="admin", charset="UTF-8"`,
+		},
+		{
+			name:          "given unknown user, when request made, then returns 401",
+			cfg:           httpserver.BasicAuthConfig{Validator: validator},
+			setAuth:       true,
+			user:          "nobody",
+			pass:          "secret",
+			wantStatus:    http.StatusUnauthorized,
+			wantChallenge: `Basic realm="Restricted", charset="UTF-8"`,
+		},
+		{
+			name:          "given nil validator, when request made, then returns 401",
+			cfg:           httpserver.BasicAuthConfig{},
+			setAuth:       true,
+			user:          "admin",
+			pass:          "secret",
+			wantStatus:    http.StatusUnauthorized,
+			wantChallenge: `Basic realm="Restricted", charset="UTF-8"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			handler := httpserver.BasicAuth(tt.cfg)(
+				http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(http.StatusOK)
+				}),
+			)
+
+			req := httptest.NewRequest(http.MethodGet, "/admin", nil)
+			if tt.setAuth {
+				req.SetBasicAuth(tt.user, tt.pass)
+			}
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Equal(t, tt.wantChallenge, rec.Header().Get("WWW-Authenticate"))
+		})
+	}
+}
+
 func TestKeyFuncByClientID(t *testing.T) {
 	t.Parallel()
 
@@ -90,6 +90,29 @@ type Config struct {
 	// Default: 10s
 	ShutdownTimeout time.Duration
 
+	// PreShutdownDelay is how long the server keeps serving after a shutdown
+	// signal, with the readiness probe failing, before shutdown begins. It
+	// gives load balancers time to notice and stop routing new requests
+	// here. It adds to ShutdownTimeout, so keep their sum within the
+	// orchestrator's grace period.
+	//
+	// Default: 0 (shutdown begins immediately)
+	PreShutdownDelay time.Duration
+
+	// MaxRequestsPerConn closes a keep-alive connection after it has served
+	// this many requests, by responding with "Connection: close". Clients
+	// then reconnect, which rebalances them across replicas behind an L4
+	// load balancer.
+	//
+	// Default: 0 (unlimited)
+	MaxRequestsPerConn int
+
+	// ConnMaxAge closes a keep-alive connection on the first response after
+	// it has been open this long, by responding with "Connection: close".
+	//
+	// Default: 0 (unlimited)
+	ConnMaxAge time.Duration
+
 	// DrainInterval is the interval between checks during shutdown to see if
 	// all connections have been drained.
 	//
@@ -113,6 +136,39 @@ type Config struct {
 
 	// RateLimitConfig enables global rate limiting.
 	RateLimitConfig *RateLimitConfig
+
+	// ServerTiming adds a Server-Timing response header with the request
+	// duration and any phases recorded with RecordServerTiming.
+	ServerTiming bool
+
+	// RouteTagger returns the route template used to label metrics and spans.
+	// It is applied to TracingConfig and MetricsConfig unless they set their
+	// own. If nil, the matched http.ServeMux pattern is used.
+	RouteTagger RouteTagger
+
+	// RouteSLO maps route templates to their latency objective. It is
+	// applied to MetricsConfig unless it sets its own.
+	RouteSLO map[string]time.Duration
+
+	// DisableExemplars stops request duration measurements from carrying
+	// exemplars that link them to traces. It is applied to MetricsConfig
+	// unless it disables exemplars itself.
+	DisableExemplars bool
+
+	// NotFoundHandler handles requests that match no route when Handler is
+	// an *http.ServeMux. If nil, NotFoundHandler() is used.
+	NotFoundHandler http.Handler
+
+	// MethodNotAllowedHandler handles requests whose path matches a route
+	// but whose method does not, when Handler is an *http.ServeMux.
+	// If nil, MethodNotAllowedHandler() is used.
+	MethodNotAllowedHandler http.Handler
+
+	// ErrorEnvelope builds the body of responses written by
+	// WriteRequestError and of the built-in handlers' and middleware's
+	// errors. If nil, WriteRequestError uses DefaultErrorEnvelope and the
+	// built-in errors keep the WriteError shape.
+	ErrorEnvelope ErrorEnvelope
 }
 
 // DefaultConfig returns a balanced configuration suitable for most use cases.
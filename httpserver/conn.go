@@ -0,0 +1,94 @@
+package httpserver
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// connInfo tracks the age and request count of a client connection.
+type connInfo struct {
+	created  time.Time
+	requests atomic.Int64
+}
+
+// connKey is the context key for the connection serving a request.
+type connKey struct{}
+
+// connLimiter closes keep-alive connections once they have served
+// maxRequests requests or are older than maxAge, so long-lived clients
+// reconnect and spread across replicas.
+type connLimiter struct {
+	maxRequests int64
+	maxAge      time.Duration
+
+	mu    sync.Mutex
+	conns map[net.Conn]*connInfo
+}
+
+// newConnLimiter returns a connLimiter, or nil if neither limit is set.
+func newConnLimiter(maxRequests int, maxAge time.Duration) *connLimiter {
+	if maxRequests <= 0 && maxAge <= 0 {
+		return nil
+	}
+	return &connLimiter{
+		maxRequests: int64(max(maxRequests, 0)),
+		maxAge:      max(maxAge, 0),
+		conns:       make(map[net.Conn]*connInfo),
+	}
+}
+
+// install hooks the limiter into srv's connection lifecycle and wraps its
+// handler.
+func (l *connLimiter) install(srv *http.Server) {
+	srv.ConnContext = func(ctx context.Context, c net.Conn) context.Context {
+		return context.WithValue(ctx, connKey{}, c)
+	}
+	srv.ConnState = l.connState
+	if srv.Handler != nil {
+		srv.Handler = l.middleware(srv.Handler)
+	}
+}
+
+// connState records new connections and forgets closed ones.
+func (l *connLimiter) connState(c net.Conn, state http.ConnState) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	switch state {
+	case http.StateNew:
+		l.conns[c] = &connInfo{created: time.Now()}
+	case http.StateHijacked, http.StateClosed:
+		delete(l.conns, c)
+	}
+}
+
+// lookup returns the tracked state of the connection serving ctx.
+func (l *connLimiter) lookup(ctx context.Context) *connInfo {
+	c, ok := ctx.Value(connKey{}).(net.Conn)
+	if !ok {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.conns[c]
+}
+
+// middleware sets "Connection: close" on the response that reaches a
+// connection limit. For HTTP/2, net/http turns it into a GOAWAY.
+func (l *connLimiter) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if info := l.lookup(r.Context()); info != nil {
+			n := info.requests.Add(1)
+			if (l.maxRequests > 0 && n >= l.maxRequests) ||
+				(l.maxAge > 0 && time.Since(info.created) >= l.maxAge) {
+				w.Header().Set("Connection", "close")
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
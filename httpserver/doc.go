@@ -65,13 +65,73 @@
 //	    Burst: 100,
 //	})(apiHandler))
 //
-// Distributed rate limiting with Redis:
+// Distributed rate limiting with Redis, which also reports the client's
+// quota in X-RateLimit-Remaining and X-RateLimit-Reset response headers:
 //
 //	rdb := redis.NewUniversalClient(&redis.UniversalOptions{
 //	    Addrs: []string{"localhost:6379"},
 //	})
 //	mux.Handle("/api/", httpserver.RateLimitByIPRedis(rdb, 100, 200)(apiHandler))
 //
+// # Deadline Propagation
+//
+// Honor the time budget sent by callers, capped by the server, and answer
+// 504 once it is spent. Outgoing calls made with the request context inherit
+// the deadline:
+//
+//	deadline := httpserver.DeadlinePropagation(httpserver.RequestTimeoutHeader)
+//	mux.Handle("/api/", deadline(apiHandler))
+//
+// # Request Coalescing
+//
+// Run expensive read handlers once for identical concurrent GET and HEAD
+// requests, sharing the response. Include the caller's identity in the key
+// for per-user responses:
+//
+//	mux.Handle("/api/catalog", httpserver.Coalesce(nil)(catalogHandler))
+//
+// # Required Headers
+//
+// Reject requests missing headers every handler would otherwise re-check,
+// with a 400 naming each one:
+//
+//	requireHeaders := httpserver.RequireHeaders(httpserver.RequireHeadersConfig{
+//	    Headers:      []string{"X-Api-Version"},
+//	    ContentTypes: []string{"application/json"}, // POST, PUT and PATCH
+//	})
+//
+// # Server Timing
+//
+// Report how long requests took in a Server-Timing response header, with
+// custom phases recorded by handlers:
+//
+//	server := httpserver.New(httpserver.WithServerTimingHeader(), ...)
+//
+//	httpserver.RecordServerTiming(r.Context(), "db", time.Since(start))
+//
+// # Error Responses
+//
+// WriteRequestError writes an error with a stable code plus the request and
+// trace IDs, so clients can quote them in bug reports. Change the body shape
+// for the whole server with WithErrorEnvelope, which also applies to the
+// errors of the built-in handlers and middleware:
+//
+//	httpserver.WriteRequestError(w, r, http.StatusNotFound,
+//	    "order_not_found", "order 42 does not exist",
+//	)
+//
+// # File Uploads
+//
+// ParseMultipart reads multipart/form-data bodies with a size limit, spilling
+// large files to disk and removing them when the request is done:
+//
+//	data, err := httpserver.ParseMultipart(r, 10<<20)
+//	if err != nil {
+//	    httpserver.WriteMultipartError(w, r, err) // 413 or 400
+//	    return
+//	}
+//	file, header, err := data.File("avatar")
+//
 // # Health Checks
 //
 // Register health endpoints with auto-configured ServiceName:
@@ -90,6 +150,12 @@
 //	mux.Handle("/livez", health.LiveHandler())
 //	mux.Handle("/readyz", health.ReadyHandler())
 //
+// Add WithPreShutdownDelay to fail readiness and keep serving for a while
+// after a shutdown signal, so load balancers drain the instance before it
+// stops accepting connections. WithMaxRequestsPerConn and WithConnMaxAge make
+// long-lived keep-alive clients reconnect periodically, so they don't stay
+// pinned to one replica.
+//
 // # Framework Adapters
 //
 // Use adapters for popular frameworks:
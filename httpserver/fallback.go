@@ -0,0 +1,106 @@
+package httpserver
+
+import (
+	"net/http"
+)
+
+// NotFoundHandler returns the default JSON handler for unmatched routes.
+//
+// It writes a 404 response using the package's standard error shape:
+//
+//	{
+//	  "errors": [{"field": "path", "message": "no route matches /unknown"}],
+//	  "message": "not found"
+//	}
+func NotFoundHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		WriteCodedError(w, r, http.StatusNotFound, "not_found", "not found",
+			Error{Field: "path", Message: "no route matches " + r.URL.Path})
+	})
+}
+
+// MethodNotAllowedHandler returns the default JSON handler for routes that
+// exist but do not accept the request method.
+//
+// The Allow header set by http.ServeMux is preserved. The response uses the
+// package's standard error shape:
+//
+//	{
+//	  "errors": [{"field": "method", "message": "method DELETE is not allowed"}],
+//	  "message": "method not allowed"
+//	}
+func MethodNotAllowedHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		WriteCodedError(w, r, http.StatusMethodNotAllowed, "method_not_allowed", "method not allowed",
+			Error{Field: "method", Message: "method " + r.Method + " is not allowed"})
+	})
+}
+
+// muxFallback wraps an http.ServeMux so that requests it cannot route are
+// answered by the configured not-found and method-not-allowed handlers
+// instead of Go's default plaintext responses.
+//
+// Only responses produced by the mux itself are replaced. A registered
+// handler that returns 404 or 405 is left untouched.
+func muxFallback(mux *http.ServeMux, notFound, methodNotAllowed http.Handler) http.Handler {
+	if notFound == nil {
+		notFound = NotFoundHandler()
+	}
+	if methodNotAllowed == nil {
+		methodNotAllowed = MethodNotAllowedHandler()
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h, pattern := mux.Handler(r)
+		if pattern != "" {
+			mux.ServeHTTP(w, r)
+			return
+		}
+
+		// The mux has no pattern for this request. Run its fallback handler
+		// against an interceptor to learn which status it would have sent.
+		fw := &fallbackWriter{ResponseWriter: w}
+		h.ServeHTTP(fw, r)
+
+		switch fw.status {
+		case http.StatusNotFound:
+			notFound.ServeHTTP(w, r)
+		case http.StatusMethodNotAllowed:
+			methodNotAllowed.ServeHTTP(w, r)
+		default:
+			// Redirects and other mux-generated responses pass through.
+			fw.flush()
+		}
+	})
+}
+
+// fallbackWriter buffers the status and body of a mux fallback handler so
+// they can be discarded or replayed.
+type fallbackWriter struct {
+	http.ResponseWriter
+	status int
+	body   []byte
+}
+
+func (fw *fallbackWriter) WriteHeader(code int) {
+	if fw.status == 0 {
+		fw.status = code
+	}
+}
+
+func (fw *fallbackWriter) Write(b []byte) (int, error) {
+	if fw.status == 0 {
+		fw.status = http.StatusOK
+	}
+	fw.body = append(fw.body, b...)
+	return len(b), nil
+}
+
+// flush replays the buffered response to the underlying writer.
+func (fw *fallbackWriter) flush() {
+	if fw.status == 0 {
+		fw.status = http.StatusOK
+	}
+	fw.ResponseWriter.WriteHeader(fw.status)
+	_, _ = fw.ResponseWriter.Write(fw.body)
+}
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"os"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	json "github.com/goccy/go-json"
@@ -82,6 +83,9 @@ type HealthHandler struct {
 	mu              sync.RWMutex
 	livenessChecks  map[string]*checkState
 	readinessChecks map[string]*checkState
+
+	// shuttingDown fails the readiness probe, see MarkShuttingDown.
+	shuttingDown atomic.Bool
 }
 
 // HealthOption configures the HealthHandler.
@@ -179,6 +183,16 @@ func (h *HealthHandler) AddReadinessCheck(name string, check HealthCheck) {
 	h.readinessChecks[name] = &checkState{check: check}
 }
 
+// MarkShuttingDown makes the readiness probe fail from now on, without
+// running the readiness checks, so load balancers stop routing traffic to a
+// server that is about to shut down. Liveness is not affected.
+//
+// The server calls it when WithPreShutdownDelay is set; call it directly when
+// managing shutdown yourself.
+func (h *HealthHandler) MarkShuttingDown() {
+	h.shuttingDown.Store(true)
+}
+
 // PingHandler returns an http.Handler for the /ping endpoint.
 //
 // This is a simple connectivity check that always returns 200 OK.
@@ -204,13 +218,39 @@ func (h *HealthHandler) LiveHandler() http.Handler {
 
 // ReadyHandler returns an http.Handler for the /readyz endpoint.
 //
-// Returns 200 if all readiness checks pass, 503 otherwise.
+// Returns 200 if all readiness checks pass, 503 otherwise. Once
+// MarkShuttingDown is called, it always returns 503.
 func (h *HealthHandler) ReadyHandler() http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if h.shuttingDown.Load() {
+			h.writeShuttingDown(w)
+			return
+		}
 		h.handleHealthCheck(w, r, h.readinessChecks)
 	})
 }
 
+// writeShuttingDown writes the failing readiness response of a server that
+// is shutting down.
+func (h *HealthHandler) writeShuttingDown(w http.ResponseWriter) {
+	data := HealthResponse{
+		Status:    "fail",
+		Service:   h.serviceName,
+		Version:   h.version,
+		Uptime:    time.Since(h.startTime).Round(time.Second).String(),
+		Hostname:  h.hostname,
+		Timestamp: time.Now().Format(time.RFC3339),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusServiceUnavailable)
+	_ = json.NewEncoder(w).Encode(Response[HealthResponse]{
+		Data:    data,
+		Errors:  []Error{{Field: "server", Message: "shutting down"}},
+		Message: "shutting down",
+	})
+}
+
 // handleHealthCheck runs the specified checks and writes the response.
 func (h *HealthHandler) handleHealthCheck(
 	w http.ResponseWriter,
@@ -3,14 +3,26 @@ package httpserver_test
 import (
 	"context"
 	"errors"
+	"io"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/kroma-labs/sentinel-go/httpserver"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/exemplar"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func TestConfigs(t *testing.T) {
@@ -92,6 +104,7 @@ func TestHealthHandler_Checks(t *testing.T) {
 		name           string
 		handler        string
 		checks         map[string]error
+		shuttingDown   bool
 		wantStatusCode int
 		wantStatus     string
 	}{
@@ -123,6 +136,22 @@ func TestHealthHandler_Checks(t *testing.T) {
 			wantStatusCode: http.StatusServiceUnavailable,
 			wantStatus:     "fail",
 		},
+		{
+			name:           "given shutting down, then readiness returns service unavailable",
+			handler:        "ready",
+			checks:         map[string]error{"db": nil},
+			shuttingDown:   true,
+			wantStatusCode: http.StatusServiceUnavailable,
+			wantStatus:     "fail",
+		},
+		{
+			name:           "given shutting down, then liveness returns ok",
+			handler:        "live",
+			checks:         map[string]error{"check1": nil},
+			shuttingDown:   true,
+			wantStatusCode: http.StatusOK,
+			wantStatus:     "ok",
+		},
 	}
 
 	for _, tt := range tests {
@@ -144,6 +173,10 @@ func TestHealthHandler_Checks(t *testing.T) {
 				}
 			}
 
+			if tt.shuttingDown {
+				health.MarkShuttingDown()
+			}
+
 			var handler http.Handler
 			if tt.handler == "live" {
 				handler = health.LiveHandler()
@@ -161,6 +194,188 @@ func TestHealthHandler_Checks(t *testing.T) {
 	}
 }
 
+func TestServer_Config(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		opts []httpserver.Option
+		want func() httpserver.Config
+	}{
+		{
+			name: "given no options, when built, then applies defaults",
+			want: func() httpserver.Config {
+				cfg := httpserver.DefaultConfig()
+				cfg.ServiceName = "http-server"
+				cfg.Addr = ":8080"
+				return cfg
+			},
+		},
+		{
+			name: "given preset then options, when built, then options override preset",
+			opts: []httpserver.Option{
+				httpserver.WithConfig(httpserver.ProductionConfig()),
+				httpserver.WithServiceName("orders"),
+				httpserver.WithMaxRequestsPerConn(100),
+			},
+			want: func() httpserver.Config {
+				cfg := httpserver.ProductionConfig()
+				cfg.ServiceName = "orders"
+				cfg.MaxRequestsPerConn = 100
+				if cfg.Addr == "" {
+					cfg.Addr = ":8080"
+				}
+				return cfg
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httpserver.New(tt.opts...)
+
+			got := server.Config()
+			want := tt.want()
+			assert.Equal(t, want.ServiceName, got.ServiceName)
+			assert.Equal(t, want.Addr, got.Addr)
+			assert.Equal(t, want.ReadTimeout, got.ReadTimeout)
+			assert.Equal(t, want.WriteTimeout, got.WriteTimeout)
+			assert.Equal(t, want.IdleTimeout, got.IdleTimeout)
+			assert.Equal(t, want.ShutdownTimeout, got.ShutdownTimeout)
+			assert.Equal(t, want.MaxRequestsPerConn, got.MaxRequestsPerConn)
+		})
+	}
+}
+
+func TestServer_PreShutdownDelay(t *testing.T) {
+	t.Parallel()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := lis.Addr().String()
+	require.NoError(t, lis.Close())
+
+	var health *httpserver.HealthHandler
+	mux := http.NewServeMux()
+	server := httpserver.New(
+		httpserver.WithConfig(httpserver.Config{Addr: addr, ShutdownTimeout: time.Second}),
+		httpserver.WithLogger(zerolog.New(io.Discard)),
+		httpserver.WithHealth(&health, "1.0.0"),
+		httpserver.WithPreShutdownDelay(300*time.Millisecond),
+		httpserver.WithHandler(mux),
+	)
+	mux.Handle("/readyz", health.ReadyHandler())
+
+	// Without keep-alive no probe connection is left open when Shutdown
+	// runs with the already cancelled context.
+	client := &http.Client{Transport: &http.Transport{DisableKeepAlives: true}}
+	readyz := func() int {
+		resp, err := client.Get("http://" + addr + "/readyz")
+		if err != nil {
+			return 0
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- server.ListenAndServe(ctx) }()
+
+	require.Eventually(t, func() bool { return readyz() == http.StatusOK },
+		time.Second, 10*time.Millisecond)
+
+	cancel()
+	start := time.Now()
+
+	// Still serving, with readiness failing
+	require.Eventually(t, func() bool { return readyz() == http.StatusServiceUnavailable },
+		200*time.Millisecond, 10*time.Millisecond)
+
+	require.NoError(t, <-done)
+	assert.GreaterOrEqual(t, time.Since(start), 300*time.Millisecond)
+	assert.Zero(t, readyz())
+}
+
+func TestServer_ConnLimits(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		option    httpserver.Option
+		wait      time.Duration
+		wantClose []bool
+	}{
+		{
+			name:      "given max requests per conn, when limit is reached, then closes connection",
+			option:    httpserver.WithMaxRequestsPerConn(2),
+			wantClose: []bool{false, true, false, true},
+		},
+		{
+			name:      "given conn max age, when connection is older, then closes connection",
+			option:    httpserver.WithConnMaxAge(50 * time.Millisecond),
+			wait:      60 * time.Millisecond,
+			wantClose: []bool{false, true, false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			lis, err := net.Listen("tcp", "127.0.0.1:0")
+			require.NoError(t, err)
+			addr := lis.Addr().String()
+			require.NoError(t, lis.Close())
+
+			server := httpserver.New(
+				httpserver.WithConfig(httpserver.Config{Addr: addr, ShutdownTimeout: time.Second}),
+				httpserver.WithLogger(zerolog.New(io.Discard)),
+				httpserver.WithHandler(http.HandlerFunc(
+					func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) },
+				)),
+				tt.option,
+			)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			done := make(chan error, 1)
+			go func() { done <- server.ListenAndServe(ctx) }()
+
+			client := &http.Client{Transport: &http.Transport{}}
+			get := func() (*http.Response, error) {
+				resp, err := client.Get("http://" + addr + "/")
+				if err != nil {
+					return nil, err
+				}
+				_, _ = io.Copy(io.Discard, resp.Body)
+				resp.Body.Close()
+				return resp, nil
+			}
+			require.Eventually(t, func() bool {
+				_, err := get()
+				return err == nil
+			}, time.Second, 10*time.Millisecond)
+			client.CloseIdleConnections()
+
+			var gotClose []bool
+			for i := range tt.wantClose {
+				if i > 0 {
+					time.Sleep(tt.wait)
+				}
+				resp, err := get()
+				require.NoError(t, err)
+				gotClose = append(gotClose, resp.Close)
+			}
+			assert.Equal(t, tt.wantClose, gotClose)
+
+			cancel()
+			require.NoError(t, <-done)
+		})
+	}
+}
+
 func TestMiddlewareChain(t *testing.T) {
 	t.Parallel()
 
@@ -361,6 +576,637 @@ func TestMetrics(t *testing.T) {
 	})
 }
 
+func TestMetrics_ActiveRequests(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+	}{
+		{
+			name: "given handler returns, then active requests returns to zero",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			},
+		},
+		{
+			name: "given handler panics, then active requests returns to zero",
+			handler: func(_ http.ResponseWriter, _ *http.Request) {
+				panic("boom")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			reader := sdkmetric.NewManualReader()
+			mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+			cfg := httpserver.DefaultMetricsConfig()
+			cfg.MeterProvider = mp
+			metrics, err := httpserver.NewMetrics(cfg)
+			require.NoError(t, err)
+
+			var inFlight int64
+			chain := httpserver.Chain(
+				httpserver.Recovery(zerolog.Nop()),
+				metrics.Middleware(),
+			)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				inFlight = activeRequests(t, reader)
+				tt.handler(w, r)
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/users/123", nil)
+			chain.ServeHTTP(httptest.NewRecorder(), req)
+
+			assert.Equal(t, int64(1), inFlight)
+			assert.Equal(t, int64(0), activeRequests(t, reader))
+		})
+	}
+}
+
+// activeRequests returns the summed http.server.active_requests value and
+// verifies it carries the method and route attributes.
+func activeRequests(t *testing.T, reader *sdkmetric.ManualReader) int64 {
+	t.Helper()
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+
+	var total int64
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != "http.server.active_requests" {
+				continue
+			}
+			sum, ok := m.Data.(metricdata.Sum[int64])
+			require.True(t, ok)
+			for _, dp := range sum.DataPoints {
+				method, _ := dp.Attributes.Value("http.request.method")
+				assert.Equal(t, http.MethodGet, method.AsString())
+				assert.True(t, dp.Attributes.HasValue("http.route"))
+				assert.False(t, dp.Attributes.HasValue("url.path"))
+				total += dp.Value
+			}
+		}
+	}
+	return total
+}
+
+func TestMetrics_ResponseStatus(t *testing.T) {
+	t.Parallel()
+
+	t.Run("given mixed responses, then counts by status code and class", func(t *testing.T) {
+		reader := sdkmetric.NewManualReader()
+		mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+		cfg := httpserver.DefaultMetricsConfig()
+		cfg.MeterProvider = mp
+		metrics, err := httpserver.NewMetrics(cfg)
+		require.NoError(t, err)
+
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Query().Get("status") {
+			case "503":
+				w.WriteHeader(http.StatusServiceUnavailable)
+			case "404":
+				w.WriteHeader(http.StatusNotFound)
+			default:
+				w.WriteHeader(http.StatusOK)
+			}
+		})
+		handler := metrics.Middleware()(next)
+
+		for _, query := range []string{"", "", "404", "503", "503", "503"} {
+			req := httptest.NewRequest(http.MethodGet, "/orders?status="+query, nil)
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+		}
+
+		var rm metricdata.ResourceMetrics
+		require.NoError(t, reader.Collect(context.Background(), &rm))
+
+		byCode := map[int64]int64{}
+		byClass := map[string]int64{}
+		for _, sm := range rm.ScopeMetrics {
+			for _, m := range sm.Metrics {
+				switch m.Name {
+				case "http.server.response.status":
+					sum, ok := m.Data.(metricdata.Sum[int64])
+					require.True(t, ok)
+					for _, dp := range sum.DataPoints {
+						code, _ := dp.Attributes.Value("http.response.status_code")
+						byCode[code.AsInt64()] += dp.Value
+					}
+				case "http.server.response.status_class":
+					sum, ok := m.Data.(metricdata.Sum[int64])
+					require.True(t, ok)
+					for _, dp := range sum.DataPoints {
+						class, _ := dp.Attributes.Value("http.response.status_class")
+						method, _ := dp.Attributes.Value("http.request.method")
+						assert.Equal(t, http.MethodGet, method.AsString())
+						assert.True(t, dp.Attributes.HasValue("http.route"))
+						assert.False(t, dp.Attributes.HasValue("http.response.status_code"))
+						byClass[class.AsString()] += dp.Value
+					}
+				}
+			}
+		}
+
+		assert.Equal(t, map[int64]int64{200: 2, 404: 1, 503: 3}, byCode)
+		assert.Equal(t, map[string]int64{"2xx": 2, "4xx": 1, "5xx": 3}, byClass)
+	})
+}
+
+func TestMetrics_RouteSLO(t *testing.T) {
+	t.Parallel()
+
+	t.Run("given route objectives, then tags request totals with slo.met", func(t *testing.T) {
+		reader := sdkmetric.NewManualReader()
+		mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+		cfg := httpserver.DefaultMetricsConfig()
+		cfg.MeterProvider = mp
+
+		mux := http.NewServeMux()
+		mux.HandleFunc("GET /orders/{id}", func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})
+		mux.HandleFunc("GET /search", func(w http.ResponseWriter, _ *http.Request) {
+			time.Sleep(20 * time.Millisecond)
+			w.WriteHeader(http.StatusOK)
+		})
+		mux.HandleFunc("GET /export", func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})
+
+		server := httpserver.New(
+			httpserver.WithLogger(zerolog.New(io.Discard)),
+			httpserver.WithMetrics(cfg),
+			httpserver.WithRouteSLO(map[string]time.Duration{
+				"/orders/{id}": time.Second,
+				"/search":      10 * time.Millisecond,
+			}),
+			httpserver.WithHandler(mux),
+		)
+
+		for _, path := range []string{"/orders/1", "/orders/2", "/search", "/export"} {
+			req := httptest.NewRequest(http.MethodGet, path, nil)
+			server.Handler().ServeHTTP(httptest.NewRecorder(), req)
+		}
+
+		var rm metricdata.ResourceMetrics
+		require.NoError(t, reader.Collect(context.Background(), &rm))
+
+		got := map[string]string{}
+		for _, sm := range rm.ScopeMetrics {
+			for _, m := range sm.Metrics {
+				if m.Name != "http.server.request.total" {
+					continue
+				}
+				sum, ok := m.Data.(metricdata.Sum[int64])
+				require.True(t, ok)
+				for _, dp := range sum.DataPoints {
+					route, _ := dp.Attributes.Value("http.route")
+					met, ok := dp.Attributes.Value("slo.met")
+					if !ok {
+						got[route.AsString()] = "none"
+						continue
+					}
+					got[route.AsString()] = met.Emit()
+				}
+			}
+		}
+
+		assert.Equal(t, map[string]string{
+			"/orders/{id}": "true",
+			"/search":      "false",
+			"/export":      "none",
+		}, got)
+	})
+}
+
+func TestMetrics_Exemplars(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		filter        exemplar.Filter
+		opts          []httpserver.Option
+		wantExemplars bool
+	}{
+		{
+			name:          "given default filter, when request traced, then links to trace",
+			filter:        exemplar.TraceBasedFilter,
+			wantExemplars: true,
+		},
+		{
+			name:   "given exemplars disabled, when request traced, then records none",
+			filter: exemplar.TraceBasedFilter,
+			opts:   []httpserver.Option{httpserver.WithExemplars(false)},
+		},
+		{
+			name:   "given exemplars turned off in the SDK, when request traced, then records none",
+			filter: exemplar.AlwaysOffFilter,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			reader := sdkmetric.NewManualReader()
+			mp := sdkmetric.NewMeterProvider(
+				sdkmetric.WithReader(reader),
+				sdkmetric.WithExemplarFilter(tt.filter),
+			)
+			tp := sdktrace.NewTracerProvider(sdktrace.WithSampler(sdktrace.AlwaysSample()))
+
+			metricsCfg := httpserver.DefaultMetricsConfig()
+			metricsCfg.MeterProvider = mp
+			tracingCfg := httpserver.DefaultTracingConfig()
+			tracingCfg.TracerProvider = tp
+
+			handler := func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}
+			opts := append([]httpserver.Option{
+				httpserver.WithLogger(zerolog.New(io.Discard)),
+				httpserver.WithTracing(tracingCfg),
+				httpserver.WithMetrics(metricsCfg),
+				httpserver.WithHandler(http.HandlerFunc(handler)),
+			}, tt.opts...)
+			server := httpserver.New(opts...)
+
+			req := httptest.NewRequest(http.MethodGet, "/orders", nil)
+			server.Handler().ServeHTTP(httptest.NewRecorder(), req)
+
+			var rm metricdata.ResourceMetrics
+			require.NoError(t, reader.Collect(context.Background(), &rm))
+
+			var exemplars []metricdata.Exemplar[float64]
+			for _, sm := range rm.ScopeMetrics {
+				for _, m := range sm.Metrics {
+					if m.Name != "http.server.request.duration" {
+						continue
+					}
+					hist, ok := m.Data.(metricdata.Histogram[float64])
+					require.True(t, ok)
+					for _, dp := range hist.DataPoints {
+						exemplars = append(exemplars, dp.Exemplars...)
+					}
+				}
+			}
+
+			if !tt.wantExemplars {
+				assert.Empty(t, exemplars)
+				return
+			}
+			require.Len(t, exemplars, 1)
+			assert.Len(t, exemplars[0].TraceID, 16)
+			assert.Len(t, exemplars[0].SpanID, 8)
+		})
+	}
+}
+
+func TestMetrics_BodySize(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name             string
+		body             string
+		contentLength    int64
+		readBody         bool
+		wantRequestSize  int64
+		wantResponseSize int64
+	}{
+		{
+			name:             "given chunked body, when handler reads it, then records bytes read",
+			body:             "hello world",
+			contentLength:    -1,
+			readBody:         true,
+			wantRequestSize:  11,
+			wantResponseSize: 11,
+		},
+		{
+			name:             "given unread body, when handler responds, then uses Content-Length",
+			body:             "hello world",
+			contentLength:    11,
+			wantRequestSize:  11,
+			wantResponseSize: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			reader := sdkmetric.NewManualReader()
+			mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+			cfg := httpserver.DefaultMetricsConfig()
+			cfg.MeterProvider = mp
+			metrics, err := httpserver.NewMetrics(cfg)
+			require.NoError(t, err)
+
+			handler := metrics.Middleware()(http.HandlerFunc(
+				func(w http.ResponseWriter, r *http.Request) {
+					if !tt.readBody {
+						_, _ = w.Write([]byte("ok"))
+						return
+					}
+					body, _ := io.ReadAll(r.Body)
+					_, _ = w.Write(body)
+				},
+			))
+
+			req := httptest.NewRequest(http.MethodPost, "/echo", strings.NewReader(tt.body))
+			req.ContentLength = tt.contentLength
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			var rm metricdata.ResourceMetrics
+			require.NoError(t, reader.Collect(context.Background(), &rm))
+
+			got := map[string]int64{}
+			for _, sm := range rm.ScopeMetrics {
+				for _, m := range sm.Metrics {
+					hist, ok := m.Data.(metricdata.Histogram[int64])
+					if !ok {
+						continue
+					}
+					for _, dp := range hist.DataPoints {
+						got[m.Name] += dp.Sum
+					}
+				}
+			}
+
+			assert.Equal(t, map[string]int64{
+				"http.server.request.body.size":  tt.wantRequestSize,
+				"http.server.response.body.size": tt.wantResponseSize,
+				"http.server.request.size":       tt.wantRequestSize,
+				"http.server.response.size":      tt.wantResponseSize,
+			}, got)
+		})
+	}
+}
+
+func TestMetrics_Skip(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		skipPaths []string
+		skipFunc  func(r *http.Request) bool
+		wantTotal int64
+	}{
+		{
+			name:      "given no skip rules, then records all requests",
+			wantTotal: 3,
+		},
+		{
+			name:      "given skip paths, then skips matching paths",
+			skipPaths: []string{"/readyz"},
+			wantTotal: 2,
+		},
+		{
+			name: "given skip func, then skips matching requests",
+			skipFunc: func(r *http.Request) bool {
+				return strings.HasPrefix(r.URL.Path, "/debug/")
+			},
+			wantTotal: 2,
+		},
+		{
+			name:      "given skip paths and skip func, then skips either match",
+			skipPaths: []string{"/readyz"},
+			skipFunc: func(r *http.Request) bool {
+				return strings.HasPrefix(r.URL.Path, "/debug/")
+			},
+			wantTotal: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			reader := sdkmetric.NewManualReader()
+			mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+			cfg := httpserver.DefaultMetricsConfig()
+			cfg.MeterProvider = mp
+			cfg.SkipPaths = tt.skipPaths
+			cfg.SkipFunc = tt.skipFunc
+			metrics, err := httpserver.NewMetrics(cfg)
+			require.NoError(t, err)
+
+			var served int
+			handler := metrics.Middleware()(
+				http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					served++
+					w.WriteHeader(http.StatusOK)
+				}),
+			)
+			for _, path := range []string{"/orders", "/readyz", "/debug/vars"} {
+				req := httptest.NewRequest(http.MethodGet, path, nil)
+				handler.ServeHTTP(httptest.NewRecorder(), req)
+			}
+
+			var rm metricdata.ResourceMetrics
+			require.NoError(t, reader.Collect(context.Background(), &rm))
+
+			var total int64
+			for _, sm := range rm.ScopeMetrics {
+				for _, m := range sm.Metrics {
+					if m.Name != "http.server.request.total" {
+						continue
+					}
+					sum, ok := m.Data.(metricdata.Sum[int64])
+					require.True(t, ok)
+					for _, dp := range sum.DataPoints {
+						total += dp.Value
+					}
+				}
+			}
+
+			assert.Equal(t, 3, served)
+			assert.Equal(t, tt.wantTotal, total)
+		})
+	}
+}
+
+func TestServer_RouteLabels(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		path         string
+		tagger       func(r *http.Request) string
+		wantRoute    string
+		wantSpanName string
+	}{
+		{
+			name:         "given mux pattern, then labels with route template",
+			path:         "/users/123",
+			wantRoute:    "/users/{id}",
+			wantSpanName: "HTTP GET /users/{id}",
+		},
+		{
+			name:         "given unmatched path, then labels with unknown route",
+			path:         "/nope/123",
+			wantRoute:    httpserver.UnknownRoute,
+			wantSpanName: "HTTP GET " + httpserver.UnknownRoute,
+		},
+		{
+			name: "given route tagger, then tagger takes precedence",
+			path: "/users/123",
+			tagger: func(_ *http.Request) string {
+				return "/custom/{id}"
+			},
+			wantRoute:    "/custom/{id}",
+			wantSpanName: "HTTP GET /custom/{id}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			exporter := tracetest.NewInMemoryExporter()
+			tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+			reader := sdkmetric.NewManualReader()
+			mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /users/{id}", func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})
+
+			server := httpserver.New(
+				httpserver.WithHandler(mux),
+				httpserver.WithRouteTagger(tt.tagger),
+				httpserver.WithTracing(httpserver.TracingConfig{TracerProvider: tp}),
+				httpserver.WithMetrics(httpserver.MetricsConfig{MeterProvider: mp}),
+			)
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			server.Handler().ServeHTTP(httptest.NewRecorder(), req)
+
+			spans := exporter.GetSpans()
+			require.Len(t, spans, 1)
+			assert.Equal(t, tt.wantSpanName, spans[0].Name)
+
+			var rm metricdata.ResourceMetrics
+			require.NoError(t, reader.Collect(context.Background(), &rm))
+
+			var routes []string
+			for _, sm := range rm.ScopeMetrics {
+				for _, m := range sm.Metrics {
+					if m.Name != "http.server.request.total" {
+						continue
+					}
+					sum, ok := m.Data.(metricdata.Sum[int64])
+					require.True(t, ok)
+					for _, dp := range sum.DataPoints {
+						route, _ := dp.Attributes.Value("http.route")
+						routes = append(routes, route.AsString())
+					}
+				}
+			}
+			assert.Equal(t, []string{tt.wantRoute}, routes)
+		})
+	}
+}
+
+func TestServer_SetRoute(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		handler      func(route *string) http.Handler
+		wantRoute    string
+		wantSpanName string
+	}{
+		{
+			name: "given WithRoute middleware, when route unknown, then labels with its route",
+			handler: func(route *string) http.Handler {
+				return httpserver.WithRoute("/legacy/{id}")(http.HandlerFunc(
+					func(w http.ResponseWriter, r *http.Request) {
+						*route = httpserver.RouteFromContext(r.Context())
+						w.WriteHeader(http.StatusOK)
+					}))
+			},
+			wantRoute:    "/legacy/{id}",
+			wantSpanName: "HTTP GET /legacy/{id}",
+		},
+		{
+			name: "given handler calling SetRoute, when route unknown, then labels with its route",
+			handler: func(route *string) http.Handler {
+				return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					r = httpserver.SetRoute(r, "/legacy/{id}")
+					*route = httpserver.RouteFromContext(r.Context())
+					w.WriteHeader(http.StatusOK)
+				})
+			},
+			wantRoute:    "/legacy/{id}",
+			wantSpanName: "HTTP GET /legacy/{id}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			exporter := tracetest.NewInMemoryExporter()
+			tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+			reader := sdkmetric.NewManualReader()
+			mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+			var handlerRoute string
+			server := httpserver.New(
+				httpserver.WithHandler(tt.handler(&handlerRoute)),
+				httpserver.WithTracing(httpserver.TracingConfig{TracerProvider: tp}),
+				httpserver.WithMetrics(httpserver.MetricsConfig{MeterProvider: mp}),
+			)
+
+			req := httptest.NewRequest(http.MethodGet, "/legacy/7", nil)
+			server.Handler().ServeHTTP(httptest.NewRecorder(), req)
+
+			assert.Equal(t, tt.wantRoute, handlerRoute)
+
+			spans := exporter.GetSpans()
+			require.Len(t, spans, 1)
+			assert.Equal(t, tt.wantSpanName, spans[0].Name)
+			var spanRoute string
+			for _, attr := range spans[0].Attributes {
+				if attr.Key == "http.route" {
+					spanRoute = attr.Value.AsString()
+				}
+			}
+			assert.Equal(t, tt.wantRoute, spanRoute)
+
+			var rm metricdata.ResourceMetrics
+			require.NoError(t, reader.Collect(context.Background(), &rm))
+
+			var routes []string
+			for _, sm := range rm.ScopeMetrics {
+				for _, m := range sm.Metrics {
+					if m.Name != "http.server.request.total" {
+						continue
+					}
+					sum, ok := m.Data.(metricdata.Sum[int64])
+					require.True(t, ok)
+					for _, dp := range sum.DataPoints {
+						route, _ := dp.Attributes.Value("http.route")
+						routes = append(routes, route.AsString())
+					}
+				}
+			}
+			assert.Equal(t, []string{tt.wantRoute}, routes)
+		})
+	}
+}
+
 func TestRateLimit(t *testing.T) {
 	t.Parallel()
 
@@ -417,3 +1263,282 @@ func TestRateLimit(t *testing.T) {
 		assert.Equal(t, http.StatusOK, rec3.Code)
 	})
 }
+
+func TestServer_RouteFallback(t *testing.T) {
+	t.Parallel()
+
+	newMux := func() *http.ServeMux {
+		mux := http.NewServeMux()
+		mux.HandleFunc("GET /users", func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})
+		mux.HandleFunc("GET /missing", func(w http.ResponseWriter, _ *http.Request) {
+			http.Error(w, "handler not found", http.StatusNotFound)
+		})
+		return mux
+	}
+
+	tests := []struct {
+		name            string
+		opts            []httpserver.Option
+		method          string
+		path            string
+		wantStatusCode  int
+		wantContentType string
+		wantBody        string
+		wantAllow       string
+	}{
+		{
+			name:            "given unmatched route, then returns json 404",
+			method:          http.MethodGet,
+			path:            "/unknown",
+			wantStatusCode:  http.StatusNotFound,
+			wantContentType: "application/json",
+			wantBody:        `"message":"not found"`,
+		},
+		{
+			name:            "given unsupported method, then returns json 405 with allow header",
+			method:          http.MethodDelete,
+			path:            "/users",
+			wantStatusCode:  http.StatusMethodNotAllowed,
+			wantContentType: "application/json",
+			wantBody:        `"message":"method not allowed"`,
+			wantAllow:       "GET, HEAD",
+		},
+		{
+			name: "given custom not found handler, then uses it",
+			opts: []httpserver.Option{
+				httpserver.WithNotFoundHandler(
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						httpserver.WriteError(w, http.StatusNotFound, "custom")
+					}),
+				),
+			},
+			method:          http.MethodGet,
+			path:            "/unknown",
+			wantStatusCode:  http.StatusNotFound,
+			wantContentType: "application/json",
+			wantBody:        `"message":"custom"`,
+		},
+		{
+			name:            "given handler returns 404 itself, then response is untouched",
+			method:          http.MethodGet,
+			path:            "/missing",
+			wantStatusCode:  http.StatusNotFound,
+			wantContentType: "text/plain; charset=utf-8",
+			wantBody:        "handler not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := append([]httpserver.Option{httpserver.WithHandler(newMux())}, tt.opts...)
+			server := httpserver.New(opts...)
+
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			rec := httptest.NewRecorder()
+			server.Handler().ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatusCode, rec.Code)
+			assert.Equal(t, tt.wantContentType, rec.Header().Get("Content-Type"))
+			assert.Contains(t, rec.Body.String(), tt.wantBody)
+			if tt.wantAllow != "" {
+				assert.Equal(t, tt.wantAllow, rec.Header().Get("Allow"))
+			}
+		})
+	}
+}
+
+func TestWriteRequestError(t *testing.T) {
+	t.Parallel()
+
+	traceID := trace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6}
+	spanCtx := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: traceID,
+		SpanID:  trace.SpanID{1},
+	})
+
+	tests := []struct {
+		name     string
+		options  []httpserver.Option
+		withSpan bool
+		details  []any
+		wantBody string
+	}{
+		{
+			name:    "given default envelope, when writing error, then wraps it with request ID",
+			details: []any{httpserver.Error{Field: "quantity", Message: "must be positive"}},
+			wantBody: `{"error":{"code":"validation_failed","message":"invalid order",` +
+				`"details":[{"field":"quantity","message":"must be positive"}],` +
+				`"request_id":"req-1"}}`,
+		},
+		{
+			name:     "given active span, when writing error, then includes trace ID",
+			withSpan: true,
+			wantBody: `{"error":{"code":"validation_failed","message":"invalid order",` +
+				`"request_id":"req-1","trace_id":"` + traceID.String() + `"}}`,
+		},
+		{
+			name: "given custom envelope option, when writing error, then uses it",
+			options: []httpserver.Option{
+				httpserver.WithErrorEnvelope(func(info httpserver.ErrorInfo) any {
+					return map[string]any{
+						"type":   info.Code,
+						"status": info.Status,
+						"id":     info.RequestID,
+					}
+				}),
+			},
+			wantBody: `{"type":"validation_failed","status":400,"id":"req-1"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("/orders", func(w http.ResponseWriter, r *http.Request) {
+				httpserver.WriteRequestError(w, r, http.StatusBadRequest,
+					"validation_failed", "invalid order", tt.details...)
+			})
+			opts := append([]httpserver.Option{
+				httpserver.WithLogger(zerolog.New(io.Discard)),
+				httpserver.WithHandler(mux),
+				httpserver.WithMiddleware(httpserver.RequestID()),
+			}, tt.options...)
+			server := httpserver.New(opts...)
+
+			req := httptest.NewRequest(http.MethodPost, "/orders", nil)
+			req.Header.Set(httpserver.RequestIDHeader, "req-1")
+			if tt.withSpan {
+				req = req.WithContext(trace.ContextWithSpanContext(req.Context(), spanCtx))
+			}
+			rec := httptest.NewRecorder()
+			server.Handler().ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+			assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+			assert.JSONEq(t, tt.wantBody, rec.Body.String())
+		})
+	}
+}
+
+func TestErrorEnvelope_BuiltinErrors(t *testing.T) {
+	t.Parallel()
+
+	envelope := httpserver.WithErrorEnvelope(func(info httpserver.ErrorInfo) any {
+		return map[string]any{
+			"type":   info.Code,
+			"title":  info.Message,
+			"status": info.Status,
+			"id":     info.RequestID,
+		}
+	})
+
+	tests := []struct {
+		name       string
+		options    []httpserver.Option
+		path       string
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "given custom envelope, when route is unknown, then writes 404 in envelope",
+			options:    []httpserver.Option{envelope},
+			path:       "/unknown",
+			wantStatus: http.StatusNotFound,
+			wantBody:   `{"type":"not_found","title":"not found","status":404,"id":"req-1"}`,
+		},
+		{
+			name: "given custom envelope, when rate limited, then writes 429 in envelope",
+			options: []httpserver.Option{
+				envelope,
+				httpserver.WithRateLimit(httpserver.RateLimitConfig{Limit: 1, Burst: 0}),
+			},
+			path:       "/orders",
+			wantStatus: http.StatusTooManyRequests,
+			// Global rate limiting runs before RequestID sets the ID
+			wantBody: `{"type":"rate_limited","title":"rate limit exceeded",` +
+				`"status":429,"id":""}`,
+		},
+		{
+			name:       "given no envelope, when route is unknown, then writes standard error",
+			path:       "/unknown",
+			wantStatus: http.StatusNotFound,
+			wantBody: `{"errors":[{"field":"path","message":"no route matches /unknown"}],` +
+				`"message":"not found"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("/orders", func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})
+			opts := append([]httpserver.Option{
+				httpserver.WithLogger(zerolog.New(io.Discard)),
+				httpserver.WithHandler(mux),
+				httpserver.WithMiddleware(httpserver.RequestID()),
+			}, tt.options...)
+			server := httpserver.New(opts...)
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			req.Header.Set(httpserver.RequestIDHeader, "req-1")
+			rec := httptest.NewRecorder()
+			server.Handler().ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.JSONEq(t, tt.wantBody, rec.Body.String())
+		})
+	}
+}
+
+func TestPrometheusHandlers(t *testing.T) {
+	t.Parallel()
+
+	registry := prometheus.NewRegistry()
+	uploads := prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "uploads_total",
+		Help: "Number of uploads.",
+	})
+	registry.MustRegister(uploads)
+	uploads.Add(3)
+
+	tests := []struct {
+		name     string
+		handler  http.Handler
+		wantBody string
+	}{
+		{
+			name:     "given custom registry, when scraped, then serves its metrics",
+			handler:  httpserver.PrometheusRegistryHandler(registry),
+			wantBody: "uploads_total 3",
+		},
+		{
+			name:     "given handler options, when scraped, then serves default registry",
+			handler:  httpserver.PrometheusHandlerFor(promhttp.HandlerOpts{}),
+			wantBody: "go_goroutines",
+		},
+		{
+			name:     "given default handler, when scraped, then serves default registry",
+			handler:  httpserver.PrometheusHandler(),
+			wantBody: "go_goroutines",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			tt.handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Contains(t, rec.Body.String(), tt.wantBody)
+		})
+	}
+}
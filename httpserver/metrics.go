@@ -1,23 +1,35 @@
 package httpserver
 
 import (
+	"context"
+	"io"
 	"net/http"
+	"strconv"
 	"time"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Metrics provides server metrics using OpenTelemetry.
 type Metrics struct {
 	serviceName     string
+	routeTagger     RouteTagger
+	skipPaths       map[string]bool
+	skipFunc        func(r *http.Request) bool
+	routeSLO        map[string]time.Duration
+	noExemplars     bool
 	requestDuration metric.Float64Histogram
 	requestSize     metric.Int64Histogram
 	responseSize    metric.Int64Histogram
+	legacyReqSize   metric.Int64Histogram
+	legacyRespSize  metric.Int64Histogram
 	activeRequests  metric.Int64UpDownCounter
 	requestTotal    metric.Int64Counter
 	responseStatus  metric.Int64Counter
+	statusClass     metric.Int64Counter
 }
 
 // MetricsConfig configures the metrics middleware.
@@ -29,9 +41,40 @@ type MetricsConfig struct {
 	// serviceName is set internally by the server.
 	serviceName string
 
-	// SkipPaths are paths that should not be recorded.
+	// RouteTagger returns the route template used for the http.route label.
+	// If nil, the route set by the server or a framework adapter is used,
+	// falling back to UnknownRoute. The server applies WithRouteTagger here
+	// when this field is unset.
+	RouteTagger RouteTagger
+
+	// SkipPaths are paths that should not be recorded, e.g. health and
+	// metrics endpoints that would skew SLO dashboards. Tracing is
+	// configured separately, see TracingConfig.SkipPaths.
 	SkipPaths []string
 
+	// SkipFunc reports whether a request should not be recorded, for rules
+	// SkipPaths can't express, such as path prefixes. A request is skipped
+	// if it matches SkipPaths or SkipFunc returns true.
+	//
+	// SkipPaths and SkipFunc apply to Middleware; callers of TrackRequest
+	// decide themselves which requests to track.
+	SkipFunc func(r *http.Request) bool
+
+	// RouteSLO maps route templates, e.g. "/orders/{id}", to their latency
+	// objective. Requests to these routes carry a boolean slo.met attribute
+	// on http.server.request.total, true if they completed within the
+	// objective, so error-budget burn can be computed from the counter
+	// alone. The server applies WithRouteSLO here when this field is unset.
+	RouteSLO map[string]time.Duration
+
+	// DisableExemplars hides the request's span from the
+	// http.server.request.duration measurement, so the SDK attaches no
+	// exemplar linking it to the trace. Use this for backends that reject
+	// exemplars. By default the SDK's exemplar filter decides, which records
+	// exemplars for sampled spans. The server applies WithExemplars here when
+	// this field is unset.
+	DisableExemplars bool
+
 	// Buckets for request duration histogram (in seconds).
 	// Default: [0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10]
 	DurationBuckets []float64
@@ -69,7 +112,7 @@ func NewMetrics(cfg MetricsConfig) (*Metrics, error) {
 	}
 
 	requestSize, err := meter.Int64Histogram(
-		"http.server.request.size",
+		"http.server.request.body.size",
 		metric.WithDescription("Size of HTTP request bodies in bytes"),
 		metric.WithUnit("By"),
 	)
@@ -78,7 +121,7 @@ func NewMetrics(cfg MetricsConfig) (*Metrics, error) {
 	}
 
 	responseSize, err := meter.Int64Histogram(
-		"http.server.response.size",
+		"http.server.response.body.size",
 		metric.WithDescription("Size of HTTP response bodies in bytes"),
 		metric.WithUnit("By"),
 	)
@@ -86,9 +129,30 @@ func NewMetrics(cfg MetricsConfig) (*Metrics, error) {
 		return nil, err
 	}
 
+	// The pre-semconv size histograms are still recorded so dashboards
+	// built on them keep working while they move to the *.body.size names.
+	legacyReqSize, err := meter.Int64Histogram(
+		"http.server.request.size",
+		metric.WithDescription("Deprecated: use http.server.request.body.size"),
+		metric.WithUnit("By"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	legacyRespSize, err := meter.Int64Histogram(
+		"http.server.response.size",
+		metric.WithDescription("Deprecated: use http.server.response.body.size"),
+		metric.WithUnit("By"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	activeRequests, err := meter.Int64UpDownCounter(
 		"http.server.active_requests",
 		metric.WithDescription("Number of active HTTP requests"),
+		metric.WithUnit("{request}"),
 	)
 	if err != nil {
 		return nil, err
@@ -110,14 +174,36 @@ func NewMetrics(cfg MetricsConfig) (*Metrics, error) {
 		return nil, err
 	}
 
+	statusClass, err := meter.Int64Counter(
+		"http.server.response.status_class",
+		metric.WithDescription("HTTP responses by route, method, and status class"),
+		metric.WithUnit("{response}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	skipPaths := make(map[string]bool)
+	for _, path := range cfg.SkipPaths {
+		skipPaths[path] = true
+	}
+
 	return &Metrics{
 		serviceName:     cfg.serviceName,
+		routeTagger:     cfg.RouteTagger,
+		skipPaths:       skipPaths,
+		skipFunc:        cfg.SkipFunc,
+		routeSLO:        cfg.RouteSLO,
+		noExemplars:     cfg.DisableExemplars,
 		requestDuration: requestDuration,
 		requestSize:     requestSize,
 		responseSize:    responseSize,
+		legacyReqSize:   legacyReqSize,
+		legacyRespSize:  legacyRespSize,
 		activeRequests:  activeRequests,
 		requestTotal:    requestTotal,
 		responseStatus:  responseStatus,
+		statusClass:     statusClass,
 	}, nil
 }
 
@@ -125,34 +211,71 @@ func NewMetrics(cfg MetricsConfig) (*Metrics, error) {
 //
 // Metrics recorded:
 //   - http.server.request.duration: Request latency histogram
-//   - http.server.request.size: Request body size histogram
-//   - http.server.response.size: Response body size histogram
+//   - http.server.request.body.size: Request body size histogram, counting
+//     the bytes the handler read, or Content-Length if it read less
+//   - http.server.response.body.size: Response body size histogram, counting
+//     the bytes written by the handler
+//   - http.server.request.size, http.server.response.size: Deprecated
+//     copies of the body size histograms under their former names, kept
+//     for existing dashboards and to be removed in a future release
 //   - http.server.active_requests: In-flight request gauge
 //   - http.server.request.total: Total request counter
 //   - http.server.response.status: Status code distribution
+//   - http.server.response.status_class: Responses by route, method, and
+//     status class (http.response.status_class: "2xx", "4xx", "5xx", ...),
+//     for error-rate and SLO queries
+//
+// Requests to routes with an objective in MetricsConfig.RouteSLO are counted
+// in http.server.request.total with slo.met set to whether they completed
+// within it.
+//
+// Requests are labeled with the route template (http.route), never the raw
+// path, to keep cardinality bounded. See RouteTagger and UnknownRoute.
+// Requests matching MetricsConfig.SkipPaths or SkipFunc are not recorded.
 //
 // Example:
 //
 //	metrics, _ := httpserver.NewMetrics(httpserver.DefaultMetricsConfig())
 //	handler := metrics.Middleware()(myHandler)
+//
+// Example (keep probes and debug routes out of the metrics):
+//
+//	cfg := httpserver.DefaultMetricsConfig()
+//	cfg.SkipPaths = []string{"/livez", "/readyz", "/metrics"}
+//	cfg.SkipFunc = func(r *http.Request) bool {
+//	    return strings.HasPrefix(r.URL.Path, "/debug/")
+//	}
 func (m *Metrics) Middleware() Middleware {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// Skip metrics for certain paths
+			if m.skipPaths[r.URL.Path] || (m.skipFunc != nil && m.skipFunc(r)) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
 			start := time.Now()
 
-			// Track active requests
+			r, slot := withRouteSlot(r)
+			route := resolveRoute(r, m.routeTagger)
 			attrs := []attribute.KeyValue{
 				attribute.String("service.name", m.serviceName),
 				attribute.String("http.request.method", r.Method),
-				attribute.String("url.path", r.URL.Path),
+				attribute.String("http.route", route),
 			}
 
-			m.activeRequests.Add(r.Context(), 1, metric.WithAttributes(attrs...))
-			defer m.activeRequests.Add(r.Context(), -1, metric.WithAttributes(attrs...))
+			// Track active requests. The decrement is deferred so a panic
+			// recovered further up the chain does not leak the gauge.
+			activeAttrs := metric.WithAttributes(attrs...)
+			m.activeRequests.Add(r.Context(), 1, activeAttrs)
+			defer m.activeRequests.Add(r.Context(), -1, activeAttrs)
 
-			// Record request size
-			if r.ContentLength > 0 {
-				m.requestSize.Record(r.Context(), r.ContentLength, metric.WithAttributes(attrs...))
+			// Count request body bytes as the handler reads them, so
+			// chunked bodies without a Content-Length are measured too
+			var body *countingReader
+			if r.Body != nil && r.Body != http.NoBody {
+				body = &countingReader{ReadCloser: r.Body}
+				r.Body = body
 			}
 
 			// Wrap response writer
@@ -161,19 +284,143 @@ func (m *Metrics) Middleware() Middleware {
 			// Process request
 			next.ServeHTTP(wrapped, r)
 
+			// Relabel by the route the handler set, if it was unknown before.
+			// The active request gauge keeps its labels so it balances out.
+			if late := slot.resolve(route); late != route {
+				route = late
+				attrs = []attribute.KeyValue{
+					attribute.String("service.name", m.serviceName),
+					attribute.String("http.request.method", r.Method),
+					attribute.String("http.route", route),
+				}
+			}
+
 			// Record metrics
-			duration := time.Since(start).Seconds()
-			status := wrapped.Status()
-			respSize := int64(wrapped.BytesWritten())
-
-			allAttrs := make([]attribute.KeyValue, len(attrs)+1)
-			copy(allAttrs, attrs)
-			allAttrs[len(attrs)] = attribute.Int("http.response.status_code", status)
-
-			m.requestDuration.Record(r.Context(), duration, metric.WithAttributes(allAttrs...))
-			m.responseSize.Record(r.Context(), respSize, metric.WithAttributes(allAttrs...))
-			m.requestTotal.Add(r.Context(), 1, metric.WithAttributes(allAttrs...))
-			m.responseStatus.Add(r.Context(), 1, metric.WithAttributes(allAttrs...))
+			requestSize := r.ContentLength
+			if body != nil {
+				requestSize = max(requestSize, body.n)
+			}
+			m.recordRequestSize(r.Context(), attrs, requestSize)
+			m.recordResponse(r.Context(), attrs, route, start,
+				wrapped.Status(), int64(wrapped.BytesWritten()))
 		})
 	}
 }
+
+// TrackRequest records metrics for a request served outside net/http, such
+// as by a fasthttp-based framework.
+//
+// It increments http.server.active_requests and returns a function that must
+// be called exactly once when the request completes. The returned function
+// decrements the active gauge and records the request size, duration,
+// response size, and status metrics under the given route.
+//
+// Because the route is often not known until the handler has run, the
+// active request gauge is labeled by method only.
+//
+// Example:
+//
+//	done := metrics.TrackRequest(ctx, method, requestSize)
+//	defer func() { done(route, status, responseSize) }()
+func (m *Metrics) TrackRequest(
+	ctx context.Context,
+	method string,
+	requestSize int64,
+) func(route string, status int, responseSize int64) {
+	start := time.Now()
+
+	activeAttrs := metric.WithAttributes(
+		attribute.String("service.name", m.serviceName),
+		attribute.String("http.request.method", method),
+	)
+	m.activeRequests.Add(ctx, 1, activeAttrs)
+
+	return func(route string, status int, responseSize int64) {
+		m.activeRequests.Add(ctx, -1, activeAttrs)
+
+		if route == "" {
+			route = UnknownRoute
+		}
+		attrs := []attribute.KeyValue{
+			attribute.String("service.name", m.serviceName),
+			attribute.String("http.request.method", method),
+			attribute.String("http.route", route),
+		}
+
+		m.recordRequestSize(ctx, attrs, requestSize)
+		m.recordResponse(ctx, attrs, route, start, status, responseSize)
+	}
+}
+
+// recordRequestSize records the request body size, if the request had one.
+func (m *Metrics) recordRequestSize(
+	ctx context.Context,
+	attrs []attribute.KeyValue,
+	requestSize int64,
+) {
+	if requestSize <= 0 {
+		return
+	}
+	m.requestSize.Record(ctx, requestSize, metric.WithAttributes(attrs...))
+	m.legacyReqSize.Record(ctx, requestSize, metric.WithAttributes(attrs...))
+}
+
+// recordResponse records the per-response metrics for a completed request.
+func (m *Metrics) recordResponse(
+	ctx context.Context,
+	attrs []attribute.KeyValue,
+	route string,
+	start time.Time,
+	status int,
+	responseSize int64,
+) {
+	elapsed := time.Since(start)
+
+	allAttrs := make([]attribute.KeyValue, len(attrs)+1, len(attrs)+2)
+	copy(allAttrs, attrs)
+	allAttrs[len(attrs)] = attribute.Int("http.response.status_code", status)
+
+	// The SDK samples exemplars from the span in ctx, so hide it when
+	// exemplars are disabled
+	durationCtx := ctx
+	if m.noExemplars {
+		durationCtx = trace.ContextWithSpanContext(ctx, trace.SpanContext{})
+	}
+	m.requestDuration.Record(durationCtx, elapsed.Seconds(), metric.WithAttributes(allAttrs...))
+	m.responseSize.Record(ctx, responseSize, metric.WithAttributes(allAttrs...))
+	m.legacyRespSize.Record(ctx, responseSize, metric.WithAttributes(allAttrs...))
+
+	totalAttrs := allAttrs
+	if objective, ok := m.routeSLO[route]; ok {
+		totalAttrs = append(totalAttrs, attribute.Bool("slo.met", elapsed <= objective))
+	}
+	m.requestTotal.Add(ctx, 1, metric.WithAttributes(totalAttrs...))
+	m.responseStatus.Add(ctx, 1, metric.WithAttributes(allAttrs...))
+
+	// The class counter is keyed by class rather than exact code so error
+	// budgets can be queried directly, e.g. 5xx / total per route.
+	classAttrs := make([]attribute.KeyValue, len(attrs)+1)
+	copy(classAttrs, attrs)
+	classAttrs[len(attrs)] = attribute.String("http.response.status_class", statusClass(status))
+	m.statusClass.Add(ctx, 1, metric.WithAttributes(classAttrs...))
+}
+
+// statusClass returns the class of an HTTP status code, e.g. "2xx" or "5xx".
+func statusClass(status int) string {
+	if status < 100 || status > 599 {
+		return "unknown"
+	}
+	return strconv.Itoa(status/100) + "xx"
+}
+
+// countingReader counts the bytes read from a request body.
+type countingReader struct {
+	io.ReadCloser
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.ReadCloser.Read(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,196 @@
+package httpserver
+
+import (
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// AccessLogFormat selects the layout of access log entries.
+type AccessLogFormat string
+
+const (
+	// AccessLogJSON logs each request as a structured zerolog event.
+	AccessLogJSON AccessLogFormat = "json"
+
+	// AccessLogCommon logs each request in the Apache Common Log Format:
+	//
+	//	127.0.0.1 - frank [10/Oct/2000:13:55:36 -0700] "GET /a.gif HTTP/1.0" 200 2326
+	AccessLogCommon AccessLogFormat = "common"
+
+	// AccessLogCombined logs each request in the Apache Combined Log Format,
+	// which adds the referer and user agent to AccessLogCommon.
+	AccessLogCombined AccessLogFormat = "combined"
+)
+
+// accessLogTimeFormat is the timestamp layout of the Common Log Format.
+const accessLogTimeFormat = "02/Jan/2006:15:04:05 -0700"
+
+// AccessLogConfig configures the access log middleware.
+type AccessLogConfig struct {
+	// Format selects the log layout.
+	// Default: AccessLogJSON
+	Format AccessLogFormat
+
+	// Logger receives the access log entries.
+	// Default: the zero zerolog.Logger, which discards every entry
+	Logger zerolog.Logger
+
+	// SkipPaths are paths that should not be logged.
+	// Useful for health check and metrics endpoints.
+	SkipPaths []string
+
+	// SampleRate is the fraction of requests to log, between 0 and 1.
+	// Server errors (5xx) are always logged.
+	// Default: 0 (log every request)
+	SampleRate float64
+}
+
+// AccessLog returns middleware that writes an access log entry for every
+// request.
+//
+// Unlike Logger, which is meant for debugging and can capture bodies,
+// AccessLog produces one compact line per request in a fixed format.
+//
+// The JSON format includes:
+//   - Method, path, status code, response bytes
+//   - Request duration
+//   - Request ID (if present)
+//   - Client IP (first X-Forwarded-For entry, else the remote address)
+//   - Trace ID (if the request has an active span)
+//
+// The Common and Combined formats use the Apache layouts as the message so
+// existing tooling can parse them, with the duration and request ID added as
+// fields.
+//
+// Example:
+//
+//	handler := httpserver.AccessLog(httpserver.AccessLogConfig{
+//	    Format:     httpserver.AccessLogJSON,
+//	    Logger:     logger,
+//	    SkipPaths:  []string{"/livez", "/readyz", "/metrics"},
+//	    SampleRate: 0.1, // log 10% of successful requests
+//	})(myHandler)
+func AccessLog(cfg AccessLogConfig) Middleware {
+	if cfg.Format == "" {
+		cfg.Format = AccessLogJSON
+	}
+
+	skipPaths := make(map[string]bool)
+	for _, path := range cfg.SkipPaths {
+		skipPaths[path] = true
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if skipPaths[r.URL.Path] {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			start := time.Now()
+			wrapped := wrapResponseWriter(w)
+
+			next.ServeHTTP(wrapped, r)
+
+			status := wrapped.Status()
+			if cfg.SampleRate > 0 && cfg.SampleRate < 1 && status < 500 &&
+				rand.Float64() >= cfg.SampleRate {
+				return
+			}
+
+			duration := time.Since(start)
+			requestID := RequestIDFromContext(r.Context())
+
+			switch cfg.Format {
+			case AccessLogCommon, AccessLogCombined:
+				event := cfg.Logger.Log().Dur("duration", duration)
+				if requestID != "" {
+					event.Str("request_id", requestID)
+				}
+				event.Msg(accessLogLine(cfg.Format, r, start, status, wrapped.BytesWritten()))
+			default:
+				event := cfg.Logger.Info().
+					Str("method", r.Method).
+					Str("path", r.URL.Path).
+					Int("status", status).
+					Int("bytes", wrapped.BytesWritten()).
+					Dur("duration", duration).
+					Str("client_ip", clientIP(r)).
+					Str("user_agent", r.UserAgent())
+
+				if requestID != "" {
+					event.Str("request_id", requestID)
+				}
+				if sc := trace.SpanContextFromContext(r.Context()); sc.HasTraceID() {
+					event.Str("trace_id", sc.TraceID().String())
+				}
+
+				event.Msg("access")
+			}
+		})
+	}
+}
+
+// accessLogLine formats a request in the Common or Combined Log Format.
+func accessLogLine(
+	format AccessLogFormat,
+	r *http.Request,
+	start time.Time,
+	status, bytesWritten int,
+) string {
+	user := "-"
+	if u, _, ok := r.BasicAuth(); ok && u != "" {
+		user = u
+	}
+
+	size := "-"
+	if bytesWritten > 0 {
+		size = strconv.Itoa(bytesWritten)
+	}
+
+	var b strings.Builder
+	b.WriteString(clientIP(r))
+	b.WriteString(" - ")
+	b.WriteString(user)
+	b.WriteString(" [")
+	b.WriteString(start.Format(accessLogTimeFormat))
+	b.WriteString(`] "`)
+	b.WriteString(r.Method)
+	b.WriteByte(' ')
+	b.WriteString(r.URL.RequestURI())
+	b.WriteByte(' ')
+	b.WriteString(r.Proto)
+	b.WriteString(`" `)
+	b.WriteString(strconv.Itoa(status))
+	b.WriteByte(' ')
+	b.WriteString(size)
+
+	if format == AccessLogCombined {
+		b.WriteByte(' ')
+		b.WriteString(strconv.Quote(r.Referer()))
+		b.WriteByte(' ')
+		b.WriteString(strconv.Quote(r.UserAgent()))
+	}
+
+	return b.String()
+}
+
+// clientIP returns the first X-Forwarded-For address, or the host part of
+// RemoteAddr.
+func clientIP(r *http.Request) string {
+	if xff := r.Header.Get("X-Forwarded-For"); xff != "" {
+		ip, _, _ := strings.Cut(xff, ",")
+		return strings.TrimSpace(ip)
+	}
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return host
+	}
+	return r.RemoteAddr
+}
@@ -2,8 +2,10 @@ package httpserver
 
 import (
 	"context"
+	"crypto/subtle"
 	"database/sql"
 	"errors"
+	"fmt"
 	"net/http"
 )
 
@@ -147,7 +149,7 @@ func ServiceAuth(cfg ServiceAuthConfig) Middleware {
 			passkey := r.Header.Get(cfg.PassKeyHeader)
 
 			if err := cfg.Validator.Validate(r.Context(), clientID, passkey); err != nil {
-				WriteError(w, http.StatusUnauthorized, "unauthorized",
+				WriteCodedError(w, r, http.StatusUnauthorized, "unauthorized", "unauthorized",
 					Error{Field: "auth", Message: "invalid credentials"})
 				return
 			}
@@ -170,3 +172,76 @@ func ClientIDFromContext(ctx context.Context) string {
 	}
 	return ""
 }
+
+// BasicAuthConfig configures the HTTP Basic Authentication middleware.
+type BasicAuthConfig struct {
+	// Validator checks if the username and password are valid.
+	// Compare secrets with subtle.ConstantTimeCompare to avoid timing attacks,
+	// or use NewStaticBasicAuthValidator.
+	Validator func(user, pass string) bool
+
+	// Realm is sent in the WWW-Authenticate challenge.
+	// Default: "Restricted"
+	Realm string
+}
+
+// NewStaticBasicAuthValidator creates a basic auth validator from a map of
+// username -> password.
+//
+// Every entry is compared in constant time, so response timing does not
+// reveal whether a username exists or how much of a password matched.
+// Intended for a handful of static credentials, such as internal admin UIs.
+//
+// Example:
+//
+//	validator := httpserver.NewStaticBasicAuthValidator(map[string]string{
+//	    os.Getenv("ADMIN_USER"): os.Getenv("ADMIN_PASSWORD"),
+//	})
+func NewStaticBasicAuthValidator(users map[string]string) func(user, pass string) bool {
+	return func(user, pass string) bool {
+		matched := 0
+		for u, p := range users {
+			userMatch := subtle.ConstantTimeCompare([]byte(user), []byte(u))
+			passMatch := subtle.ConstantTimeCompare([]byte(pass), []byte(p))
+			matched |= userMatch & passMatch
+		}
+		return matched == 1
+	}
+}
+
+// BasicAuth returns middleware that requires HTTP Basic Authentication.
+//
+// Requests without an "Authorization: Basic" header, or whose credentials
+// are rejected by the validator, receive 401 Unauthorized with a
+// WWW-Authenticate challenge so browsers prompt for credentials.
+//
+// Basic auth sends credentials on every request; only serve it over TLS.
+//
+// Example:
+//
+//	mux.Handle("/admin/", httpserver.BasicAuth(httpserver.BasicAuthConfig{
+//	    Validator: httpserver.NewStaticBasicAuthValidator(map[string]string{
+//	        "admin": os.Getenv("ADMIN_PASSWORD"),
+//	    }),
+//	    Realm: "admin",
+//	})(adminHandler))
+func BasicAuth(cfg BasicAuthConfig) Middleware {
+	if cfg.Realm == "" {
+		cfg.Realm = "Restricted"
+	}
+	challenge := fmt.Sprintf("Basic realm=%q, charset=\"UTF-8\"", cfg.Realm)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user, pass, ok := r.BasicAuth()
+			if !ok || cfg.Validator == nil || !cfg.Validator(user, pass) {
+				w.Header().Set("WWW-Authenticate", challenge)
+				WriteCodedError(w, r, http.StatusUnauthorized, "unauthorized", "unauthorized",
+					Error{Field: "auth", Message: "invalid credentials"})
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
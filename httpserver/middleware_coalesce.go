@@ -0,0 +1,255 @@
+package httpserver
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"golang.org/x/sync/singleflight"
+)
+
+// errCoalesceLeaderPanicked is returned to requests waiting on a handler
+// that panicked, so they run the handler themselves.
+var errCoalesceLeaderPanicked = errors.New("httpserver: coalesced handler panicked")
+
+// coalescePrivateHeaders are response headers specific to the first
+// request, which are not replayed to the requests sharing its response.
+var coalescePrivateHeaders = map[string]bool{
+	"Set-Cookie":    true,
+	"X-Request-Id":  true,
+	"Server-Timing": true,
+	"Traceparent":   true,
+	"Tracestate":    true,
+}
+
+// CoalesceConfig configures the request coalescing middleware.
+type CoalesceConfig struct {
+	// KeyFunc groups requests that may share a response. The request
+	// method is always part of the key. Requests for which it returns ""
+	// are not coalesced.
+	// Default: host and request URI (path and query)
+	KeyFunc KeyFunc
+
+	// MaxBodySize is the largest response body that is buffered and shared.
+	// Requests waiting on a larger response run the handler themselves.
+	// Default: 1 MiB
+	MaxBodySize int
+
+	// MeterProvider is the OTel meter provider for the
+	// http.server.coalesce counter. If nil, uses otel.GetMeterProvider().
+	MeterProvider metric.MeterProvider
+}
+
+// DefaultCoalesceConfig returns the default coalescing configuration.
+func DefaultCoalesceConfig() CoalesceConfig {
+	return CoalesceConfig{
+		KeyFunc:     KeyFuncByHostAndURI(),
+		MaxBodySize: 1 << 20,
+	}
+}
+
+// KeyFuncByHostAndURI returns a KeyFunc that uses the host and the request
+// URI, i.e. the path and query string.
+func KeyFuncByHostAndURI() KeyFunc {
+	return func(r *http.Request) string {
+		return r.Host + r.URL.RequestURI()
+	}
+}
+
+// Coalesce returns middleware that runs the handler once for identical
+// concurrent GET and HEAD requests and shares the response among them.
+//
+// Requests with the same key that arrive while the first one is in flight
+// wait for it and receive a copy of its status, headers and body. This
+// protects expensive read handlers from cache stampedes. Other methods are
+// never coalesced. Each shared request increments the
+// http.server.coalesce counter.
+//
+// Headers tied to the first request (Set-Cookie, X-Request-ID,
+// Server-Timing and trace context) are not copied to the others. If the
+// handler panics, the panic propagates in the first request as usual and
+// the waiting requests run the handler themselves.
+//
+// The key must capture everything the response depends on. The default
+// ignores headers, so include the caller's identity in the key for
+// per-user responses, or they will be served to other users.
+//
+// Example:
+//
+//	mux.Handle("/api/catalog", httpserver.Coalesce(nil)(catalogHandler))
+//
+// Example (per tenant):
+//
+//	coalesce := httpserver.Coalesce(func(r *http.Request) string {
+//	    return r.Header.Get("X-Tenant-ID") + ":" + r.URL.RequestURI()
+//	})
+func Coalesce(keyFunc KeyFunc) Middleware {
+	cfg := DefaultCoalesceConfig()
+	if keyFunc != nil {
+		cfg.KeyFunc = keyFunc
+	}
+	return CoalesceWithConfig(cfg)
+}
+
+// CoalesceWithConfig returns request coalescing middleware with custom
+// configuration.
+//
+// Example:
+//
+//	handler := httpserver.CoalesceWithConfig(httpserver.CoalesceConfig{
+//	    MaxBodySize: 256 << 10,
+//	})(catalogHandler)
+func CoalesceWithConfig(cfg CoalesceConfig) Middleware {
+	defaults := DefaultCoalesceConfig()
+	if cfg.KeyFunc == nil {
+		cfg.KeyFunc = defaults.KeyFunc
+	}
+	if cfg.MaxBodySize <= 0 {
+		cfg.MaxBodySize = defaults.MaxBodySize
+	}
+	if cfg.MeterProvider == nil {
+		cfg.MeterProvider = otel.GetMeterProvider()
+	}
+
+	meter := cfg.MeterProvider.Meter(
+		"github.com/kroma-labs/sentinel-go/httpserver",
+		metric.WithInstrumentationVersion("1.0.0"),
+	)
+	hits, _ := meter.Int64Counter(
+		"http.server.coalesce",
+		metric.WithDescription("Requests served the response of an identical in-flight request"),
+		metric.WithUnit("{request}"),
+	)
+
+	var group singleflight.Group
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodGet && r.Method != http.MethodHead {
+				next.ServeHTTP(w, r)
+				return
+			}
+			key := cfg.KeyFunc(r)
+			if key == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			executed := false
+			var panicked any
+			v, err, _ := group.Do(r.Method+" "+key, func() (_ any, err error) {
+				executed = true
+				// singleflight re-panics in a new goroutine when a panic
+				// has waiters, which no recovery middleware can catch
+				defer func() {
+					if p := recover(); p != nil {
+						panicked = p
+						err = errCoalesceLeaderPanicked
+					}
+				}()
+
+				rec := &coalesceRecorder{
+					ResponseWriter: w,
+					status:         http.StatusOK,
+					maxBodySize:    cfg.MaxBodySize,
+				}
+				next.ServeHTTP(rec, r)
+				if !rec.wroteHeader {
+					rec.header = w.Header().Clone()
+				}
+				return rec, nil
+			})
+			if executed {
+				if panicked != nil {
+					panic(panicked)
+				}
+				return
+			}
+			if err != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			rec := v.(*coalesceRecorder)
+			if rec.overflow {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if hits != nil {
+				hits.Add(r.Context(), 1, metric.WithAttributes(
+					attribute.String("http.request.method", r.Method),
+					attribute.String("http.route", resolveRoute(r, nil)),
+				))
+			}
+			rec.replay(w)
+		})
+	}
+}
+
+// coalesceRecorder writes the response to the first caller while keeping a
+// copy for the requests waiting on it.
+type coalesceRecorder struct {
+	http.ResponseWriter
+	header      http.Header
+	status      int
+	body        bytes.Buffer
+	maxBodySize int
+	overflow    bool
+	wroteHeader bool
+}
+
+// WriteHeader snapshots the headers and status for replay.
+func (c *coalesceRecorder) WriteHeader(code int) {
+	if c.wroteHeader {
+		return
+	}
+	c.wroteHeader = true
+	c.status = code
+	c.header = c.ResponseWriter.Header().Clone()
+	c.ResponseWriter.WriteHeader(code)
+}
+
+// Write writes b to the first caller and buffers it until the body exceeds
+// maxBodySize, after which the response is no longer shared.
+func (c *coalesceRecorder) Write(b []byte) (int, error) {
+	if !c.wroteHeader {
+		c.WriteHeader(http.StatusOK)
+	}
+	if !c.overflow {
+		if c.body.Len()+len(b) > c.maxBodySize {
+			c.overflow = true
+			c.body = bytes.Buffer{}
+		} else {
+			c.body.Write(b)
+		}
+	}
+	return c.ResponseWriter.Write(b)
+}
+
+// Unwrap returns the underlying ResponseWriter.
+func (c *coalesceRecorder) Unwrap() http.ResponseWriter {
+	return c.ResponseWriter
+}
+
+// Flush implements http.Flusher.
+func (c *coalesceRecorder) Flush() {
+	if f, ok := c.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// replay writes the recorded response to w, without the headers private to
+// the first request.
+func (c *coalesceRecorder) replay(w http.ResponseWriter) {
+	for k, v := range c.header {
+		if coalescePrivateHeaders[http.CanonicalHeaderKey(k)] {
+			continue
+		}
+		w.Header()[k] = append([]string(nil), v...)
+	}
+	w.WriteHeader(c.status)
+	_, _ = w.Write(c.body.Bytes())
+}
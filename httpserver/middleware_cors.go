@@ -12,6 +12,11 @@ type CORSConfig struct {
 	// Use "*" to allow all origins (not recommended for production).
 	AllowedOrigins []string
 
+	// AllowOriginFunc reports whether an origin not listed in
+	// AllowedOrigins is allowed, for patterns a fixed list can't express
+	// such as any subdomain of a domain. Optional.
+	AllowOriginFunc func(origin string) bool
+
 	// AllowedMethods is a list of HTTP methods allowed.
 	// Default: GET, POST, PUT, DELETE, OPTIONS, HEAD, PATCH
 	AllowedMethods []string
@@ -26,7 +31,9 @@ type CORSConfig struct {
 	// are allowed in cross-origin requests.
 	AllowCredentials bool
 
-	// MaxAge is the maximum age (in seconds) of the preflight cache.
+	// MaxAge is how long (in seconds) browsers may cache a preflight
+	// response, sent as Access-Control-Max-Age. Zero omits the header, so
+	// browsers fall back to their own short default.
 	// Default: 86400 (24 hours)
 	MaxAge int
 }
@@ -64,12 +71,26 @@ func DefaultCORSConfig() CORSConfig {
 
 // CORS returns middleware that handles Cross-Origin Resource Sharing.
 //
+// OPTIONS requests are answered as preflights with 204 No Content, without
+// calling the wrapped handler; the response headers are computed once when
+// the middleware is created. Since the response depends on the request's
+// Origin, every response varies on it.
+//
 // Example:
 //
 //	handler := httpserver.CORS(httpserver.CORSConfig{
 //	    AllowedOrigins:   []string{"https://example.com"},
 //	    AllowCredentials: true,
 //	})(myHandler)
+//
+// Example - Allow any subdomain:
+//
+//	handler := httpserver.CORS(httpserver.CORSConfig{
+//	    AllowOriginFunc: func(origin string) bool {
+//	        return strings.HasSuffix(origin, ".example.com")
+//	    },
+//	    MaxAge: 3600,
+//	})(myHandler)
 func CORS(cfg CORSConfig) Middleware {
 	// Build origin lookup map
 	allowAllOrigins := false
@@ -90,12 +111,12 @@ func CORS(cfg CORSConfig) Middleware {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			origin := r.Header.Get("Origin")
+			w.Header().Add("Vary", "Origin")
 
 			// Check if origin is allowed
 			if origin != "" {
-				if allowAllOrigins {
-					w.Header().Set("Access-Control-Allow-Origin", origin)
-				} else if origins[origin] {
+				if allowAllOrigins || origins[origin] ||
+					(cfg.AllowOriginFunc != nil && cfg.AllowOriginFunc(origin)) {
 					w.Header().Set("Access-Control-Allow-Origin", origin)
 				}
 			}
@@ -113,7 +134,9 @@ func CORS(cfg CORSConfig) Middleware {
 			if r.Method == http.MethodOptions {
 				w.Header().Set("Access-Control-Allow-Methods", allowMethods)
 				w.Header().Set("Access-Control-Allow-Headers", allowHeaders)
-				w.Header().Set("Access-Control-Max-Age", maxAge)
+				if cfg.MaxAge > 0 {
+					w.Header().Set("Access-Control-Max-Age", maxAge)
+				}
 				w.WriteHeader(http.StatusNoContent)
 				return
 			}
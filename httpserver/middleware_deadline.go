@@ -0,0 +1,312 @@
+package httpserver
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Deadline propagation headers.
+const (
+	// RequestTimeoutHeader carries the caller's remaining time budget as a
+	// Go duration, e.g. "1.5s" or "250ms".
+	RequestTimeoutHeader = "X-Request-Timeout"
+
+	// GRPCTimeoutHeader carries the caller's remaining time budget in the
+	// gRPC format, e.g. "250m" for 250 milliseconds.
+	GRPCTimeoutHeader = "grpc-timeout"
+)
+
+// errInvalidTimeout is returned when a timeout header can't be parsed.
+var errInvalidTimeout = errors.New("invalid timeout")
+
+// DeadlinePropagationConfig configures the deadline propagation middleware.
+type DeadlinePropagationConfig struct {
+	// Header is the request header carrying the caller's timeout.
+	// Default: "X-Request-Timeout"
+	Header string
+
+	// MaxTimeout caps the accepted timeout, so callers can't hold server
+	// resources for longer than the server allows. Requests without the
+	// header get no deadline from this middleware.
+	// Default: 30s
+	MaxTimeout time.Duration
+
+	// Parse converts the header value into a timeout.
+	// Default: ParseGRPCTimeout for the "grpc-timeout" header,
+	// time.ParseDuration otherwise
+	Parse func(value string) (time.Duration, error)
+}
+
+// DefaultDeadlinePropagationConfig returns the default deadline propagation
+// configuration.
+func DefaultDeadlinePropagationConfig() DeadlinePropagationConfig {
+	return DeadlinePropagationConfig{
+		Header:     RequestTimeoutHeader,
+		MaxTimeout: 30 * time.Second,
+	}
+}
+
+// DeadlinePropagation returns middleware that honors the deadline sent by
+// the caller in the given header.
+//
+// The timeout in the header, capped at 30s, becomes the deadline of the
+// request context. If the deadline passes before the handler responds, or
+// the caller's budget is already spent, a 504 Gateway Timeout is returned.
+// Missing or unparsable headers are ignored.
+//
+// Outgoing calls made with the request context inherit the deadline, and
+// the httpclient per-request Timeout can only shorten it, so a budget set
+// at the edge holds across every hop.
+//
+// Note: The handler must respect context cancellation for this to work
+// effectively.
+//
+// Example:
+//
+//	handler := httpserver.DeadlinePropagation(httpserver.GRPCTimeoutHeader)(myHandler)
+func DeadlinePropagation(header string) Middleware {
+	cfg := DefaultDeadlinePropagationConfig()
+	cfg.Header = header
+	return DeadlinePropagationWithConfig(cfg)
+}
+
+// DeadlinePropagationWithConfig returns deadline propagation middleware with
+// custom configuration.
+//
+// Example:
+//
+//	handler := httpserver.DeadlinePropagationWithConfig(httpserver.DeadlinePropagationConfig{
+//	    Header:     httpserver.RequestTimeoutHeader,
+//	    MaxTimeout: 5 * time.Second,
+//	})(myHandler)
+func DeadlinePropagationWithConfig(cfg DeadlinePropagationConfig) Middleware {
+	defaults := DefaultDeadlinePropagationConfig()
+	if cfg.Header == "" {
+		cfg.Header = defaults.Header
+	}
+	if cfg.MaxTimeout <= 0 {
+		cfg.MaxTimeout = defaults.MaxTimeout
+	}
+	if cfg.Parse == nil {
+		cfg.Parse = time.ParseDuration
+		if strings.EqualFold(cfg.Header, GRPCTimeoutHeader) {
+			cfg.Parse = ParseGRPCTimeout
+		}
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			value := r.Header.Get(cfg.Header)
+			if value == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			timeout, err := cfg.Parse(value)
+			if err != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if timeout <= 0 {
+				writeDeadlineExceeded(w, r)
+				return
+			}
+			timeout = min(timeout, cfg.MaxTimeout)
+
+			ctx, cancel := context.WithTimeout(r.Context(), timeout)
+			defer cancel()
+
+			serveWithDeadline(ctx, w, r, next)
+		})
+	}
+}
+
+// writeDeadlineExceeded writes the 504 response for a request whose
+// deadline has passed.
+func writeDeadlineExceeded(w http.ResponseWriter, r *http.Request) {
+	WriteCodedError(w, r, http.StatusGatewayTimeout,
+		"deadline_exceeded", "deadline exceeded",
+		Error{Field: "server", Message: "request deadline exceeded"},
+	)
+}
+
+// serveWithDeadline runs next in its own goroutine with ctx, so the 504 can
+// be sent as soon as the deadline passes. If the handler has not written its
+// response by then, its later writes are discarded.
+//
+// A panic in next is re-raised on the calling goroutine, where Recovery can
+// handle it, instead of crashing the process.
+func serveWithDeadline(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	next http.Handler,
+) {
+	done := make(chan struct{})
+	panicked := make(chan any, 1)
+
+	// Wrap response writer to prevent writes after the deadline
+	wrapped := &deadlineWriter{
+		ResponseWriter: w,
+		req:            r,
+		header:         w.Header().Clone(),
+	}
+
+	// Run handler in goroutine
+	go func() {
+		defer func() {
+			if p := recover(); p != nil {
+				panicked <- p
+			}
+		}()
+		next.ServeHTTP(wrapped, r.WithContext(ctx))
+		wrapped.complete(ctx)
+		close(done)
+	}()
+
+	// Wait for completion, panic or deadline
+	select {
+	case <-done:
+		// Handler completed normally
+	case p := <-panicked:
+		panic(p)
+	case <-ctx.Done():
+		wrapped.deadlineExceeded()
+	}
+}
+
+// deadlineWriter prevents writes after the deadline. The handler writes its
+// headers to a copy, so they can't race with the 504 response; they are
+// copied to the underlying writer when the handler writes its header.
+type deadlineWriter struct {
+	http.ResponseWriter
+	req    *http.Request
+	header http.Header
+
+	mu       sync.Mutex
+	timedOut bool
+	wrote    bool
+}
+
+func (dw *deadlineWriter) Header() http.Header {
+	return dw.header
+}
+
+func (dw *deadlineWriter) WriteHeader(code int) {
+	dw.mu.Lock()
+	defer dw.mu.Unlock()
+
+	dw.writeHeaderLocked(code)
+}
+
+func (dw *deadlineWriter) Write(b []byte) (int, error) {
+	dw.mu.Lock()
+	defer dw.mu.Unlock()
+
+	if dw.timedOut {
+		return 0, context.DeadlineExceeded
+	}
+	dw.writeHeaderLocked(http.StatusOK)
+	return dw.ResponseWriter.Write(b)
+}
+
+// writeHeaderLocked sends the handler's headers and status once. dw.mu must
+// be held.
+func (dw *deadlineWriter) writeHeaderLocked(code int) {
+	if dw.timedOut || dw.wrote {
+		return
+	}
+	dw.wrote = true
+
+	dst := dw.ResponseWriter.Header()
+	for key := range dst {
+		if _, ok := dw.header[key]; !ok {
+			delete(dst, key)
+		}
+	}
+	for key, values := range dw.header {
+		dst[key] = values
+	}
+	dw.ResponseWriter.WriteHeader(code)
+}
+
+// complete sends the handler's headers with a 200 if it returned without
+// writing before the deadline, or the 504 if the deadline has passed. Once
+// it has run, deadlineExceeded is a no-op.
+func (dw *deadlineWriter) complete(ctx context.Context) {
+	dw.mu.Lock()
+	defer dw.mu.Unlock()
+
+	if ctx.Err() != nil {
+		dw.timeoutLocked()
+		return
+	}
+	dw.writeHeaderLocked(http.StatusOK)
+}
+
+// deadlineExceeded marks the writer as timed out and, unless the handler
+// already started its response, writes the 504.
+func (dw *deadlineWriter) deadlineExceeded() {
+	dw.mu.Lock()
+	defer dw.mu.Unlock()
+
+	dw.timeoutLocked()
+}
+
+// timeoutLocked writes the 504 at most once. dw.mu must be held.
+func (dw *deadlineWriter) timeoutLocked() {
+	if dw.timedOut {
+		return
+	}
+	dw.timedOut = true
+	if !dw.wrote {
+		writeDeadlineExceeded(dw.ResponseWriter, dw.req)
+	}
+}
+
+// ParseGRPCTimeout parses a timeout in the gRPC wire format: a positive
+// integer of at most 8 digits followed by a unit, one of H (hours),
+// M (minutes), S (seconds), m (milliseconds), u (microseconds) or
+// n (nanoseconds).
+//
+// Example:
+//
+//	d, err := httpserver.ParseGRPCTimeout("250m") // 250ms
+func ParseGRPCTimeout(value string) (time.Duration, error) {
+	if len(value) < 2 || len(value) > 9 {
+		return 0, errInvalidTimeout
+	}
+
+	var unit time.Duration
+	switch value[len(value)-1] {
+	case 'H':
+		unit = time.Hour
+	case 'M':
+		unit = time.Minute
+	case 'S':
+		unit = time.Second
+	case 'm':
+		unit = time.Millisecond
+	case 'u':
+		unit = time.Microsecond
+	case 'n':
+		unit = time.Nanosecond
+	default:
+		return 0, errInvalidTimeout
+	}
+
+	n, err := strconv.ParseUint(value[:len(value)-1], 10, 64)
+	if err != nil {
+		return 0, errInvalidTimeout
+	}
+
+	// 8 digits of hours overflows time.Duration
+	if time.Duration(n) > time.Duration(1<<63-1)/unit {
+		return 0, errInvalidTimeout
+	}
+	return time.Duration(n) * unit, nil
+}
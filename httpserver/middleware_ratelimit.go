@@ -2,7 +2,9 @@ package httpserver
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"strconv"
 	"sync"
 	"time"
 
@@ -29,6 +31,8 @@ type RateLimitConfig struct {
 
 	// Redis enables distributed rate limiting across multiple instances.
 	// If nil, an in-memory rate limiter is used (single-instance only).
+	// The Redis limiter also reports the client's quota in the
+	// X-RateLimit-Remaining and X-RateLimit-Reset response headers.
 	Redis redis.UniversalClient
 
 	// RedisKeyPrefix is the prefix for Redis keys.
@@ -73,7 +77,7 @@ func memoryRateLimiter(cfg RateLimitConfig) Middleware {
 		return func(next http.Handler) http.Handler {
 			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				if !limiter.Allow() {
-					WriteError(w, http.StatusTooManyRequests, "rate limit exceeded",
+					WriteCodedError(w, r, http.StatusTooManyRequests, "rate_limited", "rate limit exceeded",
 						Error{Field: "rate_limit", Message: "too many requests"})
 					return
 				}
@@ -104,7 +108,7 @@ func memoryRateLimiter(cfg RateLimitConfig) Middleware {
 			}
 
 			if !limiter.Allow() {
-				WriteError(w, http.StatusTooManyRequests, "rate limit exceeded",
+				WriteCodedError(w, r, http.StatusTooManyRequests, "rate_limited", "rate limit exceeded",
 					Error{Field: "rate_limit", Message: "too many requests"})
 				return
 			}
@@ -113,12 +117,24 @@ func memoryRateLimiter(cfg RateLimitConfig) Middleware {
 	}
 }
 
+// Rate limit headers set by the Redis rate limiter.
+const (
+	// RateLimitRemainingHeader is the number of requests the client can
+	// still make before being limited.
+	RateLimitRemainingHeader = "X-RateLimit-Remaining"
+
+	// RateLimitResetHeader is the Unix time, in seconds, at which the next
+	// token is added to the client's bucket.
+	RateLimitResetHeader = "X-RateLimit-Reset"
+)
+
 // tokenBucketScript is a Lua script for atomic token bucket rate limiting in Redis.
 // It implements a proper token bucket algorithm:
 // - Stores: tokens (remaining), last_update (timestamp in milliseconds)
 // - Calculates tokens to add based on elapsed time
 // - Caps tokens at burst capacity
 // - Atomically checks and decrements tokens
+// - Returns {allowed, whole tokens remaining, next refill time in milliseconds}
 var tokenBucketScript = redis.NewScript(`
 local key = KEYS[1]
 local rate = tonumber(ARGV[1])       -- tokens per second
@@ -143,68 +159,90 @@ local tokens_to_add = (elapsed_ms / 1000.0) * rate
 tokens = math.min(burst, tokens + tokens_to_add)
 
 -- Try to consume one token
+local allowed = 0
 if tokens >= 1 then
     tokens = tokens - 1
-    redis.call('HMSET', key, 'tokens', tokens, 'last_update', now)
-    redis.call('EXPIRE', key, ttl)
-    return 1  -- allowed
-else
-    -- Update timestamp even if denied (for accurate rate calculation)
-    redis.call('HMSET', key, 'tokens', tokens, 'last_update', now)
-    redis.call('EXPIRE', key, ttl)
-    return 0  -- denied
+    allowed = 1
+end
+
+-- Update timestamp even if denied (for accurate rate calculation)
+redis.call('HMSET', key, 'tokens', tokens, 'last_update', now)
+redis.call('EXPIRE', key, ttl)
+
+-- Time at which the next whole token is added (now if the bucket is full)
+local remaining = math.floor(tokens)
+local reset = now
+if tokens < burst and rate > 0 then
+    reset = now + math.ceil((remaining + 1 - tokens) / rate * 1000)
 end
+
+return {allowed, remaining, reset}
 `)
 
+// tokenBucketResult is the outcome of a tokenBucketScript run.
+type tokenBucketResult struct {
+	allowed   bool
+	remaining int64
+	reset     time.Time
+}
+
+// takeToken runs tokenBucketScript for key.
+func takeToken(
+	ctx context.Context,
+	rdb redis.UniversalClient,
+	key string,
+	rps float64,
+	burst, ttl int,
+) (tokenBucketResult, error) {
+	now := time.Now().UnixMilli()
+	vals, err := tokenBucketScript.Run(ctx, rdb, []string{key}, rps, burst, now, ttl).
+		Int64Slice()
+	if err != nil {
+		return tokenBucketResult{}, err
+	}
+	if len(vals) != 3 {
+		return tokenBucketResult{}, fmt.Errorf("unexpected token bucket result: %v", vals)
+	}
+	return tokenBucketResult{
+		allowed:   vals[0] == 1,
+		remaining: vals[1],
+		reset:     time.UnixMilli(vals[2]),
+	}, nil
+}
+
+// setRateLimitHeaders reports the client's remaining quota and next refill.
+func setRateLimitHeaders(w http.ResponseWriter, res tokenBucketResult) {
+	// Round up so clients waiting until the reset time find a token
+	reset := res.reset.Add(time.Second - time.Millisecond).Unix()
+	w.Header().Set(RateLimitRemainingHeader, strconv.FormatInt(res.remaining, 10))
+	w.Header().Set(RateLimitResetHeader, strconv.FormatInt(reset, 10))
+}
+
 // redisRateLimiter creates a distributed rate limiter using Redis with proper token bucket.
+// It sets the X-RateLimit-Remaining and X-RateLimit-Reset headers on every
+// response it decides.
 func redisRateLimiter(cfg RateLimitConfig) Middleware {
 	rps := float64(cfg.Limit) // requests per second
 	ttl := 60                 // key TTL in seconds (cleanup inactive keys)
 
-	if cfg.KeyFunc == nil {
-		// Global limiter
-		return func(next http.Handler) http.Handler {
-			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-				ctx := r.Context()
-				key := cfg.RedisKeyPrefix + "global"
-				now := time.Now().UnixMilli()
-
-				allowed, err := tokenBucketScript.Run(ctx, cfg.Redis, []string{key}, rps, cfg.Burst, now, ttl).
-					Int()
-				if err != nil {
-					// On error, fail open
-					next.ServeHTTP(w, r)
-					return
-				}
-
-				if allowed == 0 {
-					WriteError(w, http.StatusTooManyRequests, "rate limit exceeded",
-						Error{Field: "rate_limit", Message: "too many requests"})
-					return
-				}
-
-				next.ServeHTTP(w, r)
-			})
-		}
-	}
-
-	// Per-key limiter
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			ctx := r.Context()
-			key := cfg.RedisKeyPrefix + cfg.KeyFunc(r)
-			now := time.Now().UnixMilli()
+			// Global limiter unless a KeyFunc is set
+			key := cfg.RedisKeyPrefix + "global"
+			if cfg.KeyFunc != nil {
+				key = cfg.RedisKeyPrefix + cfg.KeyFunc(r)
+			}
 
-			allowed, err := tokenBucketScript.Run(ctx, cfg.Redis, []string{key}, rps, cfg.Burst, now, ttl).
-				Int()
+			res, err := takeToken(r.Context(), cfg.Redis, key, rps, cfg.Burst, ttl)
 			if err != nil {
 				// On error, fail open
 				next.ServeHTTP(w, r)
 				return
 			}
 
-			if allowed == 0 {
-				WriteError(w, http.StatusTooManyRequests, "rate limit exceeded",
+			setRateLimitHeaders(w, res)
+			if !res.allowed {
+				WriteCodedError(w, r, http.StatusTooManyRequests, "rate_limited", "rate limit exceeded",
 					Error{Field: "rate_limit", Message: "too many requests"})
 				return
 			}
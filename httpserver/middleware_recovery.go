@@ -1,12 +1,51 @@
 package httpserver
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
+	"runtime"
 	"runtime/debug"
 
 	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// RecoveryConfig configures the recovery middleware.
+type RecoveryConfig struct {
+	// Logger logs recovered panics with the captured stack.
+	Logger zerolog.Logger
+
+	// Handler writes the response after a panic is recovered.
+	// Use this to render a custom error page or JSON body.
+	// Default: 500 JSON error in the package's standard shape
+	Handler func(w http.ResponseWriter, r *http.Request, recovered any)
+
+	// StackSize bounds the number of bytes of stack captured for logs and
+	// spans. Stacks larger than this are truncated.
+	// Default: 0 (the full stack is captured)
+	StackSize int
+
+	// RecordToSpan attaches the panic value and stack to the active span as
+	// an exception event and marks the span as errored.
+	// Default: false
+	RecordToSpan bool
+
+	// RepanicAbort re-panics http.ErrAbortHandler so net/http can abort the
+	// response as intended, instead of recovering it like any other panic.
+	// Default: false
+	RepanicAbort bool
+}
+
+// DefaultRecoveryConfig returns the default recovery configuration.
+func DefaultRecoveryConfig() RecoveryConfig {
+	return RecoveryConfig{
+		Logger: zerolog.Nop(),
+	}
+}
+
 // Recovery returns middleware that recovers from panics.
 //
 // When a panic occurs:
@@ -19,30 +58,80 @@ import (
 //
 //	handler := httpserver.Recovery(logger)(myHandler)
 func Recovery(logger zerolog.Logger) Middleware {
+	cfg := DefaultRecoveryConfig()
+	cfg.Logger = logger
+	return RecoveryWithConfig(cfg)
+}
+
+// RecoveryWithConfig returns recovery middleware with custom configuration.
+//
+// Example (custom error page with span recording):
+//
+//	handler := httpserver.RecoveryWithConfig(httpserver.RecoveryConfig{
+//	    Logger: logger,
+//	    Handler: func(w http.ResponseWriter, r *http.Request, recovered any) {
+//	        w.WriteHeader(http.StatusInternalServerError)
+//	        _ = errorPage.Execute(w, nil)
+//	    },
+//	    RecordToSpan: true,
+//	})(myHandler)
+func RecoveryWithConfig(cfg RecoveryConfig) Middleware {
+	if cfg.Handler == nil {
+		cfg.Handler = defaultRecoveryHandler
+	}
+
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			defer func() {
-				if rec := recover(); rec != nil {
-					// Get stack trace
-					stack := debug.Stack()
-
-					// Log the panic
-					logger.Error().
-						Interface("panic", rec).
-						Str("method", r.Method).
-						Str("path", r.URL.Path).
-						Str("stack", string(stack)).
-						Msg("panic recovered")
-
-					// Return 500 error
-					WriteError(w, http.StatusInternalServerError,
-						"internal server error",
-						Error{Field: "server", Message: "an unexpected error occurred"},
-					)
+				rec := recover()
+				if rec == nil {
+					return
+				}
+				if err, ok := rec.(error); ok && cfg.RepanicAbort &&
+					errors.Is(err, http.ErrAbortHandler) {
+					panic(rec)
+				}
+
+				stack := captureStack(cfg.StackSize)
+
+				// Log the panic
+				cfg.Logger.Error().
+					Interface("panic", rec).
+					Str("method", r.Method).
+					Str("path", r.URL.Path).
+					Str("stack", stack).
+					Msg("panic recovered")
+
+				// Record the panic on the active span
+				if cfg.RecordToSpan {
+					span := trace.SpanFromContext(r.Context())
+					span.RecordError(fmt.Errorf("panic: %v", rec),
+						trace.WithAttributes(semconv.ExceptionStacktrace(stack)))
+					span.SetStatus(codes.Error, "panic recovered")
 				}
+
+				cfg.Handler(w, r, rec)
 			}()
 
 			next.ServeHTTP(w, r)
 		})
 	}
 }
+
+// captureStack returns the stack of the calling goroutine, truncated to size
+// bytes when size is positive.
+func captureStack(size int) string {
+	if size <= 0 {
+		return string(debug.Stack())
+	}
+	buf := make([]byte, size)
+	return string(buf[:runtime.Stack(buf, false)])
+}
+
+// defaultRecoveryHandler writes the standard 500 JSON error.
+func defaultRecoveryHandler(w http.ResponseWriter, r *http.Request, _ any) {
+	WriteCodedError(w, r, http.StatusInternalServerError,
+		"internal", "internal server error",
+		Error{Field: "server", Message: "an unexpected error occurred"},
+	)
+}
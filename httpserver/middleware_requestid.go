@@ -5,14 +5,47 @@ import (
 	"net/http"
 
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // RequestIDHeader is the header key for request IDs.
 const RequestIDHeader = "X-Request-ID"
 
+// requestIDBaggageKey is the baggage member key used when TraceBaggage is enabled.
+const requestIDBaggageKey = "request.id"
+
 // requestIDKey is the context key for request ID.
 type requestIDKey struct{}
 
+// RequestIDConfig configures the request ID middleware.
+type RequestIDConfig struct {
+	// Generator creates a new request ID when the incoming request has none.
+	// Use this to plug in ULID, UUIDv7, or any other ID format.
+	// Default: UUID v4
+	Generator func() string
+
+	// Header is the header used to read and write the request ID.
+	// Default: "X-Request-ID"
+	Header string
+
+	// TraceBaggage attaches the request ID to the active span as the
+	// "request.id" attribute and adds it to the W3C baggage of the request
+	// context, so outgoing calls made with that context propagate it.
+	//
+	// Place the middleware after Tracing so a server span exists.
+	TraceBaggage bool
+}
+
+// DefaultRequestIDConfig returns the default request ID configuration.
+func DefaultRequestIDConfig() RequestIDConfig {
+	return RequestIDConfig{
+		Generator: func() string { return uuid.New().String() },
+		Header:    RequestIDHeader,
+	}
+}
+
 // RequestID returns middleware that generates or forwards request IDs.
 //
 // Behavior:
@@ -31,25 +64,78 @@ type requestIDKey struct{}
 //	    log.Printf("Request ID: %s", id)
 //	}
 func RequestID() Middleware {
+	return RequestIDWithConfig(DefaultRequestIDConfig())
+}
+
+// RequestIDWithConfig returns request ID middleware with custom configuration.
+//
+// Example (ULID generator with trace propagation):
+//
+//	handler := httpserver.Chain(
+//	    httpserver.Tracing(httpserver.DefaultTracingConfig()),
+//	    httpserver.RequestIDWithConfig(httpserver.RequestIDConfig{
+//	        Generator:    func() string { return ulid.Make().String() },
+//	        TraceBaggage: true,
+//	    }),
+//	)(myHandler)
+func RequestIDWithConfig(cfg RequestIDConfig) Middleware {
+	if cfg.Generator == nil {
+		cfg.Generator = func() string { return uuid.New().String() }
+	}
+	if cfg.Header == "" {
+		cfg.Header = RequestIDHeader
+	}
+
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			// Get or generate request ID
-			id := r.Header.Get(RequestIDHeader)
+			id := r.Header.Get(cfg.Header)
 			if id == "" {
-				id = uuid.New().String()
+				id = cfg.Generator()
 			}
 
 			// Add to response header
-			w.Header().Set(RequestIDHeader, id)
+			w.Header().Set(cfg.Header, id)
 
 			// Add to context
-			ctx := context.WithValue(r.Context(), requestIDKey{}, id)
+			ctx := ContextWithRequestID(r.Context(), id)
+
+			if cfg.TraceBaggage {
+				ctx = withRequestIDTrace(ctx, id)
+			}
 
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
 }
 
+// withRequestIDTrace records the request ID on the current span and in baggage.
+// Invalid baggage values are skipped; the span attribute is always set.
+func withRequestIDTrace(ctx context.Context, id string) context.Context {
+	trace.SpanFromContext(ctx).SetAttributes(attribute.String("request.id", id))
+
+	member, err := baggage.NewMemberRaw(requestIDBaggageKey, id)
+	if err != nil {
+		return ctx
+	}
+
+	bag, err := baggage.FromContext(ctx).SetMember(member)
+	if err != nil {
+		return ctx
+	}
+
+	return baggage.ContextWithBaggage(ctx, bag)
+}
+
+// ContextWithRequestID returns a copy of ctx carrying the request ID.
+//
+// The RequestID middleware calls this automatically. Use it directly when
+// handling requests outside net/http (e.g. fasthttp) so that
+// RequestIDFromContext works downstream.
+func ContextWithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
 // RequestIDFromContext extracts the request ID from the context.
 //
 // Returns an empty string if no request ID is present.
@@ -0,0 +1,103 @@
+package httpserver
+
+import (
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// RequireHeadersConfig configures the required headers middleware.
+type RequireHeadersConfig struct {
+	// Headers must be present and non-empty on every request,
+	// e.g. "X-Api-Version".
+	Headers []string
+
+	// MethodHeaders lists additional headers required for specific methods,
+	// e.g. {"POST": {"Idempotency-Key"}}. Methods are matched exactly.
+	MethodHeaders map[string][]string
+
+	// ContentTypes are the media types accepted in the Content-Type header,
+	// e.g. "application/json". Parameters such as charset are ignored. If
+	// empty, Content-Type is not checked.
+	ContentTypes []string
+
+	// ContentTypeMethods are the methods ContentTypes is enforced for.
+	// Default: POST, PUT, PATCH
+	ContentTypeMethods []string
+}
+
+// RequireHeaders returns middleware that rejects requests missing required
+// headers, or sending an unsupported Content-Type, with 400 Bad Request
+// before the handler runs. The response names each offending header in its
+// errors, so handlers no longer need to re-check them.
+//
+// Example:
+//
+//	requireHeaders := httpserver.RequireHeaders(httpserver.RequireHeadersConfig{
+//	    Headers:       []string{"X-Api-Version"},
+//	    MethodHeaders: map[string][]string{http.MethodPost: {"Idempotency-Key"}},
+//	    ContentTypes:  []string{"application/json"},
+//	})
+//	mux.Handle("/api/", requireHeaders(apiHandler))
+//
+// A request without X-Api-Version receives:
+//
+//	{
+//	  "errors": [{"field": "X-Api-Version", "message": "header is required"}],
+//	  "message": "missing or invalid headers"
+//	}
+func RequireHeaders(cfg RequireHeadersConfig) Middleware {
+	if len(cfg.ContentTypeMethods) == 0 {
+		cfg.ContentTypeMethods = []string{http.MethodPost, http.MethodPut, http.MethodPatch}
+	}
+
+	contentTypes := make(map[string]bool, len(cfg.ContentTypes))
+	for _, ct := range cfg.ContentTypes {
+		contentTypes[strings.ToLower(ct)] = true
+	}
+	contentTypeMethods := make(map[string]bool, len(cfg.ContentTypeMethods))
+	for _, m := range cfg.ContentTypeMethods {
+		contentTypeMethods[m] = true
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var errs []Error
+			for _, h := range cfg.Headers {
+				if r.Header.Get(h) == "" {
+					errs = append(errs, Error{Field: h, Message: "header is required"})
+				}
+			}
+			for _, h := range cfg.MethodHeaders[r.Method] {
+				if r.Header.Get(h) == "" {
+					errs = append(errs, Error{Field: h, Message: "header is required"})
+				}
+			}
+			if len(contentTypes) > 0 && contentTypeMethods[r.Method] {
+				if err := checkContentType(r.Header.Get("Content-Type"), contentTypes); err != nil {
+					errs = append(errs, *err)
+				}
+			}
+
+			if len(errs) > 0 {
+				WriteCodedError(w, r, http.StatusBadRequest,
+					"invalid_headers", "missing or invalid headers", errs...)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// checkContentType returns an error if value is missing or not one of the
+// accepted media types.
+func checkContentType(value string, accepted map[string]bool) *Error {
+	if value == "" {
+		return &Error{Field: "Content-Type", Message: "header is required"}
+	}
+	mediaType, _, err := mime.ParseMediaType(value)
+	if err != nil || !accepted[mediaType] {
+		return &Error{Field: "Content-Type", Message: "unsupported media type " + value}
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+package httpserver
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ServerTimingHeader is the response header carrying server-side timings.
+const ServerTimingHeader = "Server-Timing"
+
+// serverTimingKey is the context key for the request's recorded timings.
+type serverTimingKey struct{}
+
+// serverTimings collects the phases recorded by a handler.
+type serverTimings struct {
+	mu      sync.Mutex
+	metrics []string
+	sent    bool
+}
+
+// add records a phase unless the header has already been sent.
+func (s *serverTimings) add(name string, dur time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.sent {
+		s.metrics = append(s.metrics, formatServerTiming(name, dur))
+	}
+}
+
+// header returns the Server-Timing value with total as the final metric and
+// stops recording further phases.
+func (s *serverTimings) header(total time.Duration) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sent = true
+	return strings.Join(append(s.metrics, formatServerTiming("total", total)), ", ")
+}
+
+// ServerTiming returns middleware that reports the handler duration to the
+// client in a Server-Timing response header, so latency can be inspected in
+// browser dev tools or client logs without access to server traces.
+//
+// The "total" metric is the time from the request reaching the middleware
+// until the response headers are written. Handlers can add their own phases
+// with RecordServerTiming. Phases recorded after the handler starts writing
+// the response are not reported.
+//
+// Example:
+//
+//	handler := httpserver.ServerTiming()(myHandler)
+//
+//	// Response header:
+//	// Server-Timing: db;dur=12.4, total;dur=15.1
+func ServerTiming() Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			timings := &serverTimings{}
+			tw := &serverTimingWriter{
+				ResponseWriter: w,
+				timings:        timings,
+				start:          time.Now(),
+			}
+			ctx := context.WithValue(r.Context(), serverTimingKey{}, timings)
+
+			next.ServeHTTP(tw, r.WithContext(ctx))
+
+			tw.writeTimingHeader()
+		})
+	}
+}
+
+// RecordServerTiming adds a phase, such as a database query, to the
+// Server-Timing header of the current response. It is a no-op when the
+// ServerTiming middleware is not installed, the response headers have
+// already been written, or name is not a valid token.
+//
+// Example:
+//
+//	func myHandler(w http.ResponseWriter, r *http.Request) {
+//	    start := time.Now()
+//	    orders, err := repo.ListOrders(r.Context())
+//	    httpserver.RecordServerTiming(r.Context(), "db", time.Since(start))
+//	    ...
+//	}
+func RecordServerTiming(ctx context.Context, name string, dur time.Duration) {
+	timings, ok := ctx.Value(serverTimingKey{}).(*serverTimings)
+	if !ok || !isToken(name) {
+		return
+	}
+	timings.add(name, dur)
+}
+
+// formatServerTiming formats a metric as name;dur=<milliseconds>.
+func formatServerTiming(name string, dur time.Duration) string {
+	ms := float64(dur.Microseconds()) / 1000
+	return name + ";dur=" + strconv.FormatFloat(ms, 'f', -1, 64)
+}
+
+// isToken reports whether s is a token as defined in RFC 7230, which
+// Server-Timing requires for metric names.
+func isToken(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9':
+		case strings.IndexByte("!#$%&'*+-.^_`|~", c) >= 0:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// serverTimingWriter sets the Server-Timing header just before the response
+// headers are written.
+type serverTimingWriter struct {
+	http.ResponseWriter
+	timings     *serverTimings
+	start       time.Time
+	wroteHeader bool
+}
+
+// writeTimingHeader sets the Server-Timing header once.
+func (w *serverTimingWriter) writeTimingHeader() {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	w.Header().Add(ServerTimingHeader, w.timings.header(time.Since(w.start)))
+}
+
+// WriteHeader sets the Server-Timing header and writes the status code.
+func (w *serverTimingWriter) WriteHeader(code int) {
+	w.writeTimingHeader()
+	w.ResponseWriter.WriteHeader(code)
+}
+
+// Write sets the Server-Timing header if needed and writes b.
+func (w *serverTimingWriter) Write(b []byte) (int, error) {
+	w.writeTimingHeader()
+	return w.ResponseWriter.Write(b)
+}
+
+// Unwrap returns the underlying ResponseWriter.
+func (w *serverTimingWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// Flush implements http.Flusher.
+func (w *serverTimingWriter) Flush() {
+	w.writeTimingHeader()
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
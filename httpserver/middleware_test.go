@@ -1,15 +1,33 @@
 package httpserver_test
 
 import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
 	"context"
+	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/kroma-labs/sentinel-go/httpserver"
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	"golang.org/x/time/rate"
 )
 
@@ -86,6 +104,173 @@ func TestRecoveryMiddleware(t *testing.T) {
 	}
 }
 
+func TestRecoveryWithConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		cfg            httpserver.RecoveryConfig
+		panicValue     any
+		wantStatusCode int
+		wantBody       string
+		wantSpanEvent  bool
+		wantPanic      bool
+	}{
+		{
+			name: "given custom handler, when handler panics, then renders custom response",
+			cfg: httpserver.RecoveryConfig{
+				Handler: func(w http.ResponseWriter, _ *http.Request, recovered any) {
+					w.WriteHeader(http.StatusTeapot)
+					_, _ = fmt.Fprintf(w, "oops: %v", recovered)
+				},
+			},
+			panicValue:     "boom",
+			wantStatusCode: http.StatusTeapot,
+			wantBody:       "oops: boom",
+		},
+		{
+			name:           "given record to span, when handler panics, then adds exception event",
+			cfg:            httpserver.RecoveryConfig{RecordToSpan: true},
+			panicValue:     "boom",
+			wantStatusCode: http.StatusInternalServerError,
+			wantSpanEvent:  true,
+		},
+		{
+			name:           "given record to span disabled, then span untouched",
+			cfg:            httpserver.RecoveryConfig{},
+			panicValue:     "boom",
+			wantStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name:           "given abort handler panic, then recovers",
+			cfg:            httpserver.RecoveryConfig{},
+			panicValue:     http.ErrAbortHandler,
+			wantStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name:       "given repanic abort, when abort handler panics, then re-panics",
+			cfg:        httpserver.RecoveryConfig{RepanicAbort: true},
+			panicValue: http.ErrAbortHandler,
+			wantPanic:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			exporter := tracetest.NewInMemoryExporter()
+			tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+			ctx, span := tp.Tracer("test").Start(context.Background(), "request")
+
+			handler := httpserver.RecoveryWithConfig(tt.cfg)(
+				http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {
+					panic(tt.panicValue)
+				}),
+			)
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx)
+			rec := httptest.NewRecorder()
+
+			if tt.wantPanic {
+				assert.Panics(t, func() { handler.ServeHTTP(rec, req) })
+				return
+			}
+
+			handler.ServeHTTP(rec, req)
+			span.End()
+
+			assert.Equal(t, tt.wantStatusCode, rec.Code)
+			if tt.wantBody != "" {
+				assert.Equal(t, tt.wantBody, rec.Body.String())
+			}
+
+			spans := exporter.GetSpans()
+			require.Len(t, spans, 1)
+			if tt.wantSpanEvent {
+				assert.Equal(t, codes.Error, spans[0].Status.Code)
+				require.Len(t, spans[0].Events, 1)
+				assert.Equal(t, "exception", spans[0].Events[0].Name)
+
+				attrs := attribute.NewSet(spans[0].Events[0].Attributes...)
+				value, _ := attrs.Value("exception.message")
+				assert.Equal(t, "panic: boom", value.AsString())
+				assert.True(t, attrs.HasValue("exception.stacktrace"))
+			} else {
+				assert.Empty(t, spans[0].Events)
+			}
+		})
+	}
+}
+
+func TestRecovery_LeavesSpanUntouched(t *testing.T) {
+	t.Parallel()
+
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	ctx, span := tp.Tracer("test").Start(context.Background(), "request")
+
+	handler := httpserver.Recovery(zerolog.Nop())(
+		http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {
+			panic("boom")
+		}),
+	)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx))
+	span.End()
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, codes.Unset, spans[0].Status.Code)
+	assert.Empty(t, spans[0].Events)
+}
+
+func TestRecoveryWithConfig_StackSize(t *testing.T) {
+	t.Parallel()
+
+	t.Run("given stack size, then logged stack is bounded", func(t *testing.T) {
+		var buf bytes.Buffer
+		handler := httpserver.RecoveryWithConfig(httpserver.RecoveryConfig{
+			Logger:    zerolog.New(&buf),
+			StackSize: 64,
+		})(http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {
+			panic("boom")
+		}))
+
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+		var entry struct {
+			Stack string `json:"stack"`
+		}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+		assert.NotEmpty(t, entry.Stack)
+		assert.LessOrEqual(t, len(entry.Stack), 64)
+	})
+
+	t.Run("given no stack size, then logs full stack", func(t *testing.T) {
+		var buf bytes.Buffer
+		handler := httpserver.Recovery(zerolog.New(&buf))(
+			http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {
+				panic("boom")
+			}),
+		)
+
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+		var entry struct {
+			Stack string `json:"stack"`
+		}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+		assert.Contains(t, entry.Stack, "runtime/debug.Stack")
+	})
+}
+
+func allowExampleSubdomains(origin string) bool {
+	return strings.HasSuffix(origin, ".example.com")
+}
+
 func TestCORSMiddleware(t *testing.T) {
 	t.Parallel()
 
@@ -98,12 +283,15 @@ func TestCORSMiddleware(t *testing.T) {
 		allowedMethods   []string
 		allowedHeaders   []string
 		allowCredentials bool
+		allowOriginFunc  func(origin string) bool
+		maxAge           int
 	}
 
 	tests := []struct {
 		name            string
 		args            args
 		wantAllowOrigin string
+		wantMaxAge      string
 		wantStatusCode  int
 	}{
 		{
@@ -154,6 +342,42 @@ func TestCORSMiddleware(t *testing.T) {
 			wantAllowOrigin: "https://example.com",
 			wantStatusCode:  http.StatusOK,
 		},
+		{
+			name: "given preflight request with max age, then sets max age header",
+			args: args{
+				method:         http.MethodOptions,
+				origin:         "https://example.com",
+				requestMethod:  "GET",
+				allowedOrigins: []string{"https://example.com"},
+				allowedMethods: []string{"GET"},
+				maxAge:         600,
+			},
+			wantAllowOrigin: "https://example.com",
+			wantMaxAge:      "600",
+			wantStatusCode:  http.StatusNoContent,
+		},
+		{
+			name: "given origin func matching subdomain, then allows origin",
+			args: args{
+				method:          http.MethodGet,
+				origin:          "https://app.example.com",
+				allowedOrigins:  []string{"https://example.com"},
+				allowOriginFunc: allowExampleSubdomains,
+			},
+			wantAllowOrigin: "https://app.example.com",
+			wantStatusCode:  http.StatusOK,
+		},
+		{
+			name: "given origin func rejecting origin, when preflight, then no CORS headers",
+			args: args{
+				method:          http.MethodOptions,
+				origin:          "https://example.com.evil.com",
+				requestMethod:   "GET",
+				allowOriginFunc: allowExampleSubdomains,
+			},
+			wantAllowOrigin: "",
+			wantStatusCode:  http.StatusNoContent,
+		},
 	}
 
 	for _, tt := range tests {
@@ -165,6 +389,8 @@ func TestCORSMiddleware(t *testing.T) {
 				AllowedMethods:   tt.args.allowedMethods,
 				AllowedHeaders:   tt.args.allowedHeaders,
 				AllowCredentials: tt.args.allowCredentials,
+				AllowOriginFunc:  tt.args.allowOriginFunc,
+				MaxAge:           tt.args.maxAge,
 			}
 
 			middleware := httpserver.CORS(cfg)
@@ -188,6 +414,8 @@ func TestCORSMiddleware(t *testing.T) {
 
 			assert.Equal(t, tt.wantStatusCode, rec.Code)
 			assert.Equal(t, tt.wantAllowOrigin, rec.Header().Get("Access-Control-Allow-Origin"))
+			assert.Equal(t, tt.wantMaxAge, rec.Header().Get("Access-Control-Max-Age"))
+			assert.Equal(t, "Origin", rec.Header().Get("Vary"))
 		})
 	}
 }
@@ -246,6 +474,357 @@ func TestRequestIDMiddleware(t *testing.T) {
 	}
 }
 
+func TestRequestIDWithConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		cfg            httpserver.RequestIDConfig
+		incomingHeader string
+		incomingID     string
+		wantHeader     string
+		wantID         string
+		wantSpanAttr   bool
+		wantBaggage    bool
+	}{
+		{
+			name: "given custom generator, when no ID present, then uses generator",
+			cfg: httpserver.RequestIDConfig{
+				Generator: func() string { return "01HZXK5V7Q" },
+			},
+			wantHeader: "X-Request-ID",
+			wantID:     "01HZXK5V7Q",
+		},
+		{
+			name: "given custom header, when ID present, then forwards from custom header",
+			cfg: httpserver.RequestIDConfig{
+				Header: "X-Correlation-ID",
+			},
+			incomingHeader: "X-Correlation-ID",
+			incomingID:     "corr-123",
+			wantHeader:     "X-Correlation-ID",
+			wantID:         "corr-123",
+		},
+		{
+			name: "given trace baggage enabled, then sets span attribute and baggage",
+			cfg: httpserver.RequestIDConfig{
+				Generator:    func() string { return "req-abc" },
+				TraceBaggage: true,
+			},
+			wantHeader:   "X-Request-ID",
+			wantID:       "req-abc",
+			wantSpanAttr: true,
+			wantBaggage:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			exporter := tracetest.NewInMemoryExporter()
+			tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+			var capturedID, capturedBaggage string
+			handler := httpserver.Chain(
+				httpserver.Tracing(httpserver.TracingConfig{TracerProvider: tp}),
+				httpserver.RequestIDWithConfig(tt.cfg),
+			)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				capturedID = httpserver.RequestIDFromContext(r.Context())
+				capturedBaggage = baggage.FromContext(r.Context()).Member("request.id").Value()
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.incomingHeader != "" {
+				req.Header.Set(tt.incomingHeader, tt.incomingID)
+			}
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantID, capturedID)
+			assert.Equal(t, tt.wantID, rec.Header().Get(tt.wantHeader))
+
+			if tt.wantBaggage {
+				assert.Equal(t, tt.wantID, capturedBaggage)
+			} else {
+				assert.Empty(t, capturedBaggage)
+			}
+
+			spans := exporter.GetSpans()
+			require.Len(t, spans, 1)
+
+			var gotAttr string
+			for _, attr := range spans[0].Attributes {
+				if attr.Key == "request.id" {
+					gotAttr = attr.Value.AsString()
+				}
+			}
+			if tt.wantSpanAttr {
+				assert.Equal(t, tt.wantID, gotAttr)
+			} else {
+				assert.Empty(t, gotAttr)
+			}
+		})
+	}
+}
+
+func TestTracing_Skip(t *testing.T) {
+	t.Parallel()
+
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	handler := httpserver.Tracing(httpserver.TracingConfig{
+		TracerProvider: tp,
+		SkipPaths:      []string{"/readyz"},
+		SkipFunc: func(r *http.Request) bool {
+			return strings.HasPrefix(r.URL.Path, "/debug/")
+		},
+	})(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	for _, path := range []string{"/orders", "/readyz", "/debug/vars"} {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "HTTP GET "+httpserver.UnknownRoute, spans[0].Name)
+}
+
+func TestRequestDecompress(t *testing.T) {
+	t.Parallel()
+
+	payload := []byte(`{"name":"sentinel"}`)
+
+	gzipped := func(data []byte) []byte {
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		_, _ = zw.Write(data)
+		_ = zw.Close()
+		return buf.Bytes()
+	}
+	zlibbed := func(data []byte) []byte {
+		var buf bytes.Buffer
+		zw := zlib.NewWriter(&buf)
+		_, _ = zw.Write(data)
+		_ = zw.Close()
+		return buf.Bytes()
+	}
+	deflated := func(data []byte) []byte {
+		var buf bytes.Buffer
+		fw, _ := flate.NewWriter(&buf, flate.DefaultCompression)
+		_, _ = fw.Write(data)
+		_ = fw.Close()
+		return buf.Bytes()
+	}
+
+	tests := []struct {
+		name           string
+		cfg            httpserver.RequestDecompressConfig
+		encoding       string
+		body           []byte
+		wantStatusCode int
+		wantBody       []byte
+	}{
+		{
+			name:           "given gzip body, when request made, then handler sees plain body",
+			cfg:            httpserver.DefaultRequestDecompressConfig(),
+			encoding:       "gzip",
+			body:           gzipped(payload),
+			wantStatusCode: http.StatusOK,
+			wantBody:       payload,
+		},
+		{
+			name:           "given zlib deflate body, then handler sees plain body",
+			cfg:            httpserver.DefaultRequestDecompressConfig(),
+			encoding:       "deflate",
+			body:           zlibbed(payload),
+			wantStatusCode: http.StatusOK,
+			wantBody:       payload,
+		},
+		{
+			name:           "given raw deflate body, then handler sees plain body",
+			cfg:            httpserver.DefaultRequestDecompressConfig(),
+			encoding:       "deflate",
+			body:           deflated(payload),
+			wantStatusCode: http.StatusOK,
+			wantBody:       payload,
+		},
+		{
+			name:           "given uncompressed body, when request made, then passes through",
+			cfg:            httpserver.DefaultRequestDecompressConfig(),
+			body:           payload,
+			wantStatusCode: http.StatusOK,
+			wantBody:       payload,
+		},
+		{
+			name:           "given body over max size, when request made, then returns 413",
+			cfg:            httpserver.RequestDecompressConfig{MaxSize: 1024},
+			encoding:       "gzip",
+			body:           gzipped(bytes.Repeat([]byte("a"), 4096)),
+			wantStatusCode: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:           "given corrupt gzip body, when request made, then returns 400",
+			cfg:            httpserver.DefaultRequestDecompressConfig(),
+			encoding:       "gzip",
+			body:           []byte("not gzip"),
+			wantStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var gotBody []byte
+			var gotEncoding string
+			handler := httpserver.RequestDecompressWithConfig(tt.cfg)(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					gotEncoding = r.Header.Get("Content-Encoding")
+					gotBody, _ = io.ReadAll(r.Body)
+					w.WriteHeader(http.StatusOK)
+				}),
+			)
+
+			req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(tt.body))
+			if tt.encoding != "" {
+				req.Header.Set("Content-Encoding", tt.encoding)
+			}
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatusCode, rec.Code)
+			if tt.wantBody != nil {
+				assert.Equal(t, tt.wantBody, gotBody)
+				assert.Empty(t, gotEncoding)
+			}
+		})
+	}
+}
+
+func TestAccessLog(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		format      httpserver.AccessLogFormat
+		path        string
+		skipPaths   []string
+		wantLogged  bool
+		wantMessage string
+	}{
+		{
+			name:        "given common format, then logs common log line",
+			format:      httpserver.AccessLogCommon,
+			path:        "/users?page=2",
+			wantLogged:  true,
+			wantMessage: `10.0.0.1 - - [%s] "GET /users?page=2 HTTP/1.1" 201 5`,
+		},
+		{
+			name:       "given combined format, then logs referer and user agent",
+			format:     httpserver.AccessLogCombined,
+			path:       "/users",
+			wantLogged: true,
+			wantMessage: `10.0.0.1 - - [%s] "GET /users HTTP/1.1" 201 5 ` +
+				`"https://example.com" "test-agent"`,
+		},
+		{
+			name:       "given json format, then logs structured fields",
+			format:     httpserver.AccessLogJSON,
+			path:       "/users",
+			wantLogged: true,
+		},
+		{
+			name:      "given skipped path, then logs nothing",
+			format:    httpserver.AccessLogJSON,
+			path:      "/livez",
+			skipPaths: []string{"/livez"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+			handler := httpserver.AccessLog(httpserver.AccessLogConfig{
+				Format:    tt.format,
+				Logger:    zerolog.New(&buf),
+				SkipPaths: tt.skipPaths,
+			})(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusCreated)
+				_, _ = w.Write([]byte("hello"))
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			req.RemoteAddr = "10.0.0.1:5555"
+			req.Header.Set("Referer", "https://example.com")
+			req.Header.Set("User-Agent", "test-agent")
+			req = req.WithContext(httpserver.ContextWithRequestID(req.Context(), "req-1"))
+
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			if !tt.wantLogged {
+				assert.Empty(t, buf.String())
+				return
+			}
+
+			var entry map[string]any
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+			assert.Equal(t, "req-1", entry["request_id"])
+			assert.Contains(t, entry, "duration")
+
+			if tt.wantMessage != "" {
+				msg, _ := entry["message"].(string)
+				start := strings.Index(msg, "[")
+				end := strings.Index(msg, "]")
+				require.True(t, start >= 0 && end > start)
+				assert.Equal(t, fmt.Sprintf(tt.wantMessage, msg[start+1:end]), msg)
+				return
+			}
+
+			assert.Equal(t, "GET", entry["method"])
+			assert.Equal(t, "/users", entry["path"])
+			assert.EqualValues(t, http.StatusCreated, entry["status"])
+			assert.EqualValues(t, 5, entry["bytes"])
+			assert.Equal(t, "10.0.0.1", entry["client_ip"])
+		})
+	}
+}
+
+func TestAccessLog_TraceID(t *testing.T) {
+	t.Parallel()
+
+	t.Run("given active span, when json format, then logs trace ID", func(t *testing.T) {
+		tp := sdktrace.NewTracerProvider()
+		ctx, span := tp.Tracer("test").Start(context.Background(), "request")
+		defer span.End()
+
+		var buf bytes.Buffer
+		handler := httpserver.AccessLog(httpserver.AccessLogConfig{
+			Logger: zerolog.New(&buf),
+		})(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx)
+		req.Header.Set("X-Forwarded-For", "203.0.113.7, 10.0.0.1")
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+
+		var entry map[string]any
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+		assert.Equal(t, span.SpanContext().TraceID().String(), entry["trace_id"])
+		assert.Equal(t, "203.0.113.7", entry["client_ip"])
+	})
+}
+
 func TestChainMiddleware(t *testing.T) {
 	t.Parallel()
 
@@ -321,6 +900,267 @@ func TestChainMiddleware(t *testing.T) {
 	}
 }
 
+func TestDeadlinePropagation(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		cfg          httpserver.DeadlinePropagationConfig
+		headerValue  string
+		handlerDelay time.Duration
+	}
+
+	tests := []struct {
+		name            string
+		args            args
+		wantStatus      int
+		wantDeadline    bool
+		wantMaxDeadline time.Duration
+	}{
+		{
+			name: "given no header, when applied, then sets no deadline",
+			args: args{
+				cfg: httpserver.DefaultDeadlinePropagationConfig(),
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "given invalid header, when applied, then sets no deadline",
+			args: args{
+				cfg:         httpserver.DefaultDeadlinePropagationConfig(),
+				headerValue: "soon",
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "given duration header, when applied, then sets deadline",
+			args: args{
+				cfg:         httpserver.DefaultDeadlinePropagationConfig(),
+				headerValue: "2s",
+			},
+			wantStatus:      http.StatusOK,
+			wantDeadline:    true,
+			wantMaxDeadline: 2 * time.Second,
+		},
+		{
+			name: "given grpc-timeout header, when applied, then parses gRPC format",
+			args: args{
+				cfg:         httpserver.DeadlinePropagationConfig{Header: "Grpc-Timeout"},
+				headerValue: "1500m",
+			},
+			wantStatus:      http.StatusOK,
+			wantDeadline:    true,
+			wantMaxDeadline: 1500 * time.Millisecond,
+		},
+		{
+			name: "given timeout above max, when applied, then caps deadline",
+			args: args{
+				cfg: httpserver.DeadlinePropagationConfig{
+					MaxTimeout: time.Second,
+				},
+				headerValue: "1h",
+			},
+			wantStatus:      http.StatusOK,
+			wantDeadline:    true,
+			wantMaxDeadline: time.Second,
+		},
+		{
+			name: "given spent budget, when applied, then returns 504",
+			args: args{
+				cfg:         httpserver.DefaultDeadlinePropagationConfig(),
+				headerValue: "0s",
+			},
+			wantStatus: http.StatusGatewayTimeout,
+		},
+		{
+			name: "given slow handler, when deadline passes, then returns 504",
+			args: args{
+				cfg:          httpserver.DefaultDeadlinePropagationConfig(),
+				headerValue:  "20ms",
+				handlerDelay: time.Second,
+			},
+			wantStatus: http.StatusGatewayTimeout,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				hasDeadline bool
+				remaining   time.Duration
+			)
+			handler := httpserver.DeadlinePropagationWithConfig(tt.args.cfg)(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					var deadline time.Time
+					deadline, hasDeadline = r.Context().Deadline()
+					remaining = time.Until(deadline)
+					select {
+					case <-time.After(tt.args.handlerDelay):
+					case <-r.Context().Done():
+						return
+					}
+					w.WriteHeader(http.StatusOK)
+				}),
+			)
+
+			header := tt.args.cfg.Header
+			if header == "" {
+				header = httpserver.RequestTimeoutHeader
+			}
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.args.headerValue != "" {
+				req.Header.Set(header, tt.args.headerValue)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			assert.Equal(t, tt.wantDeadline, hasDeadline)
+			if tt.wantDeadline {
+				assert.LessOrEqual(t, remaining, tt.wantMaxDeadline)
+				assert.Greater(t, remaining, tt.wantMaxDeadline-time.Second/2)
+			}
+		})
+	}
+}
+
+func TestDeadlinePropagation_LateWrites(t *testing.T) {
+	t.Parallel()
+
+	// release lets slow handlers write only after the 504 response
+	release := make(chan struct{})
+	t.Cleanup(func() { close(release) })
+
+	tests := []struct {
+		name       string
+		handler    http.HandlerFunc
+		wantStatus int
+		wantHeader string
+		wantPanic  bool
+	}{
+		{
+			name: "given fast handler, when applied, then passes headers through",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("X-Handler", "done")
+				w.WriteHeader(http.StatusCreated)
+			},
+			wantStatus: http.StatusCreated,
+			wantHeader: "done",
+		},
+		{
+			name: "given handler that only sets headers, when applied, then sends them with 200",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("X-Handler", "done")
+			},
+			wantStatus: http.StatusOK,
+			wantHeader: "done",
+		},
+		{
+			name: "given slow handler, when deadline passes, then discards late writes",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				<-r.Context().Done()
+				<-release
+				w.Header().Set("X-Handler", "late")
+				_, _ = w.Write([]byte("late"))
+			},
+			wantStatus: http.StatusGatewayTimeout,
+		},
+		{
+			name: "given panicking handler, when applied, then re-panics on caller",
+			handler: func(_ http.ResponseWriter, _ *http.Request) {
+				panic("boom")
+			},
+			wantPanic: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			handler := httpserver.DeadlinePropagation(httpserver.RequestTimeoutHeader)(tt.handler)
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set(httpserver.RequestTimeoutHeader, "20ms")
+			rec := httptest.NewRecorder()
+
+			if tt.wantPanic {
+				assert.PanicsWithValue(t, "boom", func() { handler.ServeHTTP(rec, req) })
+				return
+			}
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Equal(t, tt.wantHeader, rec.Header().Get("X-Handler"))
+		})
+	}
+}
+
+func TestParseGRPCTimeout(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		value   string
+		want    time.Duration
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "given hours, then parses",
+			value:   "2H",
+			want:    2 * time.Hour,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "given minutes, then parses",
+			value:   "3M",
+			want:    3 * time.Minute,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "given seconds, then parses",
+			value:   "5S",
+			want:    5 * time.Second,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "given milliseconds, then parses",
+			value:   "250m",
+			want:    250 * time.Millisecond,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "given microseconds, then parses",
+			value:   "7u",
+			want:    7 * time.Microsecond,
+			wantErr: assert.NoError,
+		},
+		{name: "given nanoseconds, then parses", value: "9n", want: 9, wantErr: assert.NoError},
+		{name: "given unknown unit, then errors", value: "5s", wantErr: assert.Error},
+		{name: "given no digits, then errors", value: "S", wantErr: assert.Error},
+		{
+			name:    "given more than 8 digits, then errors",
+			value:   "123456789S",
+			wantErr: assert.Error,
+		},
+		{name: "given sign, then errors", value: "-5S", wantErr: assert.Error},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := httpserver.ParseGRPCTimeout(tt.value)
+
+			tt.wantErr(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
 func TestRateLimitMiddleware(t *testing.T) {
 	t.Parallel()
 
@@ -711,3 +1551,315 @@ func TestKeyFuncByHeaderHelper(t *testing.T) {
 		})
 	}
 }
+
+func TestCoalesceMiddleware(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		method      string
+		body        string
+		maxBodySize int
+		wantCalls   int64
+		wantHits    int64
+	}{
+		{
+			name:      "given concurrent GETs, when handler is slow, then runs it once",
+			method:    http.MethodGet,
+			body:      "catalog",
+			wantCalls: 1,
+			wantHits:  4,
+		},
+		{
+			name:      "given concurrent POSTs, when handler is slow, then runs it for each",
+			method:    http.MethodPost,
+			body:      "catalog",
+			wantCalls: 5,
+		},
+		{
+			name:        "given response over max body size, when waiting, then reruns handler",
+			method:      http.MethodGet,
+			body:        "catalog",
+			maxBodySize: 3,
+			wantCalls:   5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			reader := sdkmetric.NewManualReader()
+			mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+			var calls atomic.Int64
+			release := make(chan struct{})
+			handler := httpserver.CoalesceWithConfig(httpserver.CoalesceConfig{
+				MaxBodySize:   tt.maxBodySize,
+				MeterProvider: mp,
+			})(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				if calls.Add(1) == 1 {
+					<-release
+				}
+				w.Header().Set("X-Source", "handler")
+				w.Header().Set("Set-Cookie", "session=leader")
+				w.WriteHeader(http.StatusAccepted)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+
+			const n = 5
+			recs := make([]*httptest.ResponseRecorder, n)
+			var wg sync.WaitGroup
+			for i := range n {
+				recs[i] = httptest.NewRecorder()
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					req := httptest.NewRequest(tt.method, "/catalog?page=1", nil)
+					handler.ServeHTTP(recs[i], req)
+				}()
+			}
+
+			// Let the other requests join the first one before it completes
+			time.Sleep(50 * time.Millisecond)
+			close(release)
+			wg.Wait()
+
+			assert.Equal(t, tt.wantCalls, calls.Load())
+			var cookies int
+			for _, rec := range recs {
+				assert.Equal(t, http.StatusAccepted, rec.Code)
+				assert.Equal(t, "handler", rec.Header().Get("X-Source"))
+				assert.Equal(t, tt.body, rec.Body.String())
+				if rec.Header().Get("Set-Cookie") != "" {
+					cookies++
+				}
+			}
+			// Only requests that ran the handler get its cookie
+			assert.EqualValues(t, tt.wantCalls, cookies)
+
+			var rm metricdata.ResourceMetrics
+			require.NoError(t, reader.Collect(context.Background(), &rm))
+			var hits int64
+			for _, sm := range rm.ScopeMetrics {
+				for _, m := range sm.Metrics {
+					if m.Name != "http.server.coalesce" {
+						continue
+					}
+					for _, dp := range m.Data.(metricdata.Sum[int64]).DataPoints {
+						hits += dp.Value
+					}
+				}
+			}
+			assert.Equal(t, tt.wantHits, hits)
+		})
+	}
+}
+
+func TestCoalesceMiddleware_Panic(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int64
+	release := make(chan struct{})
+	next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if calls.Add(1) == 1 {
+			<-release
+			panic("boom")
+		}
+		_, _ = w.Write([]byte("ok"))
+	})
+	handler := httpserver.Coalesce(nil)(next)
+
+	const n = 3
+	panics := make(chan any, n)
+	recs := make([]*httptest.ResponseRecorder, n)
+	var wg sync.WaitGroup
+	for i := range n {
+		recs[i] = httptest.NewRecorder()
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { panics <- recover() }()
+			handler.ServeHTTP(recs[i], httptest.NewRequest(http.MethodGet, "/catalog", nil))
+		}()
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(panics)
+
+	var recovered []any
+	for p := range panics {
+		if p != nil {
+			recovered = append(recovered, p)
+		}
+	}
+	assert.Equal(t, []any{"boom"}, recovered)
+	assert.EqualValues(t, n, calls.Load())
+	var served int
+	for _, rec := range recs {
+		if rec.Body.String() == "ok" {
+			served++
+		}
+	}
+	assert.Equal(t, n-1, served)
+}
+
+func TestServerTiming(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		want    string
+	}{
+		{
+			name: "given no phases, when handler writes, then reports total only",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte("ok"))
+			},
+			want: `^total;dur=[0-9.]+$`,
+		},
+		{
+			name: "given recorded phases, when handler writes, then reports them before total",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				httpserver.RecordServerTiming(r.Context(), "db", 12345*time.Microsecond)
+				httpserver.RecordServerTiming(r.Context(), "cache", 2*time.Millisecond)
+				w.WriteHeader(http.StatusCreated)
+			},
+			want: `^db;dur=12\.345, cache;dur=2, total;dur=[0-9.]+$`,
+		},
+		{
+			name: "given invalid phase name, when recorded, then skips it",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				httpserver.RecordServerTiming(r.Context(), "db query", time.Millisecond)
+				w.WriteHeader(http.StatusOK)
+			},
+			want: `^total;dur=[0-9.]+$`,
+		},
+		{
+			name: "given phase after headers, when recorded, then skips it",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				httpserver.RecordServerTiming(r.Context(), "render", time.Millisecond)
+			},
+			want: `^total;dur=[0-9.]+$`,
+		},
+		{
+			name:    "given handler writes nothing, when done, then still reports total",
+			handler: func(http.ResponseWriter, *http.Request) {},
+			want:    `^total;dur=[0-9.]+$`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			handler := httpserver.ServerTiming()(tt.handler)
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			assert.Regexp(t, tt.want, rec.Header().Get(httpserver.ServerTimingHeader))
+		})
+	}
+
+	t.Run("given no middleware, when recorded, then does nothing", func(t *testing.T) {
+		t.Parallel()
+
+		assert.NotPanics(t, func() {
+			httpserver.RecordServerTiming(context.Background(), "db", time.Millisecond)
+		})
+	})
+}
+
+func TestRequireHeaders(t *testing.T) {
+	t.Parallel()
+
+	cfg := httpserver.RequireHeadersConfig{
+		Headers:       []string{"X-Api-Version"},
+		MethodHeaders: map[string][]string{http.MethodPost: {"Idempotency-Key"}},
+		ContentTypes:  []string{"application/json"},
+	}
+
+	tests := []struct {
+		name       string
+		method     string
+		headers    map[string]string
+		wantStatus int
+		wantFields []string
+	}{
+		{
+			name:   "given all headers, when POST, then calls handler",
+			method: http.MethodPost,
+			headers: map[string]string{
+				"X-Api-Version":   "2",
+				"Idempotency-Key": "k1",
+				"Content-Type":    "application/json; charset=utf-8",
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "given version header, when GET, then skips method and content type checks",
+			method:     http.MethodGet,
+			headers:    map[string]string{"X-Api-Version": "2"},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "given no headers, when GET, then names missing header",
+			method:     http.MethodGet,
+			wantStatus: http.StatusBadRequest,
+			wantFields: []string{"X-Api-Version"},
+		},
+		{
+			name:       "given no headers, when POST, then names every missing header",
+			method:     http.MethodPost,
+			wantStatus: http.StatusBadRequest,
+			wantFields: []string{"X-Api-Version", "Idempotency-Key", "Content-Type"},
+		},
+		{
+			name:       "given unsupported content type, when PUT, then rejects it",
+			method:     http.MethodPut,
+			headers:    map[string]string{"X-Api-Version": "2", "Content-Type": "text/plain"},
+			wantStatus: http.StatusBadRequest,
+			wantFields: []string{"Content-Type"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			called := false
+			handler := httpserver.RequireHeaders(cfg)(
+				http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					called = true
+					w.WriteHeader(http.StatusOK)
+				}),
+			)
+
+			req := httptest.NewRequest(tt.method, "/api/orders", nil)
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Equal(t, tt.wantStatus == http.StatusOK, called)
+			if len(tt.wantFields) == 0 {
+				return
+			}
+
+			var body httpserver.Response[any]
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			var gotFields []string
+			for _, e := range body.Errors {
+				gotFields = append(gotFields, e.Field)
+			}
+			assert.Equal(t, tt.wantFields, gotFields)
+		})
+	}
+}
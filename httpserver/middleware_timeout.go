@@ -46,8 +46,8 @@ func Timeout(timeout time.Duration) Middleware {
 			case <-ctx.Done():
 				// Timeout occurred
 				wrapped.timedOut = true
-				WriteError(w, http.StatusServiceUnavailable,
-					"request timeout",
+				WriteCodedError(w, r, http.StatusServiceUnavailable,
+					"timeout", "request timeout",
 					Error{Field: "server", Message: "request processing timed out"},
 				)
 			}
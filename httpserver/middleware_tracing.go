@@ -27,8 +27,18 @@ type TracingConfig struct {
 	// SkipPaths are paths that should not be traced.
 	SkipPaths []string
 
+	// SkipFunc reports whether a request should not be traced. A request is
+	// skipped if it matches SkipPaths or SkipFunc returns true.
+	SkipFunc func(r *http.Request) bool
+
+	// RouteTagger returns the route template used for the http.route
+	// attribute and the default span name. If nil, the route set by the
+	// server or a framework adapter is used, falling back to UnknownRoute.
+	// The server applies WithRouteTagger here when this field is unset.
+	RouteTagger RouteTagger
+
 	// SpanNameFormatter formats the span name.
-	// Default: "HTTP {method} {path}"
+	// Default: "HTTP {method} {route}"
 	SpanNameFormatter func(r *http.Request) string
 }
 
@@ -37,9 +47,6 @@ func DefaultTracingConfig() TracingConfig {
 	return TracingConfig{
 		TracerProvider: otel.GetTracerProvider(),
 		Propagator:     otel.GetTextMapPropagator(),
-		SpanNameFormatter: func(r *http.Request) string {
-			return "HTTP " + r.Method + " " + r.URL.Path
-		},
 	}
 }
 
@@ -64,11 +71,6 @@ func Tracing(cfg TracingConfig) Middleware {
 	if cfg.Propagator == nil {
 		cfg.Propagator = otel.GetTextMapPropagator()
 	}
-	if cfg.SpanNameFormatter == nil {
-		cfg.SpanNameFormatter = func(r *http.Request) string {
-			return "HTTP " + r.Method + " " + r.URL.Path
-		}
-	}
 
 	tracer := cfg.TracerProvider.Tracer(
 		"github.com/kroma-labs/sentinel-go/httpserver",
@@ -83,21 +85,28 @@ func Tracing(cfg TracingConfig) Middleware {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			// Skip tracing for certain paths
-			if skipPaths[r.URL.Path] {
+			if skipPaths[r.URL.Path] || (cfg.SkipFunc != nil && cfg.SkipFunc(r)) {
 				next.ServeHTTP(w, r)
 				return
 			}
 
+			r, slot := withRouteSlot(r)
+
 			// Extract trace context from request headers
 			ctx := cfg.Propagator.Extract(r.Context(), propagation.HeaderCarrier(r.Header))
 
-			// Start server span
-			spanName := cfg.SpanNameFormatter(r)
+			// Start server span named after the route template
+			route := resolveRoute(r, cfg.RouteTagger)
+			spanName := "HTTP " + r.Method + " " + route
+			if cfg.SpanNameFormatter != nil {
+				spanName = cfg.SpanNameFormatter(r)
+			}
 			ctx, span := tracer.Start(ctx, spanName,
 				trace.WithSpanKind(trace.SpanKindServer),
 				trace.WithAttributes(
 					semconv.ServiceName(cfg.serviceName),
 					semconv.HTTPRequestMethodKey.String(r.Method),
+					semconv.HTTPRoute(route),
 					semconv.URLPath(r.URL.Path),
 					semconv.URLScheme(r.URL.Scheme),
 					semconv.ServerAddress(r.Host),
@@ -118,6 +127,14 @@ func Tracing(cfg TracingConfig) Middleware {
 			// Process request with updated context
 			next.ServeHTTP(wrapped, r.WithContext(ctx))
 
+			// Relabel by the route the handler set, if it was unknown before
+			if late := slot.resolve(route); late != route {
+				span.SetAttributes(semconv.HTTPRoute(late))
+				if cfg.SpanNameFormatter == nil {
+					span.SetName("HTTP " + r.Method + " " + late)
+				}
+			}
+
 			// Record response attributes
 			status := wrapped.Status()
 			span.SetAttributes(semconv.HTTPResponseStatusCode(status))
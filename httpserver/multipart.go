@@ -0,0 +1,277 @@
+package httpserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"sync"
+)
+
+const (
+	// defaultMultipartMaxMemory is the default amount of a multipart body
+	// kept in memory before files spill to disk.
+	defaultMultipartMaxMemory = 10 << 20
+
+	// defaultMultipartMaxSize is the default limit for multipart bodies.
+	defaultMultipartMaxSize = 32 << 20
+)
+
+var (
+	// ErrMultipartTooLarge is returned by ParseMultipart when the request
+	// body exceeds MultipartConfig.MaxSize.
+	ErrMultipartTooLarge = errors.New("httpserver: multipart body too large")
+
+	// ErrNotMultipart is returned by ParseMultipart when the request is not
+	// multipart/form-data or its body is malformed.
+	ErrNotMultipart = errors.New("httpserver: invalid multipart body")
+)
+
+// MultipartConfig configures ParseMultipartWithConfig.
+type MultipartConfig struct {
+	// MaxMemory is the number of bytes of file parts kept in memory. The
+	// rest of the files are written to temporary files on disk.
+	// Default: 10 MiB
+	MaxMemory int64
+
+	// MaxSize is the maximum size in bytes of the whole request body.
+	// Larger bodies fail with ErrMultipartTooLarge.
+	// Default: 32 MiB
+	MaxSize int64
+}
+
+// DefaultMultipartConfig returns the default multipart configuration.
+func DefaultMultipartConfig() MultipartConfig {
+	return MultipartConfig{
+		MaxMemory: defaultMultipartMaxMemory,
+		MaxSize:   defaultMultipartMaxSize,
+	}
+}
+
+// FileHeader describes an uploaded file.
+type FileHeader struct {
+	// Filename is the file name sent by the client. It is not sanitized
+	// and must not be used as a path as is.
+	Filename string
+
+	// ContentType is the Content-Type of the file part, if sent.
+	ContentType string
+
+	// Size is the size of the file in bytes.
+	Size int64
+}
+
+// MultipartData gives typed access to the fields and files of a parsed
+// multipart/form-data request.
+//
+// Files that didn't fit in memory are stored in temporary files, which are
+// removed by Close, or when the request context is done.
+type MultipartData struct {
+	form    *multipart.Form
+	cleanup func()
+}
+
+// ParseMultipart parses a multipart/form-data request body, keeping up to
+// maxMemory bytes of files in memory and limiting the body to the default
+// MaxSize of 32 MiB. See ParseMultipartWithConfig.
+//
+// Example:
+//
+//	func upload(w http.ResponseWriter, r *http.Request) {
+//	    data, err := httpserver.ParseMultipart(r, 10<<20)
+//	    if err != nil {
+//	        httpserver.WriteMultipartError(w, r, err)
+//	        return
+//	    }
+//	    defer data.Close()
+//
+//	    file, header, err := data.File("avatar")
+//	    if err != nil {
+//	        httpserver.WriteError(w, http.StatusBadRequest, "avatar is required")
+//	        return
+//	    }
+//	    defer file.Close()
+//
+//	    store(r.Context(), data.Value("user_id"), header.Filename, file)
+//	}
+func ParseMultipart(r *http.Request, maxMemory int64) (*MultipartData, error) {
+	cfg := DefaultMultipartConfig()
+	cfg.MaxMemory = maxMemory
+	return ParseMultipartWithConfig(r, cfg)
+}
+
+// ParseMultipartWithConfig parses a multipart/form-data request body with
+// custom limits.
+//
+// Bodies larger than MaxSize fail with ErrMultipartTooLarge, which
+// WriteMultipartError turns into 413 Request Entity Too Large. Requests that
+// are not multipart/form-data, or are malformed, fail with ErrNotMultipart.
+//
+// Temporary files are removed when the returned MultipartData is closed or
+// when the request context is done, whichever comes first.
+func ParseMultipartWithConfig(r *http.Request, cfg MultipartConfig) (*MultipartData, error) {
+	if cfg.MaxMemory <= 0 {
+		cfg.MaxMemory = defaultMultipartMaxMemory
+	}
+	if cfg.MaxSize <= 0 {
+		cfg.MaxSize = defaultMultipartMaxSize
+	}
+
+	if r.Body == nil {
+		return nil, fmt.Errorf("%w: missing body", ErrNotMultipart)
+	}
+	if r.ContentLength > cfg.MaxSize {
+		return nil, ErrMultipartTooLarge
+	}
+
+	body := &limitedBody{ReadCloser: r.Body, remaining: cfg.MaxSize}
+	r.Body = body
+
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrNotMultipart, err)
+	}
+
+	form, err := reader.ReadForm(cfg.MaxMemory)
+	if body.exceeded || errors.Is(err, multipart.ErrMessageTooLarge) {
+		if form != nil {
+			_ = form.RemoveAll()
+		}
+		return nil, ErrMultipartTooLarge
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrNotMultipart, err)
+	}
+
+	var once sync.Once
+	cleanup := func() {
+		once.Do(func() { _ = form.RemoveAll() })
+	}
+	stop := context.AfterFunc(r.Context(), cleanup)
+
+	return &MultipartData{
+		form: form,
+		cleanup: func() {
+			stop()
+			cleanup()
+		},
+	}, nil
+}
+
+// Value returns the first value of the form field, or "" if it is not set.
+func (d *MultipartData) Value(field string) string {
+	if values := d.form.Value[field]; len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// Values returns all values of the form field.
+func (d *MultipartData) Values(field string) []string {
+	return d.form.Value[field]
+}
+
+// File opens the first file uploaded under field.
+//
+// Returns http.ErrMissingFile if the field has no file. The caller must
+// close the returned reader.
+func (d *MultipartData) File(field string) (io.ReadCloser, FileHeader, error) {
+	headers := d.form.File[field]
+	if len(headers) == 0 {
+		return nil, FileHeader{}, http.ErrMissingFile
+	}
+	return openFile(headers[0])
+}
+
+// Files returns the headers of all files uploaded under field, in order.
+// Use OpenFile to read one of them.
+func (d *MultipartData) Files(field string) []FileHeader {
+	headers := d.form.File[field]
+	files := make([]FileHeader, len(headers))
+	for i, h := range headers {
+		files[i] = fileHeader(h)
+	}
+	return files
+}
+
+// OpenFile opens the i-th file uploaded under field, as listed by Files.
+//
+// Returns http.ErrMissingFile if there is no such file. The caller must
+// close the returned reader.
+func (d *MultipartData) OpenFile(field string, i int) (io.ReadCloser, FileHeader, error) {
+	headers := d.form.File[field]
+	if i < 0 || i >= len(headers) {
+		return nil, FileHeader{}, http.ErrMissingFile
+	}
+	return openFile(headers[i])
+}
+
+// Close removes the temporary files of the form. Files must not be read
+// after Close. It is safe to call Close more than once.
+func (d *MultipartData) Close() error {
+	d.cleanup()
+	return nil
+}
+
+// WriteMultipartError writes the error response for an error returned by
+// ParseMultipart: 413 Request Entity Too Large for ErrMultipartTooLarge and
+// 400 Bad Request otherwise.
+func WriteMultipartError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, ErrMultipartTooLarge) {
+		WriteCodedError(w, r, http.StatusRequestEntityTooLarge,
+			"body_too_large", "request body too large",
+			Error{Field: "body", Message: "multipart body exceeds the size limit"},
+		)
+		return
+	}
+	WriteCodedError(w, r, http.StatusBadRequest,
+		"invalid_body", "invalid request body",
+		Error{Field: "body", Message: "malformed multipart/form-data content"},
+	)
+}
+
+// openFile opens an uploaded file and describes it.
+func openFile(h *multipart.FileHeader) (io.ReadCloser, FileHeader, error) {
+	file, err := h.Open()
+	if err != nil {
+		return nil, FileHeader{}, err
+	}
+	return file, fileHeader(h), nil
+}
+
+// fileHeader converts a multipart.FileHeader to a FileHeader.
+func fileHeader(h *multipart.FileHeader) FileHeader {
+	return FileHeader{
+		Filename:    h.Filename,
+		ContentType: h.Header.Get("Content-Type"),
+		Size:        h.Size,
+	}
+}
+
+// limitedBody is a request body that fails once more than remaining bytes
+// are read, and remembers that it did.
+type limitedBody struct {
+	io.ReadCloser
+	remaining int64
+	exceeded  bool
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.remaining < 0 {
+		b.exceeded = true
+		return 0, ErrMultipartTooLarge
+	}
+	// Read one byte past the limit to tell an exact fit from an overflow
+	if int64(len(p)) > b.remaining+1 {
+		p = p[:b.remaining+1]
+	}
+	n, err := b.ReadCloser.Read(p)
+	b.remaining -= int64(n)
+	if b.remaining < 0 {
+		b.exceeded = true
+		return 0, ErrMultipartTooLarge
+	}
+	return n, err
+}
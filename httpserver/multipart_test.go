@@ -0,0 +1,140 @@
+package httpserver_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kroma-labs/sentinel-go/httpserver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newMultipartRequest builds a multipart/form-data request with the given
+// fields and files, keyed by field name.
+func newMultipartRequest(
+	t *testing.T,
+	fields map[string]string,
+	files map[string]string,
+) *http.Request {
+	t.Helper()
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	for name, value := range fields {
+		require.NoError(t, writer.WriteField(name, value))
+	}
+	for name, content := range files {
+		part, err := writer.CreateFormFile(name, name+".txt")
+		require.NoError(t, err)
+		_, err = part.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/upload", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req
+}
+
+func TestParseMultipart(t *testing.T) {
+	t.Parallel()
+
+	t.Run("given fields and files, when parsed, then returns them", func(t *testing.T) {
+		req := newMultipartRequest(t,
+			map[string]string{"user_id": "42"},
+			map[string]string{"avatar": "image-bytes"},
+		)
+
+		data, err := httpserver.ParseMultipart(req, 1<<20)
+		require.NoError(t, err)
+		defer data.Close()
+
+		assert.Equal(t, "42", data.Value("user_id"))
+		assert.Equal(t, []string{"42"}, data.Values("user_id"))
+		assert.Empty(t, data.Value("missing"))
+
+		file, header, err := data.File("avatar")
+		require.NoError(t, err)
+		defer file.Close()
+		content, err := io.ReadAll(file)
+		require.NoError(t, err)
+		assert.Equal(t, "image-bytes", string(content))
+		assert.Equal(t, "avatar.txt", header.Filename)
+		assert.Equal(t, "application/octet-stream", header.ContentType)
+		assert.Equal(t, int64(len("image-bytes")), header.Size)
+		assert.Equal(t, []httpserver.FileHeader{header}, data.Files("avatar"))
+
+		_, _, err = data.File("missing")
+		require.ErrorIs(t, err, http.ErrMissingFile)
+		_, _, err = data.OpenFile("avatar", 1)
+		require.ErrorIs(t, err, http.ErrMissingFile)
+	})
+
+	t.Run("given body over max size, when parsed, then returns too large", func(t *testing.T) {
+		req := newMultipartRequest(t, nil, map[string]string{"file": strings.Repeat("x", 2048)})
+		req.ContentLength = -1
+
+		_, err := httpserver.ParseMultipartWithConfig(req, httpserver.MultipartConfig{
+			MaxSize: 1024,
+		})
+		require.ErrorIs(t, err, httpserver.ErrMultipartTooLarge)
+
+		rec := httptest.NewRecorder()
+		httpserver.WriteMultipartError(rec, req, err)
+		assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+	})
+
+	t.Run("given content length over max size, when parsed, then returns too large",
+		func(t *testing.T) {
+			req := newMultipartRequest(t, nil, map[string]string{"file": strings.Repeat("x", 2048)})
+
+			_, err := httpserver.ParseMultipartWithConfig(req, httpserver.MultipartConfig{
+				MaxSize: 1024,
+			})
+			require.ErrorIs(t, err, httpserver.ErrMultipartTooLarge)
+		})
+
+	t.Run("given non-multipart body, when parsed, then returns not multipart", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/upload", strings.NewReader(`{}`))
+		req.Header.Set("Content-Type", "application/json")
+
+		_, err := httpserver.ParseMultipart(req, 1<<20)
+		require.ErrorIs(t, err, httpserver.ErrNotMultipart)
+
+		rec := httptest.NewRecorder()
+		httpserver.WriteMultipartError(rec, req, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("given file spilled to disk, when context done, then removes temp file",
+		func(t *testing.T) {
+			req := newMultipartRequest(t, nil, map[string]string{"file": strings.Repeat("x", 4096)})
+			ctx, cancel := context.WithCancel(req.Context())
+			req = req.WithContext(ctx)
+
+			data, err := httpserver.ParseMultipart(req, 1)
+			require.NoError(t, err)
+
+			file, _, err := data.File("file")
+			require.NoError(t, err)
+			osFile, ok := file.(*os.File)
+			require.True(t, ok, "file should be stored on disk")
+			name := osFile.Name()
+			require.NoError(t, file.Close())
+
+			cancel()
+			assert.Eventually(t, func() bool {
+				_, err := os.Stat(name)
+				return os.IsNotExist(err)
+			}, time.Second, 10*time.Millisecond)
+			require.NoError(t, data.Close())
+		})
+}
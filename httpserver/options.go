@@ -2,6 +2,7 @@ package httpserver
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/rs/zerolog"
 )
@@ -161,6 +162,77 @@ func WithMetrics(cfg MetricsConfig) Option {
 	}
 }
 
+// WithRouteTagger sets how requests are mapped to route templates for
+// metrics and span names.
+//
+// Labeling by template ("/users/{id}") instead of the concrete path
+// ("/users/123") keeps metric and span-name cardinality bounded. When the
+// handler is an *http.ServeMux, its matched pattern is used automatically;
+// the framework adapters (gin, echo) supply their matched route. Use this
+// option for other routers, e.g. with the chi adapter's RouteTagger. When
+// no template is available, the label is UnknownRoute ("unknown_route").
+//
+// Example:
+//
+//	// gorilla/mux
+//	server := httpserver.New(
+//	    httpserver.WithRouteTagger(func(r *http.Request) string {
+//	        return mux.CurrentRoute(r).GetPathTemplate()
+//	    }),
+//	    httpserver.WithMetrics(httpserver.DefaultMetricsConfig()),
+//	    httpserver.WithHandler(router),
+//	)
+func WithRouteTagger(tagger func(r *http.Request) string) Option {
+	return func(c *Config) {
+		c.RouteTagger = tagger
+	}
+}
+
+// WithRouteSLO sets a latency objective per route template.
+//
+// The metrics middleware counts each request to these routes in
+// http.server.request.total with a boolean slo.met attribute, so the
+// error-budget burn rate can be queried directly, without keeping a
+// separate mapping of routes to objectives next to the dashboards. Routes
+// are matched by the same template used for the http.route label.
+//
+// Example:
+//
+//	server := httpserver.New(
+//	    httpserver.WithMetrics(httpserver.DefaultMetricsConfig()),
+//	    httpserver.WithRouteSLO(map[string]time.Duration{
+//	        "/orders/{id}": 300 * time.Millisecond,
+//	        "/search":      time.Second,
+//	    }),
+//	    httpserver.WithHandler(mux),
+//	)
+func WithRouteSLO(objectives map[string]time.Duration) Option {
+	return func(c *Config) {
+		c.RouteSLO = objectives
+	}
+}
+
+// WithExemplars controls whether http.server.request.duration measurements
+// carry exemplars with the trace and span ID of the request, so a dashboard
+// can jump from a latency spike to the trace behind it.
+//
+// Exemplars are recorded by default, following the OTel SDK's exemplar
+// filter, which keeps them for sampled spans. Pass false for backends that
+// reject exemplars.
+//
+// Example:
+//
+//	server := httpserver.New(
+//	    httpserver.WithMetrics(httpserver.DefaultMetricsConfig()),
+//	    httpserver.WithExemplars(false),
+//	    httpserver.WithHandler(mux),
+//	)
+func WithExemplars(enabled bool) Option {
+	return func(c *Config) {
+		c.DisableExemplars = !enabled
+	}
+}
+
 // WithLogging enables request logging middleware.
 //
 // The server's ServiceName is automatically included in all log entries.
@@ -208,6 +280,85 @@ func WithHealth(handler **HealthHandler, version string) Option {
 	}
 }
 
+// WithPreShutdownDelay delays shutdown after a shutdown signal by d.
+//
+// During the delay the readiness probe of the health handler configured with
+// WithHealth returns 503 while requests are still served, so load balancers
+// stop routing traffic here before the server stops accepting connections.
+// Set d to at least the load balancer's readiness check interval times its
+// failure threshold.
+//
+// Example:
+//
+//	var health *httpserver.HealthHandler
+//	server := httpserver.New(
+//	    httpserver.WithConfig(httpserver.ProductionConfig()),
+//	    httpserver.WithHealth(&health, "1.0.0"),
+//	    httpserver.WithPreShutdownDelay(5 * time.Second),
+//	    httpserver.WithHandler(mux),
+//	)
+func WithPreShutdownDelay(d time.Duration) Option {
+	return func(c *Config) {
+		c.PreShutdownDelay = d
+	}
+}
+
+// WithMaxRequestsPerConn closes keep-alive connections after n requests.
+//
+// The response to the n-th request carries "Connection: close", so the
+// client opens a new connection, which the load balancer may route to
+// another replica. This keeps long-lived clients from pinning to one
+// instance, e.g. one that is about to drain.
+//
+// Limits apply to connections accepted by the server's own listener; they
+// are not enforced when serving Handler() from another http.Server.
+//
+// Example:
+//
+//	server := httpserver.New(
+//	    httpserver.WithHandler(mux),
+//	    httpserver.WithMaxRequestsPerConn(1000),
+//	)
+func WithMaxRequestsPerConn(n int) Option {
+	return func(c *Config) {
+		c.MaxRequestsPerConn = n
+	}
+}
+
+// WithConnMaxAge closes keep-alive connections that have been open for at
+// least d, on their next response.
+//
+// Like WithMaxRequestsPerConn, it makes clients reconnect periodically so
+// traffic rebalances as replicas are added or drained.
+//
+// Example:
+//
+//	server := httpserver.New(
+//	    httpserver.WithHandler(mux),
+//	    httpserver.WithConnMaxAge(5 * time.Minute),
+//	)
+func WithConnMaxAge(d time.Duration) Option {
+	return func(c *Config) {
+		c.ConnMaxAge = d
+	}
+}
+
+// WithServerTimingHeader enables the ServerTiming middleware for all
+// requests, reporting how long the server took in a Server-Timing response
+// header. Handlers can add phases with RecordServerTiming.
+//
+// Example:
+//
+//	server := httpserver.New(
+//	    httpserver.WithHandler(mux),
+//	    httpserver.WithServerTimingHeader(),
+//	)
+func WithServerTimingHeader() Option {
+	return func(c *Config) {
+		c.ServerTiming = true
+	}
+}
+
 // WithRateLimit enables global rate limiting for all requests.
 //
 // For per-endpoint rate limiting, use the RateLimit middleware directly
@@ -238,3 +389,67 @@ func WithRateLimit(cfg RateLimitConfig) Option {
 		c.RateLimitConfig = &cfg
 	}
 }
+
+// WithNotFoundHandler sets the handler for requests that match no route.
+//
+// This applies when the server's handler is an *http.ServeMux. By default,
+// unmatched routes receive a JSON 404 body in the package's standard error
+// shape (see NotFoundHandler) instead of Go's plaintext response.
+//
+// Example:
+//
+//	server := httpserver.New(
+//	    httpserver.WithHandler(mux),
+//	    httpserver.WithNotFoundHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+//	        httpserver.WriteError(w, http.StatusNotFound, "no such endpoint")
+//	    })),
+//	)
+func WithNotFoundHandler(h http.Handler) Option {
+	return func(c *Config) {
+		c.NotFoundHandler = h
+	}
+}
+
+// WithMethodNotAllowedHandler sets the handler for requests whose path is
+// registered but whose method is not.
+//
+// This applies when the server's handler is an *http.ServeMux. The Allow
+// header computed by the mux is set before the handler runs. By default,
+// a JSON 405 body is written (see MethodNotAllowedHandler).
+//
+// Example:
+//
+//	server := httpserver.New(
+//	    httpserver.WithHandler(mux),
+//	    httpserver.WithMethodNotAllowedHandler(customHandler),
+//	)
+func WithMethodNotAllowedHandler(h http.Handler) Option {
+	return func(c *Config) {
+		c.MethodNotAllowedHandler = h
+	}
+}
+
+// WithErrorEnvelope sets the body shape of error responses written by
+// WriteRequestError, so they can follow an organization's API standard.
+//
+// The envelope also shapes the errors of the built-in handlers and
+// middleware, such as the 404 fallback and RateLimit, each with a stable
+// code like "not_found" or "rate_limited".
+//
+// Example:
+//
+//	server := httpserver.New(
+//	    httpserver.WithHandler(mux),
+//	    httpserver.WithErrorEnvelope(func(info httpserver.ErrorInfo) any {
+//	        return map[string]any{
+//	            "type":   info.Code,
+//	            "title":  info.Message,
+//	            "status": info.Status,
+//	        }
+//	    }),
+//	)
+func WithErrorEnvelope(fn ErrorEnvelope) Option {
+	return func(c *Config) {
+		c.ErrorEnvelope = fn
+	}
+}
@@ -3,12 +3,23 @@ package httpserver
 import (
 	"net/http"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
 // PrometheusHandler returns an http.Handler for the /metrics endpoint.
 //
-// This exposes Prometheus metrics in the standard text format.
+// This exposes Prometheus metrics in the standard text format from the
+// default Prometheus registry. The OpenTelemetry Prometheus exporter
+// registers with that registry unless given another, so the handler serves
+// the server metrics once the MeterProvider the server uses reads from the
+// exporter:
+//
+//	exporter, _ := otelprom.New()
+//	otel.SetMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(exporter)))
+//
+// If the exporter uses its own registry, serve it with
+// PrometheusRegistryHandler instead.
 //
 // Example:
 //
@@ -17,11 +28,32 @@ func PrometheusHandler() http.Handler {
 	return promhttp.Handler()
 }
 
-// PrometheusHandlerFor returns a Prometheus handler with custom options.
+// PrometheusHandlerFor returns a Prometheus handler for the default
+// registry with custom options.
 //
 // Example:
 //
 //	mux.Handle("/metrics", httpserver.PrometheusHandlerFor(opts))
 func PrometheusHandlerFor(opts promhttp.HandlerOpts) http.Handler {
-	return promhttp.HandlerFor(nil, opts)
+	return promhttp.HandlerFor(prometheus.DefaultGatherer, opts)
+}
+
+// PrometheusRegistryHandler returns a Prometheus handler for the given
+// registry, such as the one passed to the OpenTelemetry Prometheus exporter
+// with WithRegisterer. Serving the registry the exporter writes to avoids
+// exposing an empty or unrelated set of metrics.
+//
+// Example:
+//
+//	registry := prometheus.NewRegistry()
+//	exporter, _ := otelprom.New(otelprom.WithRegisterer(registry))
+//	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(exporter))
+//
+//	server := httpserver.New(
+//	    httpserver.WithMetrics(httpserver.MetricsConfig{MeterProvider: mp}),
+//	    httpserver.WithHandler(mux),
+//	)
+//	mux.Handle("/metrics", httpserver.PrometheusRegistryHandler(registry))
+func PrometheusRegistryHandler(gatherer prometheus.Gatherer) http.Handler {
+	return promhttp.HandlerFor(gatherer, promhttp.HandlerOpts{})
 }
@@ -3,6 +3,7 @@ package httpserver_test
 import (
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"testing"
 	"time"
 
@@ -244,6 +245,46 @@ func TestRateLimit_Redis(t *testing.T) {
 		assert.Equal(t, http.StatusOK, rec.Code)
 	})
 
+	t.Run("given redis rate limit, then sets quota headers", func(t *testing.T) {
+		mr := miniredis.RunT(t)
+		rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+		defer rdb.Close()
+
+		// Rate: 1 per second, Burst: 2
+		chain := httpserver.RateLimit(httpserver.RateLimitConfig{
+			Limit: 1,
+			Burst: 2,
+			Redis: rdb,
+		})(okHandler())
+
+		tests := []struct {
+			wantStatus    int
+			wantRemaining string
+		}{
+			{wantStatus: http.StatusOK, wantRemaining: "1"},
+			{wantStatus: http.StatusOK, wantRemaining: "0"},
+			{wantStatus: http.StatusTooManyRequests, wantRemaining: "0"},
+		}
+
+		for i, tt := range tests {
+			before := time.Now()
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			rec := httptest.NewRecorder()
+			chain.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code, "request %d", i+1)
+			assert.Equal(t, tt.wantRemaining,
+				rec.Header().Get(httpserver.RateLimitRemainingHeader), "request %d", i+1)
+
+			// The next token arrives within a second at 1 token per second
+			resetHeader := rec.Header().Get(httpserver.RateLimitResetHeader)
+			reset, err := strconv.ParseInt(resetHeader, 10, 64)
+			require.NoError(t, err)
+			assert.GreaterOrEqual(t, reset, before.Unix())
+			assert.LessOrEqual(t, reset, before.Add(2*time.Second).Unix())
+		}
+	})
+
 	t.Run("given redis failure, then fails open (allows request)", func(t *testing.T) {
 		// Create a client pointing to non-existent Redis
 		rdb := redis.NewClient(&redis.Options{Addr: "localhost:59999"})
@@ -56,10 +56,15 @@ type Error struct {
 //	    Message: "success",
 //	})
 func WriteJSON[T any](w http.ResponseWriter, statusCode int, response Response[T]) {
+	writeJSON(w, statusCode, response)
+}
+
+// writeJSON encodes v as the JSON body of a response with the given status code.
+func writeJSON(w http.ResponseWriter, statusCode int, v any) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(statusCode)
 
-	if err := json.NewEncoder(w).Encode(response); err != nil {
+	if err := json.NewEncoder(w).Encode(v); err != nil {
 		// Log encoding error - we can't send a new HTTP error since headers are already written
 		log.Error().
 			Err(err).
@@ -0,0 +1,175 @@
+package httpserver
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ErrorInfo describes an error response written by WriteRequestError.
+type ErrorInfo struct {
+	// Status is the HTTP status code of the response.
+	Status int
+
+	// Code is a stable, machine-readable error code, e.g. "order_not_found".
+	Code string
+
+	// Message is a human-readable description of the error.
+	Message string
+
+	// Details holds optional extra information, e.g. field-level errors.
+	Details []any
+
+	// RequestID is the request ID set by the RequestID middleware, if any.
+	RequestID string
+
+	// TraceID is the trace ID of the active span, if any.
+	TraceID string
+}
+
+// ErrorEnvelope builds the JSON body of an error response from an ErrorInfo,
+// so error responses can follow an organization's API standard.
+type ErrorEnvelope func(info ErrorInfo) any
+
+// errorEnvelopeKey is the context key for the server's ErrorEnvelope.
+type errorEnvelopeKey struct{}
+
+// errorBody is the error object of DefaultErrorEnvelope.
+type errorBody struct {
+	Code      string `json:"code"`
+	Message   string `json:"message"`
+	Details   []any  `json:"details,omitempty"`
+	RequestID string `json:"request_id,omitempty"`
+	TraceID   string `json:"trace_id,omitempty"`
+}
+
+// DefaultErrorEnvelope is the ErrorEnvelope used unless the server sets
+// another with WithErrorEnvelope.
+//
+// Example body:
+//
+//	{
+//	  "error": {
+//	    "code": "order_not_found",
+//	    "message": "order 42 does not exist",
+//	    "request_id": "7f1c...",
+//	    "trace_id": "4bf92f3577b34da6a3ce929d0e0e4736"
+//	  }
+//	}
+func DefaultErrorEnvelope(info ErrorInfo) any {
+	return struct {
+		Error errorBody `json:"error"`
+	}{
+		Error: errorBody{
+			Code:      info.Code,
+			Message:   info.Message,
+			Details:   info.Details,
+			RequestID: info.RequestID,
+			TraceID:   info.TraceID,
+		},
+	}
+}
+
+// WriteRequestError writes a JSON error response in the server's error
+// envelope, with the request and trace IDs taken from the request context.
+//
+// Unlike WriteError, which writes the field-level Response shape, it carries
+// an error code and correlation IDs so clients and support staff can match
+// an error to its logs and trace. The body is built by the ErrorEnvelope set
+// with WithErrorEnvelope, or DefaultErrorEnvelope.
+//
+// Example:
+//
+//	httpserver.WriteRequestError(w, r, http.StatusNotFound,
+//	    "order_not_found", "order 42 does not exist",
+//	)
+//
+// Example (with details):
+//
+//	httpserver.WriteRequestError(w, r, http.StatusBadRequest,
+//	    "validation_failed", "invalid order",
+//	    httpserver.Error{Field: "quantity", Message: "must be positive"},
+//	)
+func WriteRequestError(
+	w http.ResponseWriter,
+	r *http.Request,
+	status int,
+	code, message string,
+	details ...any,
+) {
+	envelope, _ := r.Context().Value(errorEnvelopeKey{}).(ErrorEnvelope)
+	if envelope == nil {
+		envelope = DefaultErrorEnvelope
+	}
+	writeEnvelope(w, r, envelope, status, code, message, details)
+}
+
+// WriteCodedError writes a JSON error response for the handlers and
+// middleware of this package and its adapters. If the server sets an
+// ErrorEnvelope with WithErrorEnvelope, the body is built by it as in
+// WriteRequestError, with code as its stable error code and errors as its
+// details. Otherwise it is the WriteError shape.
+//
+// Example:
+//
+//	httpserver.WriteCodedError(w, r, http.StatusTooManyRequests,
+//	    "rate_limited", "rate limit exceeded",
+//	    httpserver.Error{Field: "rate_limit", Message: "too many requests"},
+//	)
+func WriteCodedError(
+	w http.ResponseWriter,
+	r *http.Request,
+	status int,
+	code, message string,
+	errors ...Error,
+) {
+	envelope, _ := r.Context().Value(errorEnvelopeKey{}).(ErrorEnvelope)
+	if envelope == nil {
+		WriteError(w, status, message, errors...)
+		return
+	}
+
+	details := make([]any, len(errors))
+	for i, e := range errors {
+		details[i] = e
+	}
+	writeEnvelope(w, r, envelope, status, code, message, details)
+}
+
+// writeEnvelope writes the body built by envelope, with the request and
+// trace IDs taken from the request context.
+func writeEnvelope(
+	w http.ResponseWriter,
+	r *http.Request,
+	envelope ErrorEnvelope,
+	status int,
+	code, message string,
+	details []any,
+) {
+	ctx := r.Context()
+
+	info := ErrorInfo{
+		Status:    status,
+		Code:      code,
+		Message:   message,
+		Details:   details,
+		RequestID: RequestIDFromContext(ctx),
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.HasTraceID() {
+		info.TraceID = sc.TraceID().String()
+	}
+
+	writeJSON(w, status, envelope(info))
+}
+
+// withErrorEnvelope returns middleware that makes envelope available to
+// WriteRequestError and WriteCodedError.
+func withErrorEnvelope(envelope ErrorEnvelope) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := context.WithValue(r.Context(), errorEnvelopeKey{}, envelope)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
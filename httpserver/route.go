@@ -0,0 +1,157 @@
+package httpserver
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// UnknownRoute is the route label used by metrics and tracing when no route
+// template is available for a request.
+//
+// Concrete paths are never used as labels, so requests that bypass routing
+// (for example, 404s from an unmatched path) collapse into this single value
+// instead of creating one time series per URL.
+const UnknownRoute = "unknown_route"
+
+// RouteTagger returns the route template for a request, such as
+// "/users/{id}". Returning an empty string means the template is unknown.
+type RouteTagger func(r *http.Request) string
+
+// routeKey is the context key for the matched route template.
+type routeKey struct{}
+
+// ContextWithRoute returns a copy of ctx carrying the matched route template.
+//
+// Framework adapters call this with the router's matched pattern before
+// invoking httpserver middleware, so metrics and spans are labeled with the
+// template rather than the concrete path.
+//
+// Example:
+//
+//	ctx := httpserver.ContextWithRoute(r.Context(), "/users/{id}")
+//	next.ServeHTTP(w, r.WithContext(ctx))
+func ContextWithRoute(ctx context.Context, route string) context.Context {
+	return context.WithValue(ctx, routeKey{}, route)
+}
+
+// RouteFromContext returns the route template stored in ctx.
+//
+// Returns an empty string if no route is present.
+func RouteFromContext(ctx context.Context) string {
+	if route, ok := ctx.Value(routeKey{}).(string); ok {
+		return route
+	}
+	return ""
+}
+
+// SetRoute returns a shallow copy of r whose context carries the route
+// template, like ContextWithRoute.
+//
+// Unlike ContextWithRoute, the route is also reported back to the Tracing
+// and Metrics middleware wrapping the handler, so routers and handlers that
+// only know the template after routing can still label the request. The
+// reported route is used when those middleware found no route themselves.
+//
+// Example:
+//
+//	func (h *UserHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+//	    r = httpserver.SetRoute(r, "/users/{id}")
+//	    // ...
+//	}
+func SetRoute(r *http.Request, route string) *http.Request {
+	if slot, ok := r.Context().Value(routeSlotKey{}).(*routeSlot); ok {
+		slot.route = route
+	}
+	return r.WithContext(ContextWithRoute(r.Context(), route))
+}
+
+// WithRoute returns middleware that sets the route template of every request
+// it handles with SetRoute. Use it to label handlers registered on routers
+// that don't expose their matched pattern.
+//
+// Example:
+//
+//	router.Handle("/users/{id}", httpserver.WithRoute("/users/{id}")(userHandler))
+func WithRoute(route string) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, SetRoute(r, route))
+		})
+	}
+}
+
+// routeSlotKey is the context key for the routeSlot of a request.
+type routeSlotKey struct{}
+
+// routeSlot receives the route set by SetRoute further down the chain.
+type routeSlot struct {
+	route string
+}
+
+// withRouteSlot returns r with a routeSlot in its context, reusing the slot
+// of an enclosing middleware so all of them see the same route.
+func withRouteSlot(r *http.Request) (*http.Request, *routeSlot) {
+	if slot, ok := r.Context().Value(routeSlotKey{}).(*routeSlot); ok {
+		return r, slot
+	}
+	slot := &routeSlot{}
+	return r.WithContext(context.WithValue(r.Context(), routeSlotKey{}, slot)), slot
+}
+
+// resolve returns route, or the route set downstream if route is
+// UnknownRoute.
+func (s *routeSlot) resolve(route string) string {
+	if route == UnknownRoute && s.route != "" {
+		return s.route
+	}
+	return route
+}
+
+// resolveRoute returns the route label for r.
+//
+// Resolution order:
+//  1. The configured RouteTagger, if it returns a non-empty value
+//  2. The route stored in the request context (set by adapters or the server)
+//  3. The pattern matched by http.ServeMux
+//  4. UnknownRoute
+func resolveRoute(r *http.Request, tagger RouteTagger) string {
+	if tagger != nil {
+		if route := tagger(r); route != "" {
+			return route
+		}
+	}
+	if route := RouteFromContext(r.Context()); route != "" {
+		return route
+	}
+	if r.Pattern != "" {
+		return patternPath(r.Pattern)
+	}
+	return UnknownRoute
+}
+
+// muxRoute returns middleware that looks up the pattern mux would use for
+// the request and stores it in the context, so middleware running before the
+// mux can label requests by route.
+func muxRoute(mux *http.ServeMux) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if _, pattern := mux.Handler(r); pattern != "" {
+				r = r.WithContext(ContextWithRoute(r.Context(), patternPath(pattern)))
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// patternPath strips the optional method and host from a ServeMux pattern,
+// turning "GET example.com/users/{id}" into "/users/{id}".
+func patternPath(pattern string) string {
+	if i := strings.IndexByte(pattern, ' '); i >= 0 {
+		pattern = strings.TrimLeft(pattern[i+1:], " \t")
+	}
+	if i := strings.IndexByte(pattern, '/'); i > 0 {
+		pattern = pattern[i:]
+	}
+	return pattern
+}
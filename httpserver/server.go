@@ -7,6 +7,7 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/rs/zerolog"
 )
@@ -31,6 +32,7 @@ type Server struct {
 	config      Config
 	logger      zerolog.Logger
 	serviceName string
+	middlewares []Middleware
 }
 
 // New creates a new Server with the provided options.
@@ -74,10 +76,23 @@ func New(opts ...Option) *Server {
 	// Build middleware stack, injecting ServiceName automatically
 	var middlewares []Middleware
 
+	// Make the error envelope available to WriteRequestError
+	if cfg.ErrorEnvelope != nil {
+		middlewares = append(middlewares, withErrorEnvelope(cfg.ErrorEnvelope))
+	}
+
+	// Report server timings, covering the rest of the stack
+	if cfg.ServerTiming {
+		middlewares = append(middlewares, ServerTiming())
+	}
+
 	// Add tracing if configured
 	if cfg.TracingConfig != nil {
 		tracingCfg := *cfg.TracingConfig
 		tracingCfg.serviceName = cfg.ServiceName
+		if tracingCfg.RouteTagger == nil {
+			tracingCfg.RouteTagger = cfg.RouteTagger
+		}
 		middlewares = append(middlewares, Tracing(tracingCfg))
 	}
 
@@ -85,6 +100,15 @@ func New(opts ...Option) *Server {
 	if cfg.MetricsConfig != nil {
 		metricsCfg := *cfg.MetricsConfig
 		metricsCfg.serviceName = cfg.ServiceName
+		if metricsCfg.RouteTagger == nil {
+			metricsCfg.RouteTagger = cfg.RouteTagger
+		}
+		if metricsCfg.RouteSLO == nil {
+			metricsCfg.RouteSLO = cfg.RouteSLO
+		}
+		if cfg.DisableExemplars {
+			metricsCfg.DisableExemplars = true
+		}
 		metrics, _ := NewMetrics(metricsCfg)
 		if metrics != nil {
 			middlewares = append(middlewares, metrics.Middleware())
@@ -114,10 +138,19 @@ func New(opts ...Option) *Server {
 	// Add user-provided middleware
 	middlewares = append(middlewares, cfg.Middleware...)
 
-	// Wrap handler with middleware
+	// Replace the mux's plaintext 404/405 responses with JSON, and resolve
+	// the mux pattern up front so every middleware can label requests by
+	// route template instead of the raw path
 	handler := cfg.Handler
-	if handler != nil && len(middlewares) > 0 {
-		handler = Chain(middlewares...)(handler)
+	chain := middlewares
+	if mux, ok := handler.(*http.ServeMux); ok {
+		handler = muxFallback(mux, cfg.NotFoundHandler, cfg.MethodNotAllowedHandler)
+		chain = append([]Middleware{muxRoute(mux)}, middlewares...)
+	}
+
+	// Wrap handler with middleware
+	if handler != nil && len(chain) > 0 {
+		handler = Chain(chain...)(handler)
 	}
 
 	httpServer := &http.Server{
@@ -131,11 +164,17 @@ func New(opts ...Option) *Server {
 		TLSConfig:         cfg.TLSConfig,
 	}
 
+	// Close keep-alive connections that reached their request or age limit
+	if limiter := newConnLimiter(cfg.MaxRequestsPerConn, cfg.ConnMaxAge); limiter != nil {
+		limiter.install(httpServer)
+	}
+
 	return &Server{
 		httpServer:  httpServer,
 		config:      cfg,
 		logger:      logger,
 		serviceName: cfg.ServiceName,
+		middlewares: middlewares,
 	}
 }
 
@@ -146,9 +185,11 @@ func New(opts ...Option) *Server {
 //   - SIGTERM or SIGINT is received
 //
 // During shutdown:
-//  1. Server stops accepting new connections
-//  2. Waits up to ShutdownTimeout for in-flight requests
-//  3. Returns nil on clean shutdown, or error if shutdown times out
+//  1. If PreShutdownDelay is set, readiness fails while the server keeps
+//     serving for that long
+//  2. Server stops accepting new connections
+//  3. Waits up to ShutdownTimeout for in-flight requests
+//  4. Returns nil on clean shutdown, or error if shutdown times out
 //
 // Example:
 //
@@ -234,6 +275,8 @@ func (s *Server) serve(ctx context.Context, useTLS bool, certFile, keyFile strin
 
 // shutdown performs graceful shutdown of the server.
 func (s *Server) shutdown(ctx context.Context) error {
+	s.preShutdown()
+
 	s.logger.Info().
 		Dur("timeout", s.config.ShutdownTimeout).
 		Msg("starting graceful shutdown")
@@ -262,6 +305,24 @@ func (s *Server) shutdown(ctx context.Context) error {
 	return nil
 }
 
+// preShutdown fails the readiness probe and keeps serving for
+// PreShutdownDelay, so load balancers stop routing traffic here before the
+// server stops accepting connections.
+func (s *Server) preShutdown() {
+	if s.config.PreShutdownDelay <= 0 {
+		return
+	}
+
+	if s.config.HealthHandler != nil && *s.config.HealthHandler != nil {
+		(*s.config.HealthHandler).MarkShuttingDown()
+	}
+
+	s.logger.Info().
+		Dur("delay", s.config.PreShutdownDelay).
+		Msg("readiness failing, waiting before shutdown")
+	time.Sleep(s.config.PreShutdownDelay)
+}
+
 // Shutdown initiates graceful shutdown of the server.
 //
 // This is useful when you want to trigger shutdown programmatically
@@ -286,7 +347,57 @@ func (s *Server) Addr() string {
 	return s.httpServer.Addr
 }
 
+// Handler returns the server's handler with all configured middleware applied.
+//
+// This is useful for serving the fully wired handler from tests or an
+// existing http.Server:
+//
+//	ts := httptest.NewServer(server.Handler())
+func (s *Server) Handler() http.Handler {
+	return s.httpServer.Handler
+}
+
+// Middlewares returns the middleware stack built from the server's options,
+// in the order it is applied: tracing, metrics, logging, rate limiting, then
+// any middleware added with WithMiddleware.
+//
+// Framework adapters use this to install the same stack on routers that are
+// served outside the server's own handler. Build the server without
+// WithHandler when using it only as a middleware source, so the stack is
+// not applied twice.
+//
+// Example:
+//
+//	server := httpserver.New(
+//	    httpserver.WithServiceName("my-api"),
+//	    httpserver.WithTracing(httpserver.DefaultTracingConfig()),
+//	    httpserver.WithMetrics(httpserver.DefaultMetricsConfig()),
+//	    httpserver.WithMiddleware(httpserver.Recovery(logger), httpserver.RequestID()),
+//	)
+//	e.Use(echosentinel.Middlewares(server)...)
+func (s *Server) Middlewares() []Middleware {
+	return append([]Middleware(nil), s.middlewares...)
+}
+
 // ServiceName returns the configured service name.
 func (s *Server) ServiceName() string {
 	return s.serviceName
 }
+
+// Config returns the server's effective configuration, after presets,
+// options and defaults have been applied, e.g. for startup logging.
+//
+// The returned Config is a copy, but pointer fields such as TracingConfig
+// are shared with the server and must not be modified.
+//
+// Example:
+//
+//	cfg := server.Config()
+//	logger.Info().
+//	    Str("addr", cfg.Addr).
+//	    Dur("write_timeout", cfg.WriteTimeout).
+//	    Dur("shutdown_timeout", cfg.ShutdownTimeout).
+//	    Msg("starting server")
+func (s *Server) Config() Config {
+	return s.config
+}
@@ -0,0 +1,74 @@
+package sql
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestConfig_RecordArgs(t *testing.T) {
+	args := []driver.NamedValue{
+		{Ordinal: 1, Value: "john"},
+		{Ordinal: 2, Value: int64(42)},
+		{Ordinal: 3, Value: nil},
+		{Name: "token", Ordinal: 4, Value: []byte("s3cr3t")},
+	}
+
+	tests := []struct {
+		name     string
+		cfg      *config
+		args     []driver.NamedValue
+		wantArgs string
+	}{
+		{
+			name: "given default mode, then records nothing",
+			cfg:  &config{},
+			args: args,
+		},
+		{
+			name:     "given masked mode, then records types only",
+			cfg:      &config{ArgsCapture: ArgsMasked},
+			args:     args,
+			wantArgs: "$1=<string>, $2=<int64>, $3=NULL, token=<[]uint8>",
+		},
+		{
+			name:     "given full mode, then records values",
+			cfg:      &config{ArgsCapture: ArgsFull},
+			args:     args,
+			wantArgs: `$1="john", $2=42, $3=NULL, token="s3cr3t"`,
+		},
+		{
+			name: "given full mode with DisableQuery, then records nothing",
+			cfg:  &config{ArgsCapture: ArgsFull, DisableQuery: true},
+			args: args,
+		},
+		{
+			name: "given no args, then records nothing",
+			cfg:  &config{ArgsCapture: ArgsMasked},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exporter := tracetest.NewInMemoryExporter()
+			tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+			_, span := tp.Tracer("test").Start(context.Background(), "SELECT")
+			tt.cfg.recordArgs(span, tt.args)
+			span.End()
+
+			spans := exporter.GetSpans()
+			require.Len(t, spans, 1)
+			set := attribute.NewSet(spans[0].Attributes...)
+			value, ok := set.Value("db.statement.args")
+			assert.Equal(t, tt.wantArgs != "", ok)
+			assert.Equal(t, tt.wantArgs, value.AsString())
+		})
+	}
+}
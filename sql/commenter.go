@@ -0,0 +1,61 @@
+package sql
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// commentSafeSystems lists the database systems known to accept a trailing
+// block comment on any statement.
+var commentSafeSystems = map[string]bool{
+	"postgresql":  true,
+	"cockroachdb": true,
+	"mysql":       true,
+	"mariadb":     true,
+	"sqlite":      true,
+	"mssql":       true,
+	"oracle":      true,
+}
+
+// comment appends a sqlcommenter-style comment carrying the traceparent,
+// service name, and operation to query when the SQL commenter is enabled.
+//
+// The query is returned unchanged when the database system is unknown,
+// when the query already contains a comment, or when it is a single token,
+// which some drivers (e.g. pgx) treat as the name of a prepared statement.
+func (cfg *config) comment(ctx context.Context, query string) string {
+	if !cfg.SQLCommenter || !commentSafeSystems[cfg.DBSystem] {
+		return query
+	}
+
+	trimmed := strings.TrimRight(query, " \t\r\n;")
+	if !strings.ContainsAny(trimmed, " \t\r\n") ||
+		strings.Contains(trimmed, "/*") || strings.Contains(trimmed, "--") {
+		return query
+	}
+
+	// Keys are sorted, as required by the sqlcommenter specification.
+	tags := make([]string, 0, 3)
+	if operation := extractOperation(trimmed); operation != "" {
+		tags = append(tags, commentTag("operation", operation))
+	}
+	if cfg.CommenterService != "" {
+		tags = append(tags, commentTag("service", cfg.CommenterService))
+	}
+	carrier := propagation.MapCarrier{}
+	propagation.TraceContext{}.Inject(ctx, carrier)
+	if traceparent := carrier.Get("traceparent"); traceparent != "" {
+		tags = append(tags, commentTag("traceparent", traceparent))
+	}
+
+	return trimmed + " /*" + strings.Join(tags, ",") + "*/" + query[len(trimmed):]
+}
+
+// commentTag formats a sqlcommenter key='value' pair. The value is URL
+// encoded so it cannot close the comment or the quotes.
+func commentTag(key, value string) string {
+	return key + "='" + url.QueryEscape(value) + "'"
+}
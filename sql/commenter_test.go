@@ -0,0 +1,106 @@
+package sql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestConfig_Comment(t *testing.T) {
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6},
+		SpanID:     trace.SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+		TraceFlags: trace.FlagsSampled,
+	})
+	traceparent := "00-4bf92f3577b34da60000000000000000-00f067aa0ba902b7-01"
+
+	tests := []struct {
+		name    string
+		cfg     *config
+		query   string
+		sampled bool
+		want    string
+	}{
+		{
+			name:    "given commenter disabled, then leaves query unchanged",
+			cfg:     &config{DBSystem: "postgresql"},
+			query:   "SELECT * FROM users",
+			sampled: true,
+			want:    "SELECT * FROM users",
+		},
+		{
+			name:    "given span context, then appends operation, service and traceparent",
+			cfg:     &config{DBSystem: "postgresql", SQLCommenter: true, CommenterService: "api"},
+			query:   "SELECT * FROM users",
+			sampled: true,
+			want: "SELECT * FROM users /*operation='SELECT',service='api'," +
+				"traceparent='" + traceparent + "'*/",
+		},
+		{
+			name:  "given no span context, then omits traceparent",
+			cfg:   &config{DBSystem: "mysql", SQLCommenter: true},
+			query: "UPDATE users SET name = ?",
+			want:  "UPDATE users SET name = ? /*operation='UPDATE'*/",
+		},
+		{
+			name:  "given trailing semicolon, then inserts comment before it",
+			cfg:   &config{DBSystem: "postgresql", SQLCommenter: true},
+			query: "DELETE FROM users;",
+			want:  "DELETE FROM users /*operation='DELETE'*/;",
+		},
+		{
+			name:  "given service with special characters, then URL encodes it",
+			cfg:   &config{DBSystem: "postgresql", SQLCommenter: true, CommenterService: "a'b*/c"},
+			query: "SELECT 1 FROM users",
+			want:  "SELECT 1 FROM users /*operation='SELECT',service='a%27b%2A%2Fc'*/",
+		},
+		{
+			name:  "given unknown database system, then leaves query unchanged",
+			cfg:   &config{SQLCommenter: true},
+			query: "SELECT * FROM users",
+			want:  "SELECT * FROM users",
+		},
+		{
+			name:  "given existing block comment, then leaves query unchanged",
+			cfg:   &config{DBSystem: "postgresql", SQLCommenter: true},
+			query: "SELECT /*+ INDEX(users) */ * FROM users",
+			want:  "SELECT /*+ INDEX(users) */ * FROM users",
+		},
+		{
+			name:  "given line comment, then leaves query unchanged",
+			cfg:   &config{DBSystem: "postgresql", SQLCommenter: true},
+			query: "SELECT * FROM users -- all",
+			want:  "SELECT * FROM users -- all",
+		},
+		{
+			name:  "given single token, then treats it as a statement name",
+			cfg:   &config{DBSystem: "postgresql", SQLCommenter: true},
+			query: "get_user_by_id",
+			want:  "get_user_by_id",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.sampled {
+				ctx = trace.ContextWithSpanContext(ctx, sc)
+			}
+
+			assert.Equal(t, tt.want, tt.cfg.comment(ctx, tt.query))
+		})
+	}
+}
+
+func TestWithSQLCommenter(t *testing.T) {
+	t.Run("given OTEL_SERVICE_NAME, then uses it as service", func(t *testing.T) {
+		t.Setenv("OTEL_SERVICE_NAME", "checkout")
+
+		cfg := newConfig(WithSQLCommenter(true))
+
+		assert.True(t, cfg.SQLCommenter)
+		assert.Equal(t, "checkout", cfg.CommenterService)
+	})
+}
@@ -3,10 +3,10 @@ package sql
 import (
 	"context"
 	"database/sql/driver"
+	"strings"
 	"time"
 
 	"go.opentelemetry.io/otel/attribute"
-	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -26,6 +26,11 @@ var (
 type otelConn struct {
 	conn driver.Conn
 	cfg  *config
+
+	// tx is the span context of the BEGIN span of the open transaction, or
+	// the zero value outside a transaction. database/sql uses a connection
+	// from one goroutine at a time, so it needs no locking.
+	tx trace.SpanContext
 }
 
 // newOtelConn creates a new instrumented connection.
@@ -42,7 +47,9 @@ func (c *otelConn) Prepare(query string) (driver.Stmt, error) {
 	if err != nil {
 		return nil, err
 	}
-	return newOtelStmt(stmt, c.cfg, query), nil
+	otelStmt := newOtelStmt(stmt, c.cfg, query)
+	otelStmt.conn = c
+	return otelStmt, nil
 }
 
 // Close implements driver.Conn.
@@ -53,11 +60,12 @@ func (c *otelConn) Close() error {
 // Begin implements driver.Conn.
 // Deprecated: Use BeginTx instead. This exists for driver.Conn interface compatibility.
 func (c *otelConn) Begin() (driver.Tx, error) {
+	start := time.Now()
 	tx, err := c.conn.Begin() //nolint:staticcheck // Required for driver.Conn interface
 	if err != nil {
 		return nil, err
 	}
-	return newOtelTx(tx, c.cfg), nil
+	return newOtelTx(tx, c.cfg, start), nil
 }
 
 // PrepareContext implements driver.ConnPrepareContext.
@@ -74,14 +82,16 @@ func (c *otelConn) PrepareContext(ctx context.Context, query string) (driver.Stm
 	if err != nil {
 		return nil, err
 	}
-	return newOtelStmt(stmt, c.cfg, query), nil
+	otelStmt := newOtelStmt(stmt, c.cfg, query)
+	otelStmt.conn = c
+	return otelStmt, nil
 }
 
 // BeginTx implements driver.ConnBeginTx.
 func (c *otelConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
 	start := time.Now()
 	ctx, span := c.cfg.Tracer.Start(ctx, "BEGIN",
-		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithSpanKind(c.cfg.SpanKind),
 		trace.WithAttributes(c.cfg.baseAttributes()...),
 	)
 	defer span.End()
@@ -99,12 +109,14 @@ func (c *otelConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.T
 	c.cfg.Metrics.recordQueryDuration(ctx, time.Since(start), "BEGIN", c.cfg.baseAttributes(), err)
 
 	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, err.Error())
+		recordError(span, err)
 		return nil, err
 	}
 
-	return newOtelTx(tx, c.cfg), nil
+	c.tx = span.SpanContext()
+	otelTx := newOtelTx(tx, c.cfg, start)
+	otelTx.conn = c
+	return otelTx, nil
 }
 
 // ExecContext implements driver.ExecerContext.
@@ -116,27 +128,27 @@ func (c *otelConn) ExecContext(
 	start := time.Now()
 	operation := extractOperation(query)
 
-	ctx, span := c.cfg.Tracer.Start(ctx, spanName(query),
-		trace.WithSpanKind(trace.SpanKindClient),
-		trace.WithAttributes(c.cfg.queryAttributes(query)...),
+	ctx, span := c.cfg.Tracer.Start(ctx, c.cfg.spanName(query),
+		trace.WithSpanKind(c.cfg.SpanKind),
+		trace.WithAttributes(c.cfg.queryAttributes(ctx, query)...),
+		trace.WithAttributes(txAttributes(c.tx)...),
+		trace.WithLinks(txLinks(c.tx)...),
 	)
 	defer span.End()
+	c.cfg.recordArgs(span, args)
 
 	if execer, ok := c.conn.(driver.ExecerContext); ok {
-		result, err := execer.ExecContext(ctx, query, args)
+		ctx, cancel, timeout := c.cfg.withQueryTimeout(ctx)
+		defer cancel()
+
+		result, err := execer.ExecContext(ctx, c.cfg.comment(ctx, query), args)
 
 		// Record metrics
-		c.cfg.Metrics.recordQueryDuration(
-			ctx,
-			time.Since(start),
-			operation,
-			c.cfg.baseAttributes(),
-			err,
-		)
+		c.cfg.recordExec(ctx, span, time.Since(start), query, operation, result, err)
 
 		if err != nil {
-			span.RecordError(err)
-			span.SetStatus(codes.Error, err.Error())
+			recordError(span, err)
+			c.cfg.recordTimeout(ctx, span, timeout, operation, err)
 			return nil, err
 		}
 		return result, nil
@@ -155,30 +167,32 @@ func (c *otelConn) QueryContext(
 	start := time.Now()
 	operation := extractOperation(query)
 
-	ctx, span := c.cfg.Tracer.Start(ctx, spanName(query),
-		trace.WithSpanKind(trace.SpanKindClient),
-		trace.WithAttributes(c.cfg.queryAttributes(query)...),
+	ctx, span := c.cfg.Tracer.Start(ctx, c.cfg.spanName(query),
+		trace.WithSpanKind(c.cfg.SpanKind),
+		trace.WithAttributes(c.cfg.queryAttributes(ctx, query)...),
+		trace.WithAttributes(txAttributes(c.tx)...),
+		trace.WithLinks(txLinks(c.tx)...),
 	)
 	defer span.End()
+	c.cfg.recordArgs(span, args)
 
 	if queryer, ok := c.conn.(driver.QueryerContext); ok {
-		rows, err := queryer.QueryContext(ctx, query, args)
+		// The timeout also bounds reading the rows, so on success it is
+		// released when the rows are closed.
+		ctx, cancel, timeout := c.cfg.withQueryTimeout(ctx)
+
+		rows, err := queryer.QueryContext(ctx, c.cfg.comment(ctx, query), args)
 
 		// Record metrics
-		c.cfg.Metrics.recordQueryDuration(
-			ctx,
-			time.Since(start),
-			operation,
-			c.cfg.baseAttributes(),
-			err,
-		)
+		c.cfg.recordQuery(ctx, time.Since(start), query, operation, err)
 
 		if err != nil {
-			span.RecordError(err)
-			span.SetStatus(codes.Error, err.Error())
+			recordError(span, err)
+			c.cfg.recordTimeout(ctx, span, timeout, operation, err)
+			cancel()
 			return nil, err
 		}
-		return rows, nil
+		return withTimeoutRows(rows, cancel, timeout), nil
 	}
 
 	// Fallback: let database/sql handle it
@@ -189,7 +203,7 @@ func (c *otelConn) QueryContext(
 func (c *otelConn) Ping(ctx context.Context) error {
 	start := time.Now()
 	ctx, span := c.cfg.Tracer.Start(ctx, "PING",
-		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithSpanKind(c.cfg.SpanKind),
 		trace.WithAttributes(c.cfg.baseAttributes()...),
 	)
 	defer span.End()
@@ -203,8 +217,7 @@ func (c *otelConn) Ping(ctx context.Context) error {
 	c.cfg.Metrics.recordQueryDuration(ctx, time.Since(start), "PING", c.cfg.baseAttributes(), err)
 
 	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, err.Error())
+		recordError(span, err)
 		return err
 	}
 
@@ -242,23 +255,53 @@ func (cfg *config) baseAttributes() []attribute.KeyValue {
 	return attrs
 }
 
-// queryAttributes returns attributes for query spans.
-func (cfg *config) queryAttributes(query string) []attribute.KeyValue {
-	attrs := cfg.baseAttributes()
+// metricAttributes returns the base attributes followed by the attributes
+// SpanAttributesFn derives from ctx.
+func (cfg *config) metricAttributes(ctx context.Context) []attribute.KeyValue {
+	return append(cfg.baseAttributes(), cfg.contextAttributes(ctx)...)
+}
 
-	if !cfg.DisableQuery && query != "" {
-		sanitized := query
-		if cfg.QuerySanitizer != nil {
-			sanitized = cfg.QuerySanitizer(query)
-		}
-		attrs = append(attrs, attribute.String("db.statement", sanitized))
+// contextAttributes returns the attributes SpanAttributesFn derives from
+// ctx, or nil when it is not set.
+func (cfg *config) contextAttributes(ctx context.Context) []attribute.KeyValue {
+	if cfg.SpanAttributesFn == nil {
+		return nil
+	}
+	return cfg.SpanAttributesFn(ctx)
+}
+
+// queryAttributes returns attributes for query spans, including those
+// SpanAttributesFn derives from ctx.
+func (cfg *config) queryAttributes(ctx context.Context, query string) []attribute.KeyValue {
+	attrs := cfg.metricAttributes(ctx)
+
+	if statement, ok := cfg.statement(query); ok {
+		attrs = append(attrs, attribute.String("db.statement", statement))
 	}
 
-	// Extract operation from query
-	op := extractOperation(query)
+	op, target := cfg.operation(query)
 	if op != "" {
 		attrs = append(attrs, attribute.String("db.operation", op))
 	}
+	if op == batchOperation {
+		attrs = append(attrs, attribute.String("db.operations",
+			strings.Join(statementOperations(query), ";")))
+	}
+	if target != "" {
+		attrs = append(attrs, attribute.String("db.sql.table", target))
+	}
 
 	return attrs
 }
+
+// statement returns the query as it may be recorded in telemetry, applying
+// the sanitizer. Returns false when queries must not be recorded.
+func (cfg *config) statement(query string) (string, bool) {
+	if cfg.DisableQuery || query == "" {
+		return "", false
+	}
+	if cfg.QuerySanitizer != nil {
+		return cfg.QuerySanitizer(query), true
+	}
+	return query, true
+}
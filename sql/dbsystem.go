@@ -0,0 +1,74 @@
+package sql
+
+import (
+	"strings"
+	"sync"
+)
+
+// knownDBSystems are the OpenTelemetry semantic convention db.system values
+// of SQL databases.
+var knownDBSystems = map[string]bool{
+	"clickhouse":  true,
+	"cockroachdb": true,
+	"db2":         true,
+	"derby":       true,
+	"firebird":    true,
+	"h2":          true,
+	"hanadb":      true,
+	"hsqldb":      true,
+	"informix":    true,
+	"mariadb":     true,
+	"mssql":       true,
+	"mysql":       true,
+	"oracle":      true,
+	"other_sql":   true,
+	"postgresql":  true,
+	"redshift":    true,
+	"spanner":     true,
+	"sqlite":      true,
+	"sybase":      true,
+	"teradata":    true,
+	"trino":       true,
+	"vertica":     true,
+}
+
+// dbSystemAliases maps common driver and product names to their semantic
+// convention db.system value.
+var dbSystemAliases = map[string]string{
+	"postgres":  "postgresql",
+	"pg":        "postgresql",
+	"pgx":       "postgresql",
+	"sqlite3":   "sqlite",
+	"sqlserver": "mssql",
+	"cockroach": "cockroachdb",
+	"crdb":      "cockroachdb",
+	"godror":    "oracle",
+}
+
+// warnedDBSystems holds the unknown db.system values already warned about,
+// so each is logged once per process.
+var warnedDBSystems sync.Map
+
+// checkDBSystem returns the semantic convention value for system, logging a
+// warning the first time an unknown value is seen. Unknown values are
+// returned unchanged.
+func (cfg *config) checkDBSystem(system string) string {
+	if system == "" {
+		return ""
+	}
+
+	normalized := strings.ToLower(strings.TrimSpace(system))
+	if alias, ok := dbSystemAliases[normalized]; ok {
+		normalized = alias
+	}
+	if knownDBSystems[normalized] {
+		return normalized
+	}
+
+	if _, warned := warnedDBSystems.LoadOrStore(system, struct{}{}); !warned {
+		cfg.Logger.Warn().
+			Str("db.system", system).
+			Msg("sql: unknown db.system, use a semantic convention value such as postgresql")
+	}
+	return system
+}
@@ -0,0 +1,101 @@
+package sql
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/kroma-labs/sentinel-go/sql/mocks"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestWithDBSystem_Normalization(t *testing.T) {
+	tests := []struct {
+		name     string
+		system   string
+		want     string
+		wantWarn bool
+	}{
+		{
+			name:   "given semantic convention value, then keeps it",
+			system: "postgresql",
+			want:   "postgresql",
+		},
+		{
+			name:   "given alias with different case, then normalizes it",
+			system: " Postgres ",
+			want:   "postgresql",
+		},
+		{
+			name:   "given driver name, then maps to system",
+			system: "sqlite3",
+			want:   "sqlite",
+		},
+		{
+			name:     "given unknown value, then keeps it and warns",
+			system:   "postgress-normalization-test",
+			want:     "postgress-normalization-test",
+			wantWarn: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			cfg := newConfig(WithDBSystem(tt.system), WithLogger(zerolog.New(&buf)))
+
+			assert.Equal(t, tt.want, cfg.DBSystem)
+			if !tt.wantWarn {
+				assert.Empty(t, buf.String())
+				return
+			}
+			assert.Contains(t, buf.String(), `"level":"warn"`)
+			assert.Contains(t, buf.String(), tt.system)
+
+			// Warned once per value
+			buf.Reset()
+			newConfig(WithDBSystem(tt.system), WithLogger(zerolog.New(&buf)))
+			assert.Empty(t, buf.String())
+		})
+	}
+}
+
+func TestWithSpanKind(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Option
+		want trace.SpanKind
+	}{
+		{
+			name: "given no option, then uses client kind",
+			want: trace.SpanKindClient,
+		},
+		{
+			name: "given internal kind, then uses it",
+			opts: []Option{WithSpanKind(trace.SpanKindInternal)},
+			want: trace.SpanKindInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTx := mocks.NewDriverTx(t)
+			mockTx.EXPECT().Commit().Return(nil)
+
+			exporter := tracetest.NewInMemoryExporter()
+			tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+			cfg := newConfig(append(tt.opts, WithTracerProvider(tp))...)
+
+			require.NoError(t, newOtelTx(mockTx, cfg, time.Now()).Commit())
+
+			spans := exporter.GetSpans()
+			require.Len(t, spans, 1)
+			assert.Equal(t, tt.want, spans[0].SpanKind)
+		})
+	}
+}
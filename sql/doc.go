@@ -71,4 +71,6 @@
 //
 // Metrics:
 //   - db.client.query.duration (histogram by operation)
+//   - db.client.tx.total (counter by outcome: commit, rollback)
+//   - db.client.tx.duration (histogram from BEGIN to COMMIT or ROLLBACK)
 package sql
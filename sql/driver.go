@@ -75,7 +75,17 @@ func Open(driverName, dsn string, opts ...Option) (*sql.DB, error) {
 	}
 
 	// Open using the wrapped driver
-	return sql.Open(wrappedName, dsn)
+	db, err := sql.Open(wrappedName, dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.PoolMetrics {
+		// Ignore errors like newConfig does; the database is still usable.
+		_ = (&metrics{}).registerPoolMetrics(cfg.Meter, db, cfg.baseAttributes())
+	}
+
+	return db, nil
 }
 
 // WrapDriver wraps a driver.Driver with OpenTelemetry instrumentation.
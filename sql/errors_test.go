@@ -0,0 +1,169 @@
+package sql
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// pgError mimics *pq.Error and *pgconn.PgError.
+type pgError struct{ code string }
+
+func (e *pgError) Error() string    { return "pq: " + e.code }
+func (e *pgError) SQLState() string { return e.code }
+
+// MySQLError mimics *mysql.MySQLError.
+type MySQLError struct {
+	Number  uint16
+	Message string
+}
+
+func (e *MySQLError) Error() string { return fmt.Sprintf("Error %d: %s", e.Number, e.Message) }
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want ErrorKind
+	}{
+		{
+			name: "given nil error, then returns unknown",
+			err:  nil,
+			want: ErrorKindUnknown,
+		},
+		{
+			name: "given postgres unique violation, then returns unique_violation",
+			err:  &pgError{code: "23505"},
+			want: ErrorKindUniqueViolation,
+		},
+		{
+			name: "given wrapped postgres FK violation, then returns foreign_key_violation",
+			err:  fmt.Errorf("insert order: %w", &pgError{code: "23503"}),
+			want: ErrorKindForeignKeyViolation,
+		},
+		{
+			name: "given postgres not null violation, then returns not_null_violation",
+			err:  &pgError{code: "23502"},
+			want: ErrorKindNotNullViolation,
+		},
+		{
+			name: "given postgres deadlock, then returns deadlock",
+			err:  &pgError{code: "40P01"},
+			want: ErrorKindDeadlock,
+		},
+		{
+			name: "given postgres serialization failure, then returns serialization_failure",
+			err:  &pgError{code: "40001"},
+			want: ErrorKindSerializationFailure,
+		},
+		{
+			name: "given postgres connection exception class, then returns connection",
+			err:  &pgError{code: "08006"},
+			want: ErrorKindConnection,
+		},
+		{
+			name: "given postgres statement timeout, then returns timeout",
+			err:  &pgError{code: "57014"},
+			want: ErrorKindTimeout,
+		},
+		{
+			name: "given unmapped postgres code, then returns unknown",
+			err:  &pgError{code: "42601"},
+			want: ErrorKindUnknown,
+		},
+		{
+			name: "given mysql duplicate entry, then returns unique_violation",
+			err:  &MySQLError{Number: 1062, Message: "Duplicate entry"},
+			want: ErrorKindUniqueViolation,
+		},
+		{
+			name: "given wrapped mysql deadlock, then returns deadlock",
+			err:  fmt.Errorf("update: %w", &MySQLError{Number: 1213}),
+			want: ErrorKindDeadlock,
+		},
+		{
+			name: "given mysql foreign key failure, then returns foreign_key_violation",
+			err:  &MySQLError{Number: 1452},
+			want: ErrorKindForeignKeyViolation,
+		},
+		{
+			name: "given mysql null column, then returns not_null_violation",
+			err:  &MySQLError{Number: 1048},
+			want: ErrorKindNotNullViolation,
+		},
+		{
+			name: "given context deadline, then returns timeout",
+			err:  context.DeadlineExceeded,
+			want: ErrorKindTimeout,
+		},
+		{
+			name: "given bad connection, then returns connection",
+			err:  driver.ErrBadConn,
+			want: ErrorKindConnection,
+		},
+		{
+			name: "given network error, then returns connection",
+			err:  &net.OpError{Op: "dial", Err: errors.New("connection refused")},
+			want: ErrorKindConnection,
+		},
+		{
+			name: "given other error, then returns unknown",
+			err:  errors.New("boom"),
+			want: ErrorKindUnknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ClassifyError(tt.err))
+		})
+	}
+}
+
+func TestRecordError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantType string
+	}{
+		{
+			name:     "given classified error, then sets error.type",
+			err:      &pgError{code: "23505"},
+			wantType: "unique_violation",
+		},
+		{
+			name: "given unclassified error, then omits error.type",
+			err:  errors.New("boom"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exporter := tracetest.NewInMemoryExporter()
+			tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+			_, span := tp.Tracer("test").Start(context.Background(), "INSERT")
+			recordError(span, tt.err)
+			span.End()
+
+			spans := exporter.GetSpans()
+			require.Len(t, spans, 1)
+			assert.Equal(t, codes.Error, spans[0].Status.Code)
+
+			set := attribute.NewSet(spans[0].Attributes...)
+			value, ok := set.Value("error.type")
+			assert.Equal(t, tt.wantType != "", ok)
+			assert.Equal(t, tt.wantType, value.AsString())
+		})
+	}
+}
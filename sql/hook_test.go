@@ -0,0 +1,81 @@
+package sql
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestConfig_QueryHook(t *testing.T) {
+	errQuery := errors.New("connection reset")
+
+	tests := []struct {
+		name   string
+		cfg    *config
+		record func(*config)
+		want   QueryInfo
+	}{
+		{
+			name: "given query, then passes sanitized statement and unknown rows affected",
+			cfg:  &config{QuerySanitizer: DefaultQuerySanitizer},
+			record: func(cfg *config) {
+				cfg.recordQuery(context.Background(), time.Second,
+					"SELECT * FROM users WHERE id = 1", "SELECT", nil)
+			},
+			want: QueryInfo{
+				Operation:    "SELECT",
+				Statement:    "SELECT * FROM users WHERE id = ?",
+				Duration:     time.Second,
+				RowsAffected: -1,
+			},
+		},
+		{
+			name: "given exec, then passes rows affected",
+			cfg:  &config{},
+			record: func(cfg *config) {
+				cfg.recordExec(context.Background(), noop.Span{}, time.Second,
+					"DELETE FROM users", "DELETE", driver.RowsAffected(3), nil)
+			},
+			want: QueryInfo{
+				Operation:    "DELETE",
+				Statement:    "DELETE FROM users",
+				Duration:     time.Second,
+				RowsAffected: 3,
+			},
+		},
+		{
+			name: "given failed exec and disabled queries, then passes error without statement",
+			cfg:  &config{DisableQuery: true},
+			record: func(cfg *config) {
+				cfg.recordExec(context.Background(), noop.Span{}, time.Second,
+					"DELETE FROM users", "DELETE", nil, errQuery)
+			},
+			want: QueryInfo{
+				Operation:    "DELETE",
+				Duration:     time.Second,
+				Err:          errQuery,
+				RowsAffected: -1,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []QueryInfo
+			tt.cfg.QueryHook = func(_ context.Context, info QueryInfo) {
+				got = append(got, info)
+			}
+
+			tt.record(tt.cfg)
+
+			require.Len(t, got, 1)
+			assert.Equal(t, tt.want, got[0])
+		})
+	}
+}
@@ -1,11 +1,19 @@
 package sql
 
 import (
+	"bytes"
 	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"strings"
 	"testing"
 	"time"
 
+	_ "github.com/DATA-DOG/go-sqlmock" // registers the "sqlmock" driver
+	"github.com/kroma-labs/sentinel-go/sql/mocks"
+	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/otel/attribute"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
@@ -37,7 +45,7 @@ func TestNewMetrics(t *testing.T) {
 			defer mp.Shutdown(context.Background())
 
 			meter := mp.Meter("test")
-			m, err := newMetrics(meter)
+			m, err := newMetrics(meter, nil)
 
 			if !tt.wantErr(t, err) {
 				return
@@ -104,7 +112,7 @@ func TestRecordQueryDuration(t *testing.T) {
 			defer mp.Shutdown(context.Background())
 
 			meter := mp.Meter("test")
-			m, err := newMetrics(meter)
+			m, err := newMetrics(meter, nil)
 			require.NoError(t, err)
 
 			// Execute
@@ -151,3 +159,291 @@ func TestRecordQueryDuration_NilHistogram(t *testing.T) {
 		})
 	})
 }
+
+func TestRecordQuery_SlowQuery(t *testing.T) {
+	query := "SELECT * FROM orders WHERE total > 1000"
+
+	tests := []struct {
+		name          string
+		opts          []Option
+		duration      time.Duration
+		wantLogged    bool
+		wantStatement string
+		wantFields    map[string]any
+	}{
+		{
+			name:     "given query under threshold, then does not log",
+			opts:     []Option{},
+			duration: 10 * time.Millisecond,
+		},
+		{
+			name:          "given query over threshold, then logs sanitized statement",
+			opts:          []Option{WithQuerySanitizer(DefaultQuerySanitizer)},
+			duration:      200 * time.Millisecond,
+			wantLogged:    true,
+			wantStatement: "SELECT * FROM orders WHERE total > ?",
+		},
+		{
+			name:       "given disabled query, then logs without statement",
+			opts:       []Option{WithDisableQuery()},
+			duration:   200 * time.Millisecond,
+			wantLogged: true,
+		},
+		{
+			name: "given non-string span attributes, then logs their values",
+			opts: []Option{
+				WithSpanAttributesFn(func(context.Context) []attribute.KeyValue {
+					return []attribute.KeyValue{
+						attribute.Int("tenant.shard", 7),
+						attribute.Bool("user.admin", true),
+					}
+				}),
+			},
+			duration:      200 * time.Millisecond,
+			wantLogged:    true,
+			wantStatement: query,
+			wantFields:    map[string]any{"tenant.shard": float64(7), "user.admin": true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader := sdkmetric.NewManualReader()
+			mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+			defer mp.Shutdown(context.Background())
+
+			var buf bytes.Buffer
+			opts := append([]Option{
+				WithMeterProvider(mp),
+				WithDBSystem("postgresql"),
+				WithSlowQueryThreshold(100*time.Millisecond, zerolog.New(&buf)),
+			}, tt.opts...)
+			cfg := newConfig(opts...)
+
+			ctx := context.Background()
+			cfg.recordQuery(ctx, tt.duration, query, "SELECT", nil)
+
+			var rm metricdata.ResourceMetrics
+			require.NoError(t, reader.Collect(ctx, &rm))
+			var slowCount int64
+			for _, sm := range rm.ScopeMetrics {
+				for _, m := range sm.Metrics {
+					if m.Name != "db.client.slow_query" {
+						continue
+					}
+					sum, ok := m.Data.(metricdata.Sum[int64])
+					require.True(t, ok)
+					for _, dp := range sum.DataPoints {
+						slowCount += dp.Value
+					}
+				}
+			}
+
+			if !tt.wantLogged {
+				assert.Empty(t, buf.String())
+				assert.Zero(t, slowCount)
+				return
+			}
+
+			assert.EqualValues(t, 1, slowCount)
+
+			var entry map[string]any
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+			assert.Equal(t, "warn", entry["level"])
+			assert.Equal(t, "slow query", entry["message"])
+			assert.Equal(t, "SELECT", entry["db.operation"])
+			assert.Equal(t, "postgresql", entry["db.system"])
+			if tt.wantStatement != "" {
+				assert.Equal(t, tt.wantStatement, entry["db.statement"])
+			} else {
+				assert.NotContains(t, entry, "db.statement")
+			}
+			for key, want := range tt.wantFields {
+				assert.Equal(t, want, entry[key], key)
+			}
+		})
+	}
+}
+
+func TestOpen_WithPoolMetrics(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      []Option
+		wantNames []string
+	}{
+		{
+			name: "given WithPoolMetrics, then registers pool gauges",
+			opts: []Option{WithPoolMetrics(), WithDBName("orders")},
+			wantNames: []string{
+				"db.client.connections.open",
+				"db.client.connections.used",
+				"db.client.connections.idle",
+				"db.client.connections.max",
+				"db.client.connections.wait_count",
+				"db.client.connections.wait_duration",
+			},
+		},
+		{
+			name: "given no option, then registers no pool gauges",
+			opts: []Option{WithDBName("orders")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader := sdkmetric.NewManualReader()
+			mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+			defer mp.Shutdown(context.Background())
+
+			db, err := Open("sqlmock", "pool_metrics", append(tt.opts, WithMeterProvider(mp))...)
+			require.NoError(t, err)
+			defer db.Close()
+
+			var rm metricdata.ResourceMetrics
+			require.NoError(t, reader.Collect(context.Background(), &rm))
+
+			var names []string
+			for _, sm := range rm.ScopeMetrics {
+				for _, m := range sm.Metrics {
+					if strings.HasPrefix(m.Name, "db.client.connections.") {
+						names = append(names, m.Name)
+					}
+				}
+			}
+			assert.ElementsMatch(t, tt.wantNames, names)
+		})
+	}
+}
+
+func TestNewMetrics_DurationBuckets(t *testing.T) {
+	tests := []struct {
+		name    string
+		buckets []float64
+		want    []float64
+	}{
+		{
+			name: "given nil buckets, then uses default buckets",
+			want: DefaultDurationBuckets(),
+		},
+		{
+			name:    "given fast OLTP buckets, then uses them",
+			buckets: FastOLTPBuckets(),
+			want:    FastOLTPBuckets(),
+		},
+		{
+			name:    "given analytics buckets, then uses them",
+			buckets: AnalyticsBuckets(),
+			want:    AnalyticsBuckets(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader := sdkmetric.NewManualReader()
+			mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+			defer mp.Shutdown(context.Background())
+
+			m, err := newMetrics(mp.Meter("test"), tt.buckets)
+			require.NoError(t, err)
+			m.recordQueryDuration(context.Background(), time.Millisecond, "SELECT", nil, nil)
+
+			var rm metricdata.ResourceMetrics
+			require.NoError(t, reader.Collect(context.Background(), &rm))
+			require.Len(t, rm.ScopeMetrics, 1)
+			require.NotEmpty(t, rm.ScopeMetrics[0].Metrics)
+			histogram, ok := rm.ScopeMetrics[0].Metrics[0].Data.(metricdata.Histogram[float64])
+			require.True(t, ok)
+			require.Len(t, histogram.DataPoints, 1)
+			assert.Equal(t, tt.want, histogram.DataPoints[0].Bounds)
+		})
+	}
+}
+
+func TestOtelTx_Metrics(t *testing.T) {
+	tests := []struct {
+		name        string
+		mockFn      func(*mocks.DriverTx)
+		finish      func(driver.Tx) error
+		wantErr     assert.ErrorAssertionFunc
+		wantOutcome string
+		wantStatus  string
+	}{
+		{
+			name: "given commit, then counts one commit",
+			mockFn: func(m *mocks.DriverTx) {
+				m.EXPECT().Commit().Return(nil)
+			},
+			finish:      func(tx driver.Tx) error { return tx.Commit() },
+			wantErr:     assert.NoError,
+			wantOutcome: "commit",
+			wantStatus:  "ok",
+		},
+		{
+			name: "given rollback, then counts one rollback",
+			mockFn: func(m *mocks.DriverTx) {
+				m.EXPECT().Rollback().Return(nil)
+			},
+			finish:      func(tx driver.Tx) error { return tx.Rollback() },
+			wantErr:     assert.NoError,
+			wantOutcome: "rollback",
+			wantStatus:  "ok",
+		},
+		{
+			name: "given failed commit, then counts commit with error status",
+			mockFn: func(m *mocks.DriverTx) {
+				m.EXPECT().Commit().Return(assert.AnError)
+			},
+			finish:      func(tx driver.Tx) error { return tx.Commit() },
+			wantErr:     assert.Error,
+			wantOutcome: "commit",
+			wantStatus:  "error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader := sdkmetric.NewManualReader()
+			mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+			defer mp.Shutdown(context.Background())
+
+			mockTx := mocks.NewDriverTx(t)
+			tt.mockFn(mockTx)
+			mockConn := mocks.NewDriverConn(t)
+			mockConn.EXPECT().BeginTx(mock.Anything, mock.Anything).Return(mockTx, nil)
+			conn := newOtelConn(mockConn, newConfig(WithMeterProvider(mp)))
+
+			tx, err := conn.BeginTx(context.Background(), driver.TxOptions{})
+			require.NoError(t, err)
+			tt.wantErr(t, tt.finish(tx))
+
+			var rm metricdata.ResourceMetrics
+			require.NoError(t, reader.Collect(context.Background(), &rm))
+
+			var total, durations int
+			for _, sm := range rm.ScopeMetrics {
+				for _, m := range sm.Metrics {
+					switch m.Name {
+					case "db.client.tx.total":
+						sum, ok := m.Data.(metricdata.Sum[int64])
+						require.True(t, ok)
+						for _, dp := range sum.DataPoints {
+							total += int(dp.Value)
+							outcome, _ := dp.Attributes.Value("outcome")
+							status, _ := dp.Attributes.Value("status")
+							assert.Equal(t, tt.wantOutcome, outcome.AsString())
+							assert.Equal(t, tt.wantStatus, status.AsString())
+						}
+					case "db.client.tx.duration":
+						hist, ok := m.Data.(metricdata.Histogram[float64])
+						require.True(t, ok)
+						for _, dp := range hist.DataPoints {
+							durations += int(dp.Count)
+						}
+					}
+				}
+			}
+			assert.Equal(t, 1, total)
+			assert.Equal(t, 1, durations)
+		})
+	}
+}
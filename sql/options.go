@@ -1,7 +1,14 @@
 package sql
 
 import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/trace"
 )
@@ -65,6 +72,59 @@ type config struct {
 	// Use this for security if queries may contain sensitive data
 	// and you cannot use a sanitizer.
 	DisableQuery bool
+
+	// OperationExtractor returns the operation and target table of a query,
+	// used to name spans ("SELECT users") and set db.operation and
+	// db.sql.table. If nil, spans are named after the operation only.
+	OperationExtractor func(query string) (operation, target string)
+
+	// ArgsCapture controls whether query arguments are recorded in the
+	// db.statement.args span attribute. Defaults to ArgsNone.
+	ArgsCapture ArgsMode
+
+	// SlowQueryThreshold is the duration above which a query is logged to
+	// SlowQueryLogger and counted in db.client.slow_query.
+	// Zero disables slow query detection.
+	SlowQueryThreshold time.Duration
+
+	// SlowQueryLogger receives a warning for every slow query.
+	SlowQueryLogger zerolog.Logger
+
+	// RowMetrics enables recording of rows affected by Exec calls as the
+	// "db.rows_affected" span attribute and db.client.rows_affected metric.
+	RowMetrics bool
+
+	// SQLCommenter appends a sqlcommenter comment with trace context to
+	// executed queries.
+	SQLCommenter bool
+
+	// CommenterService is the service name written by the SQL commenter.
+	CommenterService string
+
+	// PoolMetrics registers connection pool metrics when the database is
+	// opened with Open.
+	PoolMetrics bool
+
+	// QueryTimeout bounds each query that has no shorter deadline.
+	// Zero disables the timeout.
+	QueryTimeout time.Duration
+
+	// SpanAttributesFn adds attributes derived from the context to query
+	// spans and metrics.
+	SpanAttributesFn func(ctx context.Context) []attribute.KeyValue
+
+	// DurationBuckets are the query duration histogram boundaries in
+	// seconds. Nil uses DefaultDurationBuckets.
+	DurationBuckets []float64
+
+	// QueryHook is called after each query completes.
+	QueryHook QueryHook
+
+	// SpanKind is the kind of every query span.
+	SpanKind trace.SpanKind
+
+	// Logger receives configuration warnings, such as an unknown DBSystem.
+	Logger zerolog.Logger
 }
 
 // newConfig creates a new config with defaults and applies options.
@@ -72,12 +132,16 @@ func newConfig(opts ...Option) *config {
 	cfg := &config{
 		TracerProvider: otel.GetTracerProvider(),
 		MeterProvider:  otel.GetMeterProvider(),
+		SpanKind:       trace.SpanKindClient,
+		Logger:         log.Logger,
 	}
 
 	for _, opt := range opts {
 		opt(cfg)
 	}
 
+	cfg.DBSystem = cfg.checkDBSystem(cfg.DBSystem)
+
 	// Initialize tracer and meter after options are applied.
 	// If no provider is configured globally, these will be no-op implementations
 	// that safely do nothing - no errors, just no telemetry data collected.
@@ -85,7 +149,7 @@ func newConfig(opts ...Option) *config {
 	cfg.Meter = cfg.MeterProvider.Meter(scope)
 
 	// Initialize metrics (ignore errors, will just be nil if fails)
-	cfg.Metrics, _ = newMetrics(cfg.Meter)
+	cfg.Metrics, _ = newMetrics(cfg.Meter, cfg.DurationBuckets)
 
 	return cfg
 }
@@ -133,6 +197,11 @@ func WithMeterProvider(mp metric.MeterProvider) Option {
 //   - "mssql" - Microsoft SQL Server
 //   - "oracle" - Oracle Database
 //
+// The value is lowercased and common aliases such as "postgres", "pgx" or
+// "sqlite3" are mapped to their semantic convention value. Unknown values
+// are kept as is, with a warning logged once to the WithLogger logger, as
+// dashboards that filter on db.system would miss them.
+//
 // Example:
 //
 //	db, _ := sentinelsql.Open("postgres", dsn,
@@ -204,7 +273,8 @@ func WithInstanceName(name string) Option {
 // with sensitive data (like literals) replaced with placeholders.
 //
 // Use DefaultQuerySanitizer for a basic implementation that replaces
-// string literals, numbers, and hex values with "?" placeholders.
+// string literals, numbers, and hex values with "?" placeholders, or
+// NumericPreservingSanitizer to mask only string literals.
 //
 // Example:
 //
@@ -235,3 +305,267 @@ func WithDisableQuery() Option {
 		cfg.DisableQuery = true
 	}
 }
+
+// WithOperationExtractor sets the function that derives the operation and
+// target table from a query. Spans are named "<operation> <target>", and the
+// target is recorded as the db.sql.table attribute, so different queries of
+// the same kind get distinct span names. Metrics keep using the bare
+// operation to bound their cardinality.
+//
+// Use DefaultOperationExtractor for a conservative built-in parser, or
+// provide your own, e.g. one that reads a table name from a query comment.
+//
+// Example:
+//
+//	db, _ := sentinelsql.Open("postgres", dsn,
+//	    sentinelsql.WithOperationExtractor(sentinelsql.DefaultOperationExtractor),
+//	)
+//	// Query: "SELECT * FROM users WHERE id = $1"
+//	// Span:  "SELECT users" with db.sql.table="users"
+func WithOperationExtractor(fn func(query string) (operation, target string)) Option {
+	return func(cfg *config) {
+		cfg.OperationExtractor = fn
+	}
+}
+
+// WithArgsCapture records query arguments in the db.statement.args span
+// attribute. ArgsMasked records only each argument's position and type,
+// which is safe for sensitive data; ArgsFull records the values themselves
+// and should be used only for debugging. Arguments are never recorded when
+// WithDisableQuery is set.
+//
+// Example:
+//
+//	db, _ := sentinelsql.Open("postgres", dsn,
+//	    sentinelsql.WithArgsCapture(sentinelsql.ArgsMasked),
+//	)
+//	// Query: db.ExecContext(ctx, "UPDATE users SET name = $1 WHERE id = $2", "john", 42)
+//	// Recorded as: db.statement.args="$1=<string>, $2=<int64>"
+func WithArgsCapture(mode ArgsMode) Option {
+	return func(cfg *config) {
+		cfg.ArgsCapture = mode
+	}
+}
+
+// WithSlowQueryThreshold logs every query that takes longer than threshold.
+//
+// Slow queries are logged at warn level with the operation, duration, and
+// statement, and counted in the db.client.slow_query metric. The statement
+// goes through the query sanitizer and is omitted when WithDisableQuery is
+// set, so the log never contains more than the spans do.
+//
+// Example:
+//
+//	db, _ := sentinelsql.Open("postgres", dsn,
+//	    sentinelsql.WithQuerySanitizer(sentinelsql.DefaultQuerySanitizer),
+//	    sentinelsql.WithSlowQueryThreshold(500*time.Millisecond, logger),
+//	)
+//	// {"level":"warn","db.operation":"SELECT","duration":812.4,
+//	//  "db.statement":"SELECT * FROM orders WHERE total > ?","message":"slow query"}
+func WithSlowQueryThreshold(threshold time.Duration, logger zerolog.Logger) Option {
+	return func(cfg *config) {
+		cfg.SlowQueryThreshold = threshold
+		cfg.SlowQueryLogger = logger
+	}
+}
+
+// WithRowMetrics records the number of rows affected by each Exec.
+//
+// The count is added as the "db.rows_affected" span attribute and recorded
+// in the db.client.rows_affected histogram, which makes "UPDATE touched 0
+// rows" visible where latency alone would not. It is opt-in because it
+// calls RowsAffected on every result.
+//
+// Example:
+//
+//	db, _ := sentinelsql.Open("postgres", dsn,
+//	    sentinelsql.WithRowMetrics(),
+//	)
+func WithRowMetrics() Option {
+	return func(cfg *config) {
+		cfg.RowMetrics = true
+	}
+}
+
+// WithSQLCommenter appends a sqlcommenter-style comment to every query
+// executed through a connection, so database-side logs such as
+// pg_stat_statements can be joined with traces:
+//
+//	SELECT * FROM users WHERE id = $1
+//	/*operation='SELECT',service='api',traceparent='00-4bf9...-00f0...-01'*/
+//
+// The service name is read from the OTEL_SERVICE_NAME environment variable
+// and omitted when unset. The comment is only added when WithDBSystem names
+// a database known to accept trailing comments (postgresql, cockroachdb,
+// mysql, mariadb, sqlite, mssql, oracle). Queries that already contain a
+// comment, and single-token queries that drivers like pgx may treat as
+// prepared statement names, are left untouched. Prepared statements are
+// never commented. Spans still record the original query.
+//
+// Each comment is unique per trace, so disable this if the database or a
+// proxy caches plans by exact query text.
+//
+// Example:
+//
+//	db, _ := sentinelsql.Open("postgres", dsn,
+//	    sentinelsql.WithDBSystem("postgresql"),
+//	    sentinelsql.WithSQLCommenter(true),
+//	)
+func WithSQLCommenter(enabled bool) Option {
+	return func(cfg *config) {
+		cfg.SQLCommenter = enabled
+		cfg.CommenterService = os.Getenv("OTEL_SERVICE_NAME")
+	}
+}
+
+// WithPoolMetrics registers the connection pool metrics described in
+// RecordPoolMetrics as soon as Open returns, using the configured meter
+// provider, so pool exhaustion is visible without an extra call.
+//
+// The gauges are observed from db.Stats() on each collection, so no
+// background goroutine is started. Only Open honours this option; call
+// RecordPoolMetrics for databases created with WrapDriver or Register.
+//
+// Time spent waiting for a free connection is only available as the
+// cumulative db.client.connections.wait_* counters. The pool hands out
+// connections inside database/sql, before the driver is called, so the
+// driver wrapper can't time individual acquisitions.
+//
+// Example:
+//
+//	db, _ := sentinelsql.Open("postgres", dsn,
+//	    sentinelsql.WithDBName("orders"),
+//	    sentinelsql.WithInstanceName("primary"),
+//	    sentinelsql.WithPoolMetrics(),
+//	)
+func WithPoolMetrics() Option {
+	return func(cfg *config) {
+		cfg.PoolMetrics = true
+	}
+}
+
+// WithQueryTimeout bounds every ExecContext and QueryContext call to timeout, so a stuck connection
+// cannot hang a caller that passed a context without a deadline. An earlier
+// deadline already on the context still applies.
+//
+// For queries that return rows, the timeout covers iterating the rows too.
+// When a query fails because the timeout expired, its span is marked as
+// errored with "query timed out after <timeout>" and the
+// db.client.query.timeout counter is incremented. Use
+// ContextWithQueryTimeout to change or disable the timeout for one call.
+//
+// Example:
+//
+//	db, _ := sentinelsql.Open("postgres", dsn,
+//	    sentinelsql.WithQueryTimeout(2*time.Second),
+//	)
+func WithQueryTimeout(timeout time.Duration) Option {
+	return func(cfg *config) {
+		cfg.QueryTimeout = timeout
+	}
+}
+
+// WithSpanAttributesFn sets a function that derives attributes from the
+// query context. The function is called for each query and the returned
+// attributes are added to its span and to the query duration, rows and
+// timeout metrics.
+//
+// Use this to stamp request-scoped dimensions, set on the context by an
+// HTTP or RPC layer, onto database telemetry.
+//
+// Every distinct attribute value creates a new metric time series, so only
+// return attributes with a small, bounded set of values, such as a user
+// role or an endpoint name. Never return user IDs, request IDs or other
+// unbounded values.
+//
+// Example:
+//
+//	db, _ := sentinelsql.Open("postgres", dsn,
+//	    sentinelsql.WithSpanAttributesFn(func(ctx context.Context) []attribute.KeyValue {
+//	        return []attribute.KeyValue{
+//	            attribute.String("user.role", auth.RoleFromContext(ctx)),
+//	        }
+//	    }),
+//	)
+func WithSpanAttributesFn(fn func(ctx context.Context) []attribute.KeyValue) Option {
+	return func(cfg *config) {
+		cfg.SpanAttributesFn = fn
+	}
+}
+
+// WithDurationBuckets sets the histogram boundaries, in seconds, of the
+// db.client.operation.duration metric. The defaults, DefaultDurationBuckets,
+// are too coarse below 1ms for fast OLTP queries and too fine for queries
+// that run for minutes; use FastOLTPBuckets or AnalyticsBuckets for those
+// workloads, or pass boundaries tuned to your own.
+//
+// Boundaries must be in increasing order.
+//
+// Example:
+//
+//	db, _ := sentinelsql.Open("postgres", dsn,
+//	    sentinelsql.WithDurationBuckets([]float64{0.0005, 0.001, 0.005, 0.01, 0.05, 0.1}),
+//	)
+func WithDurationBuckets(buckets []float64) Option {
+	return func(cfg *config) {
+		cfg.DurationBuckets = buckets
+	}
+}
+
+// WithQueryHook sets a function called after each query completes, with its
+// operation, sanitized statement, duration, error and rows affected. Use it
+// to ship query events to a custom sink, such as an audit log or a query
+// performance service, without parsing spans.
+//
+// The hook runs synchronously on the calling goroutine, so it should return
+// quickly; hand slow work off to another goroutine.
+//
+// Example:
+//
+//	db, _ := sentinelsql.Open("postgres", dsn,
+//	    sentinelsql.WithQueryHook(func(ctx context.Context, info sentinelsql.QueryInfo) {
+//	        auditLog.Info().
+//	            Str("operation", info.Operation).
+//	            Str("statement", info.Statement).
+//	            Dur("duration", info.Duration).
+//	            Int64("rows_affected", info.RowsAffected).
+//	            Err(info.Err).
+//	            Msg("query")
+//	    }),
+//	)
+func WithQueryHook(hook QueryHook) Option {
+	return func(cfg *config) {
+		cfg.QueryHook = hook
+	}
+}
+
+// WithSpanKind sets the kind of query spans. The default is
+// trace.SpanKindClient, which suits network databases. Use
+// trace.SpanKindInternal for embedded databases such as SQLite, so they are
+// not shown as remote dependencies.
+//
+// Example:
+//
+//	db, _ := sentinelsql.Open("sqlite3", "file:app.db",
+//	    sentinelsql.WithDBSystem("sqlite"),
+//	    sentinelsql.WithSpanKind(trace.SpanKindInternal),
+//	)
+func WithSpanKind(kind trace.SpanKind) Option {
+	return func(cfg *config) {
+		cfg.SpanKind = kind
+	}
+}
+
+// WithLogger sets the logger for configuration warnings, such as an unknown
+// WithDBSystem value. The default is the global zerolog logger.
+//
+// Example:
+//
+//	db, _ := sentinelsql.Open("postgres", dsn,
+//	    sentinelsql.WithLogger(logger.With().Str("component", "db").Logger()),
+//	)
+func WithLogger(logger zerolog.Logger) Option {
+	return func(cfg *config) {
+		cfg.Logger = logger
+	}
+}
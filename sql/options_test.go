@@ -1,6 +1,7 @@
 package sql
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -208,7 +209,7 @@ func TestConfigQueryAttributes(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			cfg := newConfig(tt.opts...)
-			attrs := cfg.queryAttributes(tt.query)
+			attrs := cfg.queryAttributes(context.Background(), tt.query)
 
 			hasStatement := false
 			hasOperation := false
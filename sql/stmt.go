@@ -3,8 +3,8 @@ package sql
 import (
 	"context"
 	"database/sql/driver"
+	"time"
 
-	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -20,6 +20,9 @@ type otelStmt struct {
 	stmt  driver.Stmt
 	cfg   *config
 	query string
+
+	// conn is the connection the statement was prepared on, if known.
+	conn *otelConn
 }
 
 // newOtelStmt creates a new instrumented statement.
@@ -58,11 +61,19 @@ func (s *otelStmt) ExecContext(
 	ctx context.Context,
 	args []driver.NamedValue,
 ) (driver.Result, error) {
-	ctx, span := s.cfg.Tracer.Start(ctx, spanName(s.query),
-		trace.WithSpanKind(trace.SpanKindClient),
-		trace.WithAttributes(s.cfg.queryAttributes(s.query)...),
+	start := time.Now()
+
+	ctx, span := s.cfg.Tracer.Start(ctx, s.cfg.spanName(s.query),
+		trace.WithSpanKind(s.cfg.SpanKind),
+		trace.WithAttributes(s.cfg.queryAttributes(ctx, s.query)...),
+		trace.WithAttributes(txAttributes(s.tx())...),
+		trace.WithLinks(txLinks(s.tx())...),
 	)
 	defer span.End()
+	s.cfg.recordArgs(span, args)
+
+	ctx, cancel, timeout := s.cfg.withQueryTimeout(ctx)
+	defer cancel()
 
 	var result driver.Result
 	var err error
@@ -75,9 +86,12 @@ func (s *otelStmt) ExecContext(
 		result, err = s.stmt.Exec(values) //nolint:staticcheck // Fallback for older drivers
 	}
 
+	s.cfg.recordRowsAffected(ctx, span, extractOperation(s.query), result, err)
+	s.cfg.runExecHook(ctx, time.Since(start), s.query, extractOperation(s.query), result, err)
+
 	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, err.Error())
+		recordError(span, err)
+		s.cfg.recordTimeout(ctx, span, timeout, extractOperation(s.query), err)
 		return nil, err
 	}
 
@@ -89,11 +103,20 @@ func (s *otelStmt) QueryContext(
 	ctx context.Context,
 	args []driver.NamedValue,
 ) (driver.Rows, error) {
-	ctx, span := s.cfg.Tracer.Start(ctx, spanName(s.query),
-		trace.WithSpanKind(trace.SpanKindClient),
-		trace.WithAttributes(s.cfg.queryAttributes(s.query)...),
+	start := time.Now()
+
+	ctx, span := s.cfg.Tracer.Start(ctx, s.cfg.spanName(s.query),
+		trace.WithSpanKind(s.cfg.SpanKind),
+		trace.WithAttributes(s.cfg.queryAttributes(ctx, s.query)...),
+		trace.WithAttributes(txAttributes(s.tx())...),
+		trace.WithLinks(txLinks(s.tx())...),
 	)
 	defer span.End()
+	s.cfg.recordArgs(span, args)
+
+	// The timeout also bounds reading the rows, so on success it is
+	// released when the rows are closed.
+	ctx, cancel, timeout := s.cfg.withQueryTimeout(ctx)
 
 	var rows driver.Rows
 	var err error
@@ -105,14 +128,16 @@ func (s *otelStmt) QueryContext(
 		values := namedValueToValue(args)
 		rows, err = s.stmt.Query(values) //nolint:staticcheck // Fallback for older drivers
 	}
+	s.cfg.runQueryHook(ctx, time.Since(start), s.query, extractOperation(s.query), -1, err)
 
 	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, err.Error())
+		recordError(span, err)
+		s.cfg.recordTimeout(ctx, span, timeout, extractOperation(s.query), err)
+		cancel()
 		return nil, err
 	}
 
-	return rows, nil
+	return withTimeoutRows(rows, cancel, timeout), nil
 }
 
 // namedValueToValue converts NamedValue slice to Value slice.
@@ -123,3 +148,12 @@ func namedValueToValue(named []driver.NamedValue) []driver.Value {
 	}
 	return values
 }
+
+// tx returns the span context of the BEGIN span of the transaction open on
+// the statement's connection, if any.
+func (s *otelStmt) tx() trace.SpanContext {
+	if s.conn == nil {
+		return trace.SpanContext{}
+	}
+	return s.conn.tx
+}
@@ -9,6 +9,11 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 )
 
 func TestNewOtelStmt(t *testing.T) {
@@ -114,6 +119,76 @@ func TestOtelStmt_ExecContext(t *testing.T) {
 	}
 }
 
+func TestOtelStmt_ExecContext_RowMetrics(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []Option
+		mockFn   func(*mocks.DriverResult)
+		wantRows bool
+	}{
+		{
+			name: "given row metrics, then records rows affected",
+			opts: []Option{WithRowMetrics()},
+			mockFn: func(result *mocks.DriverResult) {
+				result.EXPECT().RowsAffected().Return(3, nil)
+			},
+			wantRows: true,
+		},
+		{
+			name:   "given row metrics disabled, then does not call RowsAffected",
+			mockFn: func(_ *mocks.DriverResult) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exporter := tracetest.NewInMemoryExporter()
+			tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+			reader := sdkmetric.NewManualReader()
+			mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+			mockStmt := mocks.NewDriverStmt(t)
+			mockResult := mocks.NewDriverResult(t)
+			mockStmt.EXPECT().
+				ExecContext(mock.Anything, mock.Anything).
+				Return(mockResult, nil)
+			tt.mockFn(mockResult)
+
+			opts := append([]Option{WithTracerProvider(tp), WithMeterProvider(mp)}, tt.opts...)
+			otelStmt := newOtelStmt(mockStmt, newConfig(opts...), "UPDATE users SET active = ?")
+
+			_, err := otelStmt.ExecContext(context.Background(), nil)
+			require.NoError(t, err)
+
+			spans := exporter.GetSpans()
+			require.Len(t, spans, 1)
+			attrs := attribute.NewSet(spans[0].Attributes...)
+			got, ok := attrs.Value("db.rows_affected")
+
+			var rm metricdata.ResourceMetrics
+			require.NoError(t, reader.Collect(context.Background(), &rm))
+
+			if !tt.wantRows {
+				assert.False(t, ok)
+				return
+			}
+
+			require.True(t, ok)
+			assert.Equal(t, int64(3), got.AsInt64())
+
+			var found bool
+			for _, sm := range rm.ScopeMetrics {
+				for _, m := range sm.Metrics {
+					if m.Name == "db.client.rows_affected" {
+						found = true
+					}
+				}
+			}
+			assert.True(t, found)
+		})
+	}
+}
+
 func TestOtelStmt_QueryContext(t *testing.T) {
 	type args struct {
 		query    string
@@ -0,0 +1,153 @@
+package sql
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"time"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// queryTimeoutKey is the context key for a per-call query timeout.
+type queryTimeoutKey struct{}
+
+// ContextWithQueryTimeout overrides the WithQueryTimeout default for queries
+// made with the returned context. A zero or negative timeout disables the
+// default, leaving only the deadline already on ctx, if any.
+//
+// Example:
+//
+//	// Allow the nightly report more time than the 2s default.
+//	ctx = sentinelsql.ContextWithQueryTimeout(ctx, 30*time.Second)
+//	rows, err := db.QueryContext(ctx, reportQuery)
+func ContextWithQueryTimeout(ctx context.Context, timeout time.Duration) context.Context {
+	return context.WithValue(ctx, queryTimeoutKey{}, timeout)
+}
+
+// withQueryTimeout derives a context bounded by the query timeout from ctx.
+// The returned timeout is zero when no timeout applies, including when ctx
+// already has an earlier deadline: that deadline is the caller's, so it is
+// not reported as a query timeout.
+func (cfg *config) withQueryTimeout(
+	ctx context.Context,
+) (context.Context, context.CancelFunc, time.Duration) {
+	timeout := cfg.QueryTimeout
+	if override, ok := ctx.Value(queryTimeoutKey{}).(time.Duration); ok {
+		timeout = override
+	}
+	if timeout <= 0 {
+		return ctx, func() {}, 0
+	}
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) <= timeout {
+		return ctx, func() {}, 0
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	return ctx, cancel, timeout
+}
+
+// recordTimeout counts a query that failed because ctx, derived by
+// withQueryTimeout, ran out of time, and marks its span with a clear reason.
+// It must be called after any other status is set on span.
+func (cfg *config) recordTimeout(
+	ctx context.Context,
+	span trace.Span,
+	timeout time.Duration,
+	operation string,
+	err error,
+) {
+	if timeout <= 0 || err == nil || !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return
+	}
+
+	cfg.Metrics.recordQueryTimeout(ctx, operation, cfg.metricAttributes(ctx))
+	span.SetStatus(codes.Error, fmt.Sprintf("query timed out after %s", timeout))
+}
+
+// timeoutRows releases the query timeout of a successful query once its rows
+// are closed. database/sql closes the driver rows when iteration ends, so the
+// timeout's timer does not outlive the rows.
+type timeoutRows struct {
+	driver.Rows
+	cancel context.CancelFunc
+}
+
+// withTimeoutRows ties cancel to rows. Queries without a query timeout are
+// returned unwrapped.
+func withTimeoutRows(
+	rows driver.Rows,
+	cancel context.CancelFunc,
+	timeout time.Duration,
+) driver.Rows {
+	if timeout <= 0 {
+		return rows
+	}
+	return &timeoutRows{Rows: rows, cancel: cancel}
+}
+
+// Close implements driver.Rows.
+func (r *timeoutRows) Close() error {
+	defer r.cancel()
+	return r.Rows.Close()
+}
+
+// HasNextResultSet implements driver.RowsNextResultSet.
+func (r *timeoutRows) HasNextResultSet() bool {
+	if rs, ok := r.Rows.(driver.RowsNextResultSet); ok {
+		return rs.HasNextResultSet()
+	}
+	return false
+}
+
+// NextResultSet implements driver.RowsNextResultSet.
+func (r *timeoutRows) NextResultSet() error {
+	if rs, ok := r.Rows.(driver.RowsNextResultSet); ok {
+		return rs.NextResultSet()
+	}
+	return io.EOF
+}
+
+// ColumnTypeScanType implements driver.RowsColumnTypeScanType.
+func (r *timeoutRows) ColumnTypeScanType(index int) reflect.Type {
+	if ct, ok := r.Rows.(driver.RowsColumnTypeScanType); ok {
+		return ct.ColumnTypeScanType(index)
+	}
+	return reflect.TypeOf(new(any)).Elem()
+}
+
+// ColumnTypeDatabaseTypeName implements driver.RowsColumnTypeDatabaseTypeName.
+func (r *timeoutRows) ColumnTypeDatabaseTypeName(index int) string {
+	if ct, ok := r.Rows.(driver.RowsColumnTypeDatabaseTypeName); ok {
+		return ct.ColumnTypeDatabaseTypeName(index)
+	}
+	return ""
+}
+
+// ColumnTypeLength implements driver.RowsColumnTypeLength.
+func (r *timeoutRows) ColumnTypeLength(index int) (int64, bool) {
+	if ct, ok := r.Rows.(driver.RowsColumnTypeLength); ok {
+		return ct.ColumnTypeLength(index)
+	}
+	return 0, false
+}
+
+// ColumnTypeNullable implements driver.RowsColumnTypeNullable.
+func (r *timeoutRows) ColumnTypeNullable(index int) (bool, bool) {
+	if ct, ok := r.Rows.(driver.RowsColumnTypeNullable); ok {
+		return ct.ColumnTypeNullable(index)
+	}
+	return false, false
+}
+
+// ColumnTypePrecisionScale implements driver.RowsColumnTypePrecisionScale.
+func (r *timeoutRows) ColumnTypePrecisionScale(index int) (int64, int64, bool) {
+	if ct, ok := r.Rows.(driver.RowsColumnTypePrecisionScale); ok {
+		return ct.ColumnTypePrecisionScale(index)
+	}
+	return 0, 0, false
+}
@@ -0,0 +1,215 @@
+package sql
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	"github.com/kroma-labs/sentinel-go/sql/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestOtelStmt_ExecContext_QueryTimeout(t *testing.T) {
+	tests := []struct {
+		name        string
+		opts        []Option
+		ctx         func(ctx context.Context) context.Context
+		wantErr     assert.ErrorAssertionFunc
+		wantTimeout bool
+		wantStatus  string
+	}{
+		{
+			name:        "given query exceeding timeout, then marks span and counts timeout",
+			opts:        []Option{WithQueryTimeout(10 * time.Millisecond)},
+			wantErr:     assert.Error,
+			wantTimeout: true,
+			wantStatus:  "query timed out after 10ms",
+		},
+		{
+			name: "given per-call override, then uses overridden timeout",
+			opts: []Option{WithQueryTimeout(time.Hour)},
+			ctx: func(ctx context.Context) context.Context {
+				return ContextWithQueryTimeout(ctx, 5*time.Millisecond)
+			},
+			wantErr:     assert.Error,
+			wantTimeout: true,
+			wantStatus:  "query timed out after 5ms",
+		},
+		{
+			name: "given earlier parent deadline, then does not count a query timeout",
+			opts: []Option{WithQueryTimeout(time.Hour)},
+			ctx: func(ctx context.Context) context.Context {
+				ctx, cancel := context.WithTimeout(ctx, 5*time.Millisecond)
+				t.Cleanup(cancel)
+				return ctx
+			},
+			wantErr:    assert.Error,
+			wantStatus: context.DeadlineExceeded.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockStmt := mocks.NewDriverStmt(t)
+			mockStmt.EXPECT().
+				ExecContext(mock.Anything, mock.Anything).
+				RunAndReturn(
+					func(ctx context.Context, _ []driver.NamedValue) (driver.Result, error) {
+						<-ctx.Done()
+						return nil, ctx.Err()
+					},
+				)
+
+			exporter := tracetest.NewInMemoryExporter()
+			tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+			reader := sdkmetric.NewManualReader()
+			mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+			defer mp.Shutdown(context.Background())
+
+			cfg := newConfig(append(tt.opts, WithTracerProvider(tp), WithMeterProvider(mp))...)
+			otelStmt := newOtelStmt(mockStmt, cfg, "UPDATE users SET name = ?")
+
+			ctx := context.Background()
+			if tt.ctx != nil {
+				ctx = tt.ctx(ctx)
+			}
+			_, err := otelStmt.ExecContext(ctx, nil)
+			tt.wantErr(t, err)
+
+			spans := exporter.GetSpans()
+			require.Len(t, spans, 1)
+			assert.Equal(t, codes.Error, spans[0].Status.Code)
+			assert.Equal(t, tt.wantStatus, spans[0].Status.Description)
+
+			var rm metricdata.ResourceMetrics
+			require.NoError(t, reader.Collect(context.Background(), &rm))
+			var timeouts int64
+			for _, sm := range rm.ScopeMetrics {
+				for _, m := range sm.Metrics {
+					if m.Name != "db.client.query.timeout" {
+						continue
+					}
+					sum, ok := m.Data.(metricdata.Sum[int64])
+					require.True(t, ok)
+					for _, dp := range sum.DataPoints {
+						timeouts += dp.Value
+					}
+				}
+			}
+			assert.Equal(t, tt.wantTimeout, timeouts == 1)
+		})
+	}
+}
+
+func TestConfig_WithQueryTimeout(t *testing.T) {
+	tests := []struct {
+		name        string
+		cfg         *config
+		ctx         func(ctx context.Context) context.Context
+		wantTimeout time.Duration
+	}{
+		{
+			name:        "given no timeout, then leaves context unbounded",
+			cfg:         &config{},
+			wantTimeout: 0,
+		},
+		{
+			name:        "given default timeout, then applies it",
+			cfg:         &config{QueryTimeout: time.Second},
+			wantTimeout: time.Second,
+		},
+		{
+			name: "given earlier parent deadline, then keeps parent deadline",
+			cfg:  &config{QueryTimeout: time.Hour},
+			ctx: func(ctx context.Context) context.Context {
+				ctx, cancel := context.WithTimeout(ctx, time.Second)
+				t.Cleanup(cancel)
+				return ctx
+			},
+			wantTimeout: 0,
+		},
+		{
+			name: "given override of zero, then disables default",
+			cfg:  &config{QueryTimeout: time.Second},
+			ctx: func(ctx context.Context) context.Context {
+				return ContextWithQueryTimeout(ctx, 0)
+			},
+			wantTimeout: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.ctx != nil {
+				ctx = tt.ctx(ctx)
+			}
+
+			ctx, cancel, timeout := tt.cfg.withQueryTimeout(ctx)
+			defer cancel()
+
+			assert.Equal(t, tt.wantTimeout, timeout)
+			if tt.wantTimeout > 0 {
+				deadline, ok := ctx.Deadline()
+				require.True(t, ok)
+				assert.WithinDuration(t, time.Now().Add(tt.wantTimeout), deadline, time.Second)
+			}
+		})
+	}
+}
+
+func TestOtelStmt_QueryContext_QueryTimeout(t *testing.T) {
+	tests := []struct {
+		name         string
+		opts         []Option
+		wantWrapped  bool
+		wantCanceled bool
+	}{
+		{
+			name:         "given query timeout, then closing rows releases the timeout",
+			opts:         []Option{WithQueryTimeout(time.Hour)},
+			wantWrapped:  true,
+			wantCanceled: true,
+		},
+		{
+			name: "given no query timeout, then returns driver rows unwrapped",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var queryCtx context.Context
+			mockRows := mocks.NewDriverRows(t)
+			mockRows.EXPECT().Close().Return(nil)
+			mockStmt := mocks.NewDriverStmt(t)
+			mockStmt.EXPECT().
+				QueryContext(mock.Anything, mock.Anything).
+				RunAndReturn(
+					func(ctx context.Context, _ []driver.NamedValue) (driver.Rows, error) {
+						queryCtx = ctx
+						return mockRows, nil
+					},
+				)
+
+			cfg := newConfig(tt.opts...)
+			otelStmt := newOtelStmt(mockStmt, cfg, "SELECT id FROM users")
+
+			rows, err := otelStmt.QueryContext(context.Background(), nil)
+			require.NoError(t, err)
+			_, wrapped := rows.(*timeoutRows)
+			assert.Equal(t, tt.wantWrapped, wrapped)
+			require.NoError(t, queryCtx.Err())
+
+			require.NoError(t, rows.Close())
+			assert.Equal(t, tt.wantCanceled, queryCtx.Err() != nil)
+		})
+	}
+}
@@ -21,37 +21,61 @@ var (
 )
 
 // spanName returns a span name from a SQL query.
-// Returns the SQL operation (SELECT, INSERT, etc.) or "SQL" for empty/unknown queries.
-// This is used for OpenTelemetry span names which must not be empty.
+// Returns the SQL operation (SELECT, INSERT, etc.), followed by the target
+// table when the operation extractor finds one, or "SQL" for empty/unknown
+// queries. This is used for OpenTelemetry span names which must not be empty.
 //
 // Example:
 //
-//	spanName("SELECT * FROM users") // returns "SELECT"
-//	spanName("")                    // returns "SQL"
-func spanName(query string) string {
-	op := extractOperation(query)
-	if op != "" {
-		return op
+//	cfg.spanName("SELECT * FROM users") // returns "SELECT", or "SELECT users"
+//	cfg.spanName("")                    // returns "SQL"
+func (cfg *config) spanName(query string) string {
+	op, target := cfg.operation(query)
+	if op == "" {
+		return "SQL"
 	}
-	return "SQL"
+	if target != "" {
+		return op + " " + target
+	}
+	return op
 }
 
+// operation returns the operation and target table of query, using the
+// configured OperationExtractor when set.
+func (cfg *config) operation(query string) (string, string) {
+	if cfg.OperationExtractor != nil {
+		return cfg.OperationExtractor(query)
+	}
+	return extractOperation(query), ""
+}
+
+// batchOperation is the operation of queries with more than one statement.
+const batchOperation = "BATCH"
+
 // extractOperation extracts the SQL operation (first word) from a query.
 // Returns uppercase operation name or empty string if query is empty.
-// This is used for the db.operation span attribute.
+// Queries with more than one statement return "BATCH".
 //
 // Example:
 //
-//	extractOperation("SELECT * FROM users") // returns "SELECT"
-//	extractOperation("insert into users")   // returns "INSERT"
-//	extractOperation("")                    // returns ""
+//	extractOperation("SELECT * FROM users")           // returns "SELECT"
+//	extractOperation("insert into users")             // returns "INSERT"
+//	extractOperation("SELECT 1; UPDATE users SET x=1") // returns "BATCH"
+//	extractOperation("")                              // returns ""
 func extractOperation(query string) string {
 	query = strings.TrimSpace(query)
 	if query == "" {
 		return ""
 	}
+	if strings.Contains(query, ";") && len(splitStatements(query)) > 1 {
+		return batchOperation
+	}
+
+	return firstWord(query)
+}
 
-	// Find the first word (the SQL command)
+// firstWord returns the uppercase first word of a trimmed, non-empty query.
+func firstWord(query string) string {
 	spaceIdx := strings.IndexAny(query, " \t\n\r")
 	if spaceIdx == -1 {
 		return strings.ToUpper(query)
@@ -60,6 +84,138 @@ func extractOperation(query string) string {
 	return strings.ToUpper(query[:spaceIdx])
 }
 
+// statementOperations returns the operation of each statement in a
+// multi-statement query, e.g. ["SELECT", "UPDATE"].
+func statementOperations(query string) []string {
+	statements := splitStatements(query)
+	ops := make([]string, len(statements))
+	for i, statement := range statements {
+		ops[i] = firstWord(statement)
+	}
+	return ops
+}
+
+// splitStatements splits query on semicolons into trimmed, non-empty
+// statements. It is a minimal tokenizer: semicolons inside quoted strings,
+// quoted identifiers and comments do not split, and comments are dropped.
+func splitStatements(query string) []string {
+	var statements []string
+	var current strings.Builder
+
+	flush := func() {
+		if statement := strings.TrimSpace(current.String()); statement != "" {
+			statements = append(statements, statement)
+		}
+		current.Reset()
+	}
+
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		switch {
+		case c == '\'' || c == '"' || c == '`':
+			end := quotedEnd(query, i)
+			current.WriteString(query[i:end])
+			i = end - 1
+		case c == '-' && strings.HasPrefix(query[i:], "--"):
+			end := strings.IndexByte(query[i:], '\n')
+			if end == -1 {
+				i = len(query)
+			} else {
+				i += end - 1
+			}
+			current.WriteByte(' ')
+		case c == '/' && strings.HasPrefix(query[i:], "/*"):
+			end := strings.Index(query[i+2:], "*/")
+			if end == -1 {
+				i = len(query)
+			} else {
+				i += end + 3
+			}
+			current.WriteByte(' ')
+		case c == ';':
+			flush()
+		default:
+			current.WriteByte(c)
+		}
+	}
+	flush()
+
+	return statements
+}
+
+// quotedEnd returns the index just past the quoted string or identifier
+// starting at query[start]. A doubled quote is an escaped quote, as is a
+// backslash-escaped single quote.
+func quotedEnd(query string, start int) int {
+	quote := query[start]
+	for i := start + 1; i < len(query); i++ {
+		switch {
+		case query[i] == '\\' && quote == '\'':
+			i++
+		case query[i] == quote:
+			if i+1 < len(query) && query[i+1] == quote {
+				i++
+				continue
+			}
+			return i + 1
+		}
+	}
+	return len(query)
+}
+
+// operationKeywords maps an operation to the keyword that precedes its
+// target table in DefaultOperationExtractor.
+var operationKeywords = map[string]string{
+	"SELECT":  "FROM",
+	"DELETE":  "FROM",
+	"INSERT":  "INTO",
+	"REPLACE": "INTO",
+	"UPDATE":  "UPDATE",
+}
+
+// tableNameRegex matches a plain or schema-qualified table name.
+var tableNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_$]*(\.[A-Za-z_][A-Za-z0-9_$]*)?$`)
+
+// DefaultOperationExtractor returns the SQL operation of query and the table
+// it targets: the name after FROM in a SELECT or DELETE, after INTO in an
+// INSERT or REPLACE, or after UPDATE. It is deliberately conservative and
+// returns an empty target when the table is not a plain name outside any
+// parentheses, such as for subqueries, CTEs and table functions.
+//
+// Example:
+//
+//	DefaultOperationExtractor("SELECT * FROM users WHERE id = $1")
+//	// returns "SELECT", "users"
+//
+//	DefaultOperationExtractor("SELECT * FROM (SELECT 1) t")
+//	// returns "SELECT", ""
+func DefaultOperationExtractor(query string) (operation, target string) {
+	operation = extractOperation(query)
+	keyword, ok := operationKeywords[operation]
+	if !ok {
+		return operation, ""
+	}
+
+	fields := strings.Fields(query)
+	depth := 0
+	for i, field := range fields {
+		if depth == 0 && i+1 < len(fields) && strings.EqualFold(field, keyword) {
+			name := strings.TrimRight(fields[i+1], ",;")
+			if keyword == "INTO" {
+				// INSERT INTO users(name) lists columns right after the table.
+				name, _, _ = strings.Cut(name, "(")
+			}
+			name = strings.NewReplacer(`"`, "", "`", "").Replace(name)
+			if tableNameRegex.MatchString(name) {
+				return operation, name
+			}
+			return operation, ""
+		}
+		depth += strings.Count(field, "(") - strings.Count(field, ")")
+	}
+	return operation, ""
+}
+
 // DefaultQuerySanitizer is a basic query sanitizer that replaces
 // literal values with placeholders to prevent sensitive data from
 // appearing in traces.
@@ -91,3 +247,16 @@ func DefaultQuerySanitizer(query string) string {
 
 	return query
 }
+
+// NumericPreservingSanitizer is a query sanitizer that replaces quoted string
+// literals with '?' but leaves numeric and hex literals intact, so numeric
+// IDs remain visible in db.statement for correlation while strings, which
+// may hold personal data, are masked.
+//
+// Example:
+//
+//	NumericPreservingSanitizer("SELECT * FROM users WHERE id = 123 AND name = 'john'")
+//	// returns "SELECT * FROM users WHERE id = 123 AND name = '?'"
+func NumericPreservingSanitizer(query string) string {
+	return stringLiteralRegex.ReplaceAllString(query, "'?'")
+}
@@ -3,8 +3,9 @@ package sql
 import (
 	"context"
 	"database/sql/driver"
+	"time"
 
-	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -15,28 +16,39 @@ var _ driver.Tx = (*otelTx)(nil)
 type otelTx struct {
 	tx  driver.Tx
 	cfg *config
+
+	// conn is the connection the transaction runs on. Its tx field links
+	// the spans of the transaction to its BEGIN span until it ends.
+	conn *otelConn
+
+	// started is when BEGIN was issued, for db.client.tx.duration.
+	started time.Time
 }
 
-// newOtelTx creates a new instrumented transaction.
-func newOtelTx(tx driver.Tx, cfg *config) *otelTx {
+// newOtelTx creates a new instrumented transaction that began at started.
+func newOtelTx(tx driver.Tx, cfg *config, started time.Time) *otelTx {
 	return &otelTx{
-		tx:  tx,
-		cfg: cfg,
+		tx:      tx,
+		cfg:     cfg,
+		started: started,
 	}
 }
 
 // Commit implements driver.Tx.
 func (t *otelTx) Commit() error {
-	_, span := t.cfg.Tracer.Start(context.Background(), "COMMIT",
-		trace.WithSpanKind(trace.SpanKindClient),
+	ctx, span := t.cfg.Tracer.Start(context.Background(), "COMMIT",
+		trace.WithSpanKind(t.cfg.SpanKind),
 		trace.WithAttributes(t.cfg.baseAttributes()...),
+		trace.WithAttributes(txAttributes(t.begin())...),
+		trace.WithLinks(txLinks(t.begin())...),
 	)
 	defer span.End()
 
 	err := t.tx.Commit()
+	t.end()
+	t.cfg.Metrics.recordTx(ctx, time.Since(t.started), "commit", t.cfg.baseAttributes(), err)
 	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, err.Error())
+		recordError(span, err)
 		return err
 	}
 
@@ -45,18 +57,55 @@ func (t *otelTx) Commit() error {
 
 // Rollback implements driver.Tx.
 func (t *otelTx) Rollback() error {
-	_, span := t.cfg.Tracer.Start(context.Background(), "ROLLBACK",
-		trace.WithSpanKind(trace.SpanKindClient),
+	ctx, span := t.cfg.Tracer.Start(context.Background(), "ROLLBACK",
+		trace.WithSpanKind(t.cfg.SpanKind),
 		trace.WithAttributes(t.cfg.baseAttributes()...),
+		trace.WithAttributes(txAttributes(t.begin())...),
+		trace.WithLinks(txLinks(t.begin())...),
 	)
 	defer span.End()
 
 	err := t.tx.Rollback()
+	t.end()
+	t.cfg.Metrics.recordTx(ctx, time.Since(t.started), "rollback", t.cfg.baseAttributes(), err)
 	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, err.Error())
+		recordError(span, err)
 		return err
 	}
 
 	return nil
 }
+
+// begin returns the span context of the BEGIN span of the transaction.
+func (t *otelTx) begin() trace.SpanContext {
+	if t.conn == nil {
+		return trace.SpanContext{}
+	}
+	return t.conn.tx
+}
+
+// end stops linking the spans of the connection to the transaction.
+func (t *otelTx) end() {
+	if t.conn != nil {
+		t.conn.tx = trace.SpanContext{}
+	}
+}
+
+// txLinks returns a link to begin, the span context of the BEGIN span of
+// the transaction a span belongs to, or nil outside a transaction.
+func txLinks(begin trace.SpanContext) []trace.Link {
+	if !begin.IsValid() {
+		return nil
+	}
+	return []trace.Link{{SpanContext: begin}}
+}
+
+// txAttributes returns the db.transaction.id attribute shared by every span
+// of the transaction whose BEGIN span has span context begin, or nil outside
+// a transaction. The ID is the span ID of the BEGIN span.
+func txAttributes(begin trace.SpanContext) []attribute.KeyValue {
+	if !begin.IsValid() {
+		return nil
+	}
+	return []attribute.KeyValue{attribute.String("db.transaction.id", begin.SpanID().String())}
+}
@@ -1,11 +1,18 @@
 package sql
 
 import (
+	"context"
+	"database/sql/driver"
 	"testing"
+	"time"
 
 	"github.com/kroma-labs/sentinel-go/sql/mocks"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 )
 
 func TestNewOtelTx(t *testing.T) {
@@ -13,7 +20,7 @@ func TestNewOtelTx(t *testing.T) {
 		mockTx := mocks.NewDriverTx(t)
 		cfg := newConfig(WithDBSystem("postgresql"))
 
-		otelTx := newOtelTx(mockTx, cfg)
+		otelTx := newOtelTx(mockTx, cfg, time.Now())
 
 		require.NotNil(t, otelTx)
 		assert.Equal(t, mockTx, otelTx.tx)
@@ -49,7 +56,7 @@ func TestOtelTx_Commit(t *testing.T) {
 			tt.mockFn(mockTx)
 
 			cfg := newConfig(WithDBSystem("postgresql"))
-			otelTx := newOtelTx(mockTx, cfg)
+			otelTx := newOtelTx(mockTx, cfg, time.Now())
 
 			err := otelTx.Commit()
 
@@ -86,7 +93,7 @@ func TestOtelTx_Rollback(t *testing.T) {
 			tt.mockFn(mockTx)
 
 			cfg := newConfig(WithDBSystem("postgresql"))
-			otelTx := newOtelTx(mockTx, cfg)
+			otelTx := newOtelTx(mockTx, cfg, time.Now())
 
 			err := otelTx.Rollback()
 
@@ -94,3 +101,43 @@ func TestOtelTx_Rollback(t *testing.T) {
 		})
 	}
 }
+
+func TestOtelConn_TransactionSpanLinks(t *testing.T) {
+	ctx := context.Background()
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	mockTx := mocks.NewDriverTx(t)
+	mockTx.EXPECT().Commit().Return(nil)
+	mockConn := mocks.NewDriverConn(t)
+	mockConn.EXPECT().BeginTx(mock.Anything, mock.Anything).Return(mockTx, nil)
+	mockConn.EXPECT().ExecContext(mock.Anything, mock.Anything, mock.Anything).
+		Return(driver.RowsAffected(1), nil)
+	conn := newOtelConn(mockConn, newConfig(WithTracerProvider(tp)))
+
+	tx, err := conn.BeginTx(ctx, driver.TxOptions{})
+	require.NoError(t, err)
+	_, err = conn.ExecContext(ctx, "UPDATE users SET active = true", nil)
+	require.NoError(t, err)
+	require.NoError(t, tx.Commit())
+	_, err = conn.ExecContext(ctx, "DELETE FROM sessions", nil)
+	require.NoError(t, err)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 4)
+	begin := spans[0].SpanContext
+	for _, span := range spans[1:3] {
+		require.Len(t, span.Links, 1, span.Name)
+		assert.Equal(t, begin, span.Links[0].SpanContext)
+		set := attribute.NewSet(span.Attributes...)
+		id, ok := set.Value("db.transaction.id")
+		assert.True(t, ok)
+		assert.Equal(t, begin.SpanID().String(), id.AsString())
+	}
+
+	after := spans[3]
+	assert.Empty(t, after.Links)
+	set := attribute.NewSet(after.Attributes...)
+	_, ok := set.Value("db.transaction.id")
+	assert.False(t, ok)
+}
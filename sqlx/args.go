@@ -0,0 +1,73 @@
+package sqlx
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ArgsMode controls how query arguments are recorded on spans.
+type ArgsMode int
+
+const (
+	// ArgsNone does not record query arguments. This is the default.
+	ArgsNone ArgsMode = iota
+
+	// ArgsMasked records the position and Go type of each argument without
+	// its value, e.g. "$1=<string>, $2=<int64>". This is safe for
+	// sensitive data.
+	ArgsMasked
+
+	// ArgsFull records argument values, e.g. `$1="john", $2=42`.
+	// Values may contain sensitive data such as passwords or personal
+	// information, so enable this only for debugging.
+	ArgsFull
+)
+
+// recordArgs sets the db.statement.args attribute on span according to the
+// configured ArgsMode.
+func (cfg *config) recordArgs(span trace.Span, args []interface{}) {
+	if cfg.ArgsCapture == ArgsNone || len(args) == 0 || cfg.DisableQuery {
+		return
+	}
+
+	var b strings.Builder
+	for i, arg := range args {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		if named, ok := arg.(sql.NamedArg); ok {
+			b.WriteString(named.Name)
+			arg = named.Value
+		} else {
+			b.WriteString("$" + strconv.Itoa(i+1))
+		}
+		b.WriteByte('=')
+		b.WriteString(formatArg(cfg.ArgsCapture, arg))
+	}
+
+	span.SetAttributes(attribute.String("db.statement.args", b.String()))
+}
+
+// formatArg formats a single argument value for the given mode.
+func formatArg(mode ArgsMode, value interface{}) string {
+	if value == nil {
+		return "NULL"
+	}
+	if mode == ArgsMasked {
+		return fmt.Sprintf("<%T>", value)
+	}
+
+	switch v := value.(type) {
+	case string:
+		return strconv.Quote(v)
+	case []byte:
+		return strconv.Quote(string(v))
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
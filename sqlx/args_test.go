@@ -0,0 +1,66 @@
+package sqlx
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestDB_ArgsCapture(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []Option
+		wantArgs string
+	}{
+		{
+			name: "given default mode, then records nothing",
+		},
+		{
+			name:     "given masked mode, then records types only",
+			opts:     []Option{WithArgsCapture(ArgsMasked)},
+			wantArgs: "$1=<string>, $2=<int>, $3=NULL, id=<int64>",
+		},
+		{
+			name:     "given full mode, then records values",
+			opts:     []Option{WithArgsCapture(ArgsFull)},
+			wantArgs: `$1="john", $2=42, $3=NULL, id=7`,
+		},
+		{
+			name: "given full mode with DisableQuery, then records nothing",
+			opts: []Option{WithArgsCapture(ArgsFull), WithDisableQuery()},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer mockDB.Close()
+
+			exporter := tracetest.NewInMemoryExporter()
+			tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+			db := NewDB(mockDB, "postgres", append(tt.opts, WithTracerProvider(tp))...)
+
+			mock.ExpectExec("UPDATE users").WillReturnResult(sqlmock.NewResult(0, 1))
+
+			_, err = db.ExecContext(context.Background(),
+				"UPDATE users SET name = $1, age = $2, note = $3 WHERE id = @id",
+				"john", 42, nil, sql.Named("id", int64(7)))
+			require.NoError(t, err)
+
+			spans := exporter.GetSpans()
+			require.Len(t, spans, 1)
+			set := attribute.NewSet(spans[0].Attributes...)
+			value, ok := set.Value("db.statement.args")
+			assert.Equal(t, tt.wantArgs != "", ok)
+			assert.Equal(t, tt.wantArgs, value.AsString())
+		})
+	}
+}
@@ -0,0 +1,317 @@
+package sqlx
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/jmoiron/sqlx/reflectx"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// maxBindParams is the maximum number of bind parameters in one statement.
+// PostgreSQL encodes the parameter count as a uint16; MySQL uses the same limit.
+const maxBindParams = 65535
+
+// ErrBatchColumns is returned when no insertable columns can be derived from
+// the rows passed to BatchInsertContext.
+var ErrBatchColumns = errors.New("sqlx: batch insert rows have no mapped columns")
+
+// BatchOptions configures BatchInsertContext.
+type BatchOptions struct {
+	// ChunkSize is the maximum number of rows per INSERT statement.
+	// Default: as many rows as fit in 65535 bind parameters
+	ChunkSize int
+
+	// Columns restricts the inserted columns.
+	// Default: derived from the first row (db struct tags or map keys)
+	Columns []string
+}
+
+// batchExecer is implemented by *sqlx.DB and *sqlx.Tx.
+type batchExecer interface {
+	BindNamed(query string, arg interface{}) (string, []interface{}, error)
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// BatchInsertContext inserts rows into table using multi-row
+// INSERT ... VALUES statements, traced as a single span.
+//
+// Rows may be structs, pointers to structs, or map[string]interface{}.
+// Columns are derived from the first row the same way NamedExec resolves
+// names: struct fields by their db tag (or the mapper's name for untagged
+// fields), maps by their sorted keys.
+//
+// Rows are split into chunks of opts.ChunkSize so no statement exceeds the
+// bind parameter limit. Chunks are executed in order outside a transaction;
+// use Tx.BatchInsertContext when the batch must be atomic. If a chunk fails,
+// the returned result covers the chunks that succeeded.
+//
+// The table and column names are inserted into the query verbatim and must
+// not come from user input.
+//
+// Example:
+//
+//	type User struct {
+//	    ID   int    `db:"id"`
+//	    Name string `db:"name"`
+//	}
+//
+//	rows := []any{User{ID: 1, Name: "John"}, User{ID: 2, Name: "Jane"}}
+//	result, err := db.BatchInsertContext(ctx, "users", rows, sentinelsqlx.BatchOptions{})
+func (db *DB) BatchInsertContext(
+	ctx context.Context,
+	table string,
+	rows []any,
+	opts BatchOptions,
+) (sql.Result, error) {
+	return batchInsert(ctx, db.cfg, db.DB, db.DB.Mapper, table, rows, opts)
+}
+
+// BatchInsertContext inserts rows within the transaction.
+//
+// See DB.BatchInsertContext for details.
+func (tx *Tx) BatchInsertContext(
+	ctx context.Context,
+	table string,
+	rows []any,
+	opts BatchOptions,
+) (sql.Result, error) {
+	return batchInsert(ctx, tx.cfg, tx.Tx, tx.Tx.Mapper, table, rows, opts)
+}
+
+// batchInsert implements BatchInsertContext for DB and Tx.
+func batchInsert(
+	ctx context.Context,
+	cfg *config,
+	execer batchExecer,
+	mapper *reflectx.Mapper,
+	table string,
+	rows []any,
+	opts BatchOptions,
+) (sql.Result, error) {
+	if len(rows) == 0 {
+		return batchResult{}, nil
+	}
+
+	columns, binds := opts.Columns, opts.Columns
+	if len(columns) == 0 {
+		columns, binds = batchColumns(mapper, rows[0])
+	}
+	if len(columns) == 0 {
+		return nil, ErrBatchColumns
+	}
+
+	chunkSize := opts.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = max(maxBindParams/len(columns), 1)
+	}
+
+	query := fmt.Sprintf("INSERT INTO %s (%s) VALUES (:%s)",
+		table, strings.Join(columns, ", "), strings.Join(binds, ", :"))
+
+	start := time.Now()
+	operation := extractOperation(query)
+	chunks := (len(rows) + chunkSize - 1) / chunkSize
+
+	attrs := append(cfg.queryAttributes(ctx, query),
+		attribute.Int("db.batch.rows", len(rows)),
+		attribute.Int("db.batch.chunks", chunks),
+	)
+	ctx, span := cfg.Tracer.Start(ctx, cfg.sqlxSpanName("sqlx.BatchInsert", query),
+		trace.WithSpanKind(cfg.SpanKind),
+		trace.WithAttributes(attrs...),
+	)
+	defer span.End()
+
+	results := make(batchResult, 0, chunks)
+	var err error
+	for offset := 0; offset < len(rows); offset += chunkSize {
+		chunk := rows[offset:min(offset+chunkSize, len(rows))]
+
+		var (
+			bound  string
+			args   []interface{}
+			result sql.Result
+		)
+		bound, args, err = execer.BindNamed(query, chunk)
+		if err != nil {
+			break
+		}
+		result, err = execer.ExecContext(ctx, cfg.comment(ctx, bound), args...)
+		if err != nil {
+			break
+		}
+		results = append(results, result)
+	}
+
+	cfg.recordExec(ctx, span, time.Since(start), query, operation, results, err)
+
+	if err != nil {
+		recordError(span, err)
+	}
+
+	return results, err
+}
+
+// namedPreparer is implemented by *sqlx.DB and *sqlx.Tx.
+type namedPreparer interface {
+	PrepareNamedContext(ctx context.Context, query string) (*sqlx.NamedStmt, error)
+}
+
+// NamedBatchExecContext executes a named query once per element of args,
+// preparing it only once, and traces the batch as a single span.
+//
+// Each element is bound like NamedExecContext binds its arg: a struct,
+// pointer to struct, or map[string]interface{}. Elements are executed in
+// order outside a transaction; use Tx.NamedBatchExecContext when the batch
+// must be atomic. Execution stops at the first error, and the returned
+// result covers the elements that succeeded. RowsAffected is the total
+// across all executions.
+//
+// Example:
+//
+//	updates := []any{
+//	    map[string]any{"id": 1, "status": "shipped"},
+//	    map[string]any{"id": 2, "status": "canceled"},
+//	}
+//	result, err := db.NamedBatchExecContext(ctx,
+//	    "UPDATE orders SET status = :status WHERE id = :id", updates)
+func (db *DB) NamedBatchExecContext(
+	ctx context.Context,
+	query string,
+	args []any,
+) (sql.Result, error) {
+	return namedBatchExec(ctx, db.cfg, db.DB, query, args)
+}
+
+// NamedBatchExecContext executes a named query once per element of args
+// within the transaction.
+//
+// See DB.NamedBatchExecContext for details.
+func (tx *Tx) NamedBatchExecContext(
+	ctx context.Context,
+	query string,
+	args []any,
+) (sql.Result, error) {
+	return namedBatchExec(ctx, tx.cfg, tx.Tx, query, args)
+}
+
+// namedBatchExec implements NamedBatchExecContext for DB and Tx.
+func namedBatchExec(
+	ctx context.Context,
+	cfg *config,
+	preparer namedPreparer,
+	query string,
+	args []any,
+) (sql.Result, error) {
+	if len(args) == 0 {
+		return batchResult{}, nil
+	}
+
+	start := time.Now()
+	operation := extractOperation(query)
+
+	attrs := append(cfg.queryAttributes(ctx, query),
+		attribute.Int("db.batch.rows", len(args)),
+	)
+	ctx, span := cfg.Tracer.Start(ctx, cfg.sqlxSpanName("sqlx.NamedBatchExec", query),
+		trace.WithSpanKind(cfg.SpanKind),
+		trace.WithAttributes(attrs...),
+	)
+	defer span.End()
+
+	results := make(batchResult, 0, len(args))
+	stmt, err := preparer.PrepareNamedContext(ctx, query)
+	if err == nil {
+		for _, arg := range args {
+			var result sql.Result
+			result, err = stmt.ExecContext(ctx, arg)
+			if err != nil {
+				break
+			}
+			results = append(results, result)
+		}
+		_ = stmt.Close()
+	}
+
+	cfg.recordExec(ctx, span, time.Since(start), query, operation, results, err)
+
+	if err != nil {
+		recordError(span, err)
+	}
+
+	return results, err
+}
+
+// batchColumns derives column names and their named-parameter paths from a
+// struct or map row. Fields of tagged embedded structs are bound by their
+// full path ("base.id") but inserted under their own name ("id").
+func batchColumns(mapper *reflectx.Mapper, row any) (columns, binds []string) {
+	if m, ok := row.(map[string]interface{}); ok {
+		columns = make([]string, 0, len(m))
+		for k := range m {
+			columns = append(columns, k)
+		}
+		sort.Strings(columns)
+		return columns, columns
+	}
+
+	t := reflect.TypeOf(row)
+	if t == nil {
+		return nil, nil
+	}
+	t = reflectx.Deref(t)
+	if t.Kind() != reflect.Struct {
+		return nil, nil
+	}
+
+	var walk func(fields []*reflectx.FieldInfo)
+	walk = func(fields []*reflectx.FieldInfo) {
+		for _, f := range fields {
+			if f == nil {
+				continue
+			}
+			if f.Embedded {
+				walk(f.Children)
+				continue
+			}
+			columns = append(columns, f.Name)
+			binds = append(binds, f.Path)
+		}
+	}
+	walk(mapper.TypeMap(t).Tree.Children)
+
+	return columns, binds
+}
+
+// batchResult aggregates the results of each statement of a batch.
+type batchResult []sql.Result
+
+// LastInsertId returns the last insert ID reported by the final statement.
+func (r batchResult) LastInsertId() (int64, error) {
+	if len(r) == 0 {
+		return 0, nil
+	}
+	return r[len(r)-1].LastInsertId()
+}
+
+// RowsAffected returns the total rows affected across all statements.
+func (r batchResult) RowsAffected() (int64, error) {
+	var total int64
+	for _, result := range r {
+		n, err := result.RowsAffected()
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	return total, nil
+}
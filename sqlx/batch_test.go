@@ -0,0 +1,333 @@
+package sqlx
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestDB_BatchInsertContext(t *testing.T) {
+	type user struct {
+		ID   int    `db:"id"`
+		Name string `db:"name"`
+	}
+
+	users := func(n int) []any {
+		rows := make([]any, n)
+		for i := range rows {
+			rows[i] = user{ID: i + 1, Name: "user"}
+		}
+		return rows
+	}
+
+	tests := []struct {
+		name         string
+		rows         []any
+		opts         BatchOptions
+		wantChunks   []int
+		wantAffected int64
+	}{
+		{
+			name:         "given default chunk size, then inserts in one statement",
+			rows:         users(5),
+			opts:         BatchOptions{},
+			wantChunks:   []int{5},
+			wantAffected: 5,
+		},
+		{
+			name:         "given chunk size dividing rows evenly, then inserts equal chunks",
+			rows:         users(4),
+			opts:         BatchOptions{ChunkSize: 2},
+			wantChunks:   []int{2, 2},
+			wantAffected: 4,
+		},
+		{
+			name:         "given chunk size with remainder, then last chunk is smaller",
+			rows:         users(5),
+			opts:         BatchOptions{ChunkSize: 2},
+			wantChunks:   []int{2, 2, 1},
+			wantAffected: 5,
+		},
+		{
+			name:         "given chunk size of one, then inserts row by row",
+			rows:         users(3),
+			opts:         BatchOptions{ChunkSize: 1},
+			wantChunks:   []int{1, 1, 1},
+			wantAffected: 3,
+		},
+		{
+			name:         "given chunk size larger than rows, then inserts in one statement",
+			rows:         users(3),
+			opts:         BatchOptions{ChunkSize: 100},
+			wantChunks:   []int{3},
+			wantAffected: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer mockDB.Close()
+
+			exporter := tracetest.NewInMemoryExporter()
+			tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+			db := NewDB(mockDB, "postgres", WithTracerProvider(tp))
+
+			id := 1
+			for _, size := range tt.wantChunks {
+				args := make([]driver.Value, 0, size*2)
+				for range size {
+					args = append(args, id, "user")
+					id++
+				}
+				mock.ExpectExec(`INSERT INTO users \(id, name\) VALUES`).
+					WithArgs(args...).
+					WillReturnResult(sqlmock.NewResult(0, int64(size)))
+			}
+
+			result, err := db.BatchInsertContext(context.Background(), "users", tt.rows, tt.opts)
+			require.NoError(t, err)
+			require.NoError(t, mock.ExpectationsWereMet())
+
+			affected, err := result.RowsAffected()
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantAffected, affected)
+
+			spans := exporter.GetSpans()
+			require.Len(t, spans, 1)
+			assert.Equal(t, "sqlx.BatchInsert: INSERT", spans[0].Name)
+
+			attrs := attribute.NewSet(spans[0].Attributes...)
+			rows, _ := attrs.Value("db.batch.rows")
+			assert.Equal(t, int64(len(tt.rows)), rows.AsInt64())
+			chunks, _ := attrs.Value("db.batch.chunks")
+			assert.Equal(t, int64(len(tt.wantChunks)), chunks.AsInt64())
+		})
+	}
+}
+
+func TestDB_BatchInsertContext_Columns(t *testing.T) {
+	type base struct {
+		ID int `db:"id"`
+	}
+	type tagged struct {
+		base
+		Name    string `db:"name"`
+		Ignored string `db:"-"`
+		secret  string
+	}
+
+	tests := []struct {
+		name      string
+		rows      []any
+		opts      BatchOptions
+		wantQuery string
+		wantArgs  []driver.Value
+		wantErr   error
+	}{
+		{
+			name:      "given struct with embedded and skipped fields, then maps db tags",
+			rows:      []any{&tagged{base: base{ID: 1}, Name: "John", Ignored: "x", secret: "y"}},
+			wantQuery: `INSERT INTO users \(id, name\) VALUES \(\$1, \$2\)`,
+			wantArgs:  []driver.Value{1, "John"},
+		},
+		{
+			name:      "given maps, then uses sorted keys",
+			rows:      []any{map[string]interface{}{"name": "John", "id": 1}},
+			wantQuery: `INSERT INTO users \(id, name\) VALUES \(\$1, \$2\)`,
+			wantArgs:  []driver.Value{1, "John"},
+		},
+		{
+			name:      "given explicit columns, then inserts only those",
+			rows:      []any{tagged{base: base{ID: 1}, Name: "John"}},
+			opts:      BatchOptions{Columns: []string{"name"}},
+			wantQuery: `INSERT INTO users \(name\) VALUES \(\$1\)`,
+			wantArgs:  []driver.Value{"John"},
+		},
+		{
+			name:    "given non-struct rows, then returns ErrBatchColumns",
+			rows:    []any{42},
+			wantErr: ErrBatchColumns,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer mockDB.Close()
+
+			db := NewDB(mockDB, "postgres")
+			if tt.wantQuery != "" {
+				mock.ExpectExec(tt.wantQuery).
+					WithArgs(tt.wantArgs...).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			}
+
+			_, err = db.BatchInsertContext(context.Background(), "users", tt.rows, tt.opts)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTx_BatchInsertContext(t *testing.T) {
+	t.Run("given failing chunk, then returns error and partial result", func(t *testing.T) {
+		mockDB, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer mockDB.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO users").WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectExec("INSERT INTO users").WillReturnError(assert.AnError)
+
+		db := NewDB(mockDB, "postgres")
+		tx, err := db.BeginTxx(context.Background(), nil)
+		require.NoError(t, err)
+
+		rows := []any{
+			map[string]interface{}{"id": 1},
+			map[string]interface{}{"id": 2},
+			map[string]interface{}{"id": 3},
+		}
+		result, err := tx.BatchInsertContext(
+			context.Background(), "users", rows, BatchOptions{ChunkSize: 2},
+		)
+		require.ErrorIs(t, err, assert.AnError)
+
+		affected, err := result.RowsAffected()
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), affected)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestDB_NamedBatchExecContext(t *testing.T) {
+	query := "UPDATE orders SET status = :status WHERE id = :id"
+
+	tests := []struct {
+		name         string
+		args         []any
+		mockFn       func(sqlmock.Sqlmock)
+		wantErr      assert.ErrorAssertionFunc
+		wantAffected int64
+		wantSpans    int
+	}{
+		{
+			name: "given maps and structs, then prepares once and executes each",
+			args: []any{
+				map[string]interface{}{"id": 1, "status": "shipped"},
+				struct {
+					ID     int    `db:"id"`
+					Status string `db:"status"`
+				}{ID: 2, Status: "canceled"},
+			},
+			mockFn: func(mock sqlmock.Sqlmock) {
+				prep := mock.ExpectPrepare(`UPDATE orders SET status = \$1 WHERE id = \$2`)
+				prep.ExpectExec().WithArgs("shipped", 1).WillReturnResult(sqlmock.NewResult(0, 1))
+				prep.ExpectExec().WithArgs("canceled", 2).WillReturnResult(sqlmock.NewResult(0, 1))
+				prep.WillBeClosed()
+			},
+			wantErr:      assert.NoError,
+			wantAffected: 2,
+			wantSpans:    1,
+		},
+		{
+			name: "given failing execution, then stops and returns partial result",
+			args: []any{
+				map[string]interface{}{"id": 1, "status": "shipped"},
+				map[string]interface{}{"id": 2, "status": "shipped"},
+				map[string]interface{}{"id": 3, "status": "shipped"},
+			},
+			mockFn: func(mock sqlmock.Sqlmock) {
+				prep := mock.ExpectPrepare("UPDATE orders")
+				prep.ExpectExec().WithArgs("shipped", 1).WillReturnResult(sqlmock.NewResult(0, 1))
+				prep.ExpectExec().WithArgs("shipped", 2).WillReturnError(assert.AnError)
+				prep.WillBeClosed()
+			},
+			wantErr:      assert.Error,
+			wantAffected: 1,
+			wantSpans:    1,
+		},
+		{
+			name:         "given no args, then does nothing",
+			mockFn:       func(sqlmock.Sqlmock) {},
+			wantErr:      assert.NoError,
+			wantAffected: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer mockDB.Close()
+
+			exporter := tracetest.NewInMemoryExporter()
+			tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+			db := NewDB(mockDB, "postgres", WithTracerProvider(tp))
+			tt.mockFn(mock)
+
+			result, err := db.NamedBatchExecContext(context.Background(), query, tt.args)
+			tt.wantErr(t, err)
+			require.NoError(t, mock.ExpectationsWereMet())
+
+			affected, err := result.RowsAffected()
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantAffected, affected)
+
+			spans := exporter.GetSpans()
+			require.Len(t, spans, tt.wantSpans)
+			if tt.wantSpans > 0 {
+				assert.Equal(t, "sqlx.NamedBatchExec: UPDATE", spans[0].Name)
+				attrs := attribute.NewSet(spans[0].Attributes...)
+				rows, _ := attrs.Value("db.batch.rows")
+				assert.Equal(t, int64(len(tt.args)), rows.AsInt64())
+			}
+		})
+	}
+}
+
+func TestTx_NamedBatchExecContext(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer mockDB.Close()
+
+	mock.ExpectBegin()
+	prep := mock.ExpectPrepare("UPDATE orders")
+	prep.ExpectExec().WithArgs("shipped", 1).WillReturnResult(sqlmock.NewResult(0, 1))
+	prep.ExpectExec().WithArgs("shipped", 2).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	db := NewDB(mockDB, "postgres")
+	tx, err := db.BeginTxx(context.Background(), nil)
+	require.NoError(t, err)
+
+	result, err := tx.NamedBatchExecContext(context.Background(),
+		"UPDATE orders SET status = :status WHERE id = :id",
+		[]any{
+			map[string]interface{}{"id": 1, "status": "shipped"},
+			map[string]interface{}{"id": 2, "status": "shipped"},
+		})
+	require.NoError(t, err)
+	require.NoError(t, tx.Commit())
+
+	affected, err := result.RowsAffected()
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), affected)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
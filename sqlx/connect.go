@@ -0,0 +1,116 @@
+package sqlx
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RetryOptions configures how ConnectWithRetry waits for the database.
+type RetryOptions struct {
+	// MaxAttempts is the maximum number of pings. Zero means retry until
+	// the context is done.
+	MaxAttempts int
+
+	// Backoff is the delay after the first failed ping. It doubles after
+	// each further failure, up to MaxBackoff.
+	// Default: 500ms
+	Backoff time.Duration
+
+	// MaxBackoff caps the delay between pings.
+	// Default: 10s
+	MaxBackoff time.Duration
+}
+
+// delay returns the wait after the given failed attempt, starting at 1.
+func (o RetryOptions) delay(attempt int) time.Duration {
+	backoff := o.Backoff
+	if backoff <= 0 {
+		backoff = 500 * time.Millisecond
+	}
+	maxBackoff := o.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = 10 * time.Second
+	}
+
+	for i := 1; i < attempt && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, maxBackoff)
+}
+
+// ConnectWithRetry opens a database connection and pings it until the
+// database is reachable, waiting with exponential backoff between pings.
+//
+// Use it instead of Connect when the database may start after the
+// application, as in orchestrated deployments. Each ping is traced as a
+// PING span under a sqlx.Connect span that records the number of attempts.
+// If the context is done or MaxAttempts pings fail, the connection is closed
+// and the last ping error is returned.
+//
+// Example:
+//
+//	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+//	defer cancel()
+//
+//	db, err := sentinelsqlx.ConnectWithRetry(ctx, "postgres", dsn,
+//	    sentinelsqlx.RetryOptions{Backoff: time.Second},
+//	    sentinelsqlx.WithDBSystem("postgresql"),
+//	)
+func ConnectWithRetry(
+	ctx context.Context,
+	driverName, dsn string,
+	retry RetryOptions,
+	opts ...Option,
+) (*DB, error) {
+	db, err := Open(driverName, dsn, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, span := db.cfg.Tracer.Start(ctx, "sqlx.Connect",
+		trace.WithSpanKind(db.cfg.SpanKind),
+		trace.WithAttributes(db.cfg.baseAttributes()...),
+	)
+	defer span.End()
+
+	attempt := 1
+	for ; ; attempt++ {
+		err = db.PingContext(ctx)
+		if err == nil {
+			span.SetAttributes(attribute.Int("db.connect.attempts", attempt))
+			return db, nil
+		}
+
+		if retry.MaxAttempts > 0 && attempt >= retry.MaxAttempts {
+			err = fmt.Errorf("sqlx: database unreachable after %d attempts: %w", attempt, err)
+			break
+		}
+		if waitErr := sleepContext(ctx, retry.delay(attempt)); waitErr != nil {
+			err = fmt.Errorf("sqlx: database unreachable: %w: %w", waitErr, err)
+			break
+		}
+	}
+
+	span.SetAttributes(attribute.Int("db.connect.attempts", attempt))
+	recordError(span, err)
+	_ = db.Close()
+	return nil, err
+}
+
+// sleepContext waits for d, or returns the context error if ctx is done
+// first.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,134 @@
+package sqlx
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestRetryOptions_delay(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    RetryOptions
+		attempt int
+		want    time.Duration
+	}{
+		{
+			name:    "given defaults, when first attempt, then waits default backoff",
+			attempt: 1,
+			want:    500 * time.Millisecond,
+		},
+		{
+			name:    "given backoff, when third attempt, then doubles twice",
+			opts:    RetryOptions{Backoff: time.Second},
+			attempt: 3,
+			want:    4 * time.Second,
+		},
+		{
+			name:    "given max backoff, when many attempts, then caps delay",
+			opts:    RetryOptions{Backoff: time.Second, MaxBackoff: 5 * time.Second},
+			attempt: 50,
+			want:    5 * time.Second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.opts.delay(tt.attempt))
+		})
+	}
+}
+
+func TestConnectWithRetry(t *testing.T) {
+	errRefused := errors.New("connection refused")
+
+	tests := []struct {
+		name         string
+		dsn          string
+		failures     int
+		opts         RetryOptions
+		wantErr      bool
+		wantAttempts int64
+	}{
+		{
+			name:         "given database up after failures, then connects",
+			dsn:          "connect_retry_up",
+			failures:     2,
+			opts:         RetryOptions{Backoff: time.Millisecond},
+			wantAttempts: 3,
+		},
+		{
+			name:         "given database never up, then fails after max attempts",
+			dsn:          "connect_retry_down",
+			failures:     2,
+			opts:         RetryOptions{MaxAttempts: 2, Backoff: time.Millisecond},
+			wantErr:      true,
+			wantAttempts: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, mock, err := sqlmock.NewWithDSN(tt.dsn, sqlmock.MonitorPingsOption(true))
+			require.NoError(t, err)
+			for range tt.failures {
+				mock.ExpectPing().WillReturnError(errRefused)
+			}
+			if !tt.wantErr {
+				mock.ExpectPing()
+			}
+
+			exporter := tracetest.NewInMemoryExporter()
+			tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+			db, err := ConnectWithRetry(context.Background(), "sqlmock", tt.dsn, tt.opts,
+				WithTracerProvider(tp))
+			if tt.wantErr {
+				require.ErrorIs(t, err, errRefused)
+				assert.Nil(t, db)
+			} else {
+				require.NoError(t, err)
+				defer db.Close()
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+
+			var connect sdktrace.ReadOnlySpan
+			var pings int64
+			for _, span := range exporter.GetSpans().Snapshots() {
+				switch span.Name() {
+				case "sqlx.Connect":
+					connect = span
+				case "PING":
+					pings++
+				}
+			}
+			require.NotNil(t, connect)
+			assert.Equal(t, tt.wantAttempts, pings)
+			attrs := attribute.NewSet(connect.Attributes()...)
+			attempts, _ := attrs.Value("db.connect.attempts")
+			assert.Equal(t, tt.wantAttempts, attempts.AsInt64())
+		})
+	}
+
+	t.Run("given context done, then stops retrying", func(t *testing.T) {
+		_, mock, err := sqlmock.NewWithDSN("connect_retry_ctx", sqlmock.MonitorPingsOption(true))
+		require.NoError(t, err)
+		mock.ExpectPing().WillReturnError(errRefused)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		_, err = ConnectWithRetry(ctx, "sqlmock", "connect_retry_ctx",
+			RetryOptions{Backoff: time.Hour})
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.ErrorIs(t, err, errRefused)
+	})
+}
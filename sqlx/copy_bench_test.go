@@ -0,0 +1,79 @@
+package sqlx
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	_ "github.com/lib/pq"
+)
+
+// copyBenchRows is the number of rows loaded per benchmark iteration.
+const copyBenchRows = 10000
+
+// openBenchPostgres connects to the database in SENTINEL_POSTGRES_DSN and
+// creates an empty benchmark table, skipping the benchmark if unset.
+func openBenchPostgres(b *testing.B) *DB {
+	b.Helper()
+
+	dsn := os.Getenv("SENTINEL_POSTGRES_DSN")
+	if dsn == "" {
+		b.Skip("SENTINEL_POSTGRES_DSN not set")
+	}
+
+	db, err := Open("postgres", dsn)
+	if err != nil {
+		b.Fatalf("open: %v", err)
+	}
+	b.Cleanup(func() { _ = db.Close() })
+	// Temporary tables are per connection.
+	db.SetMaxOpenConns(1)
+
+	ctx := context.Background()
+	if _, err := db.ExecContext(ctx,
+		"CREATE TEMP TABLE IF NOT EXISTS bench_users (id BIGINT, name TEXT)"); err != nil {
+		b.Fatalf("create table: %v", err)
+	}
+	return db
+}
+
+// BenchmarkCopyFromContext measures loading rows with COPY.
+func BenchmarkCopyFromContext(b *testing.B) {
+	db := openBenchPostgres(b)
+	ctx := context.Background()
+
+	rows := make([][]any, copyBenchRows)
+	for i := range rows {
+		rows[i] = []any{i, fmt.Sprintf("user-%d", i)}
+	}
+
+	b.ResetTimer()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, err := db.CopyFromContext(ctx, "bench_users", []string{"id", "name"}, rows)
+		if err != nil {
+			b.Fatalf("copy: %v", err)
+		}
+	}
+}
+
+// BenchmarkBatchInsertContext measures loading the same rows with
+// multi-row INSERT statements, for comparison with COPY.
+func BenchmarkBatchInsertContext(b *testing.B) {
+	db := openBenchPostgres(b)
+	ctx := context.Background()
+
+	rows := make([]any, copyBenchRows)
+	for i := range rows {
+		rows[i] = map[string]interface{}{"id": i, "name": fmt.Sprintf("user-%d", i)}
+	}
+
+	b.ResetTimer()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := db.BatchInsertContext(ctx, "bench_users", rows, BatchOptions{}); err != nil {
+			b.Fatalf("batch insert: %v", err)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package sqlx
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestDB_CopyFromContext(t *testing.T) {
+	const copyQuery = `COPY "public"."users" ("id", "name") FROM STDIN`
+
+	tests := []struct {
+		name       string
+		driverName string
+		mockFn     func(mock sqlmock.Sqlmock)
+		wantN      int64
+		wantErr    func(t *testing.T, err error)
+		wantSpan   bool
+	}{
+		{
+			name:       "given postgres driver, then copies rows in a transaction",
+			driverName: "postgres",
+			mockFn: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				prep := mock.ExpectPrepare(copyQuery)
+				prep.ExpectExec().WithArgs(1, "John").WillReturnResult(sqlmock.NewResult(0, 0))
+				prep.ExpectExec().WithArgs(2, "Jane").WillReturnResult(sqlmock.NewResult(0, 0))
+				prep.ExpectExec().WithoutArgs().WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectCommit()
+			},
+			wantN:    2,
+			wantErr:  func(t *testing.T, err error) { require.NoError(t, err) },
+			wantSpan: true,
+		},
+		{
+			name:       "given failing row, then rolls back",
+			driverName: "postgres",
+			mockFn: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				prep := mock.ExpectPrepare(copyQuery)
+				prep.ExpectExec().WithArgs(1, "John").WillReturnError(assert.AnError)
+				mock.ExpectRollback()
+			},
+			wantErr:  func(t *testing.T, err error) { require.ErrorIs(t, err, assert.AnError) },
+			wantSpan: true,
+		},
+		{
+			name:       "given non-postgres driver, then returns ErrUnsupported",
+			driverName: "mysql",
+			mockFn:     func(sqlmock.Sqlmock) {},
+			wantErr: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, ErrUnsupported)
+				require.ErrorIs(t, err, errors.ErrUnsupported)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			require.NoError(t, err)
+			defer mockDB.Close()
+
+			exporter := tracetest.NewInMemoryExporter()
+			tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+			db := NewDB(mockDB, tt.driverName, WithTracerProvider(tp))
+			tt.mockFn(mock)
+
+			n, err := db.CopyFromContext(context.Background(), "public.users",
+				[]string{"id", "name"}, [][]any{{1, "John"}, {2, "Jane"}})
+			tt.wantErr(t, err)
+			assert.Equal(t, tt.wantN, n)
+			require.NoError(t, mock.ExpectationsWereMet())
+
+			spans := exporter.GetSpans()
+			if !tt.wantSpan {
+				assert.Empty(t, spans)
+				return
+			}
+			require.Len(t, spans, 1)
+			assert.Equal(t, "sqlx.CopyFrom: COPY", spans[0].Name)
+
+			set := attribute.NewSet(spans[0].Attributes...)
+			if err != nil {
+				assert.Equal(t, codes.Error, spans[0].Status.Code)
+				return
+			}
+			value, ok := set.Value("db.rows_affected")
+			require.True(t, ok)
+			assert.Equal(t, int64(2), value.AsInt64())
+		})
+	}
+}
+
+func TestCopyFromQuery(t *testing.T) {
+	assert.Equal(t, `COPY "users" ("id", "na""me") FROM STDIN`,
+		copyFromQuery("users", []string{"id", `na"me`}))
+}
@@ -7,7 +7,6 @@ import (
 
 	"github.com/jmoiron/sqlx"
 
-	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -16,7 +15,24 @@ import (
 // like Get, Select, NamedExec, and NamedQuery.
 type DB struct {
 	*sqlx.DB
-	cfg *config
+	cfg      *config
+	replicas *replicaSet
+	stmts    *stmtCache
+	health   *poolHealth
+}
+
+// newDB wraps db with cfg, creating the statement cache and registering pool
+// metrics when enabled.
+func newDB(db *sqlx.DB, cfg *config) *DB {
+	wrapped := &DB{DB: db, cfg: cfg, health: &poolHealth{}}
+	if cfg.StatementCacheSize > 0 {
+		wrapped.stmts = newStmtCache(cfg.StatementCacheSize)
+	}
+	if cfg.PoolMetrics {
+		// Ignore errors like newConfig does; the DB is still usable.
+		_ = (&metrics{}).registerPoolMetrics(cfg.Meter, db.DB, cfg.baseAttributes())
+	}
+	return wrapped
 }
 
 // Open opens a database connection with OpenTelemetry instrumentation.
@@ -30,13 +46,14 @@ type DB struct {
 //	)
 func Open(driverName, dsn string, opts ...Option) (*DB, error) {
 	cfg := newConfig(opts...)
+	cfg.applyDSN(dsn)
 
 	db, err := sqlx.Open(driverName, dsn)
 	if err != nil {
 		return nil, err
 	}
 
-	return &DB{DB: db, cfg: cfg}, nil
+	return newDB(db, cfg), nil
 }
 
 // Connect opens and verifies a database connection.
@@ -49,13 +66,14 @@ func Open(driverName, dsn string, opts ...Option) (*DB, error) {
 //	)
 func Connect(ctx context.Context, driverName, dsn string, opts ...Option) (*DB, error) {
 	cfg := newConfig(opts...)
+	cfg.applyDSN(dsn)
 
 	db, err := sqlx.ConnectContext(ctx, driverName, dsn)
 	if err != nil {
 		return nil, err
 	}
 
-	return &DB{DB: db, cfg: cfg}, nil
+	return newDB(db, cfg), nil
 }
 
 // NewDB wraps an existing *sql.DB with sqlx and instrumentation.
@@ -68,10 +86,7 @@ func Connect(ctx context.Context, driverName, dsn string, opts ...Option) (*DB,
 //	)
 func NewDB(db *sql.DB, driverName string, opts ...Option) *DB {
 	cfg := newConfig(opts...)
-	return &DB{
-		DB:  sqlx.NewDb(db, driverName),
-		cfg: cfg,
-	}
+	return newDB(sqlx.NewDb(db, driverName), cfg)
 }
 
 // MustConnect is like Connect but panics on error.
@@ -103,25 +118,26 @@ func (db *DB) GetContext(
 	start := time.Now()
 	operation := extractOperation(query)
 
-	ctx, span := db.cfg.Tracer.Start(ctx, sqlxSpanName("sqlx.Get", query),
-		trace.WithSpanKind(trace.SpanKindClient),
-		trace.WithAttributes(db.cfg.queryAttributes(query)...),
+	ctx, span := db.cfg.Tracer.Start(ctx, db.cfg.sqlxSpanName("sqlx.Get", query),
+		trace.WithSpanKind(db.cfg.SpanKind),
+		trace.WithAttributes(db.cfg.queryAttributes(ctx, query)...),
 	)
 	defer span.End()
+	db.cfg.recordArgs(span, args)
 
-	err := db.DB.GetContext(ctx, dest, query, args...)
+	ctx, cancel, timeout := db.cfg.withQueryTimeout(ctx)
+	defer cancel()
 
-	db.cfg.Metrics.recordQueryDuration(
-		ctx,
-		time.Since(start),
-		operation,
-		db.cfg.baseAttributes(),
-		err,
-	)
+	conn := db.reader(ctx, span, operation, query)
+	err := conn.GetContext(ctx, dest, db.cfg.comment(ctx, query), args...)
+	err = db.cfg.translateNotFound(err)
+
+	db.cfg.recordQuery(ctx, time.Since(start), query, operation, err)
+	db.cfg.recordGetRows(ctx, span, operation, err)
 
 	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, err.Error())
+		recordError(span, err)
+		db.cfg.recordTimeout(ctx, span, timeout, operation, err)
 	}
 
 	return err
@@ -137,25 +153,25 @@ func (db *DB) SelectContext(
 	start := time.Now()
 	operation := extractOperation(query)
 
-	ctx, span := db.cfg.Tracer.Start(ctx, sqlxSpanName("sqlx.Select", query),
-		trace.WithSpanKind(trace.SpanKindClient),
-		trace.WithAttributes(db.cfg.queryAttributes(query)...),
+	ctx, span := db.cfg.Tracer.Start(ctx, db.cfg.sqlxSpanName("sqlx.Select", query),
+		trace.WithSpanKind(db.cfg.SpanKind),
+		trace.WithAttributes(db.cfg.queryAttributes(ctx, query)...),
 	)
 	defer span.End()
+	db.cfg.recordArgs(span, args)
 
-	err := db.DB.SelectContext(ctx, dest, query, args...)
+	ctx, cancel, timeout := db.cfg.withQueryTimeout(ctx)
+	defer cancel()
 
-	db.cfg.Metrics.recordQueryDuration(
-		ctx,
-		time.Since(start),
-		operation,
-		db.cfg.baseAttributes(),
-		err,
-	)
+	conn := db.reader(ctx, span, operation, query)
+	err := conn.SelectContext(ctx, dest, db.cfg.comment(ctx, query), args...)
+
+	db.cfg.recordQuery(ctx, time.Since(start), query, operation, err)
+	db.cfg.recordSelectRows(ctx, span, operation, dest, err)
 
 	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, err.Error())
+		recordError(span, err)
+		db.cfg.recordTimeout(ctx, span, timeout, operation, err)
 	}
 
 	return err
@@ -170,25 +186,18 @@ func (db *DB) NamedExecContext(
 	start := time.Now()
 	operation := extractOperation(query)
 
-	ctx, span := db.cfg.Tracer.Start(ctx, sqlxSpanName("sqlx.NamedExec", query),
-		trace.WithSpanKind(trace.SpanKindClient),
-		trace.WithAttributes(db.cfg.queryAttributes(query)...),
+	ctx, span := db.cfg.Tracer.Start(ctx, db.cfg.sqlxSpanName("sqlx.NamedExec", query),
+		trace.WithSpanKind(db.cfg.SpanKind),
+		trace.WithAttributes(db.cfg.queryAttributes(ctx, query)...),
 	)
 	defer span.End()
 
-	result, err := db.DB.NamedExecContext(ctx, query, arg)
+	result, err := db.primary(span).NamedExecContext(ctx, db.cfg.comment(ctx, query), arg)
 
-	db.cfg.Metrics.recordQueryDuration(
-		ctx,
-		time.Since(start),
-		operation,
-		db.cfg.baseAttributes(),
-		err,
-	)
+	db.cfg.recordExec(ctx, span, time.Since(start), query, operation, result, err)
 
 	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, err.Error())
+		recordError(span, err)
 	}
 
 	return result, err
@@ -203,25 +212,18 @@ func (db *DB) NamedQueryContext(
 	start := time.Now()
 	operation := extractOperation(query)
 
-	ctx, span := db.cfg.Tracer.Start(ctx, sqlxSpanName("sqlx.NamedQuery", query),
-		trace.WithSpanKind(trace.SpanKindClient),
-		trace.WithAttributes(db.cfg.queryAttributes(query)...),
+	ctx, span := db.cfg.Tracer.Start(ctx, db.cfg.sqlxSpanName("sqlx.NamedQuery", query),
+		trace.WithSpanKind(db.cfg.SpanKind),
+		trace.WithAttributes(db.cfg.queryAttributes(ctx, query)...),
 	)
 	defer span.End()
 
-	rows, err := db.DB.NamedQueryContext(ctx, query, arg)
+	rows, err := db.primary(span).NamedQueryContext(ctx, db.cfg.comment(ctx, query), arg)
 
-	db.cfg.Metrics.recordQueryDuration(
-		ctx,
-		time.Since(start),
-		operation,
-		db.cfg.baseAttributes(),
-		err,
-	)
+	db.cfg.recordQuery(ctx, time.Since(start), query, operation, err)
 
 	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, err.Error())
+		recordError(span, err)
 	}
 
 	return rows, err
@@ -236,66 +238,126 @@ func (db *DB) QueryxContext(
 	start := time.Now()
 	operation := extractOperation(query)
 
-	ctx, span := db.cfg.Tracer.Start(ctx, sqlxSpanName("sqlx.Queryx", query),
-		trace.WithSpanKind(trace.SpanKindClient),
-		trace.WithAttributes(db.cfg.queryAttributes(query)...),
+	ctx, span := db.cfg.Tracer.Start(ctx, db.cfg.sqlxSpanName("sqlx.Queryx", query),
+		trace.WithSpanKind(db.cfg.SpanKind),
+		trace.WithAttributes(db.cfg.queryAttributes(ctx, query)...),
 	)
 	defer span.End()
+	db.cfg.recordArgs(span, args)
 
-	rows, err := db.DB.QueryxContext(ctx, query, args...)
+	conn := db.reader(ctx, span, operation, query)
+	rows, err := conn.QueryxContext(ctx, db.cfg.comment(ctx, query), args...)
 
-	db.cfg.Metrics.recordQueryDuration(
-		ctx,
-		time.Since(start),
-		operation,
-		db.cfg.baseAttributes(),
-		err,
-	)
+	db.cfg.recordQuery(ctx, time.Since(start), query, operation, err)
 
 	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, err.Error())
+		recordError(span, err)
 	}
 
 	return rows, err
 }
 
+// TracedQueryxContext is like QueryxContext, but its span stays open while
+// the returned Rows are read, so the time spent scanning, often the real
+// cost of a query, is part of the trace. The span ends when Next returns
+// false or Close is called, and records the db.rows_returned and
+// db.scan.duration (seconds) attributes.
+//
+// Rows that are garbage collected without being closed hold a connection
+// until then. They are closed, their span is ended with an error, and the
+// db.client.rows.leaked counter is incremented, which catches the classic
+// forgotten rows.Close() connection leak.
+//
+// Example:
+//
+//	rows, err := db.TracedQueryxContext(ctx, "SELECT id, name FROM users")
+//	if err != nil {
+//	    return err
+//	}
+//	defer rows.Close() // ends the span
+//	for rows.Next() {
+//	    // ...
+//	}
+func (db *DB) TracedQueryxContext(
+	ctx context.Context,
+	query string,
+	args ...interface{},
+) (*Rows, error) {
+	start := time.Now()
+	operation := extractOperation(query)
+
+	ctx, span := db.cfg.Tracer.Start(ctx, db.cfg.sqlxSpanName("sqlx.Queryx", query),
+		trace.WithSpanKind(db.cfg.SpanKind),
+		trace.WithAttributes(db.cfg.queryAttributes(ctx, query)...),
+	)
+	db.cfg.recordArgs(span, args)
+
+	conn := db.reader(ctx, span, operation, query)
+	rows, err := conn.QueryxContext(ctx, db.cfg.comment(ctx, query), args...)
+
+	db.cfg.recordQuery(ctx, time.Since(start), query, operation, err)
+
+	return db.cfg.traceRows(ctx, span, operation, rows, err)
+}
+
 // QueryRowxContext executes a query and returns a single sqlx.Row.
 func (db *DB) QueryRowxContext(ctx context.Context, query string, args ...interface{}) *sqlx.Row {
 	start := time.Now()
 	operation := extractOperation(query)
 
-	ctx, span := db.cfg.Tracer.Start(ctx, sqlxSpanName("sqlx.QueryRowx", query),
-		trace.WithSpanKind(trace.SpanKindClient),
-		trace.WithAttributes(db.cfg.queryAttributes(query)...),
+	ctx, span := db.cfg.Tracer.Start(ctx, db.cfg.sqlxSpanName("sqlx.QueryRowx", query),
+		trace.WithSpanKind(db.cfg.SpanKind),
+		trace.WithAttributes(db.cfg.queryAttributes(ctx, query)...),
 	)
 	defer span.End()
+	db.cfg.recordArgs(span, args)
 
-	row := db.DB.QueryRowxContext(ctx, query, args...)
+	conn := db.reader(ctx, span, operation, query)
+	row := conn.QueryRowxContext(ctx, db.cfg.comment(ctx, query), args...)
 
 	// Record metrics (we can't know if there's an error until Scan is called)
-	db.cfg.Metrics.recordQueryDuration(
-		ctx,
-		time.Since(start),
-		operation,
-		db.cfg.baseAttributes(),
-		nil,
-	)
+	db.cfg.recordQuery(ctx, time.Since(start), query, operation, nil)
 
 	return row
 }
 
+// InContext expands slice arguments for an IN clause with sqlx.In, rebinds
+// the query for the driver, and executes it through QueryxContext, so the
+// whole call is traced as a single instrumented query.
+//
+// The recorded db.statement is the expanded, rebound query.
+//
+// Example:
+//
+//	rows, err := db.InContext(ctx,
+//	    "SELECT id, name FROM users WHERE id IN (?) AND active = ?",
+//	    []int{1, 2, 3}, true,
+//	)
+func (db *DB) InContext(
+	ctx context.Context,
+	query string,
+	args ...interface{},
+) (*sqlx.Rows, error) {
+	expanded, expandedArgs, err := sqlx.In(query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return db.QueryxContext(ctx, db.Rebind(expanded), expandedArgs...)
+}
+
 // BeginTxx starts an instrumented transaction.
 func (db *DB) BeginTxx(ctx context.Context, opts *sql.TxOptions) (*Tx, error) {
 	start := time.Now()
 
 	ctx, span := db.cfg.Tracer.Start(ctx, "BEGIN",
-		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithSpanKind(db.cfg.SpanKind),
 		trace.WithAttributes(db.cfg.baseAttributes()...),
+		trace.WithAttributes(db.cfg.tagAttributes(ctx)...),
 	)
 	defer span.End()
 
-	tx, err := db.DB.BeginTxx(ctx, opts)
+	tx, err := db.primary(span).BeginTxx(ctx, opts)
 
 	db.cfg.Metrics.recordQueryDuration(
 		ctx,
@@ -306,12 +368,11 @@ func (db *DB) BeginTxx(ctx context.Context, opts *sql.TxOptions) (*Tx, error) {
 	)
 
 	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, err.Error())
+		recordError(span, err)
 		return nil, err
 	}
 
-	return &Tx{Tx: tx, cfg: db.cfg}, nil
+	return &Tx{Tx: tx, cfg: db.cfg, begin: span.SpanContext(), started: start}, nil
 }
 
 // Beginx starts an instrumented transaction with default options.
@@ -333,17 +394,78 @@ func (db *DB) MustBegin() *Tx {
 	return db.MustBeginTx(context.Background(), nil)
 }
 
+// MustExecContext executes a query and panics on error.
+//
+// It panics on error, so use it only in init paths such as startup and
+// migration code, where failing fast is desired. The query is instrumented
+// like ExecContext.
+//
+// Example:
+//
+//	db.MustExecContext(ctx, "CREATE TABLE IF NOT EXISTS users (id BIGINT PRIMARY KEY)")
+func (db *DB) MustExecContext(
+	ctx context.Context,
+	query string,
+	args ...interface{},
+) sql.Result {
+	result, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// MustNamedExecContext executes a named query and panics on error.
+// Use it only in init paths; see MustExecContext.
+func (db *DB) MustNamedExecContext(
+	ctx context.Context,
+	query string,
+	arg interface{},
+) sql.Result {
+	result, err := db.NamedExecContext(ctx, query, arg)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// MustGetContext gets a single row into dest and panics on error.
+// Use it only in init paths; see MustExecContext.
+func (db *DB) MustGetContext(
+	ctx context.Context,
+	dest interface{},
+	query string,
+	args ...interface{},
+) {
+	if err := db.GetContext(ctx, dest, query, args...); err != nil {
+		panic(err)
+	}
+}
+
+// MustSelectContext selects rows into dest and panics on error.
+// Use it only in init paths; see MustExecContext.
+func (db *DB) MustSelectContext(
+	ctx context.Context,
+	dest interface{},
+	query string,
+	args ...interface{},
+) {
+	if err := db.SelectContext(ctx, dest, query, args...); err != nil {
+		panic(err)
+	}
+}
+
 // PrepareNamedContext prepares an instrumented named statement.
 func (db *DB) PrepareNamedContext(ctx context.Context, query string) (*NamedStmt, error) {
 	start := time.Now()
 
 	ctx, span := db.cfg.Tracer.Start(ctx, "sqlx.PrepareNamed",
-		trace.WithSpanKind(trace.SpanKindClient),
-		trace.WithAttributes(db.cfg.queryAttributes(query)...),
+		trace.WithSpanKind(db.cfg.SpanKind),
+		trace.WithAttributes(db.cfg.queryAttributes(ctx, query)...),
 	)
 	defer span.End()
 
-	stmt, err := db.DB.PrepareNamedContext(ctx, query)
+	stmt, err := db.primary(span).PrepareNamedContext(ctx, query)
 
 	db.cfg.Metrics.recordQueryDuration(
 		ctx,
@@ -354,8 +476,7 @@ func (db *DB) PrepareNamedContext(ctx context.Context, query string) (*NamedStmt
 	)
 
 	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, err.Error())
+		recordError(span, err)
 		return nil, err
 	}
 
@@ -372,12 +493,12 @@ func (db *DB) PreparexContext(ctx context.Context, query string) (*Stmt, error)
 	start := time.Now()
 
 	ctx, span := db.cfg.Tracer.Start(ctx, "sqlx.Preparex",
-		trace.WithSpanKind(trace.SpanKindClient),
-		trace.WithAttributes(db.cfg.queryAttributes(query)...),
+		trace.WithSpanKind(db.cfg.SpanKind),
+		trace.WithAttributes(db.cfg.queryAttributes(ctx, query)...),
 	)
 	defer span.End()
 
-	stmt, err := db.DB.PreparexContext(ctx, query)
+	stmt, err := db.primary(span).PreparexContext(ctx, query)
 
 	db.cfg.Metrics.recordQueryDuration(
 		ctx,
@@ -388,8 +509,7 @@ func (db *DB) PreparexContext(ctx context.Context, query string) (*Stmt, error)
 	)
 
 	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, err.Error())
+		recordError(span, err)
 		return nil, err
 	}
 
@@ -416,17 +536,31 @@ func (db *DB) DriverName() string {
 	return db.DB.DriverName()
 }
 
-// MapperFunc sets a custom field name mapper.
+// MapperFunc sets a custom field name mapper on the primary and replicas.
 func (db *DB) MapperFunc(mf func(string) string) {
 	db.DB.MapperFunc(mf)
+	if db.replicas != nil {
+		for _, replica := range db.replicas.dbs {
+			replica.MapperFunc(mf)
+		}
+	}
 }
 
 // Unsafe returns a version of DB that silently ignores missing destination fields.
 func (db *DB) Unsafe() *DB {
-	return &DB{
-		DB:  db.DB.Unsafe(),
-		cfg: db.cfg,
+	unsafe := &DB{
+		DB:     db.DB.Unsafe(),
+		cfg:    db.cfg,
+		stmts:  db.stmts,
+		health: db.health,
+	}
+	if db.replicas != nil {
+		unsafe.replicas = &replicaSet{dbs: make([]*sqlx.DB, len(db.replicas.dbs))}
+		for i, replica := range db.replicas.dbs {
+			unsafe.replicas.dbs[i] = replica.Unsafe()
+		}
 	}
+	return unsafe
 }
 
 // PingContext verifies the database connection.
@@ -434,18 +568,18 @@ func (db *DB) PingContext(ctx context.Context) error {
 	start := time.Now()
 
 	ctx, span := db.cfg.Tracer.Start(ctx, "PING",
-		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithSpanKind(db.cfg.SpanKind),
 		trace.WithAttributes(db.cfg.baseAttributes()...),
+		trace.WithAttributes(db.cfg.tagAttributes(ctx)...),
 	)
 	defer span.End()
 
-	err := db.DB.PingContext(ctx)
+	err := db.primary(span).PingContext(ctx)
 
 	db.cfg.Metrics.recordQueryDuration(ctx, time.Since(start), "PING", db.cfg.baseAttributes(), err)
 
 	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, err.Error())
+		recordError(span, err)
 	}
 
 	return err
@@ -460,25 +594,29 @@ func (db *DB) ExecContext(
 	start := time.Now()
 	operation := extractOperation(query)
 
-	ctx, span := db.cfg.Tracer.Start(ctx, spanName(query),
-		trace.WithSpanKind(trace.SpanKindClient),
-		trace.WithAttributes(db.cfg.queryAttributes(query)...),
+	ctx, span := db.cfg.Tracer.Start(ctx, db.cfg.spanName(query),
+		trace.WithSpanKind(db.cfg.SpanKind),
+		trace.WithAttributes(db.cfg.queryAttributes(ctx, query)...),
 	)
 	defer span.End()
+	db.cfg.recordArgs(span, args)
 
-	result, err := db.DB.ExecContext(ctx, query, args...)
+	ctx, cancel, timeout := db.cfg.withQueryTimeout(ctx)
+	defer cancel()
 
-	db.cfg.Metrics.recordQueryDuration(
-		ctx,
-		time.Since(start),
-		operation,
-		db.cfg.baseAttributes(),
-		err,
-	)
+	conn := db.primary(span)
+	var result sql.Result
+	err := db.cfg.retryBadConn(ctx, span, operation, func() error {
+		var err error
+		result, err = db.execContext(ctx, conn, query, args)
+		return err
+	})
+
+	db.cfg.recordExec(ctx, span, time.Since(start), query, operation, result, err)
 
 	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, err.Error())
+		recordError(span, err)
+		db.cfg.recordTimeout(ctx, span, timeout, operation, err)
 	}
 
 	return result, err
@@ -493,28 +631,36 @@ func (db *DB) QueryContext(
 	start := time.Now()
 	operation := extractOperation(query)
 
-	ctx, span := db.cfg.Tracer.Start(ctx, spanName(query),
-		trace.WithSpanKind(trace.SpanKindClient),
-		trace.WithAttributes(db.cfg.queryAttributes(query)...),
+	ctx, span := db.cfg.Tracer.Start(ctx, db.cfg.spanName(query),
+		trace.WithSpanKind(db.cfg.SpanKind),
+		trace.WithAttributes(db.cfg.queryAttributes(ctx, query)...),
 	)
 	defer span.End()
+	db.cfg.recordArgs(span, args)
 
-	rows, err := db.DB.QueryContext(ctx, query, args...)
+	// The timeout also bounds reading the rows, so on success it is
+	// released with them.
+	ctx, cancel, timeout := db.cfg.withQueryTimeout(ctx)
 
-	db.cfg.Metrics.recordQueryDuration(
-		ctx,
-		time.Since(start),
-		operation,
-		db.cfg.baseAttributes(),
-		err,
-	)
+	conn := db.reader(ctx, span, operation, query)
+	var rows *sql.Rows
+	err := db.cfg.retryBadConn(ctx, span, operation, func() error {
+		var err error
+		rows, err = db.queryContext(ctx, conn, query, args)
+		return err
+	})
+
+	db.cfg.recordQuery(ctx, time.Since(start), query, operation, err)
 
 	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, err.Error())
+		recordError(span, err)
+		db.cfg.recordTimeout(ctx, span, timeout, operation, err)
+		cancel()
+		return nil, err
 	}
 
-	return rows, err
+	releaseWithRows(rows, cancel, timeout)
+	return rows, nil
 }
 
 // QueryRowContext executes a query and returns a single row.
@@ -522,21 +668,59 @@ func (db *DB) QueryRowContext(ctx context.Context, query string, args ...interfa
 	start := time.Now()
 	operation := extractOperation(query)
 
-	ctx, span := db.cfg.Tracer.Start(ctx, spanName(query),
-		trace.WithSpanKind(trace.SpanKindClient),
-		trace.WithAttributes(db.cfg.queryAttributes(query)...),
+	ctx, span := db.cfg.Tracer.Start(ctx, db.cfg.spanName(query),
+		trace.WithSpanKind(db.cfg.SpanKind),
+		trace.WithAttributes(db.cfg.queryAttributes(ctx, query)...),
 	)
 	defer span.End()
+	db.cfg.recordArgs(span, args)
 
-	row := db.DB.QueryRowContext(ctx, query, args...)
+	conn := db.reader(ctx, span, operation, query)
+	row := conn.QueryRowContext(ctx, db.cfg.comment(ctx, query), args...)
 
-	db.cfg.Metrics.recordQueryDuration(
-		ctx,
-		time.Since(start),
-		operation,
-		db.cfg.baseAttributes(),
-		nil,
-	)
+	db.cfg.recordQuery(ctx, time.Since(start), query, operation, nil)
 
 	return row
 }
+
+// execContext runs ExecContext on conn, through the statement cache when
+// enabled.
+func (db *DB) execContext(
+	ctx context.Context,
+	conn *sqlx.DB,
+	query string,
+	args []interface{},
+) (sql.Result, error) {
+	if db.stmts == nil {
+		return conn.ExecContext(ctx, db.cfg.comment(ctx, query), args...)
+	}
+
+	var result sql.Result
+	err := db.stmts.do(ctx, db.cfg, conn, query, func(stmt *sql.Stmt) error {
+		var err error
+		result, err = stmt.ExecContext(ctx, args...)
+		return err
+	})
+	return result, err
+}
+
+// queryContext runs QueryContext on conn, through the statement cache when
+// enabled.
+func (db *DB) queryContext(
+	ctx context.Context,
+	conn *sqlx.DB,
+	query string,
+	args []interface{},
+) (*sql.Rows, error) {
+	if db.stmts == nil {
+		return conn.QueryContext(ctx, db.cfg.comment(ctx, query), args...)
+	}
+
+	var rows *sql.Rows
+	err := db.stmts.do(ctx, db.cfg, conn, query, func(stmt *sql.Stmt) error {
+		var err error
+		rows, err = stmt.QueryContext(ctx, args...)
+		return err
+	})
+	return rows, err
+}
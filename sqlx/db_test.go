@@ -3,11 +3,17 @@ package sqlx
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 )
 
 func TestOpen(t *testing.T) {
@@ -607,3 +613,309 @@ func TestDB_PrepareNamedContext(t *testing.T) {
 		})
 	}
 }
+
+func TestDB_RowMetrics(t *testing.T) {
+	type user struct {
+		ID   int    `db:"id"`
+		Name string `db:"name"`
+	}
+
+	tests := []struct {
+		name       string
+		opts       []Option
+		mockFn     func(sqlmock.Sqlmock)
+		run        func(*DB) error
+		wantAttr   string
+		wantRows   int64
+		wantMetric string
+	}{
+		{
+			name: "given row metrics, when exec, then records rows affected",
+			opts: []Option{WithRowMetrics()},
+			mockFn: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("UPDATE users").WillReturnResult(sqlmock.NewResult(0, 3))
+			},
+			run: func(db *DB) error {
+				_, err := db.ExecContext(context.Background(), "UPDATE users SET active = true")
+				return err
+			},
+			wantAttr:   "db.rows_affected",
+			wantRows:   3,
+			wantMetric: "db.client.rows_affected",
+		},
+		{
+			name: "given row metrics, when select, then records rows returned",
+			opts: []Option{WithRowMetrics()},
+			mockFn: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "name"}).
+					AddRow(1, "John").
+					AddRow(2, "Jane")
+				mock.ExpectQuery("SELECT id, name FROM users").WillReturnRows(rows)
+			},
+			run: func(db *DB) error {
+				var users []user
+				return db.SelectContext(context.Background(), &users, "SELECT id, name FROM users")
+			},
+			wantAttr:   "db.rows_returned",
+			wantRows:   2,
+			wantMetric: "db.client.rows_returned",
+		},
+		{
+			name: "given row metrics, when select into filled slice, then counts scanned rows",
+			opts: []Option{WithRowMetrics()},
+			mockFn: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "name"}).AddRow(3, "Jack")
+				mock.ExpectQuery("SELECT id, name FROM users").WillReturnRows(rows)
+			},
+			run: func(db *DB) error {
+				users := []user{{ID: 1, Name: "John"}, {ID: 2, Name: "Jane"}}
+				return db.SelectContext(context.Background(), &users, "SELECT id, name FROM users")
+			},
+			wantAttr:   "db.rows_returned",
+			wantRows:   1,
+			wantMetric: "db.client.rows_returned",
+		},
+		{
+			name: "given row metrics, when get finds no row, then records zero rows",
+			opts: []Option{WithRowMetrics()},
+			mockFn: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "name"})
+				mock.ExpectQuery("SELECT id, name FROM users").WillReturnRows(rows)
+			},
+			run: func(db *DB) error {
+				var u user
+				err := db.GetContext(context.Background(), &u, "SELECT id, name FROM users")
+				if errors.Is(err, sql.ErrNoRows) {
+					return nil
+				}
+				return err
+			},
+			wantAttr:   "db.rows_returned",
+			wantRows:   0,
+			wantMetric: "db.client.rows_returned",
+		},
+		{
+			name: "given row metrics disabled, when exec, then records nothing",
+			mockFn: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("UPDATE users").WillReturnResult(sqlmock.NewResult(0, 3))
+			},
+			run: func(db *DB) error {
+				_, err := db.ExecContext(context.Background(), "UPDATE users SET active = true")
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer mockDB.Close()
+
+			exporter := tracetest.NewInMemoryExporter()
+			tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+			reader := sdkmetric.NewManualReader()
+			mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+			opts := append([]Option{WithTracerProvider(tp), WithMeterProvider(mp)}, tt.opts...)
+			db := NewDB(mockDB, "postgres", opts...)
+			tt.mockFn(mock)
+
+			require.NoError(t, tt.run(db))
+			require.NoError(t, mock.ExpectationsWereMet())
+
+			spans := exporter.GetSpans()
+			require.Len(t, spans, 1)
+			attrs := attribute.NewSet(spans[0].Attributes...)
+
+			var rm metricdata.ResourceMetrics
+			require.NoError(t, reader.Collect(context.Background(), &rm))
+
+			if tt.wantAttr == "" {
+				assert.False(t, attrs.HasValue("db.rows_affected"))
+				assert.False(t, attrs.HasValue("db.rows_returned"))
+				return
+			}
+
+			got, ok := attrs.Value(attribute.Key(tt.wantAttr))
+			require.True(t, ok)
+			assert.Equal(t, tt.wantRows, got.AsInt64())
+
+			var found bool
+			for _, sm := range rm.ScopeMetrics {
+				for _, m := range sm.Metrics {
+					if m.Name != tt.wantMetric {
+						continue
+					}
+					hist, ok := m.Data.(metricdata.Histogram[int64])
+					require.True(t, ok)
+					require.Len(t, hist.DataPoints, 1)
+					assert.Equal(t, tt.wantRows, hist.DataPoints[0].Sum)
+					found = true
+				}
+			}
+			assert.True(t, found)
+		})
+	}
+}
+
+func TestDB_InContext(t *testing.T) {
+	type args struct {
+		query string
+		args  []interface{}
+	}
+
+	tests := []struct {
+		name          string
+		args          args
+		mockFn        func(sqlmock.Sqlmock)
+		wantErr       assert.ErrorAssertionFunc
+		wantIDs       []int
+		wantStatement string
+	}{
+		{
+			name: "given slice argument, then expands, rebinds and traces one span",
+			args: args{
+				query: "SELECT id FROM users WHERE id IN (?) AND active = ?",
+				args:  []interface{}{[]int{1, 2, 3}, true},
+			},
+			mockFn: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(3)
+				mock.ExpectQuery("SELECT id FROM users WHERE id IN ($1, $2, $3) AND active = $4").
+					WithArgs(1, 2, 3, true).
+					WillReturnRows(rows)
+			},
+			wantErr:       assert.NoError,
+			wantIDs:       []int{1, 3},
+			wantStatement: "SELECT id FROM users WHERE id IN ($1, $2, $3) AND active = $4",
+		},
+		{
+			name: "given empty slice, then returns error without querying",
+			args: args{
+				query: "SELECT id FROM users WHERE id IN (?)",
+				args:  []interface{}{[]int{}},
+			},
+			mockFn:  func(_ sqlmock.Sqlmock) {},
+			wantErr: assert.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			require.NoError(t, err)
+			defer mockDB.Close()
+
+			exporter := tracetest.NewInMemoryExporter()
+			tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+			db := NewDB(mockDB, "postgres", WithTracerProvider(tp))
+			tt.mockFn(mock)
+
+			rows, err := db.InContext(context.Background(), tt.args.query, tt.args.args...)
+			tt.wantErr(t, err)
+			require.NoError(t, mock.ExpectationsWereMet())
+
+			if err != nil {
+				assert.Empty(t, exporter.GetSpans())
+				return
+			}
+			defer rows.Close()
+
+			var ids []int
+			for rows.Next() {
+				var id int
+				require.NoError(t, rows.Scan(&id))
+				ids = append(ids, id)
+			}
+			assert.Equal(t, tt.wantIDs, ids)
+
+			spans := exporter.GetSpans()
+			require.Len(t, spans, 1)
+			attrs := attribute.NewSet(spans[0].Attributes...)
+			statement, _ := attrs.Value("db.statement")
+			assert.Equal(t, tt.wantStatement, statement.AsString())
+		})
+	}
+}
+
+func TestDB_MustContextMethods(t *testing.T) {
+	tests := []struct {
+		name      string
+		mockFn    func(mock sqlmock.Sqlmock)
+		call      func(db *DB)
+		wantPanic bool
+	}{
+		{
+			name: "given successful Exec, then returns result",
+			mockFn: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("CREATE TABLE users").WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			call: func(db *DB) {
+				db.MustExecContext(context.Background(), "CREATE TABLE users (id INT)")
+			},
+		},
+		{
+			name: "given failing Exec, then panics",
+			mockFn: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("CREATE TABLE users").WillReturnError(assert.AnError)
+			},
+			call: func(db *DB) {
+				db.MustExecContext(context.Background(), "CREATE TABLE users (id INT)")
+			},
+			wantPanic: true,
+		},
+		{
+			name: "given failing NamedExec, then panics",
+			mockFn: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("INSERT INTO users").WillReturnError(assert.AnError)
+			},
+			call: func(db *DB) {
+				db.MustNamedExecContext(context.Background(),
+					"INSERT INTO users (id) VALUES (:id)", map[string]interface{}{"id": 1})
+			},
+			wantPanic: true,
+		},
+		{
+			name: "given successful Get, then scans row",
+			mockFn: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT version").
+					WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(3))
+			},
+			call: func(db *DB) {
+				var version int
+				db.MustGetContext(context.Background(), &version, "SELECT version FROM schema")
+				assert.Equal(t, 3, version)
+			},
+		},
+		{
+			name: "given failing Select, then panics",
+			mockFn: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT id").WillReturnError(assert.AnError)
+			},
+			call: func(db *DB) {
+				var ids []int
+				db.MustSelectContext(context.Background(), &ids, "SELECT id FROM users")
+			},
+			wantPanic: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer mockDB.Close()
+
+			db := NewDB(mockDB, "postgres")
+			tt.mockFn(mock)
+
+			if tt.wantPanic {
+				assert.Panics(t, func() { tt.call(db) })
+			} else {
+				assert.NotPanics(t, func() { tt.call(db) })
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -69,6 +69,19 @@
 //
 //	return tx.Commit()
 //
+// # Read Replicas
+//
+// OpenWithReplicas sends SELECTs to replicas in round-robin order and
+// everything else to the primary. Wrap the context with Primary to read
+// your own writes:
+//
+//	db, err := sentinelsqlx.OpenWithReplicas("postgres", primaryDSN,
+//	    []string{replicaDSN},
+//	)
+//
+//	err = db.GetContext(sentinelsqlx.Primary(ctx), &user,
+//	    "SELECT * FROM users WHERE id = $1", id)
+//
 // # Configuration Options
 //
 // Common options for customization:
@@ -81,6 +94,9 @@
 //	    sentinelsqlx.WithMeterProvider(mp),         // Custom meter provider
 //	)
 //
+// WithInstanceFromDSN fills db.instance and db.name from the DSN's host:port
+// and database instead.
+//
 // # Observability
 //
 // The wrapper automatically emits:
@@ -91,4 +107,6 @@
 //
 // Metrics:
 //   - db.client.query.duration (histogram by operation)
+//   - db.client.tx.total (counter by outcome: commit, rollback)
+//   - db.client.tx.duration (histogram from BEGIN to COMMIT or ROLLBACK)
 package sqlx
@@ -0,0 +1,175 @@
+package sqlx
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"net"
+	"reflect"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ErrorKind classifies a database error by its cause.
+type ErrorKind string
+
+// Error kinds returned by ClassifyError.
+const (
+	// ErrorKindUnknown is returned for nil errors and errors that match no
+	// other kind.
+	ErrorKindUnknown ErrorKind = ""
+
+	ErrorKindUniqueViolation      ErrorKind = "unique_violation"
+	ErrorKindForeignKeyViolation  ErrorKind = "foreign_key_violation"
+	ErrorKindNotNullViolation     ErrorKind = "not_null_violation"
+	ErrorKindDeadlock             ErrorKind = "deadlock"
+	ErrorKindSerializationFailure ErrorKind = "serialization_failure"
+	ErrorKindConnection           ErrorKind = "connection"
+	ErrorKindTimeout              ErrorKind = "timeout"
+)
+
+// postgresKinds maps PostgreSQL SQLSTATE codes to error kinds.
+var postgresKinds = map[string]ErrorKind{
+	"23505": ErrorKindUniqueViolation,
+	"23503": ErrorKindForeignKeyViolation,
+	"23502": ErrorKindNotNullViolation,
+	"40P01": ErrorKindDeadlock,
+	"40001": ErrorKindSerializationFailure,
+	"57014": ErrorKindTimeout,    // query_canceled, raised by statement_timeout
+	"57P01": ErrorKindConnection, // admin_shutdown
+}
+
+// mysqlKinds maps MySQL server error numbers to error kinds.
+var mysqlKinds = map[uint16]ErrorKind{
+	1062: ErrorKindUniqueViolation,     // ER_DUP_ENTRY
+	1216: ErrorKindForeignKeyViolation, // ER_NO_REFERENCED_ROW
+	1217: ErrorKindForeignKeyViolation, // ER_ROW_IS_REFERENCED
+	1451: ErrorKindForeignKeyViolation, // ER_ROW_IS_REFERENCED_2
+	1452: ErrorKindForeignKeyViolation, // ER_NO_REFERENCED_ROW_2
+	1048: ErrorKindNotNullViolation,    // ER_BAD_NULL_ERROR
+	1213: ErrorKindDeadlock,            // ER_LOCK_DEADLOCK
+	1205: ErrorKindTimeout,             // ER_LOCK_WAIT_TIMEOUT
+	3024: ErrorKindTimeout,             // ER_QUERY_TIMEOUT
+	1040: ErrorKindConnection,          // ER_CON_COUNT_ERROR
+	1053: ErrorKindConnection,          // ER_SERVER_SHUTDOWN
+}
+
+// ClassifyError reports what kind of failure err represents, so callers can
+// tell constraint violations apart from infrastructure failures.
+//
+// PostgreSQL errors are recognized by their SQLSTATE code through a
+// SQLState() string method, which *pq.Error and *pgconn.PgError both have.
+// MySQL errors are recognized by the Number field of *mysql.MySQLError.
+// Neither driver is imported, so classification adds no dependencies.
+// Context deadlines, driver.ErrBadConn and network errors are classified as
+// timeout or connection failures. Anything else is ErrorKindUnknown.
+//
+// Every span that records an error also gets an error.type attribute with
+// the kind, unless the kind is unknown.
+//
+// Example:
+//
+//	_, err := db.ExecContext(ctx, "INSERT INTO users (email) VALUES ($1)", email)
+//	if sentinelsqlx.ClassifyError(err) == sentinelsqlx.ErrorKindUniqueViolation {
+//	    return ErrEmailTaken
+//	}
+func ClassifyError(err error) ErrorKind {
+	if err == nil {
+		return ErrorKindUnknown
+	}
+
+	var pgErr interface{ SQLState() string }
+	if errors.As(err, &pgErr) {
+		if kind, ok := postgresKinds[pgErr.SQLState()]; ok {
+			return kind
+		}
+		// Class 08 covers every connection exception.
+		if len(pgErr.SQLState()) == 5 && pgErr.SQLState()[:2] == "08" {
+			return ErrorKindConnection
+		}
+	}
+	if number, ok := mysqlErrorNumber(err); ok {
+		if kind, ok := mysqlKinds[number]; ok {
+			return kind
+		}
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return ErrorKindTimeout
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		if netErr.Timeout() {
+			return ErrorKindTimeout
+		}
+		return ErrorKindConnection
+	}
+	if errors.Is(err, driver.ErrBadConn) {
+		return ErrorKindConnection
+	}
+
+	return ErrorKindUnknown
+}
+
+// mysqlErrorNumber returns the Number field of the first *MySQLError in the
+// chain of err. The type is matched by name so the MySQL driver need not be
+// imported.
+func mysqlErrorNumber(err error) (uint16, bool) {
+	for ; err != nil; err = errors.Unwrap(err) {
+		v := reflect.ValueOf(err)
+		if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+			continue
+		}
+		v = v.Elem()
+		if v.Type().Name() != "MySQLError" {
+			continue
+		}
+		if number := v.FieldByName("Number"); number.Kind() == reflect.Uint16 {
+			return uint16(number.Uint()), true
+		}
+	}
+	return 0, false
+}
+
+// notFoundError is sql.ErrNoRows translated by WithNotFoundError. It matches
+// both the configured error and sql.ErrNoRows with errors.Is.
+type notFoundError struct {
+	notFound error
+	err      error
+}
+
+func (e *notFoundError) Error() string { return e.notFound.Error() }
+
+func (e *notFoundError) Unwrap() []error { return []error{e.notFound, e.err} }
+
+// translateNotFound wraps sql.ErrNoRows in the error configured with
+// WithNotFoundError. Other errors, or all errors when none is configured,
+// are returned unchanged.
+func (cfg *config) translateNotFound(err error) error {
+	if cfg.NotFoundError == nil || !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+	return &notFoundError{notFound: cfg.NotFoundError, err: err}
+}
+
+// recordError records err on span, marks the span as errored and, when the
+// error can be classified, sets the error.type attribute.
+//
+// A not-found result translated by WithNotFoundError is not a failure: the
+// span keeps a non-error status and gets a db.not_found attribute instead.
+func recordError(span trace.Span, err error) {
+	var notFound *notFoundError
+	if errors.As(err, &notFound) {
+		span.SetAttributes(attribute.Bool("db.not_found", true))
+		return
+	}
+
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+	if kind := ClassifyError(err); kind != ErrorKindUnknown {
+		span.SetAttributes(attribute.String("error.type", string(kind)))
+	}
+}
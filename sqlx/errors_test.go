@@ -0,0 +1,182 @@
+package sqlx
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// pgError mimics *pq.Error and *pgconn.PgError.
+type pgError struct{ code string }
+
+func (e *pgError) Error() string    { return "pq: " + e.code }
+func (e *pgError) SQLState() string { return e.code }
+
+// MySQLError mimics *mysql.MySQLError.
+type MySQLError struct {
+	Number  uint16
+	Message string
+}
+
+func (e *MySQLError) Error() string { return fmt.Sprintf("Error %d: %s", e.Number, e.Message) }
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want ErrorKind
+	}{
+		{
+			name: "given postgres unique violation, then returns unique_violation",
+			err:  &pgError{code: "23505"},
+			want: ErrorKindUniqueViolation,
+		},
+		{
+			name: "given postgres serialization failure, then returns serialization_failure",
+			err:  &pgError{code: "40001"},
+			want: ErrorKindSerializationFailure,
+		},
+		{
+			name: "given mysql deadlock, then returns deadlock",
+			err:  fmt.Errorf("update: %w", &MySQLError{Number: 1213}),
+			want: ErrorKindDeadlock,
+		},
+		{
+			name: "given context deadline, then returns timeout",
+			err:  context.DeadlineExceeded,
+			want: ErrorKindTimeout,
+		},
+		{
+			name: "given other error, then returns unknown",
+			err:  errors.New("boom"),
+			want: ErrorKindUnknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ClassifyError(tt.err))
+		})
+	}
+}
+
+func TestDB_ExecContext_ErrorType(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantType string
+	}{
+		{
+			name:     "given unique violation, then span has error.type",
+			err:      &pgError{code: "23505"},
+			wantType: "unique_violation",
+		},
+		{
+			name:     "given mysql foreign key failure, then span has error.type",
+			err:      &MySQLError{Number: 1451},
+			wantType: "foreign_key_violation",
+		},
+		{
+			name: "given unclassified error, then span has no error.type",
+			err:  errors.New("syntax error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer mockDB.Close()
+
+			exporter := tracetest.NewInMemoryExporter()
+			tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+			db := NewDB(mockDB, "postgres", WithTracerProvider(tp))
+
+			mock.ExpectExec("INSERT INTO users").WillReturnError(tt.err)
+
+			_, err = db.ExecContext(context.Background(),
+				"INSERT INTO users (email) VALUES ($1)", "a@example.com")
+			require.Error(t, err)
+
+			spans := exporter.GetSpans()
+			require.Len(t, spans, 1)
+			set := attribute.NewSet(spans[0].Attributes...)
+			value, ok := set.Value("error.type")
+			assert.Equal(t, tt.wantType != "", ok)
+			assert.Equal(t, tt.wantType, value.AsString())
+		})
+	}
+}
+
+func TestDB_GetContext_NotFoundError(t *testing.T) {
+	errUserNotFound := errors.New("user not found")
+
+	tests := []struct {
+		name         string
+		opts         []Option
+		err          error
+		wantErr      error
+		wantStatus   codes.Code
+		wantNotFound bool
+	}{
+		{
+			name:         "given not found error and no rows, then returns it without span error",
+			opts:         []Option{WithNotFoundError(errUserNotFound)},
+			err:          sql.ErrNoRows,
+			wantErr:      errUserNotFound,
+			wantStatus:   codes.Unset,
+			wantNotFound: true,
+		},
+		{
+			name:       "given not found error and other error, then returns error as is",
+			opts:       []Option{WithNotFoundError(errUserNotFound)},
+			err:        errors.New("connection reset"),
+			wantStatus: codes.Error,
+		},
+		{
+			name:       "given no not found error, then no rows is a span error",
+			err:        sql.ErrNoRows,
+			wantErr:    sql.ErrNoRows,
+			wantStatus: codes.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer mockDB.Close()
+
+			exporter := tracetest.NewInMemoryExporter()
+			tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+			db := NewDB(mockDB, "postgres", append(tt.opts, WithTracerProvider(tp))...)
+
+			mock.ExpectQuery("SELECT id FROM users").WillReturnError(tt.err)
+
+			var id int
+			err = db.GetContext(context.Background(), &id, "SELECT id FROM users WHERE id = $1", 1)
+			require.Error(t, err)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.ErrorIs(t, err, sql.ErrNoRows)
+			}
+
+			spans := exporter.GetSpans()
+			require.Len(t, spans, 1)
+			assert.Equal(t, tt.wantStatus, spans[0].Status.Code)
+			set := attribute.NewSet(spans[0].Attributes...)
+			_, ok := set.Value("db.not_found")
+			assert.Equal(t, tt.wantNotFound, ok)
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package sqlx
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrPoolExhausted is returned by HealthCheck when every connection of the
+// pool has been in use for longer than the exhaustion threshold.
+var ErrPoolExhausted = errors.New("sqlx: connection pool exhausted")
+
+// HealthCheckConfig configures DB.HealthCheck.
+type HealthCheckConfig struct {
+	// PingTimeout bounds the ping, so a hung database fails the check
+	// instead of hanging the readiness probe.
+	// Default: 1s
+	PingTimeout time.Duration
+
+	// ExhaustionThreshold is how long the pool may stay exhausted, with
+	// Stats().InUse at MaxOpenConnections, before the check fails.
+	// Exhaustion is only detected when MaxOpenConnections is set.
+	// If 0, the pool is not checked.
+	// Default: 30s
+	ExhaustionThreshold time.Duration
+}
+
+// DefaultHealthCheckConfig returns the configuration used by HealthCheck
+// when WithHealthCheck is not set.
+//
+// Defaults:
+//   - PingTimeout: 1s
+//   - ExhaustionThreshold: 30s
+func DefaultHealthCheckConfig() HealthCheckConfig {
+	return HealthCheckConfig{
+		PingTimeout:         time.Second,
+		ExhaustionThreshold: 30 * time.Second,
+	}
+}
+
+// poolHealth remembers since when the pool has been exhausted.
+type poolHealth struct {
+	mu             sync.Mutex
+	exhaustedSince time.Time
+}
+
+// HealthCheck pings the primary and fails when the ping fails or the pool
+// has been exhausted for longer than the configured threshold. It has the
+// signature of httpserver.HealthCheck, so it can be used as a readiness
+// check directly.
+//
+// Exhaustion is sampled on each call: the pool counts as exhausted from the
+// first check that finds every connection in use until a check finds one
+// free. A short burst of load therefore does not fail readiness, while a
+// pool stuck on leaked or slow connections does.
+//
+// Example:
+//
+//	db, _ := sentinelsqlx.Open("postgres", dsn,
+//	    sentinelsqlx.WithHealthCheck(sentinelsqlx.HealthCheckConfig{
+//	        PingTimeout:         500 * time.Millisecond,
+//	        ExhaustionThreshold: 10 * time.Second,
+//	    }),
+//	)
+//	db.SetMaxOpenConns(20)
+//
+//	health.AddReadinessCheck("db", db.HealthCheck)
+func (db *DB) HealthCheck(ctx context.Context) error {
+	hc := DefaultHealthCheckConfig()
+	if db.cfg.HealthCheck != nil {
+		hc = *db.cfg.HealthCheck
+	}
+
+	pingCtx := ctx
+	if hc.PingTimeout > 0 {
+		var cancel context.CancelFunc
+		pingCtx, cancel = context.WithTimeout(ctx, hc.PingTimeout)
+		defer cancel()
+	}
+	if err := db.PingContext(pingCtx); err != nil {
+		return err
+	}
+
+	if hc.ExhaustionThreshold <= 0 {
+		return nil
+	}
+	return db.health.check(db.Stats(), hc.ExhaustionThreshold, time.Now())
+}
+
+// check records whether stats show an exhausted pool at now and returns
+// ErrPoolExhausted once it has been exhausted for threshold.
+func (h *poolHealth) check(stats sql.DBStats, threshold time.Duration, now time.Time) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if stats.MaxOpenConnections <= 0 || stats.InUse < stats.MaxOpenConnections {
+		h.exhaustedSince = time.Time{}
+		return nil
+	}
+	if h.exhaustedSince.IsZero() {
+		h.exhaustedSince = now
+	}
+	if exhausted := now.Sub(h.exhaustedSince); exhausted >= threshold {
+		return fmt.Errorf("%w: %d of %d connections in use for %s",
+			ErrPoolExhausted, stats.InUse, stats.MaxOpenConnections, exhausted.Round(time.Second))
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package sqlx
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDB_HealthCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		mockFn  func(sqlmock.Sqlmock)
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "given ping succeeds and pool has free connections, then returns nil",
+			mockFn: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPing()
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "given ping fails, then returns ping error",
+			mockFn: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPing().WillReturnError(errors.New("connection refused"))
+			},
+			wantErr: assert.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+			require.NoError(t, err)
+			defer mockDB.Close()
+
+			db := NewDB(mockDB, "postgres")
+			db.SetMaxOpenConns(10)
+			tt.mockFn(mock)
+
+			tt.wantErr(t, db.HealthCheck(context.Background()))
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestDB_HealthCheck_PingTimeout(t *testing.T) {
+	mockDB, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	require.NoError(t, err)
+	defer mockDB.Close()
+
+	db := NewDB(mockDB, "postgres", WithHealthCheck(HealthCheckConfig{
+		PingTimeout: 10 * time.Millisecond,
+	}))
+	mock.ExpectPing().WillDelayFor(time.Second)
+
+	start := time.Now()
+	err = db.HealthCheck(context.Background())
+
+	assert.Error(t, err)
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+}
+
+func TestPoolHealth_Check(t *testing.T) {
+	exhausted := sql.DBStats{MaxOpenConnections: 10, InUse: 10}
+	free := sql.DBStats{MaxOpenConnections: 10, InUse: 3}
+	start := time.Now()
+
+	type sample struct {
+		stats   sql.DBStats
+		after   time.Duration
+		wantErr bool
+	}
+
+	tests := []struct {
+		name    string
+		samples []sample
+	}{
+		{
+			name: "given pool exhausted shorter than threshold, then returns nil",
+			samples: []sample{
+				{stats: exhausted, after: 0},
+				{stats: exhausted, after: 20 * time.Second},
+			},
+		},
+		{
+			name: "given pool exhausted for threshold, then returns ErrPoolExhausted",
+			samples: []sample{
+				{stats: exhausted, after: 0},
+				{stats: exhausted, after: 30 * time.Second, wantErr: true},
+			},
+		},
+		{
+			name: "given pool recovered in between, then restarts the exhaustion period",
+			samples: []sample{
+				{stats: exhausted, after: 0},
+				{stats: free, after: 20 * time.Second},
+				{stats: exhausted, after: 25 * time.Second},
+				{stats: exhausted, after: 40 * time.Second},
+			},
+		},
+		{
+			name: "given unlimited pool, then never reports exhaustion",
+			samples: []sample{
+				{stats: sql.DBStats{InUse: 100}, after: 0},
+				{stats: sql.DBStats{InUse: 100}, after: time.Hour},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &poolHealth{}
+			for _, s := range tt.samples {
+				err := h.check(s.stats, 30*time.Second, start.Add(s.after))
+				if s.wantErr {
+					assert.ErrorIs(t, err, ErrPoolExhausted)
+				} else {
+					assert.NoError(t, err)
+				}
+			}
+		})
+	}
+}
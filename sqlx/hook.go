@@ -0,0 +1,94 @@
+package sqlx
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// QueryInfo describes a completed query, as passed to a QueryHook.
+type QueryInfo struct {
+	// Operation is the SQL operation, e.g. "SELECT" or "BATCH".
+	Operation string
+
+	// Statement is the query after the query sanitizer has been applied.
+	// It is empty when queries are disabled with WithDisableQuery.
+	Statement string
+
+	// Duration is how long the query took.
+	Duration time.Duration
+
+	// Err is the error the query failed with, or nil.
+	Err error
+
+	// RowsAffected is the number of rows an Exec affected, or -1 for other
+	// queries and when the driver does not report it.
+	RowsAffected int64
+}
+
+// QueryHook is called after each query completes.
+type QueryHook func(ctx context.Context, info QueryInfo)
+
+// recordExec records an Exec like recordQuery and, when row metrics are
+// enabled, the rows it affected. The query hook receives the rows affected.
+func (cfg *config) recordExec(
+	ctx context.Context,
+	span trace.Span,
+	duration time.Duration,
+	query string,
+	operation string,
+	result interface{ RowsAffected() (int64, error) },
+	err error,
+) {
+	cfg.observeQuery(ctx, duration, query, operation, err)
+	cfg.recordRowsAffected(ctx, span, operation, result, err)
+
+	cfg.runExecHook(ctx, duration, query, operation, result, err)
+}
+
+// runExecHook calls the configured query hook, if any, for an Exec. The
+// rows affected are only read from result when there is a hook.
+func (cfg *config) runExecHook(
+	ctx context.Context,
+	duration time.Duration,
+	query string,
+	operation string,
+	result interface{ RowsAffected() (int64, error) },
+	err error,
+) {
+	if cfg.QueryHook == nil {
+		return
+	}
+
+	rows := int64(-1)
+	if err == nil && result != nil {
+		if n, rowsErr := result.RowsAffected(); rowsErr == nil {
+			rows = n
+		}
+	}
+	cfg.runQueryHook(ctx, duration, query, operation, rows, err)
+}
+
+// runQueryHook calls the configured query hook, if any.
+func (cfg *config) runQueryHook(
+	ctx context.Context,
+	duration time.Duration,
+	query string,
+	operation string,
+	rowsAffected int64,
+	err error,
+) {
+	if cfg.QueryHook == nil {
+		return
+	}
+
+	statement, _ := cfg.statement(query)
+	cfg.QueryHook(ctx, QueryInfo{
+		Operation:    operation,
+		Statement:    statement,
+		Duration:     duration,
+		Err:          err,
+		RowsAffected: rowsAffected,
+	})
+}
@@ -0,0 +1,96 @@
+package sqlx
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDB_QueryHook(t *testing.T) {
+	errQuery := errors.New("connection reset")
+
+	tests := []struct {
+		name   string
+		mockFn func(sqlmock.Sqlmock)
+		run    func(*DB) error
+		want   QueryInfo
+	}{
+		{
+			name: "given exec, then passes rows affected",
+			mockFn: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("DELETE FROM users").WillReturnResult(sqlmock.NewResult(0, 3))
+			},
+			run: func(db *DB) error {
+				_, err := db.ExecContext(context.Background(),
+					"DELETE FROM users WHERE name = 'john'")
+				return err
+			},
+			want: QueryInfo{
+				Operation:    "DELETE",
+				Statement:    "DELETE FROM users WHERE name = '?'",
+				RowsAffected: 3,
+			},
+		},
+		{
+			name: "given get, then passes unknown rows affected",
+			mockFn: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT id FROM users").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+			},
+			run: func(db *DB) error {
+				var id int
+				return db.GetContext(context.Background(), &id, "SELECT id FROM users")
+			},
+			want: QueryInfo{
+				Operation:    "SELECT",
+				Statement:    "SELECT id FROM users",
+				RowsAffected: -1,
+			},
+		},
+		{
+			name: "given failed exec, then passes error",
+			mockFn: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("UPDATE users").WillReturnError(errQuery)
+			},
+			run: func(db *DB) error {
+				_, err := db.ExecContext(context.Background(), "UPDATE users SET active = false")
+				return err
+			},
+			want: QueryInfo{
+				Operation:    "UPDATE",
+				Statement:    "UPDATE users SET active = false",
+				Err:          errQuery,
+				RowsAffected: -1,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer mockDB.Close()
+
+			var got []QueryInfo
+			db := NewDB(mockDB, "postgres",
+				WithQuerySanitizer(DefaultQuerySanitizer),
+				WithQueryHook(func(_ context.Context, info QueryInfo) {
+					got = append(got, info)
+				}),
+			)
+			tt.mockFn(mock)
+
+			_ = tt.run(db)
+
+			require.Len(t, got, 1)
+			assert.Positive(t, got[0].Duration)
+			got[0].Duration = 0
+			assert.Equal(t, tt.want, got[0])
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
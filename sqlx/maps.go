@@ -0,0 +1,125 @@
+package sqlx
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// SelectMapsContext executes a query and returns each row as a map from
+// column name to value, traced as a single span like SelectContext.
+//
+// Use it for ad-hoc queries and admin tooling where defining a struct for
+// the result is not worth it. Values are whatever the driver returns for
+// each column type, so the same query may yield different Go types across
+// drivers: text is often []byte rather than string (MySQL), numerics may be
+// []byte, float64 or string, and NULL is nil. Convert values explicitly
+// instead of relying on their type.
+//
+// Rows are scanned into freshly allocated maps with boxed values, which is
+// noticeably slower and allocates more than scanning into structs with
+// SelectContext. Prefer SelectContext on hot paths.
+//
+// Example:
+//
+//	rows, err := db.SelectMapsContext(ctx,
+//	    "SELECT id, email, created_at FROM users WHERE created_at > $1", since)
+//	for _, row := range rows {
+//	    fmt.Println(row["id"], row["email"])
+//	}
+func (db *DB) SelectMapsContext(
+	ctx context.Context,
+	query string,
+	args ...interface{},
+) ([]map[string]interface{}, error) {
+	start := time.Now()
+	operation := extractOperation(query)
+
+	ctx, span := db.cfg.Tracer.Start(ctx, db.cfg.sqlxSpanName("sqlx.SelectMaps", query),
+		trace.WithSpanKind(db.cfg.SpanKind),
+		trace.WithAttributes(db.cfg.queryAttributes(ctx, query)...),
+	)
+	defer span.End()
+	db.cfg.recordArgs(span, args)
+
+	ctx, cancel, timeout := db.cfg.withQueryTimeout(ctx)
+	defer cancel()
+
+	conn := db.reader(ctx, span, operation, query)
+	result, err := selectMaps(ctx, conn, db.cfg.comment(ctx, query), args...)
+
+	db.cfg.recordQuery(ctx, time.Since(start), query, operation, err)
+	db.cfg.recordSelectRows(ctx, span, operation, result, err)
+
+	if err != nil {
+		recordError(span, err)
+		db.cfg.recordTimeout(ctx, span, timeout, operation, err)
+	}
+
+	return result, err
+}
+
+// SelectMapsContext executes a query within the transaction and returns each
+// row as a map from column name to value. See DB.SelectMapsContext for the
+// type mapping caveats.
+func (tx *Tx) SelectMapsContext(
+	ctx context.Context,
+	query string,
+	args ...interface{},
+) ([]map[string]interface{}, error) {
+	start := time.Now()
+	operation := extractOperation(query)
+
+	ctx, span := tx.cfg.Tracer.Start(ctx, tx.cfg.sqlxSpanName("sqlx.Tx.SelectMaps", query),
+		trace.WithSpanKind(tx.cfg.SpanKind),
+		trace.WithAttributes(tx.cfg.queryAttributes(ctx, query)...),
+		trace.WithAttributes(txAttributes(tx.begin)...),
+		trace.WithLinks(txLinks(tx.begin)...),
+	)
+	defer span.End()
+	tx.cfg.recordArgs(span, args)
+
+	ctx, cancel, timeout := tx.cfg.withQueryTimeout(ctx)
+	defer cancel()
+
+	result, err := selectMaps(ctx, tx.Tx, tx.cfg.comment(ctx, query), args...)
+
+	tx.cfg.recordQuery(ctx, time.Since(start), query, operation, err)
+	tx.cfg.recordSelectRows(ctx, span, operation, result, err)
+
+	if err != nil {
+		recordError(span, err)
+		tx.cfg.recordTimeout(ctx, span, timeout, operation, err)
+	}
+
+	return result, err
+}
+
+// selectMaps runs query on q and scans every row with MapScan.
+func selectMaps(
+	ctx context.Context,
+	q sqlx.QueryerContext,
+	query string,
+	args ...interface{},
+) ([]map[string]interface{}, error) {
+	rows, err := q.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []map[string]interface{}
+	for rows.Next() {
+		row := make(map[string]interface{})
+		if err := rows.MapScan(row); err != nil {
+			return nil, err
+		}
+		result = append(result, row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
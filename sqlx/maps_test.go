@@ -0,0 +1,109 @@
+package sqlx
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestDB_SelectMapsContext(t *testing.T) {
+	const query = "SELECT id, email FROM users WHERE active = ?"
+
+	tests := []struct {
+		name    string
+		mockFn  func(sqlmock.Sqlmock)
+		wantErr assert.ErrorAssertionFunc
+		want    []map[string]interface{}
+	}{
+		{
+			name: "given rows, then returns one map per row",
+			mockFn: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "email"}).
+					AddRow(int64(1), "john@example.com").
+					AddRow(int64(2), nil)
+				mock.ExpectQuery("SELECT id, email FROM users").WithArgs(true).WillReturnRows(rows)
+			},
+			wantErr: assert.NoError,
+			want: []map[string]interface{}{
+				{"id": int64(1), "email": "john@example.com"},
+				{"id": int64(2), "email": nil},
+			},
+		},
+		{
+			name: "given no rows, then returns nil",
+			mockFn: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT id, email FROM users").
+					WithArgs(true).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "email"}))
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "given query error, then returns error",
+			mockFn: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT id, email FROM users").
+					WithArgs(true).
+					WillReturnError(assert.AnError)
+			},
+			wantErr: assert.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer mockDB.Close()
+
+			exporter := tracetest.NewInMemoryExporter()
+			tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+			db := NewDB(mockDB, "postgres", WithTracerProvider(tp))
+			tt.mockFn(mock)
+
+			got, err := db.SelectMapsContext(context.Background(), query, true)
+
+			tt.wantErr(t, err)
+			assert.Equal(t, tt.want, got)
+			require.NoError(t, mock.ExpectationsWereMet())
+
+			spans := exporter.GetSpans()
+			require.Len(t, spans, 1)
+			assert.Equal(t, "sqlx.SelectMaps: SELECT", spans[0].Name)
+		})
+	}
+}
+
+func TestTx_SelectMapsContext(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer mockDB.Close()
+
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	db := NewDB(mockDB, "postgres", WithTracerProvider(tp))
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT count").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(int64(3)))
+	mock.ExpectCommit()
+
+	tx, err := db.Beginx()
+	require.NoError(t, err)
+	got, err := tx.SelectMapsContext(context.Background(), "SELECT count(*) AS count FROM users")
+	require.NoError(t, err)
+	require.NoError(t, tx.Commit())
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	assert.Equal(t, []map[string]interface{}{{"count": int64(3)}}, got)
+
+	var names []string
+	for _, span := range exporter.GetSpans() {
+		names = append(names, span.Name)
+	}
+	assert.Contains(t, names, "sqlx.Tx.SelectMaps: SELECT")
+}
@@ -3,10 +3,13 @@ package sqlx
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"reflect"
 	"time"
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // metrics holds the metric instruments for database operations.
@@ -14,6 +17,29 @@ type metrics struct {
 	// Query latency histogram
 	queryDuration metric.Float64Histogram
 
+	// Slow query counter
+	slowQueries metric.Int64Counter
+
+	// Query timeout counter
+	queryTimeouts metric.Int64Counter
+
+	// Row count histograms (recorded when row metrics are enabled)
+	rowsAffected metric.Int64Histogram
+	rowsReturned metric.Int64Histogram
+
+	// Prepared statement cache lookups (recorded when the cache is enabled)
+	stmtCache metric.Int64Counter
+
+	// Rows garbage collected without Close (recorded by TracedQueryxContext)
+	rowsLeaked metric.Int64Counter
+
+	// Retries after connection errors (recorded with WithRetryBadConn)
+	badConnRetries metric.Int64Counter
+
+	// Transaction outcomes and begin-to-end durations
+	txTotal    metric.Int64Counter
+	txDuration metric.Float64Histogram
+
 	// Connection pool gauges
 	openConnections metric.Int64ObservableGauge
 	idleConnections metric.Int64ObservableGauge
@@ -23,8 +49,50 @@ type metrics struct {
 	waitDuration    metric.Float64ObservableCounter
 }
 
-// newMetrics creates and registers metric instruments.
-func newMetrics(meter metric.Meter) (*metrics, error) {
+// DefaultDurationBuckets returns the histogram boundaries, in seconds, of
+// db.client.operation.duration when WithDurationBuckets is not set. They
+// span 1ms to 10s, which suits typical web service queries.
+func DefaultDurationBuckets() []float64 {
+	return []float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.075, 0.1, 0.25, 0.5, 0.75, 1, 2.5, 5, 10}
+}
+
+// FastOLTPBuckets returns histogram boundaries, in seconds, for OLTP
+// workloads of mostly sub-millisecond point reads and writes. They span
+// 100µs to 1s, so P99 stays accurate for queries the default buckets would
+// all put below 1ms.
+//
+// Example:
+//
+//	db, _ := sentinelsqlx.Open("postgres", dsn,
+//	    sentinelsqlx.WithDurationBuckets(sentinelsqlx.FastOLTPBuckets()),
+//	)
+func FastOLTPBuckets() []float64 {
+	return []float64{
+		0.0001, 0.00025, 0.0005, 0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1,
+	}
+}
+
+// AnalyticsBuckets returns histogram boundaries, in seconds, for reporting
+// and analytics queries. They span 10ms to 10 minutes, so queries running
+// for minutes are not all counted in the overflow bucket.
+//
+// Example:
+//
+//	db, _ := sentinelsqlx.Open("postgres", dsn,
+//	    sentinelsqlx.WithDurationBuckets(sentinelsqlx.AnalyticsBuckets()),
+//	)
+func AnalyticsBuckets() []float64 {
+	return []float64{0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 120, 300, 600}
+}
+
+// newMetrics creates and registers metric instruments. durationBuckets are
+// the boundaries of the query duration histogram; nil uses
+// DefaultDurationBuckets.
+func newMetrics(meter metric.Meter, durationBuckets []float64) (*metrics, error) {
+	if durationBuckets == nil {
+		durationBuckets = DefaultDurationBuckets()
+	}
+
 	m := &metrics{}
 	var err error
 
@@ -32,9 +100,89 @@ func newMetrics(meter metric.Meter) (*metrics, error) {
 		"db.client.operation.duration",
 		metric.WithDescription("Duration of database client operations in seconds"),
 		metric.WithUnit("s"),
-		metric.WithExplicitBucketBoundaries(
-			0.001, 0.005, 0.01, 0.025, 0.05, 0.075, 0.1, 0.25, 0.5, 0.75, 1, 2.5, 5, 10,
-		),
+		metric.WithExplicitBucketBoundaries(durationBuckets...),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	m.slowQueries, err = meter.Int64Counter(
+		"db.client.slow_query",
+		metric.WithDescription("Number of queries slower than the slow query threshold"),
+		metric.WithUnit("{query}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	m.queryTimeouts, err = meter.Int64Counter(
+		"db.client.query.timeout",
+		metric.WithDescription("Number of queries that exceeded the query timeout"),
+		metric.WithUnit("{query}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	m.rowsAffected, err = meter.Int64Histogram(
+		"db.client.rows_affected",
+		metric.WithDescription("Number of rows affected by database write operations"),
+		metric.WithUnit("{row}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	m.rowsReturned, err = meter.Int64Histogram(
+		"db.client.rows_returned",
+		metric.WithDescription("Number of rows returned by database read operations"),
+		metric.WithUnit("{row}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	m.stmtCache, err = meter.Int64Counter(
+		"db.client.stmt_cache",
+		metric.WithDescription("Number of prepared statement cache lookups by result"),
+		metric.WithUnit("{lookup}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	m.rowsLeaked, err = meter.Int64Counter(
+		"db.client.rows.leaked",
+		metric.WithDescription("Number of result sets garbage collected without being closed"),
+		metric.WithUnit("{rows}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	m.badConnRetries, err = meter.Int64Counter(
+		"db.client.badconn_retry",
+		metric.WithDescription("Number of queries retried after a connection error"),
+		metric.WithUnit("{retry}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	m.txTotal, err = meter.Int64Counter(
+		"db.client.tx.total",
+		metric.WithDescription("Number of finished transactions by outcome"),
+		metric.WithUnit("{transaction}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	m.txDuration, err = meter.Float64Histogram(
+		"db.client.tx.duration",
+		metric.WithDescription("Duration of transactions from BEGIN to end in seconds"),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(durationBuckets...),
 	)
 	if err != nil {
 		return nil, err
@@ -62,8 +210,12 @@ func (m *metrics) recordQueryDuration(
 		allAttrs = append(allAttrs, attribute.String("db.operation", operation))
 	}
 
+	var notFound *notFoundError
 	status := "ok"
-	if err != nil {
+	switch {
+	case errors.As(err, &notFound):
+		status = "not_found"
+	case err != nil:
 		status = "error"
 	}
 	allAttrs = append(allAttrs, attribute.String("status", status))
@@ -71,6 +223,284 @@ func (m *metrics) recordQueryDuration(
 	m.queryDuration.Record(ctx, duration.Seconds(), metric.WithAttributes(allAttrs...))
 }
 
+// recordSlowQuery increments the slow query counter.
+func (m *metrics) recordSlowQuery(
+	ctx context.Context,
+	operation string,
+	attrs []attribute.KeyValue,
+) {
+	if m == nil || m.slowQueries == nil {
+		return
+	}
+
+	allAttrs := make([]attribute.KeyValue, 0, len(attrs)+1)
+	allAttrs = append(allAttrs, attrs...)
+	if operation != "" {
+		allAttrs = append(allAttrs, attribute.String("db.operation", operation))
+	}
+
+	m.slowQueries.Add(ctx, 1, metric.WithAttributes(allAttrs...))
+}
+
+// recordQueryTimeout increments the query timeout counter.
+func (m *metrics) recordQueryTimeout(
+	ctx context.Context,
+	operation string,
+	attrs []attribute.KeyValue,
+) {
+	if m == nil || m.queryTimeouts == nil {
+		return
+	}
+
+	allAttrs := make([]attribute.KeyValue, 0, len(attrs)+1)
+	allAttrs = append(allAttrs, attrs...)
+	if operation != "" {
+		allAttrs = append(allAttrs, attribute.String("db.operation", operation))
+	}
+
+	m.queryTimeouts.Add(ctx, 1, metric.WithAttributes(allAttrs...))
+}
+
+// recordQuery records the duration of a query and, when it exceeds the
+// slow query threshold, logs it and increments the slow query counter.
+// It then calls the query hook. Use recordExec for Execs.
+func (cfg *config) recordQuery(
+	ctx context.Context,
+	duration time.Duration,
+	query string,
+	operation string,
+	err error,
+) {
+	cfg.observeQuery(ctx, duration, query, operation, err)
+	cfg.runQueryHook(ctx, duration, query, operation, -1, err)
+}
+
+// observeQuery records the duration and slow query telemetry of a query.
+func (cfg *config) observeQuery(
+	ctx context.Context,
+	duration time.Duration,
+	query string,
+	operation string,
+	err error,
+) {
+	attrs := cfg.metricAttributes(ctx)
+	cfg.Metrics.recordQueryDuration(ctx, duration, operation, attrs, err)
+
+	if cfg.SlowQueryThreshold <= 0 || duration <= cfg.SlowQueryThreshold {
+		return
+	}
+
+	cfg.Metrics.recordSlowQuery(ctx, operation, attrs)
+
+	event := cfg.SlowQueryLogger.Warn()
+	for _, attr := range attrs {
+		event.Interface(string(attr.Key), attr.Value.AsInterface())
+	}
+	if operation != "" {
+		event.Str("db.operation", operation)
+	}
+	event.Dur("duration", duration).Dur("threshold", cfg.SlowQueryThreshold)
+	if statement, ok := cfg.statement(query); ok {
+		event.Str("db.statement", statement)
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.HasTraceID() {
+		event.Str("trace_id", sc.TraceID().String())
+	}
+	if err != nil {
+		event.Err(err)
+	}
+	event.Msg("slow query")
+}
+
+// recordRows records a row count on the given histogram.
+func (m *metrics) recordRows(
+	ctx context.Context,
+	histogram metric.Int64Histogram,
+	rows int64,
+	operation string,
+	attrs []attribute.KeyValue,
+) {
+	if m == nil || histogram == nil {
+		return
+	}
+
+	allAttrs := make([]attribute.KeyValue, 0, len(attrs)+1)
+	allAttrs = append(allAttrs, attrs...)
+	if operation != "" {
+		allAttrs = append(allAttrs, attribute.String("db.operation", operation))
+	}
+
+	histogram.Record(ctx, rows, metric.WithAttributes(allAttrs...))
+}
+
+// recordRowsAffected records the rows affected by an Exec when row metrics
+// are enabled.
+func (cfg *config) recordRowsAffected(
+	ctx context.Context,
+	span trace.Span,
+	operation string,
+	result interface{ RowsAffected() (int64, error) },
+	err error,
+) {
+	if !cfg.RowMetrics || err != nil || result == nil || cfg.Metrics == nil {
+		return
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return
+	}
+
+	span.SetAttributes(attribute.Int64("db.rows_affected", rows))
+	cfg.Metrics.recordRows(ctx, cfg.Metrics.rowsAffected, rows, operation,
+		cfg.metricAttributes(ctx))
+}
+
+// recordGetRows records the rows returned by a Get when row metrics are
+// enabled: 1 on success and 0 when no row matched.
+func (cfg *config) recordGetRows(
+	ctx context.Context,
+	span trace.Span,
+	operation string,
+	err error,
+) {
+	switch {
+	case err == nil:
+		cfg.recordRowsReturned(ctx, span, operation, 1)
+	case errors.Is(err, sql.ErrNoRows):
+		cfg.recordRowsReturned(ctx, span, operation, 0)
+	}
+}
+
+// recordSelectRows records the rows scanned into dest by a Select when row
+// metrics are enabled. Select truncates dest before scanning, so its length
+// afterwards is the number of rows scanned, even when the caller passed a
+// pre-filled slice.
+func (cfg *config) recordSelectRows(
+	ctx context.Context,
+	span trace.Span,
+	operation string,
+	dest interface{},
+	err error,
+) {
+	if err != nil {
+		return
+	}
+
+	v := reflect.ValueOf(dest)
+	for v.Kind() == reflect.Pointer && !v.IsNil() {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Slice {
+		return
+	}
+
+	cfg.recordRowsReturned(ctx, span, operation, int64(v.Len()))
+}
+
+// recordRowsReturned records a returned row count when row metrics are enabled.
+func (cfg *config) recordRowsReturned(
+	ctx context.Context,
+	span trace.Span,
+	operation string,
+	rows int64,
+) {
+	if !cfg.RowMetrics || cfg.Metrics == nil {
+		return
+	}
+
+	span.SetAttributes(attribute.Int64("db.rows_returned", rows))
+	cfg.Metrics.recordRows(ctx, cfg.Metrics.rowsReturned, rows, operation,
+		cfg.metricAttributes(ctx))
+}
+
+// recordRowsLeaked increments the leaked rows counter.
+func (m *metrics) recordRowsLeaked(
+	ctx context.Context,
+	operation string,
+	attrs []attribute.KeyValue,
+) {
+	if m == nil || m.rowsLeaked == nil {
+		return
+	}
+
+	allAttrs := make([]attribute.KeyValue, 0, len(attrs)+1)
+	allAttrs = append(allAttrs, attrs...)
+	if operation != "" {
+		allAttrs = append(allAttrs, attribute.String("db.operation", operation))
+	}
+
+	m.rowsLeaked.Add(ctx, 1, metric.WithAttributes(allAttrs...))
+}
+
+// recordBadConnRetry increments the connection error retry counter.
+func (m *metrics) recordBadConnRetry(
+	ctx context.Context,
+	operation string,
+	attrs []attribute.KeyValue,
+) {
+	if m == nil || m.badConnRetries == nil {
+		return
+	}
+
+	allAttrs := make([]attribute.KeyValue, 0, len(attrs)+1)
+	allAttrs = append(allAttrs, attrs...)
+	if operation != "" {
+		allAttrs = append(allAttrs, attribute.String("db.operation", operation))
+	}
+
+	m.badConnRetries.Add(ctx, 1, metric.WithAttributes(allAttrs...))
+}
+
+// recordTx counts a finished transaction and records its duration from
+// BEGIN. outcome is "commit" or "rollback". Calls on a transaction that
+// already finished, such as a deferred Rollback after Commit, are ignored.
+func (m *metrics) recordTx(
+	ctx context.Context,
+	duration time.Duration,
+	outcome string,
+	attrs []attribute.KeyValue,
+	err error,
+) {
+	if m == nil || m.txTotal == nil || errors.Is(err, sql.ErrTxDone) {
+		return
+	}
+
+	status := "ok"
+	if err != nil {
+		status = "error"
+	}
+
+	allAttrs := make([]attribute.KeyValue, 0, len(attrs)+2)
+	allAttrs = append(allAttrs, attrs...)
+	allAttrs = append(allAttrs,
+		attribute.String("outcome", outcome),
+		attribute.String("status", status),
+	)
+	opt := metric.WithAttributes(allAttrs...)
+
+	m.txTotal.Add(ctx, 1, opt)
+	m.txDuration.Record(ctx, duration.Seconds(), opt)
+}
+
+// recordStmtCache counts a prepared statement cache lookup as a hit or miss.
+func (m *metrics) recordStmtCache(ctx context.Context, hit bool, attrs []attribute.KeyValue) {
+	if m == nil || m.stmtCache == nil {
+		return
+	}
+
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+
+	allAttrs := make([]attribute.KeyValue, 0, len(attrs)+1)
+	allAttrs = append(allAttrs, attrs...)
+	allAttrs = append(allAttrs, attribute.String("result", result))
+
+	m.stmtCache.Add(ctx, 1, metric.WithAttributes(allAttrs...))
+}
+
 // registerPoolMetrics registers connection pool metrics with callbacks.
 func (m *metrics) registerPoolMetrics(
 	meter metric.Meter,
@@ -165,6 +595,14 @@ func (m *metrics) registerPoolMetrics(
 
 // RecordPoolMetrics registers connection pool metrics for a sqlx database.
 //
+// The following gauges are observed from db.Stats() on each collection:
+//   - db.client.connections.open: open connections
+//   - db.client.connections.used: connections in use
+//   - db.client.connections.idle: idle connections
+//   - db.client.connections.max: maximum open connections
+//   - db.client.connections.wait_count: total waits for a connection
+//   - db.client.connections.wait_duration: total time waited, in seconds
+//
 // Example:
 //
 //	db, _ := sentinelsqlx.Open("postgres", dsn,
@@ -0,0 +1,160 @@
+package sqlx
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+
+	"github.com/jmoiron/sqlx"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Database roles recorded in the "db.role" span attribute.
+const (
+	RolePrimary = "primary"
+	RoleReplica = "replica"
+)
+
+// primaryKey is the context key that forces reads to the primary.
+type primaryKey struct{}
+
+// replicaSet holds the read replicas of a DB and picks one per read.
+type replicaSet struct {
+	dbs  []*sqlx.DB
+	next atomic.Uint64
+}
+
+// pick returns the next replica in round-robin order.
+func (r *replicaSet) pick() *sqlx.DB {
+	n := r.next.Add(1) - 1
+	return r.dbs[n%uint64(len(r.dbs))]
+}
+
+// OpenWithReplicas opens a primary connection and one connection per read
+// replica, returning a single *DB that routes queries between them.
+//
+// Reads issued through GetContext, SelectContext, QueryxContext,
+// QueryRowxContext, QueryContext, and QueryRowContext go to a replica in
+// round-robin order when their operation is SELECT. Everything else,
+// including locking reads such as SELECT ... FOR UPDATE, Exec, NamedExec,
+// NamedQuery, prepared statements and transactions, uses the primary. Use
+// Primary to force a read to the primary, e.g. to read back a row that was
+// just written.
+//
+// Spans are tagged with "db.role" set to "primary" or "replica".
+//
+// Example:
+//
+//	db, err := sentinelsqlx.OpenWithReplicas("postgres", primaryDSN,
+//	    []string{replica1DSN, replica2DSN},
+//	    sentinelsqlx.WithDBSystem("postgresql"),
+//	)
+func OpenWithReplicas(
+	driverName, primaryDSN string,
+	replicaDSNs []string,
+	opts ...Option,
+) (*DB, error) {
+	db, err := Open(driverName, primaryDSN, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if len(replicaDSNs) == 0 {
+		return db, nil
+	}
+
+	replicas := &replicaSet{dbs: make([]*sqlx.DB, 0, len(replicaDSNs))}
+	for _, dsn := range replicaDSNs {
+		replica, err := sqlx.Open(driverName, dsn)
+		if err != nil {
+			db.replicas = replicas
+			return nil, errors.Join(err, db.Close())
+		}
+		replicas.dbs = append(replicas.dbs, replica)
+	}
+	db.replicas = replicas
+
+	return db, nil
+}
+
+// Primary returns a context that routes reads made with it to the primary.
+// Use it for read-after-write consistency when replicas may lag.
+//
+// Example:
+//
+//	_, err := db.ExecContext(ctx, "UPDATE users SET name = $1 WHERE id = $2", name, id)
+//
+//	var user User
+//	err = db.GetContext(sentinelsqlx.Primary(ctx), &user,
+//	    "SELECT * FROM users WHERE id = $1", id)
+func Primary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryKey{}, true)
+}
+
+// isPrimary reports whether ctx was marked with Primary.
+func isPrimary(ctx context.Context) bool {
+	forced, _ := ctx.Value(primaryKey{}).(bool)
+	return forced
+}
+
+// reader returns the connection query, with the given operation, should
+// run on and records the chosen role on span.
+func (db *DB) reader(
+	ctx context.Context,
+	span trace.Span,
+	operation, query string,
+) *sqlx.DB {
+	if db.replicas == nil || operation != "SELECT" || isPrimary(ctx) || isLockingRead(query) {
+		return db.primary(span)
+	}
+
+	span.SetAttributes(attribute.String("db.role", RoleReplica))
+	return db.replicas.pick()
+}
+
+// lockingClauses are the row locking clauses of PostgreSQL and MySQL. A
+// SELECT using one takes locks, so it must run on the primary.
+var lockingClauses = []string{
+	" FOR UPDATE",
+	" FOR NO KEY UPDATE",
+	" FOR SHARE",
+	" FOR KEY SHARE",
+	" LOCK IN SHARE MODE",
+}
+
+// isLockingRead reports whether query contains a row locking clause, such
+// as SELECT ... FOR UPDATE.
+func isLockingRead(query string) bool {
+	normalized := " " + strings.Join(strings.Fields(strings.ToUpper(query)), " ")
+	for _, clause := range lockingClauses {
+		if strings.Contains(normalized, clause) {
+			return true
+		}
+	}
+	return false
+}
+
+// primary returns the primary connection and records its role on span when
+// replicas are configured.
+func (db *DB) primary(span trace.Span) *sqlx.DB {
+	if db.replicas != nil {
+		span.SetAttributes(attribute.String("db.role", RolePrimary))
+	}
+	return db.DB
+}
+
+// Close closes cached statements, the primary, and all replica connections.
+func (db *DB) Close() error {
+	if db.stmts != nil {
+		db.stmts.close()
+	}
+
+	err := db.DB.Close()
+	if db.replicas != nil {
+		for _, replica := range db.replicas.dbs {
+			err = errors.Join(err, replica.Close())
+		}
+	}
+	return err
+}
@@ -0,0 +1,342 @@
+package sqlx
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// newReplicaMocks registers sqlmock DSNs for a primary and n replicas.
+func newReplicaMocks(
+	t *testing.T,
+	name string,
+	n int,
+) (primaryDSN string, replicaDSNs []string, primary sqlmock.Sqlmock, replicas []sqlmock.Sqlmock) {
+	t.Helper()
+
+	primaryDSN = name + "_primary"
+	_, primary, err := sqlmock.NewWithDSN(primaryDSN)
+	require.NoError(t, err)
+
+	for i := range n {
+		dsn := name + "_replica_" + string(rune('a'+i))
+		_, mock, err := sqlmock.NewWithDSN(dsn)
+		require.NoError(t, err)
+		replicaDSNs = append(replicaDSNs, dsn)
+		replicas = append(replicas, mock)
+	}
+
+	return primaryDSN, replicaDSNs, primary, replicas
+}
+
+func spanRole(t *testing.T, span sdktrace.ReadOnlySpan) string {
+	t.Helper()
+
+	attrs := attribute.NewSet(span.Attributes()...)
+	role, _ := attrs.Value("db.role")
+	return role.AsString()
+}
+
+func TestOpenWithReplicas_Routing(t *testing.T) {
+	tests := []struct {
+		name     string
+		run      func(ctx context.Context, db *DB) error
+		ctx      func(ctx context.Context) context.Context
+		expect   func(primary sqlmock.Sqlmock, replica sqlmock.Sqlmock)
+		wantRole string
+	}{
+		{
+			name: "given Select, then routes to replica",
+			run: func(ctx context.Context, db *DB) error {
+				var ids []int
+				return db.SelectContext(ctx, &ids, "SELECT id FROM users")
+			},
+			expect: func(_ sqlmock.Sqlmock, replica sqlmock.Sqlmock) {
+				replica.ExpectQuery("SELECT id FROM users").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+			},
+			wantRole: RoleReplica,
+		},
+		{
+			name: "given Get, then routes to replica",
+			run: func(ctx context.Context, db *DB) error {
+				var id int
+				return db.GetContext(ctx, &id, "SELECT id FROM users WHERE id = $1", 1)
+			},
+			expect: func(_ sqlmock.Sqlmock, replica sqlmock.Sqlmock) {
+				replica.ExpectQuery("SELECT id FROM users").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+			},
+			wantRole: RoleReplica,
+		},
+		{
+			name: "given Queryx with Primary context, then routes to primary",
+			ctx:  Primary,
+			run: func(ctx context.Context, db *DB) error {
+				rows, err := db.QueryxContext(ctx, "SELECT id FROM users")
+				if err != nil {
+					return err
+				}
+				return rows.Close()
+			},
+			expect: func(primary sqlmock.Sqlmock, _ sqlmock.Sqlmock) {
+				primary.ExpectQuery("SELECT id FROM users").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+			},
+			wantRole: RolePrimary,
+		},
+		{
+			name: "given Get with FOR UPDATE, then routes to primary",
+			run: func(ctx context.Context, db *DB) error {
+				var id int
+				return db.GetContext(ctx, &id, "SELECT id FROM users WHERE id = $1 FOR UPDATE", 1)
+			},
+			expect: func(primary sqlmock.Sqlmock, _ sqlmock.Sqlmock) {
+				primary.ExpectQuery("SELECT id FROM users").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+			},
+			wantRole: RolePrimary,
+		},
+		{
+			name: "given Select with FOR SHARE, then routes to primary",
+			run: func(ctx context.Context, db *DB) error {
+				var ids []int
+				return db.SelectContext(ctx, &ids, "SELECT id FROM users\n\tfor share")
+			},
+			expect: func(primary sqlmock.Sqlmock, _ sqlmock.Sqlmock) {
+				primary.ExpectQuery("SELECT id FROM users").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+			},
+			wantRole: RolePrimary,
+		},
+		{
+			name: "given Queryx with non-SELECT operation, then routes to primary",
+			run: func(ctx context.Context, db *DB) error {
+				rows, err := db.QueryxContext(ctx, "INSERT INTO users (id) VALUES (1) RETURNING id")
+				if err != nil {
+					return err
+				}
+				return rows.Close()
+			},
+			expect: func(primary sqlmock.Sqlmock, _ sqlmock.Sqlmock) {
+				primary.ExpectQuery("INSERT INTO users").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+			},
+			wantRole: RolePrimary,
+		},
+		{
+			name: "given Exec, then routes to primary",
+			run: func(ctx context.Context, db *DB) error {
+				_, err := db.ExecContext(ctx, "UPDATE users SET name = $1", "John")
+				return err
+			},
+			expect: func(primary sqlmock.Sqlmock, _ sqlmock.Sqlmock) {
+				primary.ExpectExec("UPDATE users").WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantRole: RolePrimary,
+		},
+		{
+			name: "given NamedExec, then routes to primary",
+			run: func(ctx context.Context, db *DB) error {
+				_, err := db.NamedExecContext(ctx,
+					"DELETE FROM users WHERE id = :id", map[string]interface{}{"id": 1})
+				return err
+			},
+			expect: func(primary sqlmock.Sqlmock, _ sqlmock.Sqlmock) {
+				primary.ExpectExec("DELETE FROM users").WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantRole: RolePrimary,
+		},
+		{
+			name: "given transaction, then begins on primary",
+			run: func(ctx context.Context, db *DB) error {
+				tx, err := db.BeginTxx(ctx, nil)
+				if err != nil {
+					return err
+				}
+				return tx.Commit()
+			},
+			expect: func(primary sqlmock.Sqlmock, _ sqlmock.Sqlmock) {
+				primary.ExpectBegin()
+				primary.ExpectCommit()
+			},
+			wantRole: RolePrimary,
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name := "routing_" + string(rune('a'+i))
+			primaryDSN, replicaDSNs, primary, replicas := newReplicaMocks(t, name, 1)
+
+			exporter := tracetest.NewInMemoryExporter()
+			tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+			db, err := OpenWithReplicas("sqlmock", primaryDSN, replicaDSNs, WithTracerProvider(tp))
+			require.NoError(t, err)
+
+			tt.expect(primary, replicas[0])
+
+			ctx := context.Background()
+			if tt.ctx != nil {
+				ctx = tt.ctx(ctx)
+			}
+			require.NoError(t, tt.run(ctx, db))
+
+			assert.NoError(t, primary.ExpectationsWereMet())
+			assert.NoError(t, replicas[0].ExpectationsWereMet())
+
+			spans := exporter.GetSpans().Snapshots()
+			require.NotEmpty(t, spans)
+			assert.Equal(t, tt.wantRole, spanRole(t, spans[0]))
+		})
+	}
+}
+
+func TestOpenWithReplicas_RoundRobin(t *testing.T) {
+	t.Run("given multiple replicas, then reads rotate through them", func(t *testing.T) {
+		primaryDSN, replicaDSNs, primary, replicas := newReplicaMocks(t, "round_robin", 2)
+
+		db, err := OpenWithReplicas("sqlmock", primaryDSN, replicaDSNs)
+		require.NoError(t, err)
+
+		for _, replica := range []sqlmock.Sqlmock{replicas[0], replicas[1], replicas[0]} {
+			replica.ExpectQuery("SELECT 1").
+				WillReturnRows(sqlmock.NewRows([]string{"n"}).AddRow(1))
+		}
+
+		for range 3 {
+			var n int
+			require.NoError(t, db.GetContext(context.Background(), &n, "SELECT 1"))
+		}
+
+		assert.NoError(t, primary.ExpectationsWereMet())
+		for _, replica := range replicas {
+			assert.NoError(t, replica.ExpectationsWereMet())
+		}
+	})
+}
+
+func TestOpenWithReplicas(t *testing.T) {
+	tests := []struct {
+		name         string
+		driverName   string
+		replicaDSNs  []string
+		wantErr      assert.ErrorAssertionFunc
+		wantReplicas int
+	}{
+		{
+			name:         "given replica DSNs, then opens one connection per replica",
+			driverName:   "sqlmock",
+			replicaDSNs:  []string{"open_a", "open_b"},
+			wantErr:      assert.NoError,
+			wantReplicas: 2,
+		},
+		{
+			name:         "given no replica DSNs, then behaves like Open",
+			driverName:   "sqlmock",
+			wantErr:      assert.NoError,
+			wantReplicas: 0,
+		},
+		{
+			name:        "given invalid driver, then returns error",
+			driverName:  "nonexistent_driver",
+			replicaDSNs: []string{"open_a"},
+			wantErr:     assert.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, err := OpenWithReplicas(tt.driverName, "open_primary", tt.replicaDSNs)
+			tt.wantErr(t, err)
+			if err != nil {
+				assert.Nil(t, db)
+				return
+			}
+
+			if tt.wantReplicas == 0 {
+				assert.Nil(t, db.replicas)
+				return
+			}
+			require.NotNil(t, db.replicas)
+			assert.Len(t, db.replicas.dbs, tt.wantReplicas)
+			assert.NoError(t, db.Close())
+		})
+	}
+}
+
+func TestDB_NoReplicas_NoRoleAttribute(t *testing.T) {
+	t.Run("given DB without replicas, then spans have no db.role", func(t *testing.T) {
+		mockDB, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer mockDB.Close()
+
+		exporter := tracetest.NewInMemoryExporter()
+		tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+		db := NewDB(mockDB, "postgres", WithTracerProvider(tp))
+
+		mock.ExpectQuery("SELECT 1").WillReturnRows(sqlmock.NewRows([]string{"n"}).AddRow(1))
+
+		var n int
+		require.NoError(t, db.GetContext(context.Background(), &n, "SELECT 1"))
+
+		spans := exporter.GetSpans().Snapshots()
+		require.Len(t, spans, 1)
+		assert.Empty(t, spanRole(t, spans[0]))
+	})
+}
+
+func TestIsLockingRead(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  bool
+	}{
+		{name: "given plain select, then false", query: "SELECT * FROM users", want: false},
+		{
+			name:  "given column named like a clause, then false",
+			query: "SELECT for_update FROM users",
+			want:  false,
+		},
+		{name: "given FOR UPDATE, then true", query: "SELECT * FROM users FOR UPDATE", want: true},
+		{
+			name:  "given FOR UPDATE OF with NOWAIT, then true",
+			query: "SELECT * FROM users u FOR UPDATE OF u NOWAIT",
+			want:  true,
+		},
+		{
+			name:  "given FOR NO KEY UPDATE, then true",
+			query: "SELECT * FROM users FOR NO KEY UPDATE",
+			want:  true,
+		},
+		{name: "given FOR SHARE, then true", query: "SELECT * FROM users FOR SHARE", want: true},
+		{
+			name:  "given FOR KEY SHARE, then true",
+			query: "SELECT * FROM users FOR KEY SHARE",
+			want:  true,
+		},
+		{
+			name:  "given LOCK IN SHARE MODE, then true",
+			query: "SELECT * FROM users LOCK IN SHARE MODE",
+			want:  true,
+		},
+		{
+			name:  "given lowercase clause split over lines, then true",
+			query: "select * from users\nfor\n  update",
+			want:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isLockingRead(tt.query))
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package sqlx
+
+import (
+	"context"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// retryCountKey is the context key for the retry count of the last query.
+type retryCountKey struct{}
+
+// ContextWithRetryCount returns a copy of ctx that records how many times
+// WithRetryBadConn retried the last query made with it, for LastRetryCount.
+//
+// Example:
+//
+//	ctx = sentinelsqlx.ContextWithRetryCount(ctx)
+//	rows, err := db.QueryContext(ctx, "SELECT id FROM users")
+//	log.Printf("retried %d times", sentinelsqlx.LastRetryCount(ctx))
+func ContextWithRetryCount(ctx context.Context) context.Context {
+	return context.WithValue(ctx, retryCountKey{}, new(atomic.Int64))
+}
+
+// LastRetryCount returns how many times the last query made with ctx was
+// retried after a connection error. It returns 0 if ctx was not created by
+// ContextWithRetryCount, or no query has been made with it yet.
+func LastRetryCount(ctx context.Context) int {
+	if count, ok := ctx.Value(retryCountKey{}).(*atomic.Int64); ok {
+		return int(count.Load())
+	}
+	return 0
+}
+
+// retryBadConn runs fn and, when WithRetryBadConn is set and the operation
+// may be retried, runs it again while it fails with a connection error, up
+// to the configured number of retries. The number of retries is set as the
+// db.retries span attribute and reported to LastRetryCount.
+func (cfg *config) retryBadConn(
+	ctx context.Context,
+	span trace.Span,
+	operation string,
+	fn func() error,
+) error {
+	count, _ := ctx.Value(retryCountKey{}).(*atomic.Int64)
+	if count != nil {
+		count.Store(0)
+	}
+
+	err := fn()
+	if cfg.BadConnRetries <= 0 || (operation != "SELECT" && !cfg.RetryBadConnWrites) {
+		return err
+	}
+
+	retries := 0
+	for attempt := 1; attempt <= cfg.BadConnRetries; attempt++ {
+		if ClassifyError(err) != ErrorKindConnection || ctx.Err() != nil {
+			break
+		}
+
+		retries = attempt
+		cfg.Metrics.recordBadConnRetry(ctx, operation, cfg.metricAttributes(ctx))
+		span.AddEvent("retry", trace.WithAttributes(
+			attribute.Int("db.retry.attempt", attempt),
+			attribute.String("db.retry.error", err.Error()),
+		))
+		err = fn()
+	}
+
+	span.SetAttributes(attribute.Int("db.retries", retries))
+	if count != nil {
+		count.Store(int64(retries))
+	}
+	return err
+}
@@ -0,0 +1,192 @@
+package sqlx
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestDB_RetryBadConn(t *testing.T) {
+	errConnReset := &net.OpError{
+		Op:  "read",
+		Net: "tcp",
+		Err: errors.New("connection reset by peer"),
+	}
+
+	tests := []struct {
+		name        string
+		opts        []Option
+		mockFn      func(sqlmock.Sqlmock)
+		run         func(*DB) error
+		wantErr     assert.ErrorAssertionFunc
+		wantRetries int64
+	}{
+		{
+			name: "given select fails with connection error, then retries it",
+			opts: []Option{WithRetryBadConn(2)},
+			mockFn: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT id FROM users").WillReturnError(errConnReset)
+				mock.ExpectQuery("SELECT id FROM users").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+			},
+			run: func(db *DB) error {
+				rows, err := db.QueryContext(context.Background(), "SELECT id FROM users")
+				if err != nil {
+					return err
+				}
+				return rows.Close()
+			},
+			wantErr:     assert.NoError,
+			wantRetries: 1,
+		},
+		{
+			name: "given connection errors beyond max retries, then returns the error",
+			opts: []Option{WithRetryBadConn(1)},
+			mockFn: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT id FROM users").WillReturnError(errConnReset)
+				mock.ExpectQuery("SELECT id FROM users").WillReturnError(errConnReset)
+			},
+			run: func(db *DB) error {
+				_, err := db.QueryContext(context.Background(), "SELECT id FROM users")
+				return err
+			},
+			wantErr:     assert.Error,
+			wantRetries: 1,
+		},
+		{
+			name: "given select fails with other error, then does not retry",
+			opts: []Option{WithRetryBadConn(2)},
+			mockFn: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT id FROM users").WillReturnError(errors.New("syntax error"))
+			},
+			run: func(db *DB) error {
+				_, err := db.QueryContext(context.Background(), "SELECT id FROM users")
+				return err
+			},
+			wantErr: assert.Error,
+		},
+		{
+			name: "given exec fails with connection error, then does not retry by default",
+			opts: []Option{WithRetryBadConn(2)},
+			mockFn: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("UPDATE users").WillReturnError(errConnReset)
+			},
+			run: func(db *DB) error {
+				_, err := db.ExecContext(context.Background(), "UPDATE users SET active = true")
+				return err
+			},
+			wantErr: assert.Error,
+		},
+		{
+			name: "given writes opted in and exec fails with connection error, then retries it",
+			opts: []Option{WithRetryBadConn(2), WithRetryBadConnWrites()},
+			mockFn: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("UPDATE users").WillReturnError(errConnReset)
+				mock.ExpectExec("UPDATE users").WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			run: func(db *DB) error {
+				_, err := db.ExecContext(context.Background(), "UPDATE users SET active = true")
+				return err
+			},
+			wantErr:     assert.NoError,
+			wantRetries: 1,
+		},
+		{
+			name: "given retries disabled, then does not retry",
+			mockFn: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT id FROM users").WillReturnError(errConnReset)
+			},
+			run: func(db *DB) error {
+				_, err := db.QueryContext(context.Background(), "SELECT id FROM users")
+				return err
+			},
+			wantErr: assert.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer mockDB.Close()
+
+			reader := sdkmetric.NewManualReader()
+			mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+			defer mp.Shutdown(context.Background())
+
+			db := NewDB(mockDB, "postgres", append(tt.opts, WithMeterProvider(mp))...)
+			tt.mockFn(mock)
+
+			tt.wantErr(t, tt.run(db))
+			require.NoError(t, mock.ExpectationsWereMet())
+
+			var rm metricdata.ResourceMetrics
+			require.NoError(t, reader.Collect(context.Background(), &rm))
+			var retries int64
+			for _, sm := range rm.ScopeMetrics {
+				for _, m := range sm.Metrics {
+					if m.Name != "db.client.badconn_retry" {
+						continue
+					}
+					for _, dp := range m.Data.(metricdata.Sum[int64]).DataPoints {
+						retries += dp.Value
+					}
+				}
+			}
+			assert.Equal(t, tt.wantRetries, retries)
+		})
+	}
+}
+
+func TestLastRetryCount(t *testing.T) {
+	errConnReset := &net.OpError{
+		Op:  "read",
+		Net: "tcp",
+		Err: errors.New("connection reset by peer"),
+	}
+
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer mockDB.Close()
+
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	db := NewDB(mockDB, "postgres", WithRetryBadConn(3), WithTracerProvider(tp))
+
+	ctx := ContextWithRetryCount(context.Background())
+	assert.Equal(t, 0, LastRetryCount(ctx))
+
+	mock.ExpectQuery("SELECT id FROM users").WillReturnError(errConnReset)
+	mock.ExpectQuery("SELECT id FROM users").WillReturnError(errConnReset)
+	mock.ExpectQuery("SELECT id FROM users").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	rows, err := db.QueryContext(ctx, "SELECT id FROM users")
+	require.NoError(t, err)
+	require.NoError(t, rows.Close())
+	assert.Equal(t, 2, LastRetryCount(ctx))
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	set := attribute.NewSet(spans[0].Attributes...)
+	retries, ok := set.Value("db.retries")
+	require.True(t, ok)
+	assert.Equal(t, int64(2), retries.AsInt64())
+
+	mock.ExpectExec("UPDATE users").WillReturnResult(sqlmock.NewResult(0, 1))
+	_, err = db.ExecContext(ctx, "UPDATE users SET active = true")
+	require.NoError(t, err)
+	assert.Equal(t, 0, LastRetryCount(ctx), "count is reset by the next query")
+
+	assert.Equal(t, 0, LastRetryCount(context.Background()))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,167 @@
+package sqlx
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"runtime"
+	"sync/atomic"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// errRowsLeaked is recorded on the span of rows garbage collected without
+// being closed.
+var errRowsLeaked = errors.New("sqlx: rows garbage collected without Close")
+
+// Rows is returned by TracedQueryxContext. It has the reading methods of
+// *sqlx.Rows, and its query span stays open while the rows are read: it
+// ends when Next returns false or Close is called.
+//
+// The underlying *sqlx.Rows is not exposed, so every read and Close goes
+// through Rows and is accounted for.
+type Rows struct {
+	rows *sqlx.Rows
+	scan *rowScan
+}
+
+// rowScan tracks the reading of traced rows.
+type rowScan struct {
+	ctx       context.Context
+	cfg       *config
+	span      trace.Span
+	operation string
+	start     time.Time
+	rows      int64
+	done      atomic.Bool
+}
+
+// Next prepares the next result row for reading, like sql.Rows.Next.
+// The span ends when it returns false.
+func (r *Rows) Next() bool {
+	if r.rows.Next() {
+		r.scan.rows++
+		return true
+	}
+	r.scan.finish(r.rows.Err())
+	return false
+}
+
+// Close closes the rows, like sql.Rows.Close. The span ends if it has not
+// already.
+func (r *Rows) Close() error {
+	err := r.rows.Close()
+	r.scan.finish(err)
+	return err
+}
+
+// Err returns the error, if any, encountered during iteration.
+func (r *Rows) Err() error {
+	return r.rows.Err()
+}
+
+// Columns returns the column names.
+func (r *Rows) Columns() ([]string, error) {
+	return r.rows.Columns()
+}
+
+// ColumnTypes returns column information such as type, length and
+// nullability.
+func (r *Rows) ColumnTypes() ([]*sql.ColumnType, error) {
+	return r.rows.ColumnTypes()
+}
+
+// NextResultSet prepares the next result set for reading, like
+// sql.Rows.NextResultSet.
+func (r *Rows) NextResultSet() bool {
+	return r.rows.NextResultSet()
+}
+
+// Scan copies the columns of the current row into dest, like sql.Rows.Scan.
+func (r *Rows) Scan(dest ...interface{}) error {
+	return r.rows.Scan(dest...)
+}
+
+// StructScan scans the current row into dest, like sqlx.Rows.StructScan.
+func (r *Rows) StructScan(dest interface{}) error {
+	return r.rows.StructScan(dest)
+}
+
+// MapScan scans the current row into dest, like sqlx.Rows.MapScan.
+func (r *Rows) MapScan(dest map[string]interface{}) error {
+	return r.rows.MapScan(dest)
+}
+
+// SliceScan scans the current row into a slice, like sqlx.Rows.SliceScan.
+func (r *Rows) SliceScan() ([]interface{}, error) {
+	return r.rows.SliceScan()
+}
+
+// finish records the scan and ends the span. It is safe to call more than
+// once.
+func (s *rowScan) finish(err error) {
+	if !s.done.CompareAndSwap(false, true) {
+		return
+	}
+
+	s.span.SetAttributes(
+		attribute.Int64("db.rows_returned", s.rows),
+		attribute.Float64("db.scan.duration", time.Since(s.start).Seconds()),
+	)
+	if s.cfg.RowMetrics {
+		s.cfg.Metrics.recordRows(s.ctx, s.cfg.Metrics.rowsReturned, s.rows, s.operation,
+			s.cfg.baseAttributes())
+	}
+	if err != nil {
+		recordError(s.span, err)
+	}
+	s.span.End()
+}
+
+// traceRows finishes a query made by TracedQueryxContext. On error it
+// records err and ends span. Otherwise it wraps rows, ending span once the
+// rows are read or closed.
+func (cfg *config) traceRows(
+	ctx context.Context,
+	span trace.Span,
+	operation string,
+	rows *sqlx.Rows,
+	err error,
+) (*Rows, error) {
+	if err != nil {
+		recordError(span, err)
+		span.End()
+		return nil, err
+	}
+
+	wrapped := &Rows{
+		rows: rows,
+		scan: &rowScan{
+			ctx:       ctx,
+			cfg:       cfg,
+			span:      span,
+			operation: operation,
+			start:     time.Now(),
+		},
+	}
+	// The caller only holds wrapped, so once it is unreachable the rows
+	// can no longer be read or closed.
+	runtime.SetFinalizer(wrapped, (*Rows).leaked)
+	return wrapped, nil
+}
+
+// leaked is the finalizer of traced rows. Rows that were never closed hold
+// their connection until collected, so they are counted, closed and their
+// span is ended with an error.
+func (r *Rows) leaked() {
+	if r.scan.done.Load() {
+		return
+	}
+
+	r.scan.cfg.Metrics.recordRowsLeaked(r.scan.ctx, r.scan.operation, r.scan.cfg.baseAttributes())
+	_ = r.rows.Close()
+	r.scan.finish(errRowsLeaked)
+}
@@ -0,0 +1,131 @@
+package sqlx
+
+import (
+	"context"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestDB_TracedQueryxContext(t *testing.T) {
+	tests := []struct {
+		name     string
+		read     func(t *testing.T, rows *Rows)
+		wantRows int64
+	}{
+		{
+			name: "given rows read to the end, then span ends with row count",
+			read: func(t *testing.T, rows *Rows) {
+				for rows.Next() {
+					var id int
+					require.NoError(t, rows.Scan(&id))
+				}
+				require.NoError(t, rows.Err())
+			},
+			wantRows: 3,
+		},
+		{
+			name: "given rows closed early, then span ends on Close",
+			read: func(t *testing.T, rows *Rows) {
+				require.True(t, rows.Next())
+				require.NoError(t, rows.Close())
+			},
+			wantRows: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer mockDB.Close()
+
+			exporter := tracetest.NewInMemoryExporter()
+			tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+			db := NewDB(mockDB, "postgres", WithTracerProvider(tp))
+
+			mock.ExpectQuery("SELECT id FROM users").
+				WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2).AddRow(3))
+
+			rows, err := db.TracedQueryxContext(context.Background(), "SELECT id FROM users")
+			require.NoError(t, err)
+			assert.Empty(t, exporter.GetSpans())
+
+			tt.read(t, rows)
+			require.NoError(t, rows.Close())
+
+			spans := exporter.GetSpans()
+			require.Len(t, spans, 1)
+
+			set := attribute.NewSet(spans[0].Attributes...)
+			value, ok := set.Value("db.rows_returned")
+			require.True(t, ok)
+			assert.Equal(t, tt.wantRows, value.AsInt64())
+			_, ok = set.Value("db.scan.duration")
+			assert.True(t, ok)
+		})
+	}
+}
+
+func TestDB_TracedQueryxContext_RowsLeaked(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer mockDB.Close()
+
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	defer mp.Shutdown(context.Background())
+
+	db := NewDB(mockDB, "postgres",
+		WithTracerProvider(tp),
+		WithMeterProvider(mp),
+	)
+
+	mock.ExpectQuery("SELECT id FROM users").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+	func() {
+		rows, err := db.TracedQueryxContext(context.Background(), "SELECT id FROM users")
+		require.NoError(t, err)
+		require.True(t, rows.Next())
+		// rows is dropped without Close.
+	}()
+
+	require.Eventually(t, func() bool {
+		runtime.GC()
+		return len(exporter.GetSpans()) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	span := exporter.GetSpans()[0]
+	assert.Equal(t, codes.Error, span.Status.Code)
+	assert.Equal(t, errRowsLeaked.Error(), span.Status.Description)
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	var leaked int64
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != "db.client.rows.leaked" {
+				continue
+			}
+			sum, ok := m.Data.(metricdata.Sum[int64])
+			require.True(t, ok)
+			for _, dp := range sum.DataPoints {
+				leaked += dp.Value
+			}
+		}
+	}
+	assert.Equal(t, int64(1), leaked)
+}
@@ -0,0 +1,187 @@
+package sqlx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ErrInvalidSavepoint is returned when a savepoint name is not a plain SQL
+// identifier. Savepoint names cannot be bound as parameters, so they are
+// validated before being written into the statement.
+var ErrInvalidSavepoint = errors.New("sqlx: invalid savepoint name")
+
+// savepointNameRegex matches names that are safe to inline into SQL.
+var savepointNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// savepointSyntax holds the savepoint statements of one SQL dialect.
+// An empty release means the dialect has no RELEASE statement.
+type savepointSyntax struct {
+	create   string
+	rollback string
+	release  string
+}
+
+var (
+	// standardSavepoints is used by PostgreSQL, MySQL, MariaDB and SQLite.
+	standardSavepoints = savepointSyntax{
+		create:   "SAVEPOINT %s",
+		rollback: "ROLLBACK TO SAVEPOINT %s",
+		release:  "RELEASE SAVEPOINT %s",
+	}
+
+	// sqlServerSavepoints is used by Microsoft SQL Server.
+	sqlServerSavepoints = savepointSyntax{
+		create:   "SAVE TRANSACTION %s",
+		rollback: "ROLLBACK TRANSACTION %s",
+	}
+
+	// oracleSavepoints is used by Oracle Database.
+	oracleSavepoints = savepointSyntax{
+		create:   "SAVEPOINT %s",
+		rollback: "ROLLBACK TO SAVEPOINT %s",
+	}
+)
+
+// savepointSyntaxFor returns the savepoint syntax for a driver name.
+func savepointSyntaxFor(driverName string) savepointSyntax {
+	switch driverName {
+	case "sqlserver", "mssql", "azuresql":
+		return sqlServerSavepoints
+	case "oracle", "godror", "oci8":
+		return oracleSavepoints
+	default:
+		return standardSavepoints
+	}
+}
+
+// Savepoint creates a savepoint with the given name inside the transaction.
+//
+// The statement depends on the driver:
+//   - PostgreSQL, MySQL, MariaDB, SQLite: SAVEPOINT name
+//   - SQL Server (sqlserver, mssql, azuresql): SAVE TRANSACTION name
+//   - Oracle (oracle, godror, oci8): SAVEPOINT name
+//
+// The name must be a plain identifier (letters, digits and underscores)
+// or ErrInvalidSavepoint is returned.
+//
+// Example:
+//
+//	if err := tx.Savepoint(ctx, "before_items"); err != nil {
+//	    return err
+//	}
+//	if _, err := tx.ExecContext(ctx, insertItems, orderID); err != nil {
+//	    return tx.RollbackToSavepoint(ctx, "before_items")
+//	}
+//	return tx.ReleaseSavepoint(ctx, "before_items")
+func (tx *Tx) Savepoint(ctx context.Context, name string) error {
+	syntax := savepointSyntaxFor(tx.DriverName())
+	return tx.execSavepoint(ctx, "SAVEPOINT", syntax.create, name)
+}
+
+// RollbackToSavepoint rolls the transaction back to the named savepoint,
+// undoing everything executed after it while keeping the transaction open.
+//
+// The statement depends on the driver:
+//   - PostgreSQL, MySQL, MariaDB, SQLite, Oracle: ROLLBACK TO SAVEPOINT name
+//   - SQL Server: ROLLBACK TRANSACTION name
+func (tx *Tx) RollbackToSavepoint(ctx context.Context, name string) error {
+	syntax := savepointSyntaxFor(tx.DriverName())
+	return tx.execSavepoint(ctx, "ROLLBACK TO", syntax.rollback, name)
+}
+
+// ReleaseSavepoint releases the named savepoint, keeping its changes as part
+// of the enclosing transaction.
+//
+// SQL Server and Oracle have no RELEASE statement; savepoints there are
+// released when the transaction ends, so this only validates the name and
+// records the span.
+func (tx *Tx) ReleaseSavepoint(ctx context.Context, name string) error {
+	syntax := savepointSyntaxFor(tx.DriverName())
+	return tx.execSavepoint(ctx, "RELEASE", syntax.release, name)
+}
+
+// RunNested runs fn inside an automatically named savepoint. If fn returns
+// an error or panics, the transaction is rolled back to the savepoint and
+// the error is returned (or the panic re-raised); otherwise the savepoint is
+// released. The enclosing transaction stays open either way.
+//
+// RunNested calls may be nested to any depth.
+//
+// Example:
+//
+//	err := tx.RunNested(ctx, func(ctx context.Context) error {
+//	    _, err := tx.ExecContext(ctx, "INSERT INTO audit_log (msg) VALUES ($1)", msg)
+//	    return err
+//	})
+//	// A failed audit insert is undone, but the outer transaction continues.
+func (tx *Tx) RunNested(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	tx.savepoints++
+	name := "sp_" + strconv.Itoa(tx.savepoints)
+
+	if err := tx.Savepoint(ctx, name); err != nil {
+		return err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.RollbackToSavepoint(ctx, name)
+			panic(p)
+		}
+	}()
+
+	if err := fn(ctx); err != nil {
+		return errors.Join(err, tx.RollbackToSavepoint(ctx, name))
+	}
+
+	return tx.ReleaseSavepoint(ctx, name)
+}
+
+// execSavepoint executes a savepoint statement in its own span. An empty
+// format means the driver has no such statement and nothing is executed.
+func (tx *Tx) execSavepoint(ctx context.Context, operation, format, name string) error {
+	if !savepointNameRegex.MatchString(name) {
+		return fmt.Errorf("%w: %q", ErrInvalidSavepoint, name)
+	}
+
+	start := time.Now()
+
+	attrs := append(tx.cfg.baseAttributes(),
+		attribute.String("db.operation", operation),
+		attribute.String("db.savepoint", name),
+	)
+	attrs = append(attrs, tx.cfg.tagAttributes(ctx)...)
+	ctx, span := tx.cfg.Tracer.Start(ctx, operation,
+		trace.WithSpanKind(tx.cfg.SpanKind),
+		trace.WithAttributes(attrs...),
+		trace.WithAttributes(txAttributes(tx.begin)...),
+		trace.WithLinks(txLinks(tx.begin)...),
+	)
+	defer span.End()
+
+	if format == "" {
+		return nil
+	}
+
+	_, err := tx.Tx.ExecContext(ctx, fmt.Sprintf(format, name))
+
+	tx.cfg.Metrics.recordQueryDuration(
+		ctx,
+		time.Since(start),
+		operation,
+		tx.cfg.baseAttributes(),
+		err,
+	)
+
+	if err != nil {
+		recordError(span, err)
+	}
+
+	return err
+}
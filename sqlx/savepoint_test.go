@@ -0,0 +1,214 @@
+package sqlx
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// newSavepointTx begins a transaction on a sqlmock DB using driverName.
+func newSavepointTx(
+	t *testing.T,
+	driverName string,
+) (*Tx, sqlmock.Sqlmock, *tracetest.InMemoryExporter) {
+	t.Helper()
+
+	mockDB, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	t.Cleanup(func() { mockDB.Close() })
+
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	db := NewDB(mockDB, driverName, WithTracerProvider(tp))
+
+	mock.ExpectBegin()
+	tx, err := db.BeginTxx(context.Background(), nil)
+	require.NoError(t, err)
+	exporter.Reset()
+
+	return tx, mock, exporter
+}
+
+func TestTx_Savepoint(t *testing.T) {
+	tests := []struct {
+		name       string
+		driverName string
+		call       func(tx *Tx) error
+		wantSQL    string
+		wantSpan   string
+		wantErr    error
+	}{
+		{
+			name:       "given postgres, then creates savepoint",
+			driverName: "postgres",
+			call:       func(tx *Tx) error { return tx.Savepoint(context.Background(), "sp1") },
+			wantSQL:    "SAVEPOINT sp1",
+			wantSpan:   "SAVEPOINT",
+		},
+		{
+			name:       "given postgres, then rolls back to savepoint",
+			driverName: "postgres",
+			call: func(tx *Tx) error {
+				return tx.RollbackToSavepoint(context.Background(), "sp1")
+			},
+			wantSQL:  "ROLLBACK TO SAVEPOINT sp1",
+			wantSpan: "ROLLBACK TO",
+		},
+		{
+			name:       "given mysql, then releases savepoint",
+			driverName: "mysql",
+			call: func(tx *Tx) error {
+				return tx.ReleaseSavepoint(context.Background(), "sp1")
+			},
+			wantSQL:  "RELEASE SAVEPOINT sp1",
+			wantSpan: "RELEASE",
+		},
+		{
+			name:       "given sqlserver, then uses SAVE TRANSACTION",
+			driverName: "sqlserver",
+			call:       func(tx *Tx) error { return tx.Savepoint(context.Background(), "sp1") },
+			wantSQL:    "SAVE TRANSACTION sp1",
+			wantSpan:   "SAVEPOINT",
+		},
+		{
+			name:       "given sqlserver, then uses ROLLBACK TRANSACTION",
+			driverName: "sqlserver",
+			call: func(tx *Tx) error {
+				return tx.RollbackToSavepoint(context.Background(), "sp1")
+			},
+			wantSQL:  "ROLLBACK TRANSACTION sp1",
+			wantSpan: "ROLLBACK TO",
+		},
+		{
+			name:       "given sqlserver, then release executes nothing",
+			driverName: "sqlserver",
+			call: func(tx *Tx) error {
+				return tx.ReleaseSavepoint(context.Background(), "sp1")
+			},
+			wantSpan: "RELEASE",
+		},
+		{
+			name:       "given name with SQL, then returns ErrInvalidSavepoint",
+			driverName: "postgres",
+			call: func(tx *Tx) error {
+				return tx.Savepoint(context.Background(), "sp1; DROP TABLE users")
+			},
+			wantErr: ErrInvalidSavepoint,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx, mock, exporter := newSavepointTx(t, tt.driverName)
+			if tt.wantSQL != "" {
+				mock.ExpectExec(tt.wantSQL).WillReturnResult(sqlmock.NewResult(0, 0))
+			}
+
+			err := tt.call(tx)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				assert.Empty(t, exporter.GetSpans())
+				return
+			}
+			require.NoError(t, err)
+			require.NoError(t, mock.ExpectationsWereMet())
+
+			spans := exporter.GetSpans()
+			require.Len(t, spans, 1)
+			assert.Equal(t, tt.wantSpan, spans[0].Name)
+		})
+	}
+}
+
+func TestTx_RunNested(t *testing.T) {
+	errFn := errors.New("fn failed")
+
+	tests := []struct {
+		name    string
+		mockFn  func(mock sqlmock.Sqlmock)
+		fn      func(tx *Tx) func(ctx context.Context) error
+		wantErr error
+	}{
+		{
+			name: "given fn succeeds, then releases savepoint",
+			mockFn: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("SAVEPOINT sp_1").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("UPDATE users SET n = 1").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("RELEASE SAVEPOINT sp_1").WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			fn: func(tx *Tx) func(ctx context.Context) error {
+				return func(ctx context.Context) error {
+					_, err := tx.ExecContext(ctx, "UPDATE users SET n = 1")
+					return err
+				}
+			},
+		},
+		{
+			name: "given fn fails, then rolls back to savepoint",
+			mockFn: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("SAVEPOINT sp_1").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("ROLLBACK TO SAVEPOINT sp_1").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			fn: func(_ *Tx) func(ctx context.Context) error {
+				return func(context.Context) error { return errFn }
+			},
+			wantErr: errFn,
+		},
+		{
+			name: "given nested calls, then uses distinct savepoints",
+			mockFn: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("SAVEPOINT sp_1").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("SAVEPOINT sp_2").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("ROLLBACK TO SAVEPOINT sp_2").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("RELEASE SAVEPOINT sp_1").WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			fn: func(tx *Tx) func(ctx context.Context) error {
+				return func(ctx context.Context) error {
+					err := tx.RunNested(ctx, func(context.Context) error { return errFn })
+					if !errors.Is(err, errFn) {
+						return errors.New("inner error not returned")
+					}
+					return nil
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx, mock, _ := newSavepointTx(t, "postgres")
+			tt.mockFn(mock)
+
+			err := tx.RunNested(context.Background(), tt.fn(tx))
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTx_RunNested_Panic(t *testing.T) {
+	t.Run("given fn panics, then rolls back to savepoint and re-panics", func(t *testing.T) {
+		tx, mock, _ := newSavepointTx(t, "postgres")
+		mock.ExpectExec("SAVEPOINT sp_1").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("ROLLBACK TO SAVEPOINT sp_1").WillReturnResult(sqlmock.NewResult(0, 0))
+
+		assert.PanicsWithValue(t, "boom", func() {
+			_ = tx.RunNested(context.Background(), func(context.Context) error {
+				panic("boom")
+			})
+		})
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
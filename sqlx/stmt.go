@@ -7,11 +7,14 @@ import (
 
 	"github.com/jmoiron/sqlx"
 
-	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
 )
 
 // Stmt wraps *sqlx.Stmt with OpenTelemetry instrumentation.
+//
+// Execution spans record the prepared query with the same QuerySanitizer,
+// DisableQuery and ArgsCapture settings as the DB that prepared it.
 type Stmt struct {
 	*sqlx.Stmt
 	cfg   *config
@@ -23,25 +26,21 @@ func (s *Stmt) GetContext(ctx context.Context, dest interface{}, args ...interfa
 	start := time.Now()
 	operation := extractOperation(s.query)
 
-	ctx, span := s.cfg.Tracer.Start(ctx, sqlxSpanName("sqlx.Stmt.Get", s.query),
-		trace.WithSpanKind(trace.SpanKindClient),
-		trace.WithAttributes(s.cfg.queryAttributes(s.query)...),
+	ctx, span := s.cfg.Tracer.Start(ctx, s.cfg.sqlxSpanName("sqlx.Stmt.Get", s.query),
+		trace.WithSpanKind(s.cfg.SpanKind),
+		trace.WithAttributes(s.cfg.queryAttributes(ctx, s.query)...),
 	)
 	defer span.End()
+	s.cfg.recordArgs(span, args)
 
 	err := s.Stmt.GetContext(ctx, dest, args...)
+	err = s.cfg.translateNotFound(err)
 
-	s.cfg.Metrics.recordQueryDuration(
-		ctx,
-		time.Since(start),
-		operation,
-		s.cfg.baseAttributes(),
-		err,
-	)
+	s.cfg.recordQuery(ctx, time.Since(start), s.query, operation, err)
+	s.cfg.recordGetRows(ctx, span, operation, err)
 
 	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, err.Error())
+		recordError(span, err)
 	}
 
 	return err
@@ -52,25 +51,20 @@ func (s *Stmt) SelectContext(ctx context.Context, dest interface{}, args ...inte
 	start := time.Now()
 	operation := extractOperation(s.query)
 
-	ctx, span := s.cfg.Tracer.Start(ctx, sqlxSpanName("sqlx.Stmt.Select", s.query),
-		trace.WithSpanKind(trace.SpanKindClient),
-		trace.WithAttributes(s.cfg.queryAttributes(s.query)...),
+	ctx, span := s.cfg.Tracer.Start(ctx, s.cfg.sqlxSpanName("sqlx.Stmt.Select", s.query),
+		trace.WithSpanKind(s.cfg.SpanKind),
+		trace.WithAttributes(s.cfg.queryAttributes(ctx, s.query)...),
 	)
 	defer span.End()
+	s.cfg.recordArgs(span, args)
 
 	err := s.Stmt.SelectContext(ctx, dest, args...)
 
-	s.cfg.Metrics.recordQueryDuration(
-		ctx,
-		time.Since(start),
-		operation,
-		s.cfg.baseAttributes(),
-		err,
-	)
+	s.cfg.recordQuery(ctx, time.Since(start), s.query, operation, err)
+	s.cfg.recordSelectRows(ctx, span, operation, dest, err)
 
 	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, err.Error())
+		recordError(span, err)
 	}
 
 	return err
@@ -81,25 +75,19 @@ func (s *Stmt) ExecContext(ctx context.Context, args ...interface{}) (sql.Result
 	start := time.Now()
 	operation := extractOperation(s.query)
 
-	ctx, span := s.cfg.Tracer.Start(ctx, spanName(s.query),
-		trace.WithSpanKind(trace.SpanKindClient),
-		trace.WithAttributes(s.cfg.queryAttributes(s.query)...),
+	ctx, span := s.cfg.Tracer.Start(ctx, s.cfg.spanName(s.query),
+		trace.WithSpanKind(s.cfg.SpanKind),
+		trace.WithAttributes(s.cfg.queryAttributes(ctx, s.query)...),
 	)
 	defer span.End()
+	s.cfg.recordArgs(span, args)
 
 	result, err := s.Stmt.ExecContext(ctx, args...)
 
-	s.cfg.Metrics.recordQueryDuration(
-		ctx,
-		time.Since(start),
-		operation,
-		s.cfg.baseAttributes(),
-		err,
-	)
+	s.cfg.recordExec(ctx, span, time.Since(start), s.query, operation, result, err)
 
 	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, err.Error())
+		recordError(span, err)
 	}
 
 	return result, err
@@ -110,25 +98,19 @@ func (s *Stmt) QueryContext(ctx context.Context, args ...interface{}) (*sql.Rows
 	start := time.Now()
 	operation := extractOperation(s.query)
 
-	ctx, span := s.cfg.Tracer.Start(ctx, spanName(s.query),
-		trace.WithSpanKind(trace.SpanKindClient),
-		trace.WithAttributes(s.cfg.queryAttributes(s.query)...),
+	ctx, span := s.cfg.Tracer.Start(ctx, s.cfg.spanName(s.query),
+		trace.WithSpanKind(s.cfg.SpanKind),
+		trace.WithAttributes(s.cfg.queryAttributes(ctx, s.query)...),
 	)
 	defer span.End()
+	s.cfg.recordArgs(span, args)
 
 	rows, err := s.Stmt.QueryContext(ctx, args...)
 
-	s.cfg.Metrics.recordQueryDuration(
-		ctx,
-		time.Since(start),
-		operation,
-		s.cfg.baseAttributes(),
-		err,
-	)
+	s.cfg.recordQuery(ctx, time.Since(start), s.query, operation, err)
 
 	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, err.Error())
+		recordError(span, err)
 	}
 
 	return rows, err
@@ -139,21 +121,16 @@ func (s *Stmt) QueryRowContext(ctx context.Context, args ...interface{}) *sql.Ro
 	start := time.Now()
 	operation := extractOperation(s.query)
 
-	ctx, span := s.cfg.Tracer.Start(ctx, spanName(s.query),
-		trace.WithSpanKind(trace.SpanKindClient),
-		trace.WithAttributes(s.cfg.queryAttributes(s.query)...),
+	ctx, span := s.cfg.Tracer.Start(ctx, s.cfg.spanName(s.query),
+		trace.WithSpanKind(s.cfg.SpanKind),
+		trace.WithAttributes(s.cfg.queryAttributes(ctx, s.query)...),
 	)
 	defer span.End()
+	s.cfg.recordArgs(span, args)
 
 	row := s.Stmt.QueryRowContext(ctx, args...)
 
-	s.cfg.Metrics.recordQueryDuration(
-		ctx,
-		time.Since(start),
-		operation,
-		s.cfg.baseAttributes(),
-		nil,
-	)
+	s.cfg.recordQuery(ctx, time.Since(start), s.query, operation, nil)
 
 	return row
 }
@@ -163,25 +140,19 @@ func (s *Stmt) QueryxContext(ctx context.Context, args ...interface{}) (*sqlx.Ro
 	start := time.Now()
 	operation := extractOperation(s.query)
 
-	ctx, span := s.cfg.Tracer.Start(ctx, sqlxSpanName("sqlx.Stmt.Queryx", s.query),
-		trace.WithSpanKind(trace.SpanKindClient),
-		trace.WithAttributes(s.cfg.queryAttributes(s.query)...),
+	ctx, span := s.cfg.Tracer.Start(ctx, s.cfg.sqlxSpanName("sqlx.Stmt.Queryx", s.query),
+		trace.WithSpanKind(s.cfg.SpanKind),
+		trace.WithAttributes(s.cfg.queryAttributes(ctx, s.query)...),
 	)
 	defer span.End()
+	s.cfg.recordArgs(span, args)
 
 	rows, err := s.Stmt.QueryxContext(ctx, args...)
 
-	s.cfg.Metrics.recordQueryDuration(
-		ctx,
-		time.Since(start),
-		operation,
-		s.cfg.baseAttributes(),
-		err,
-	)
+	s.cfg.recordQuery(ctx, time.Since(start), s.query, operation, err)
 
 	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, err.Error())
+		recordError(span, err)
 	}
 
 	return rows, err
@@ -192,21 +163,16 @@ func (s *Stmt) QueryRowxContext(ctx context.Context, args ...interface{}) *sqlx.
 	start := time.Now()
 	operation := extractOperation(s.query)
 
-	ctx, span := s.cfg.Tracer.Start(ctx, sqlxSpanName("sqlx.Stmt.QueryRowx", s.query),
-		trace.WithSpanKind(trace.SpanKindClient),
-		trace.WithAttributes(s.cfg.queryAttributes(s.query)...),
+	ctx, span := s.cfg.Tracer.Start(ctx, s.cfg.sqlxSpanName("sqlx.Stmt.QueryRowx", s.query),
+		trace.WithSpanKind(s.cfg.SpanKind),
+		trace.WithAttributes(s.cfg.queryAttributes(ctx, s.query)...),
 	)
 	defer span.End()
+	s.cfg.recordArgs(span, args)
 
 	row := s.Stmt.QueryRowxContext(ctx, args...)
 
-	s.cfg.Metrics.recordQueryDuration(
-		ctx,
-		time.Since(start),
-		operation,
-		s.cfg.baseAttributes(),
-		nil,
-	)
+	s.cfg.recordQuery(ctx, time.Since(start), s.query, operation, nil)
 
 	return row
 }
@@ -221,6 +187,9 @@ func (s *Stmt) Unsafe() *Stmt {
 }
 
 // NamedStmt wraps *sqlx.NamedStmt with OpenTelemetry instrumentation.
+//
+// Like Stmt, execution spans honor the QuerySanitizer, DisableQuery and
+// ArgsCapture settings of the DB that prepared it.
 type NamedStmt struct {
 	*sqlx.NamedStmt
 	cfg   *config
@@ -232,25 +201,20 @@ func (ns *NamedStmt) GetContext(ctx context.Context, dest interface{}, arg inter
 	start := time.Now()
 	operation := extractOperation(ns.query)
 
-	ctx, span := ns.cfg.Tracer.Start(ctx, sqlxSpanName("sqlx.NamedStmt.Get", ns.query),
-		trace.WithSpanKind(trace.SpanKindClient),
-		trace.WithAttributes(ns.cfg.queryAttributes(ns.query)...),
+	ctx, span := ns.cfg.Tracer.Start(ctx, ns.cfg.sqlxSpanName("sqlx.NamedStmt.Get", ns.query),
+		trace.WithSpanKind(ns.cfg.SpanKind),
+		trace.WithAttributes(ns.cfg.queryAttributes(ctx, ns.query)...),
 	)
 	defer span.End()
 
 	err := ns.NamedStmt.GetContext(ctx, dest, arg)
+	err = ns.cfg.translateNotFound(err)
 
-	ns.cfg.Metrics.recordQueryDuration(
-		ctx,
-		time.Since(start),
-		operation,
-		ns.cfg.baseAttributes(),
-		err,
-	)
+	ns.cfg.recordQuery(ctx, time.Since(start), ns.query, operation, err)
+	ns.cfg.recordGetRows(ctx, span, operation, err)
 
 	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, err.Error())
+		recordError(span, err)
 	}
 
 	return err
@@ -261,25 +225,19 @@ func (ns *NamedStmt) SelectContext(ctx context.Context, dest interface{}, arg in
 	start := time.Now()
 	operation := extractOperation(ns.query)
 
-	ctx, span := ns.cfg.Tracer.Start(ctx, sqlxSpanName("sqlx.NamedStmt.Select", ns.query),
-		trace.WithSpanKind(trace.SpanKindClient),
-		trace.WithAttributes(ns.cfg.queryAttributes(ns.query)...),
+	ctx, span := ns.cfg.Tracer.Start(ctx, ns.cfg.sqlxSpanName("sqlx.NamedStmt.Select", ns.query),
+		trace.WithSpanKind(ns.cfg.SpanKind),
+		trace.WithAttributes(ns.cfg.queryAttributes(ctx, ns.query)...),
 	)
 	defer span.End()
 
 	err := ns.NamedStmt.SelectContext(ctx, dest, arg)
 
-	ns.cfg.Metrics.recordQueryDuration(
-		ctx,
-		time.Since(start),
-		operation,
-		ns.cfg.baseAttributes(),
-		err,
-	)
+	ns.cfg.recordQuery(ctx, time.Since(start), ns.query, operation, err)
+	ns.cfg.recordSelectRows(ctx, span, operation, dest, err)
 
 	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, err.Error())
+		recordError(span, err)
 	}
 
 	return err
@@ -290,25 +248,18 @@ func (ns *NamedStmt) ExecContext(ctx context.Context, arg interface{}) (sql.Resu
 	start := time.Now()
 	operation := extractOperation(ns.query)
 
-	ctx, span := ns.cfg.Tracer.Start(ctx, spanName(ns.query),
-		trace.WithSpanKind(trace.SpanKindClient),
-		trace.WithAttributes(ns.cfg.queryAttributes(ns.query)...),
+	ctx, span := ns.cfg.Tracer.Start(ctx, ns.cfg.spanName(ns.query),
+		trace.WithSpanKind(ns.cfg.SpanKind),
+		trace.WithAttributes(ns.cfg.queryAttributes(ctx, ns.query)...),
 	)
 	defer span.End()
 
 	result, err := ns.NamedStmt.ExecContext(ctx, arg)
 
-	ns.cfg.Metrics.recordQueryDuration(
-		ctx,
-		time.Since(start),
-		operation,
-		ns.cfg.baseAttributes(),
-		err,
-	)
+	ns.cfg.recordExec(ctx, span, time.Since(start), ns.query, operation, result, err)
 
 	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, err.Error())
+		recordError(span, err)
 	}
 
 	return result, err
@@ -319,25 +270,18 @@ func (ns *NamedStmt) QueryContext(ctx context.Context, arg interface{}) (*sql.Ro
 	start := time.Now()
 	operation := extractOperation(ns.query)
 
-	ctx, span := ns.cfg.Tracer.Start(ctx, spanName(ns.query),
-		trace.WithSpanKind(trace.SpanKindClient),
-		trace.WithAttributes(ns.cfg.queryAttributes(ns.query)...),
+	ctx, span := ns.cfg.Tracer.Start(ctx, ns.cfg.spanName(ns.query),
+		trace.WithSpanKind(ns.cfg.SpanKind),
+		trace.WithAttributes(ns.cfg.queryAttributes(ctx, ns.query)...),
 	)
 	defer span.End()
 
 	rows, err := ns.NamedStmt.QueryContext(ctx, arg)
 
-	ns.cfg.Metrics.recordQueryDuration(
-		ctx,
-		time.Since(start),
-		operation,
-		ns.cfg.baseAttributes(),
-		err,
-	)
+	ns.cfg.recordQuery(ctx, time.Since(start), ns.query, operation, err)
 
 	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, err.Error())
+		recordError(span, err)
 	}
 
 	return rows, err
@@ -348,21 +292,15 @@ func (ns *NamedStmt) QueryRowContext(ctx context.Context, arg interface{}) *sqlx
 	start := time.Now()
 	operation := extractOperation(ns.query)
 
-	ctx, span := ns.cfg.Tracer.Start(ctx, spanName(ns.query),
-		trace.WithSpanKind(trace.SpanKindClient),
-		trace.WithAttributes(ns.cfg.queryAttributes(ns.query)...),
+	ctx, span := ns.cfg.Tracer.Start(ctx, ns.cfg.spanName(ns.query),
+		trace.WithSpanKind(ns.cfg.SpanKind),
+		trace.WithAttributes(ns.cfg.queryAttributes(ctx, ns.query)...),
 	)
 	defer span.End()
 
 	row := ns.NamedStmt.QueryRowContext(ctx, arg)
 
-	ns.cfg.Metrics.recordQueryDuration(
-		ctx,
-		time.Since(start),
-		operation,
-		ns.cfg.baseAttributes(),
-		nil,
-	)
+	ns.cfg.recordQuery(ctx, time.Since(start), ns.query, operation, nil)
 
 	return row
 }
@@ -372,25 +310,18 @@ func (ns *NamedStmt) QueryxContext(ctx context.Context, arg interface{}) (*sqlx.
 	start := time.Now()
 	operation := extractOperation(ns.query)
 
-	ctx, span := ns.cfg.Tracer.Start(ctx, sqlxSpanName("sqlx.NamedStmt.Queryx", ns.query),
-		trace.WithSpanKind(trace.SpanKindClient),
-		trace.WithAttributes(ns.cfg.queryAttributes(ns.query)...),
+	ctx, span := ns.cfg.Tracer.Start(ctx, ns.cfg.sqlxSpanName("sqlx.NamedStmt.Queryx", ns.query),
+		trace.WithSpanKind(ns.cfg.SpanKind),
+		trace.WithAttributes(ns.cfg.queryAttributes(ctx, ns.query)...),
 	)
 	defer span.End()
 
 	rows, err := ns.NamedStmt.QueryxContext(ctx, arg)
 
-	ns.cfg.Metrics.recordQueryDuration(
-		ctx,
-		time.Since(start),
-		operation,
-		ns.cfg.baseAttributes(),
-		err,
-	)
+	ns.cfg.recordQuery(ctx, time.Since(start), ns.query, operation, err)
 
 	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, err.Error())
+		recordError(span, err)
 	}
 
 	return rows, err
@@ -401,21 +332,15 @@ func (ns *NamedStmt) QueryRowxContext(ctx context.Context, arg interface{}) *sql
 	start := time.Now()
 	operation := extractOperation(ns.query)
 
-	ctx, span := ns.cfg.Tracer.Start(ctx, sqlxSpanName("sqlx.NamedStmt.QueryRowx", ns.query),
-		trace.WithSpanKind(trace.SpanKindClient),
-		trace.WithAttributes(ns.cfg.queryAttributes(ns.query)...),
+	ctx, span := ns.cfg.Tracer.Start(ctx, ns.cfg.sqlxSpanName("sqlx.NamedStmt.QueryRowx", ns.query),
+		trace.WithSpanKind(ns.cfg.SpanKind),
+		trace.WithAttributes(ns.cfg.queryAttributes(ctx, ns.query)...),
 	)
 	defer span.End()
 
 	row := ns.NamedStmt.QueryRowxContext(ctx, arg)
 
-	ns.cfg.Metrics.recordQueryDuration(
-		ctx,
-		time.Since(start),
-		operation,
-		ns.cfg.baseAttributes(),
-		nil,
-	)
+	ns.cfg.recordQuery(ctx, time.Since(start), ns.query, operation, nil)
 
 	return row
 }
@@ -429,6 +354,72 @@ func (ns *NamedStmt) MustExecContext(ctx context.Context, arg interface{}) sql.R
 	return result
 }
 
+// ExecBatchContext executes the named statement once for each element of
+// args, traced as a single span. Each execution adds an "exec" event to the
+// span with its index and rows affected, and the total rows affected is
+// recorded as the db.rows_affected attribute.
+//
+// Executions run in order and stop at the first error, in which case the
+// results of the executions that succeeded are returned with the error.
+// Run the batch on a statement prepared within a Tx to make it atomic.
+//
+// Example:
+//
+//	stmt, _ := tx.PrepareNamedContext(ctx, "UPDATE users SET name = :name WHERE id = :id")
+//	results, err := stmt.ExecBatchContext(ctx, []any{
+//	    User{ID: 1, Name: "John"},
+//	    User{ID: 2, Name: "Jane"},
+//	})
+func (ns *NamedStmt) ExecBatchContext(ctx context.Context, args []any) ([]sql.Result, error) {
+	start := time.Now()
+	operation := extractOperation(ns.query)
+
+	attrs := append(ns.cfg.queryAttributes(ctx, ns.query),
+		attribute.Int("db.batch.rows", len(args)),
+	)
+	ctx, span := ns.cfg.Tracer.Start(ctx, ns.cfg.sqlxSpanName("sqlx.NamedStmt.ExecBatch", ns.query),
+		trace.WithSpanKind(ns.cfg.SpanKind),
+		trace.WithAttributes(attrs...),
+	)
+	defer span.End()
+
+	results := make([]sql.Result, 0, len(args))
+	var total int64
+	var err error
+	for i, arg := range args {
+		var result sql.Result
+		result, err = ns.NamedStmt.ExecContext(ctx, arg)
+		if err != nil {
+			span.AddEvent("exec", trace.WithAttributes(attribute.Int("db.batch.index", i)))
+			break
+		}
+		results = append(results, result)
+
+		eventAttrs := []attribute.KeyValue{attribute.Int("db.batch.index", i)}
+		if n, rowsErr := result.RowsAffected(); rowsErr == nil {
+			total += n
+			eventAttrs = append(eventAttrs, attribute.Int64("db.rows_affected", n))
+		}
+		span.AddEvent("exec", trace.WithAttributes(eventAttrs...))
+	}
+
+	duration := time.Since(start)
+	ns.cfg.observeQuery(ctx, duration, ns.query, operation, err)
+	ns.cfg.runQueryHook(ctx, duration, ns.query, operation, total, err)
+
+	span.SetAttributes(attribute.Int64("db.rows_affected", total))
+	if ns.cfg.RowMetrics {
+		ns.cfg.Metrics.recordRows(ctx, ns.cfg.Metrics.rowsAffected, total, operation,
+			ns.cfg.baseAttributes())
+	}
+
+	if err != nil {
+		recordError(span, err)
+	}
+
+	return results, err
+}
+
 // Unsafe returns a version of NamedStmt that silently ignores missing fields.
 func (ns *NamedStmt) Unsafe() *NamedStmt {
 	return &NamedStmt{
@@ -8,6 +8,9 @@ import (
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 )
 
 func TestStmt_GetContext(t *testing.T) {
@@ -450,3 +453,175 @@ func TestDB_Preparex(t *testing.T) {
 	require.NotNil(t, stmt)
 	require.NoError(t, mock.ExpectationsWereMet())
 }
+
+func TestNamedStmt_ExecBatchContext(t *testing.T) {
+	type user struct {
+		ID   int    `db:"id"`
+		Name string `db:"name"`
+	}
+	args := []any{user{ID: 1, Name: "John"}, user{ID: 2, Name: "Jane"}, user{ID: 3, Name: "Joe"}}
+
+	tests := []struct {
+		name        string
+		mockFn      func(prep *sqlmock.ExpectedPrepare)
+		wantErr     assert.ErrorAssertionFunc
+		wantResults int
+		wantTotal   int64
+		wantEvents  int
+	}{
+		{
+			name: "given all executions succeed, then records total rows affected",
+			mockFn: func(prep *sqlmock.ExpectedPrepare) {
+				prep.ExpectExec().WithArgs("John", 1).WillReturnResult(sqlmock.NewResult(0, 1))
+				prep.ExpectExec().WithArgs("Jane", 2).WillReturnResult(sqlmock.NewResult(0, 1))
+				prep.ExpectExec().WithArgs("Joe", 3).WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErr:     assert.NoError,
+			wantResults: 3,
+			wantTotal:   2,
+			wantEvents:  3,
+		},
+		{
+			name: "given an execution fails, then stops and returns earlier results",
+			mockFn: func(prep *sqlmock.ExpectedPrepare) {
+				prep.ExpectExec().WithArgs("John", 1).WillReturnResult(sqlmock.NewResult(0, 1))
+				prep.ExpectExec().WithArgs("Jane", 2).WillReturnError(assert.AnError)
+			},
+			wantErr:     assert.Error,
+			wantResults: 1,
+			wantTotal:   1,
+			// Two exec events plus the exception event from RecordError.
+			wantEvents: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer mockDB.Close()
+
+			exporter := tracetest.NewInMemoryExporter()
+			tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+			db := NewDB(mockDB, "postgres", WithTracerProvider(tp))
+
+			tt.mockFn(mock.ExpectPrepare("UPDATE users SET name"))
+
+			stmt, err := db.PrepareNamedContext(context.Background(),
+				"UPDATE users SET name = :name WHERE id = :id")
+			require.NoError(t, err)
+			exporter.Reset()
+
+			results, err := stmt.ExecBatchContext(context.Background(), args)
+			tt.wantErr(t, err)
+			assert.Len(t, results, tt.wantResults)
+			require.NoError(t, mock.ExpectationsWereMet())
+
+			spans := exporter.GetSpans()
+			require.Len(t, spans, 1)
+			assert.Equal(t, "sqlx.NamedStmt.ExecBatch: UPDATE", spans[0].Name)
+			assert.Len(t, spans[0].Events, tt.wantEvents)
+
+			set := attribute.NewSet(spans[0].Attributes...)
+			total, ok := set.Value("db.rows_affected")
+			require.True(t, ok)
+			assert.Equal(t, tt.wantTotal, total.AsInt64())
+			batchRows, ok := set.Value("db.batch.rows")
+			require.True(t, ok)
+			assert.Equal(t, int64(len(args)), batchRows.AsInt64())
+		})
+	}
+}
+
+func TestStmt_QuerySanitizer(t *testing.T) {
+	const (
+		selectQuery = "SELECT id FROM users WHERE status = 'active' AND id = ?"
+		updateQuery = "UPDATE users SET status = 'inactive' WHERE id = ?"
+		namedQuery  = "UPDATE users SET status = 'inactive' WHERE id = :id"
+	)
+
+	type user struct {
+		ID int `db:"id"`
+	}
+
+	tests := []struct {
+		name          string
+		opts          []Option
+		wantStatement []string
+	}{
+		{
+			name: "given query sanitizer, then every execution span has sanitized statement",
+			opts: []Option{WithQuerySanitizer(DefaultQuerySanitizer)},
+			wantStatement: []string{
+				"SELECT id FROM users WHERE status = '?' AND id = ?",
+				"UPDATE users SET status = '?' WHERE id = ?",
+				"UPDATE users SET status = '?' WHERE id = :id",
+			},
+		},
+		{
+			name: "given disabled query, then no execution span has statement",
+			opts: []Option{WithDisableQuery(), WithArgsCapture(ArgsFull)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			require.NoError(t, err)
+			defer mockDB.Close()
+
+			exporter := tracetest.NewInMemoryExporter()
+			tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+			db := NewDB(mockDB, "postgres", append(tt.opts, WithTracerProvider(tp))...)
+			ctx := context.Background()
+
+			// Statements are prepared up front and executed afterwards.
+			mock.MatchExpectationsInOrder(false)
+			prepSelect := mock.ExpectPrepare(selectQuery)
+			prepSelect.ExpectQuery().WithArgs(1).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+			prepSelect.ExpectQuery().WithArgs(1).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+			prepUpdate := mock.ExpectPrepare(updateQuery)
+			prepUpdate.ExpectExec().WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+			prepNamed := mock.ExpectPrepare("UPDATE users SET status = 'inactive' WHERE id = $1")
+			prepNamed.ExpectExec().WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+			prepNamed.ExpectExec().WithArgs(2).WillReturnResult(sqlmock.NewResult(0, 1))
+
+			selectStmt, err := db.PreparexContext(ctx, selectQuery)
+			require.NoError(t, err)
+			updateStmt, err := db.PreparexContext(ctx, updateQuery)
+			require.NoError(t, err)
+			namedStmt, err := db.PrepareNamedContext(ctx, namedQuery)
+			require.NoError(t, err)
+			exporter.Reset()
+
+			var got user
+			require.NoError(t, selectStmt.GetContext(ctx, &got, 1))
+			var all []user
+			require.NoError(t, selectStmt.SelectContext(ctx, &all, 1))
+			_, err = updateStmt.ExecContext(ctx, 1)
+			require.NoError(t, err)
+			_, err = namedStmt.ExecContext(ctx, user{ID: 1})
+			require.NoError(t, err)
+			_, err = namedStmt.ExecBatchContext(ctx, []any{user{ID: 2}})
+			require.NoError(t, err)
+			require.NoError(t, mock.ExpectationsWereMet())
+
+			spans := exporter.GetSpans()
+			require.Len(t, spans, 5)
+			for _, span := range spans {
+				set := attribute.NewSet(span.Attributes...)
+				statement, ok := set.Value("db.statement")
+				if tt.wantStatement == nil {
+					assert.False(t, ok, "span %q should not record the statement", span.Name)
+					assert.False(t, set.HasValue("db.statement.args"),
+						"span %q should not record args", span.Name)
+					continue
+				}
+				require.True(t, ok, "span %q should record the statement", span.Name)
+				assert.Contains(t, tt.wantStatement, statement.AsString(), span.Name)
+			}
+		})
+	}
+}
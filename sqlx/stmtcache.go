@@ -0,0 +1,168 @@
+package sqlx
+
+import (
+	"container/list"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"sync"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// stmtKey identifies a cached statement. Statements are prepared on a
+// specific pool, so the primary and each replica get their own entries.
+type stmtKey struct {
+	db    *sqlx.DB
+	query string
+}
+
+// cachedStmt is a prepared statement held by the cache.
+type cachedStmt struct {
+	key  stmtKey
+	stmt *sql.Stmt
+
+	// refs counts callers currently using stmt; an evicted statement is
+	// closed once the last of them releases it.
+	refs    int
+	evicted bool
+}
+
+// stmtCache is a concurrency-safe LRU cache of prepared statements.
+type stmtCache struct {
+	mu      sync.Mutex
+	size    int
+	lru     *list.List
+	entries map[stmtKey]*list.Element
+}
+
+// newStmtCache creates a cache holding at most size statements.
+func newStmtCache(size int) *stmtCache {
+	return &stmtCache{
+		size:    size,
+		lru:     list.New(),
+		entries: make(map[stmtKey]*list.Element, size),
+	}
+}
+
+// do runs fn with a cached statement for query on db, preparing it on a
+// miss. If fn fails with driver.ErrBadConn the statement is dropped,
+// re-prepared, and fn is retried once.
+func (c *stmtCache) do(
+	ctx context.Context,
+	cfg *config,
+	db *sqlx.DB,
+	query string,
+	fn func(stmt *sql.Stmt) error,
+) error {
+	key := stmtKey{db: db, query: query}
+
+	s, hit, err := c.acquire(ctx, key)
+	cfg.Metrics.recordStmtCache(ctx, hit, cfg.baseAttributes())
+	if err != nil {
+		return err
+	}
+
+	err = fn(s.stmt)
+	if !errors.Is(err, driver.ErrBadConn) {
+		c.release(s)
+		return err
+	}
+
+	c.invalidate(s)
+	s, _, err = c.acquire(ctx, key)
+	if err != nil {
+		return err
+	}
+	defer c.release(s)
+
+	return fn(s.stmt)
+}
+
+// acquire returns the cached statement for key, preparing and caching it on
+// a miss. The caller must release the statement when done.
+func (c *stmtCache) acquire(ctx context.Context, key stmtKey) (*cachedStmt, bool, error) {
+	c.mu.Lock()
+	if el, ok := c.entries[key]; ok {
+		c.lru.MoveToFront(el)
+		s := el.Value.(*cachedStmt)
+		s.refs++
+		c.mu.Unlock()
+		return s, true, nil
+	}
+	c.mu.Unlock()
+
+	// Prepare outside the lock so a slow prepare does not block hits.
+	stmt, err := key.db.PrepareContext(ctx, key.query)
+	if err != nil {
+		return nil, false, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Another caller may have prepared the same query concurrently.
+	if el, ok := c.entries[key]; ok {
+		_ = stmt.Close()
+		c.lru.MoveToFront(el)
+		s := el.Value.(*cachedStmt)
+		s.refs++
+		return s, false, nil
+	}
+
+	s := &cachedStmt{key: key, stmt: stmt, refs: 1}
+	c.entries[key] = c.lru.PushFront(s)
+
+	for c.lru.Len() > c.size {
+		c.remove(c.lru.Back())
+	}
+
+	return s, false, nil
+}
+
+// release returns a statement obtained from acquire.
+func (c *stmtCache) release(s *cachedStmt) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	s.refs--
+	if s.evicted && s.refs == 0 {
+		_ = s.stmt.Close()
+	}
+}
+
+// invalidate drops s from the cache and releases it.
+func (c *stmtCache) invalidate(s *cachedStmt) {
+	c.mu.Lock()
+	if el, ok := c.entries[s.key]; ok && el.Value == s {
+		c.lru.Remove(el)
+		delete(c.entries, s.key)
+		s.evicted = true
+	}
+	c.mu.Unlock()
+
+	c.release(s)
+}
+
+// remove evicts el, closing its statement unless it is still in use.
+// The caller must hold c.mu.
+func (c *stmtCache) remove(el *list.Element) {
+	s := el.Value.(*cachedStmt)
+	c.lru.Remove(el)
+	delete(c.entries, s.key)
+	s.evicted = true
+	if s.refs == 0 {
+		_ = s.stmt.Close()
+	}
+}
+
+// close evicts all statements.
+func (c *stmtCache) close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for c.lru.Len() > 0 {
+		c.remove(c.lru.Back())
+	}
+}
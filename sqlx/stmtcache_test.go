@@ -0,0 +1,197 @@
+package sqlx
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"sync"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// stmtCacheLookups returns the db.client.stmt_cache counts keyed by result.
+func stmtCacheLookups(t *testing.T, reader *sdkmetric.ManualReader) map[string]int64 {
+	t.Helper()
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+
+	lookups := map[string]int64{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != "db.client.stmt_cache" {
+				continue
+			}
+			sum, ok := m.Data.(metricdata.Sum[int64])
+			require.True(t, ok)
+			for _, dp := range sum.DataPoints {
+				result, _ := dp.Attributes.Value("result")
+				lookups[result.AsString()] += dp.Value
+			}
+		}
+	}
+	return lookups
+}
+
+func TestDB_StatementCache(t *testing.T) {
+	const (
+		queryA = "UPDATE a SET n = 1"
+		queryB = "UPDATE b SET n = 1"
+	)
+
+	tests := []struct {
+		name        string
+		size        int
+		queries     []string
+		wantPrepare []bool
+		wantLookups map[string]int64
+	}{
+		{
+			name:        "given repeated query, then prepares once and reuses",
+			size:        10,
+			queries:     []string{queryA, queryA, queryA},
+			wantPrepare: []bool{true, false, false},
+			wantLookups: map[string]int64{"miss": 1, "hit": 2},
+		},
+		{
+			name:        "given distinct queries within size, then caches each",
+			size:        2,
+			queries:     []string{queryA, queryB, queryA},
+			wantPrepare: []bool{true, true, false},
+			wantLookups: map[string]int64{"miss": 2, "hit": 1},
+		},
+		{
+			name:        "given more queries than size, then evicts least recently used",
+			size:        1,
+			queries:     []string{queryA, queryB, queryA},
+			wantPrepare: []bool{true, true, true},
+			wantLookups: map[string]int64{"miss": 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			require.NoError(t, err)
+			defer mockDB.Close()
+
+			reader := sdkmetric.NewManualReader()
+			mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+			defer mp.Shutdown(context.Background())
+
+			db := NewDB(mockDB, "postgres", WithMeterProvider(mp), WithStatementCache(tt.size))
+
+			prepared := map[string]*sqlmock.ExpectedPrepare{}
+			for i, query := range tt.queries {
+				if tt.wantPrepare[i] {
+					prepared[query] = mock.ExpectPrepare(query)
+				}
+				prepared[query].ExpectExec().WillReturnResult(sqlmock.NewResult(0, 1))
+			}
+
+			for _, query := range tt.queries {
+				_, err := db.ExecContext(context.Background(), query)
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+			assert.Equal(t, tt.wantLookups, stmtCacheLookups(t, reader))
+		})
+	}
+}
+
+func TestDB_StatementCache_Query(t *testing.T) {
+	t.Run("given repeated QueryContext, then reuses prepared statement", func(t *testing.T) {
+		mockDB, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer mockDB.Close()
+
+		db := NewDB(mockDB, "postgres", WithStatementCache(10))
+
+		prep := mock.ExpectPrepare("SELECT id FROM users")
+		for range 2 {
+			prep.ExpectQuery().WithArgs(1).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+		}
+
+		for range 2 {
+			rows, err := db.QueryContext(context.Background(),
+				"SELECT id FROM users WHERE id = $1", 1)
+			require.NoError(t, err)
+			require.True(t, rows.Next())
+			require.NoError(t, rows.Close())
+		}
+
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestStmtCache_BadConn(t *testing.T) {
+	t.Run("given ErrBadConn, then re-prepares and retries once", func(t *testing.T) {
+		mockDB, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer mockDB.Close()
+
+		db := NewDB(mockDB, "postgres", WithStatementCache(10))
+
+		mock.ExpectPrepare("UPDATE users").WillBeClosed()
+		mock.ExpectPrepare("UPDATE users")
+
+		calls := 0
+		err = db.stmts.do(context.Background(), db.cfg, db.DB, "UPDATE users SET n = 1",
+			func(_ *sql.Stmt) error {
+				calls++
+				if calls == 1 {
+					return driver.ErrBadConn
+				}
+				return nil
+			},
+		)
+		require.NoError(t, err)
+		assert.Equal(t, 2, calls)
+		require.NoError(t, mock.ExpectationsWereMet())
+
+		// The re-prepared statement replaces the invalidated one.
+		assert.Equal(t, 1, db.stmts.lru.Len())
+	})
+}
+
+func TestStmtCache_Concurrent(t *testing.T) {
+	t.Run("given concurrent lookups, then cache stays consistent", func(t *testing.T) {
+		mockDB, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer mockDB.Close()
+		mock.MatchExpectationsInOrder(false)
+
+		db := NewDB(mockDB, "postgres", WithStatementCache(1))
+		for range 100 {
+			mock.ExpectPrepare("SELECT")
+		}
+
+		queries := []string{"SELECT 1", "SELECT 2"}
+		var wg sync.WaitGroup
+		for i := range 20 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_ = db.stmts.do(context.Background(), db.cfg, db.DB, queries[i%2],
+					func(stmt *sql.Stmt) error {
+						assert.NotNil(t, stmt)
+						return nil
+					},
+				)
+			}()
+		}
+		wg.Wait()
+
+		db.stmts.mu.Lock()
+		defer db.stmts.mu.Unlock()
+		assert.LessOrEqual(t, db.stmts.lru.Len(), 1)
+		assert.Len(t, db.stmts.entries, db.stmts.lru.Len())
+	})
+}
@@ -0,0 +1,70 @@
+package sqlx
+
+import (
+	"context"
+	"maps"
+	"slices"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// tagsKey is the context key for query tags.
+type tagsKey struct{}
+
+// ContextWithTags returns a context carrying tags that are added as
+// attributes to every span started with it, so business context such as a
+// tenant can be stamped once, e.g. in an HTTP middleware, instead of being
+// passed to each query.
+//
+// Tags already on ctx are kept; tags passed here override them by key.
+// Use WithContextTags to restrict which keys are recorded.
+//
+// Example:
+//
+//	func tenantMiddleware(next http.Handler) http.Handler {
+//	    return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+//	        ctx := sentinelsqlx.ContextWithTags(r.Context(), map[string]string{
+//	            "tenant.id": r.Header.Get("X-Tenant-ID"),
+//	        })
+//	        next.ServeHTTP(w, r.WithContext(ctx))
+//	    })
+//	}
+func ContextWithTags(ctx context.Context, tags map[string]string) context.Context {
+	merged := maps.Clone(TagsFromContext(ctx))
+	if merged == nil {
+		merged = make(map[string]string, len(tags))
+	}
+	maps.Copy(merged, tags)
+
+	return context.WithValue(ctx, tagsKey{}, merged)
+}
+
+// TagsFromContext returns the tags attached with ContextWithTags, or nil.
+// The returned map must not be modified.
+func TagsFromContext(ctx context.Context) map[string]string {
+	tags, _ := ctx.Value(tagsKey{}).(map[string]string)
+	return tags
+}
+
+// tagAttributes returns the context tags as span attributes. When an
+// allowlist is configured only its keys are returned, in allowlist order;
+// otherwise all tags are returned sorted by key.
+func (cfg *config) tagAttributes(ctx context.Context) []attribute.KeyValue {
+	tags := TagsFromContext(ctx)
+	if len(tags) == 0 {
+		return nil
+	}
+
+	keys := cfg.ContextTags
+	if keys == nil {
+		keys = slices.Sorted(maps.Keys(tags))
+	}
+
+	attrs := make([]attribute.KeyValue, 0, len(keys))
+	for _, key := range keys {
+		if value, ok := tags[key]; ok {
+			attrs = append(attrs, attribute.String(key, value))
+		}
+	}
+	return attrs
+}
@@ -0,0 +1,89 @@
+package sqlx
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestContextWithTags(t *testing.T) {
+	tests := []struct {
+		name string
+		ctx  func() context.Context
+		want map[string]string
+	}{
+		{
+			name: "given no tags, then returns nil",
+			ctx:  context.Background,
+			want: nil,
+		},
+		{
+			name: "given tags, then returns them",
+			ctx: func() context.Context {
+				return ContextWithTags(context.Background(), map[string]string{"tenant.id": "acme"})
+			},
+			want: map[string]string{"tenant.id": "acme"},
+		},
+		{
+			name: "given nested tags, then merges and overrides by key",
+			ctx: func() context.Context {
+				ctx := ContextWithTags(context.Background(), map[string]string{
+					"tenant.id":  "acme",
+					"request.id": "req-1",
+				})
+				return ContextWithTags(ctx, map[string]string{"request.id": "req-2"})
+			},
+			want: map[string]string{"tenant.id": "acme", "request.id": "req-2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, TagsFromContext(tt.ctx()))
+		})
+	}
+}
+
+func TestDB_ContextTags(t *testing.T) {
+	t.Run("given tagged context, then query and BEGIN spans carry tags", func(t *testing.T) {
+		mockDB, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer mockDB.Close()
+
+		exporter := tracetest.NewInMemoryExporter()
+		tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+		db := NewDB(mockDB, "postgres",
+			WithTracerProvider(tp),
+			WithContextTags("tenant.id"),
+		)
+
+		mock.ExpectQuery("SELECT 1").WillReturnRows(sqlmock.NewRows([]string{"n"}).AddRow(1))
+		mock.ExpectBegin()
+
+		ctx := ContextWithTags(context.Background(), map[string]string{
+			"tenant.id": "acme",
+			"user.id":   "42",
+		})
+
+		var n int
+		require.NoError(t, db.GetContext(ctx, &n, "SELECT 1"))
+		_, err = db.BeginTxx(ctx, nil)
+		require.NoError(t, err)
+
+		spans := exporter.GetSpans()
+		require.Len(t, spans, 2)
+		for _, span := range spans {
+			attrs := attribute.NewSet(span.Attributes...)
+			tenant, ok := attrs.Value("tenant.id")
+			assert.True(t, ok, span.Name)
+			assert.Equal(t, "acme", tenant.AsString())
+			assert.False(t, attrs.HasValue("user.id"), span.Name)
+		}
+	})
+}
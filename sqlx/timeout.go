@@ -0,0 +1,160 @@
+package sqlx
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"runtime"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// queryTimeoutKey is the context key for a per-call query timeout.
+type queryTimeoutKey struct{}
+
+// ContextWithQueryTimeout overrides the WithQueryTimeout default for queries
+// made with the returned context. A zero or negative timeout disables the
+// default, leaving only the deadline already on ctx, if any.
+//
+// Example:
+//
+//	// Allow the nightly report more time than the 2s default.
+//	ctx = sentinelsqlx.ContextWithQueryTimeout(ctx, 30*time.Second)
+//	rows, err := db.QueryContext(ctx, reportQuery)
+func ContextWithQueryTimeout(ctx context.Context, timeout time.Duration) context.Context {
+	return context.WithValue(ctx, queryTimeoutKey{}, timeout)
+}
+
+// withQueryTimeout derives a context bounded by the query timeout from ctx.
+// The returned timeout is zero when no timeout applies, including when ctx
+// already has an earlier deadline: that deadline is the caller's, so it is
+// not reported as a query timeout.
+func (cfg *config) withQueryTimeout(
+	ctx context.Context,
+) (context.Context, context.CancelFunc, time.Duration) {
+	timeout := cfg.QueryTimeout
+	if override, ok := ctx.Value(queryTimeoutKey{}).(time.Duration); ok {
+		timeout = override
+	}
+	if timeout <= 0 {
+		return ctx, func() {}, 0
+	}
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) <= timeout {
+		return ctx, func() {}, 0
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	return ctx, cancel, timeout
+}
+
+// releaseWithRows ties the query timeout of a successful QueryContext to
+// rows. *sql.Rows has no close hook, so cancel runs once the rows have been
+// closed and garbage collected rather than when the timeout expires.
+func releaseWithRows(rows *sql.Rows, cancel context.CancelFunc, timeout time.Duration) {
+	if timeout <= 0 {
+		return
+	}
+	runtime.AddCleanup(rows, func(cancel context.CancelFunc) { cancel() }, cancel)
+}
+
+// recordTimeout counts a query that failed because ctx, derived by
+// withQueryTimeout, ran out of time, and marks its span with a clear reason.
+// It must be called after any other status is set on span.
+func (cfg *config) recordTimeout(
+	ctx context.Context,
+	span trace.Span,
+	timeout time.Duration,
+	operation string,
+	err error,
+) {
+	if timeout <= 0 || err == nil || !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return
+	}
+
+	cfg.Metrics.recordQueryTimeout(ctx, operation, cfg.metricAttributes(ctx))
+	span.SetStatus(codes.Error, fmt.Sprintf("query timed out after %s", timeout))
+}
+
+// postgresDrivers lists the driver names of PostgreSQL drivers.
+var postgresDrivers = map[string]bool{
+	"postgres": true, // github.com/lib/pq
+	"pgx":      true, // github.com/jackc/pgx/v4/stdlib and v5/stdlib
+	"pgx/v5":   true, // github.com/jackc/pgx/v5/stdlib
+}
+
+// WithStatementTimeout sets the PostgreSQL statement_timeout for the rest of
+// the transaction with SET LOCAL, traced as its own span.
+//
+// The server cancels any statement of the transaction that runs longer than
+// d, even if the client's context cancellation never reaches it, such as
+// when a connection pooler sits in between. A zero or negative d disables
+// the timeout. d is rounded up to whole milliseconds.
+//
+// SET LOCAL only lasts until the transaction commits or rolls back, so the
+// timeout never leaks to other users of the pooled connection. Outside a
+// transaction block PostgreSQL ignores it, which is why this is only
+// available on Tx.
+//
+// Only PostgreSQL is supported: the driver name must be "postgres", "pgx" or
+// "pgx/v5", or the DB must be configured with WithDBSystem("postgresql").
+// Other databases get an error matching ErrUnsupported.
+//
+// Example:
+//
+//	tx, err := db.BeginTxx(ctx, nil)
+//	if err != nil {
+//	    return err
+//	}
+//	defer tx.Rollback()
+//
+//	if err := tx.WithStatementTimeout(ctx, 5*time.Second); err != nil {
+//	    return err
+//	}
+//	// Statements below are killed by the server after 5s
+//	rows, err := tx.QueryContext(ctx, reportQuery)
+func (tx *Tx) WithStatementTimeout(ctx context.Context, d time.Duration) error {
+	if !postgresDrivers[tx.DriverName()] && tx.cfg.DBSystem != "postgresql" {
+		return fmt.Errorf("%w: SET LOCAL statement_timeout with %q",
+			ErrUnsupported, tx.DriverName())
+	}
+
+	var ms int64
+	if d > 0 {
+		ms = int64((d + time.Millisecond - 1) / time.Millisecond)
+	}
+	query := fmt.Sprintf("SET LOCAL statement_timeout = %d", ms)
+
+	start := time.Now()
+	operation := extractOperation(query)
+
+	attrs := append(tx.cfg.queryAttributes(ctx, query),
+		attribute.Int64("db.statement_timeout_ms", ms),
+	)
+	ctx, span := tx.cfg.Tracer.Start(ctx, tx.cfg.spanName(query),
+		trace.WithSpanKind(tx.cfg.SpanKind),
+		trace.WithAttributes(attrs...),
+		trace.WithAttributes(txAttributes(tx.begin)...),
+		trace.WithLinks(txLinks(tx.begin)...),
+	)
+	defer span.End()
+
+	_, err := tx.Tx.ExecContext(ctx, query)
+
+	tx.cfg.Metrics.recordQueryDuration(
+		ctx,
+		time.Since(start),
+		operation,
+		tx.cfg.baseAttributes(),
+		err,
+	)
+
+	if err != nil {
+		recordError(span, err)
+	}
+
+	return err
+}
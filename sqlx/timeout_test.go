@@ -0,0 +1,222 @@
+package sqlx
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestDB_QueryTimeout(t *testing.T) {
+	tests := []struct {
+		name         string
+		ctx          func(ctx context.Context) context.Context
+		delay        time.Duration
+		call         func(ctx context.Context, db *DB) error
+		mockFn       func(mock sqlmock.Sqlmock, delay time.Duration)
+		wantErr      assert.ErrorAssertionFunc
+		wantTimeouts int64
+		wantStatus   string
+	}{
+		{
+			name:  "given Exec exceeding timeout, then marks span and counts timeout",
+			delay: time.Second,
+			call: func(ctx context.Context, db *DB) error {
+				_, err := db.ExecContext(ctx, "UPDATE users SET name = $1", "John")
+				return err
+			},
+			mockFn: func(mock sqlmock.Sqlmock, delay time.Duration) {
+				mock.ExpectExec("UPDATE users").WillDelayFor(delay).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantErr:      assert.Error,
+			wantTimeouts: 1,
+			wantStatus:   "query timed out after 10ms",
+		},
+		{
+			name:  "given Select exceeding timeout, then marks span and counts timeout",
+			delay: time.Second,
+			call: func(ctx context.Context, db *DB) error {
+				var ids []int
+				return db.SelectContext(ctx, &ids, "SELECT id FROM users")
+			},
+			mockFn: func(mock sqlmock.Sqlmock, delay time.Duration) {
+				mock.ExpectQuery("SELECT id FROM users").WillDelayFor(delay).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+			},
+			wantErr:      assert.Error,
+			wantTimeouts: 1,
+			wantStatus:   "query timed out after 10ms",
+		},
+		{
+			name: "given per-call override, then query outlives the default",
+			ctx: func(ctx context.Context) context.Context {
+				return ContextWithQueryTimeout(ctx, time.Second)
+			},
+			delay: 30 * time.Millisecond,
+			call: func(ctx context.Context, db *DB) error {
+				var id int
+				return db.GetContext(ctx, &id, "SELECT id FROM users LIMIT 1")
+			},
+			mockFn: func(mock sqlmock.Sqlmock, delay time.Duration) {
+				mock.ExpectQuery("SELECT id FROM users").WillDelayFor(delay).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "given Query within timeout, then rows stay readable",
+			call: func(ctx context.Context, db *DB) error {
+				rows, err := db.QueryContext(ctx, "SELECT id FROM users")
+				if err != nil {
+					return err
+				}
+				defer rows.Close()
+				for rows.Next() {
+				}
+				return rows.Err()
+			},
+			mockFn: func(mock sqlmock.Sqlmock, _ time.Duration) {
+				mock.ExpectQuery("SELECT id FROM users").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+			},
+			wantErr: assert.NoError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer mockDB.Close()
+
+			exporter := tracetest.NewInMemoryExporter()
+			tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+			reader := sdkmetric.NewManualReader()
+			mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+			defer mp.Shutdown(context.Background())
+
+			db := NewDB(mockDB, "postgres",
+				WithTracerProvider(tp),
+				WithMeterProvider(mp),
+				WithQueryTimeout(10*time.Millisecond),
+			)
+			tt.mockFn(mock, tt.delay)
+
+			ctx := context.Background()
+			if tt.ctx != nil {
+				ctx = tt.ctx(ctx)
+			}
+			tt.wantErr(t, tt.call(ctx, db))
+
+			var rm metricdata.ResourceMetrics
+			require.NoError(t, reader.Collect(context.Background(), &rm))
+			var timeouts int64
+			for _, sm := range rm.ScopeMetrics {
+				for _, m := range sm.Metrics {
+					if m.Name != "db.client.query.timeout" {
+						continue
+					}
+					sum, ok := m.Data.(metricdata.Sum[int64])
+					require.True(t, ok)
+					for _, dp := range sum.DataPoints {
+						timeouts += dp.Value
+					}
+				}
+			}
+			assert.Equal(t, tt.wantTimeouts, timeouts)
+
+			if tt.wantStatus != "" {
+				spans := exporter.GetSpans()
+				require.Len(t, spans, 1)
+				assert.Equal(t, codes.Error, spans[0].Status.Code)
+				assert.Equal(t, tt.wantStatus, spans[0].Status.Description)
+			}
+		})
+	}
+}
+
+func TestTx_QueryTimeout(t *testing.T) {
+	t.Run("given Tx Exec exceeding timeout, then returns error", func(t *testing.T) {
+		mockDB, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer mockDB.Close()
+
+		db := NewDB(mockDB, "postgres", WithQueryTimeout(10*time.Millisecond))
+
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE users").WillDelayFor(time.Second).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		tx, err := db.BeginTxx(context.Background(), nil)
+		require.NoError(t, err)
+
+		_, err = tx.ExecContext(context.Background(), "UPDATE users SET name = $1", "John")
+		require.Error(t, err)
+	})
+}
+
+func TestTx_WithStatementTimeout(t *testing.T) {
+	tests := []struct {
+		name       string
+		driverName string
+		timeout    time.Duration
+		wantSQL    string
+		wantErr    error
+	}{
+		{
+			name:       "given postgres, then sets local statement timeout",
+			driverName: "postgres",
+			timeout:    5 * time.Second,
+			wantSQL:    "SET LOCAL statement_timeout = 5000",
+		},
+		{
+			name:       "given pgx and sub-millisecond timeout, then rounds up",
+			driverName: "pgx",
+			timeout:    1500 * time.Microsecond,
+			wantSQL:    "SET LOCAL statement_timeout = 2",
+		},
+		{
+			name:       "given zero timeout, then disables the timeout",
+			driverName: "postgres",
+			wantSQL:    "SET LOCAL statement_timeout = 0",
+		},
+		{
+			name:       "given mysql, then returns unsupported",
+			driverName: "mysql",
+			timeout:    time.Second,
+			wantErr:    errors.ErrUnsupported,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx, mock, exporter := newSavepointTx(t, tt.driverName)
+			if tt.wantSQL != "" {
+				mock.ExpectExec(tt.wantSQL).WillReturnResult(sqlmock.NewResult(0, 0))
+			}
+
+			err := tx.WithStatementTimeout(context.Background(), tt.timeout)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				assert.Empty(t, exporter.GetSpans())
+				return
+			}
+			require.NoError(t, err)
+			require.NoError(t, mock.ExpectationsWereMet())
+
+			spans := exporter.GetSpans()
+			require.Len(t, spans, 1)
+			assert.Equal(t, "SET", spans[0].Name)
+		})
+	}
+}
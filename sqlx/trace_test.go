@@ -1,10 +1,12 @@
 package sqlx
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 func TestSpanName(t *testing.T) {
@@ -61,7 +63,7 @@ func TestSpanName(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := spanName(tt.args.query)
+			got := (&config{}).spanName(tt.args.query)
 			assert.Equal(t, tt.wantName, got)
 		})
 	}
@@ -127,6 +129,26 @@ func TestExtractOperation(t *testing.T) {
 			args:          args{query: "SELECT\t* FROM users"},
 			wantOperation: "SELECT",
 		},
+		{
+			name:          "given multiple statements, then returns BATCH",
+			args:          args{query: "SELECT 1; UPDATE users SET name = 'x'"},
+			wantOperation: "BATCH",
+		},
+		{
+			name:          "given single statement with trailing semicolon, then returns operation",
+			args:          args{query: "SELECT id FROM users;"},
+			wantOperation: "SELECT",
+		},
+		{
+			name:          "given semicolon inside string literal, then returns operation",
+			args:          args{query: "UPDATE users SET name = 'a;b' WHERE id = 1"},
+			wantOperation: "UPDATE",
+		},
+		{
+			name:          "given semicolon inside comment, then returns operation",
+			args:          args{query: "SELECT id FROM users -- first; second"},
+			wantOperation: "SELECT",
+		},
 	}
 
 	for _, tt := range tests {
@@ -137,6 +159,45 @@ func TestExtractOperation(t *testing.T) {
 	}
 }
 
+func TestStatementOperations(t *testing.T) {
+	type args struct {
+		query string
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want []string
+	}{
+		{
+			name: "given two statements, then returns both operations",
+			args: args{query: "SELECT 1; update users SET name = 'x'"},
+			want: []string{"SELECT", "UPDATE"},
+		},
+		{
+			name: "given semicolons in quotes, then does not split on them",
+			args: args{query: `SELECT 'a;''b'; SELECT "c;d"; INSERT INTO t VALUES ('e\';f')`},
+			want: []string{"SELECT", "SELECT", "INSERT"},
+		},
+		{
+			name: "given semicolons in comments, then ignores the comments",
+			args: args{query: "/* a; b */ SELECT 1; -- c; d\nDELETE FROM users; /* e; */"},
+			want: []string{"SELECT", "DELETE"},
+		},
+		{
+			name: "given empty statements, then skips them",
+			args: args{query: ";; SELECT 1;;"},
+			want: []string{"SELECT"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, statementOperations(tt.args.query))
+		})
+	}
+}
+
 func TestSqlxSpanName(t *testing.T) {
 	type args struct {
 		method string
@@ -177,12 +238,93 @@ func TestSqlxSpanName(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := sqlxSpanName(tt.args.method, tt.args.query)
+			got := (&config{}).sqlxSpanName(tt.args.method, tt.args.query)
 			assert.Equal(t, tt.wantName, got)
 		})
 	}
 }
 
+func TestDefaultOperationExtractor(t *testing.T) {
+	tests := []struct {
+		name          string
+		query         string
+		wantOperation string
+		wantTarget    string
+	}{
+		{
+			name:          "given SELECT, then returns table after FROM",
+			query:         "SELECT id, name FROM users WHERE id = $1",
+			wantOperation: "SELECT",
+			wantTarget:    "users",
+		},
+		{
+			name:          "given function call before FROM, then still finds table",
+			query:         "select count(*) from public.orders",
+			wantOperation: "SELECT",
+			wantTarget:    "public.orders",
+		},
+		{
+			name:          "given INSERT with column list, then returns table after INTO",
+			query:         "INSERT INTO users(name, email) VALUES ($1, $2)",
+			wantOperation: "INSERT",
+			wantTarget:    "users",
+		},
+		{
+			name:          "given UPDATE with quoted table, then returns unquoted table",
+			query:         `UPDATE "users" SET name = $1`,
+			wantOperation: "UPDATE",
+			wantTarget:    "users",
+		},
+		{
+			name:          "given DELETE, then returns table after FROM",
+			query:         "DELETE FROM sessions WHERE expires_at < now()",
+			wantOperation: "DELETE",
+			wantTarget:    "sessions",
+		},
+		{
+			name:          "given subquery in FROM, then returns no target",
+			query:         "SELECT * FROM (SELECT id FROM users) u",
+			wantOperation: "SELECT",
+		},
+		{
+			name:          "given subquery in select list, then returns outer table",
+			query:         "SELECT (SELECT max(id) FROM orders) FROM users",
+			wantOperation: "SELECT",
+			wantTarget:    "users",
+		},
+		{
+			name:          "given CTE, then returns no target",
+			query:         "WITH recent AS (SELECT * FROM orders) SELECT * FROM recent",
+			wantOperation: "WITH",
+		},
+		{
+			name:          "given SELECT without FROM, then returns no target",
+			query:         "SELECT 1",
+			wantOperation: "SELECT",
+		},
+		{
+			name: "given empty query, then returns nothing",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			operation, target := DefaultOperationExtractor(tt.query)
+			assert.Equal(t, tt.wantOperation, operation)
+			assert.Equal(t, tt.wantTarget, target)
+		})
+	}
+}
+
+func TestConfig_SpanName_OperationExtractor(t *testing.T) {
+	cfg := &config{OperationExtractor: DefaultOperationExtractor}
+
+	assert.Equal(t, "SELECT users", cfg.spanName("SELECT * FROM users"))
+	assert.Equal(t, "SELECT", cfg.spanName("SELECT 1"))
+	assert.Equal(t, "SQL", cfg.spanName(""))
+	assert.Equal(t, "sqlx.Get: SELECT users", cfg.sqlxSpanName("sqlx.Get", "SELECT * FROM users"))
+}
+
 func TestDefaultQuerySanitizer(t *testing.T) {
 	type args struct {
 		query string
@@ -243,6 +385,56 @@ func TestDefaultQuerySanitizer(t *testing.T) {
 	}
 }
 
+func TestNumericPreservingSanitizer(t *testing.T) {
+	type args struct {
+		query string
+	}
+
+	tests := []struct {
+		name      string
+		args      args
+		wantQuery string
+	}{
+		{
+			name:      "given mixed literals, then masks strings and keeps numbers",
+			args:      args{query: "SELECT * FROM users WHERE id = 123 AND name = 'john'"},
+			wantQuery: "SELECT * FROM users WHERE id = 123 AND name = '?'",
+		},
+		{
+			name:      "given float literal, then keeps it",
+			args:      args{query: "UPDATE accounts SET balance = 45.67 WHERE id = 7"},
+			wantQuery: "UPDATE accounts SET balance = 45.67 WHERE id = 7",
+		},
+		{
+			name:      "given hex literal, then keeps it",
+			args:      args{query: "SELECT * FROM blobs WHERE hash = 0xDEADBEEF"},
+			wantQuery: "SELECT * FROM blobs WHERE hash = 0xDEADBEEF",
+		},
+		{
+			name:      "given escaped quotes, then masks the whole string",
+			args:      args{query: `INSERT INTO notes (id, body) VALUES (1, 'it\'s 42')`},
+			wantQuery: "INSERT INTO notes (id, body) VALUES (1, '?')",
+		},
+		{
+			name:      "given digits inside a string, then masks them with the string",
+			args:      args{query: "SELECT * FROM users WHERE phone = '555-0100' AND age > 30"},
+			wantQuery: "SELECT * FROM users WHERE phone = '?' AND age > 30",
+		},
+		{
+			name:      "given no literals, then returns query unchanged",
+			args:      args{query: "SELECT * FROM users WHERE id = $1"},
+			wantQuery: "SELECT * FROM users WHERE id = $1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NumericPreservingSanitizer(tt.args.query)
+			assert.Equal(t, tt.wantQuery, got)
+		})
+	}
+}
+
 func TestBaseAttributes(t *testing.T) {
 	type args struct {
 		cfg *config
@@ -309,6 +501,7 @@ func TestQueryAttributes(t *testing.T) {
 	type args struct {
 		cfg   *config
 		query string
+		tags  map[string]string
 	}
 
 	tests := []struct {
@@ -341,6 +534,28 @@ func TestQueryAttributes(t *testing.T) {
 				"db.operation": "SELECT",
 			},
 		},
+		{
+			name: "given operation extractor, then includes target table",
+			args: args{
+				cfg:   &config{OperationExtractor: DefaultOperationExtractor},
+				query: "UPDATE users SET name = $1",
+			},
+			wantContains: map[string]string{
+				"db.operation": "UPDATE",
+				"db.sql.table": "users",
+			},
+		},
+		{
+			name: "given multi-statement query, then lists statement operations",
+			args: args{
+				cfg:   &config{},
+				query: "SELECT 1; UPDATE users SET name = $1",
+			},
+			wantContains: map[string]string{
+				"db.operation":  "BATCH",
+				"db.operations": "SELECT;UPDATE",
+			},
+		},
 		{
 			name: "given config with DisableQuery, then omits statement",
 			args: args{
@@ -363,11 +578,48 @@ func TestQueryAttributes(t *testing.T) {
 			},
 			wantMissing: []string{"db.statement", "db.operation"},
 		},
+		{
+			name: "given context tags, then includes them",
+			args: args{
+				cfg:   &config{DBSystem: "postgresql"},
+				query: "SELECT * FROM users",
+				tags:  map[string]string{"tenant.id": "acme", "request.id": "req-1"},
+			},
+			wantContains: map[string]string{
+				"tenant.id":  "acme",
+				"request.id": "req-1",
+			},
+		},
+		{
+			name: "given context tags with allowlist, then includes only allowed keys",
+			args: args{
+				cfg:   &config{DBSystem: "postgresql", ContextTags: []string{"tenant.id"}},
+				query: "SELECT * FROM users",
+				tags:  map[string]string{"tenant.id": "acme", "user.id": "42"},
+			},
+			wantContains: map[string]string{
+				"tenant.id": "acme",
+			},
+			wantMissing: []string{"user.id"},
+		},
+		{
+			name: "given empty allowlist, then includes no tags",
+			args: args{
+				cfg:   &config{DBSystem: "postgresql", ContextTags: []string{}},
+				query: "SELECT * FROM users",
+				tags:  map[string]string{"tenant.id": "acme"},
+			},
+			wantMissing: []string{"tenant.id"},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			attrs := tt.args.cfg.queryAttributes(tt.args.query)
+			ctx := context.Background()
+			if tt.args.tags != nil {
+				ctx = ContextWithTags(ctx, tt.args.tags)
+			}
+			attrs := tt.args.cfg.queryAttributes(ctx, tt.args.query)
 
 			attrMap := make(map[string]string)
 			for _, attr := range attrs {
@@ -463,3 +715,53 @@ func TestNewConfig(t *testing.T) {
 		})
 	}
 }
+
+type roleKey struct{}
+
+func TestConfig_SpanAttributesFn(t *testing.T) {
+	roleAttributes := func(ctx context.Context) []attribute.KeyValue {
+		role, ok := ctx.Value(roleKey{}).(string)
+		if !ok {
+			return nil
+		}
+		return []attribute.KeyValue{attribute.String("user.role", role)}
+	}
+
+	tests := []struct {
+		name     string
+		cfg      *config
+		ctx      context.Context
+		wantRole string
+	}{
+		{
+			name:     "given fn and context value, then adds attribute to spans and metrics",
+			cfg:      &config{DBSystem: "postgresql", SpanAttributesFn: roleAttributes},
+			ctx:      context.WithValue(context.Background(), roleKey{}, "admin"),
+			wantRole: "admin",
+		},
+		{
+			name: "given fn without context value, then adds nothing",
+			cfg:  &config{DBSystem: "postgresql", SpanAttributesFn: roleAttributes},
+			ctx:  context.Background(),
+		},
+		{
+			name: "given no fn, then adds nothing",
+			cfg:  &config{DBSystem: "postgresql"},
+			ctx:  context.WithValue(context.Background(), roleKey{}, "admin"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, attrs := range [][]attribute.KeyValue{
+				tt.cfg.queryAttributes(tt.ctx, "SELECT 1"),
+				tt.cfg.metricAttributes(tt.ctx),
+			} {
+				set := attribute.NewSet(attrs...)
+				role, ok := set.Value("user.role")
+				assert.Equal(t, tt.wantRole != "", ok)
+				assert.Equal(t, tt.wantRole, role.AsString())
+			}
+		})
+	}
+}
@@ -6,8 +6,7 @@ import (
 	"time"
 
 	"github.com/jmoiron/sqlx"
-
-	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -15,6 +14,16 @@ import (
 type Tx struct {
 	*sqlx.Tx
 	cfg *config
+
+	// savepoints counts savepoints created by RunNested, used to name them.
+	savepoints int
+
+	// begin is the span context of the BEGIN span. Every span of the
+	// transaction links to it.
+	begin trace.SpanContext
+
+	// started is when BEGIN was issued, for db.client.tx.duration.
+	started time.Time
 }
 
 // GetContext executes a query that returns at most one row and scans into dest.
@@ -27,25 +36,27 @@ func (tx *Tx) GetContext(
 	start := time.Now()
 	operation := extractOperation(query)
 
-	ctx, span := tx.cfg.Tracer.Start(ctx, sqlxSpanName("sqlx.Tx.Get", query),
-		trace.WithSpanKind(trace.SpanKindClient),
-		trace.WithAttributes(tx.cfg.queryAttributes(query)...),
+	ctx, span := tx.cfg.Tracer.Start(ctx, tx.cfg.sqlxSpanName("sqlx.Tx.Get", query),
+		trace.WithSpanKind(tx.cfg.SpanKind),
+		trace.WithAttributes(tx.cfg.queryAttributes(ctx, query)...),
+		trace.WithAttributes(txAttributes(tx.begin)...),
+		trace.WithLinks(txLinks(tx.begin)...),
 	)
 	defer span.End()
+	tx.cfg.recordArgs(span, args)
 
-	err := tx.Tx.GetContext(ctx, dest, query, args...)
+	ctx, cancel, timeout := tx.cfg.withQueryTimeout(ctx)
+	defer cancel()
 
-	tx.cfg.Metrics.recordQueryDuration(
-		ctx,
-		time.Since(start),
-		operation,
-		tx.cfg.baseAttributes(),
-		err,
-	)
+	err := tx.Tx.GetContext(ctx, dest, tx.cfg.comment(ctx, query), args...)
+	err = tx.cfg.translateNotFound(err)
+
+	tx.cfg.recordQuery(ctx, time.Since(start), query, operation, err)
+	tx.cfg.recordGetRows(ctx, span, operation, err)
 
 	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, err.Error())
+		recordError(span, err)
+		tx.cfg.recordTimeout(ctx, span, timeout, operation, err)
 	}
 
 	return err
@@ -61,25 +72,26 @@ func (tx *Tx) SelectContext(
 	start := time.Now()
 	operation := extractOperation(query)
 
-	ctx, span := tx.cfg.Tracer.Start(ctx, sqlxSpanName("sqlx.Tx.Select", query),
-		trace.WithSpanKind(trace.SpanKindClient),
-		trace.WithAttributes(tx.cfg.queryAttributes(query)...),
+	ctx, span := tx.cfg.Tracer.Start(ctx, tx.cfg.sqlxSpanName("sqlx.Tx.Select", query),
+		trace.WithSpanKind(tx.cfg.SpanKind),
+		trace.WithAttributes(tx.cfg.queryAttributes(ctx, query)...),
+		trace.WithAttributes(txAttributes(tx.begin)...),
+		trace.WithLinks(txLinks(tx.begin)...),
 	)
 	defer span.End()
+	tx.cfg.recordArgs(span, args)
 
-	err := tx.Tx.SelectContext(ctx, dest, query, args...)
+	ctx, cancel, timeout := tx.cfg.withQueryTimeout(ctx)
+	defer cancel()
 
-	tx.cfg.Metrics.recordQueryDuration(
-		ctx,
-		time.Since(start),
-		operation,
-		tx.cfg.baseAttributes(),
-		err,
-	)
+	err := tx.Tx.SelectContext(ctx, dest, tx.cfg.comment(ctx, query), args...)
+
+	tx.cfg.recordQuery(ctx, time.Since(start), query, operation, err)
+	tx.cfg.recordSelectRows(ctx, span, operation, dest, err)
 
 	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, err.Error())
+		recordError(span, err)
+		tx.cfg.recordTimeout(ctx, span, timeout, operation, err)
 	}
 
 	return err
@@ -94,25 +106,20 @@ func (tx *Tx) NamedExecContext(
 	start := time.Now()
 	operation := extractOperation(query)
 
-	ctx, span := tx.cfg.Tracer.Start(ctx, sqlxSpanName("sqlx.Tx.NamedExec", query),
-		trace.WithSpanKind(trace.SpanKindClient),
-		trace.WithAttributes(tx.cfg.queryAttributes(query)...),
+	ctx, span := tx.cfg.Tracer.Start(ctx, tx.cfg.sqlxSpanName("sqlx.Tx.NamedExec", query),
+		trace.WithSpanKind(tx.cfg.SpanKind),
+		trace.WithAttributes(tx.cfg.queryAttributes(ctx, query)...),
+		trace.WithAttributes(txAttributes(tx.begin)...),
+		trace.WithLinks(txLinks(tx.begin)...),
 	)
 	defer span.End()
 
-	result, err := tx.Tx.NamedExecContext(ctx, query, arg)
+	result, err := tx.Tx.NamedExecContext(ctx, tx.cfg.comment(ctx, query), arg)
 
-	tx.cfg.Metrics.recordQueryDuration(
-		ctx,
-		time.Since(start),
-		operation,
-		tx.cfg.baseAttributes(),
-		err,
-	)
+	tx.cfg.recordExec(ctx, span, time.Since(start), query, operation, result, err)
 
 	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, err.Error())
+		recordError(span, err)
 	}
 
 	return result, err
@@ -124,25 +131,20 @@ func (tx *Tx) NamedQuery(query string, arg interface{}) (*sqlx.Rows, error) {
 	ctx := context.Background()
 	operation := extractOperation(query)
 
-	ctx, span := tx.cfg.Tracer.Start(ctx, sqlxSpanName("sqlx.Tx.NamedQuery", query),
-		trace.WithSpanKind(trace.SpanKindClient),
-		trace.WithAttributes(tx.cfg.queryAttributes(query)...),
+	ctx, span := tx.cfg.Tracer.Start(ctx, tx.cfg.sqlxSpanName("sqlx.Tx.NamedQuery", query),
+		trace.WithSpanKind(tx.cfg.SpanKind),
+		trace.WithAttributes(tx.cfg.queryAttributes(ctx, query)...),
+		trace.WithAttributes(txAttributes(tx.begin)...),
+		trace.WithLinks(txLinks(tx.begin)...),
 	)
 	defer span.End()
 
-	rows, err := tx.Tx.NamedQuery(query, arg)
+	rows, err := tx.Tx.NamedQuery(tx.cfg.comment(ctx, query), arg)
 
-	tx.cfg.Metrics.recordQueryDuration(
-		ctx,
-		time.Since(start),
-		operation,
-		tx.cfg.baseAttributes(),
-		err,
-	)
+	tx.cfg.recordQuery(ctx, time.Since(start), query, operation, err)
 
 	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, err.Error())
+		recordError(span, err)
 	}
 
 	return rows, err
@@ -157,54 +159,97 @@ func (tx *Tx) QueryxContext(
 	start := time.Now()
 	operation := extractOperation(query)
 
-	ctx, span := tx.cfg.Tracer.Start(ctx, sqlxSpanName("sqlx.Tx.Queryx", query),
-		trace.WithSpanKind(trace.SpanKindClient),
-		trace.WithAttributes(tx.cfg.queryAttributes(query)...),
+	ctx, span := tx.cfg.Tracer.Start(ctx, tx.cfg.sqlxSpanName("sqlx.Tx.Queryx", query),
+		trace.WithSpanKind(tx.cfg.SpanKind),
+		trace.WithAttributes(tx.cfg.queryAttributes(ctx, query)...),
+		trace.WithAttributes(txAttributes(tx.begin)...),
+		trace.WithLinks(txLinks(tx.begin)...),
 	)
 	defer span.End()
+	tx.cfg.recordArgs(span, args)
 
-	rows, err := tx.Tx.QueryxContext(ctx, query, args...)
+	rows, err := tx.Tx.QueryxContext(ctx, tx.cfg.comment(ctx, query), args...)
 
-	tx.cfg.Metrics.recordQueryDuration(
-		ctx,
-		time.Since(start),
-		operation,
-		tx.cfg.baseAttributes(),
-		err,
-	)
+	tx.cfg.recordQuery(ctx, time.Since(start), query, operation, err)
 
 	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, err.Error())
+		recordError(span, err)
 	}
 
 	return rows, err
 }
 
+// TracedQueryxContext is like QueryxContext, but its span stays open until
+// the returned Rows are read or closed. See DB.TracedQueryxContext.
+func (tx *Tx) TracedQueryxContext(
+	ctx context.Context,
+	query string,
+	args ...interface{},
+) (*Rows, error) {
+	start := time.Now()
+	operation := extractOperation(query)
+
+	ctx, span := tx.cfg.Tracer.Start(ctx, tx.cfg.sqlxSpanName("sqlx.Tx.Queryx", query),
+		trace.WithSpanKind(tx.cfg.SpanKind),
+		trace.WithAttributes(tx.cfg.queryAttributes(ctx, query)...),
+		trace.WithAttributes(txAttributes(tx.begin)...),
+		trace.WithLinks(txLinks(tx.begin)...),
+	)
+	tx.cfg.recordArgs(span, args)
+
+	rows, err := tx.Tx.QueryxContext(ctx, tx.cfg.comment(ctx, query), args...)
+
+	tx.cfg.recordQuery(ctx, time.Since(start), query, operation, err)
+
+	return tx.cfg.traceRows(ctx, span, operation, rows, err)
+}
+
 // QueryRowxContext executes a query and returns a single sqlx.Row.
 func (tx *Tx) QueryRowxContext(ctx context.Context, query string, args ...interface{}) *sqlx.Row {
 	start := time.Now()
 	operation := extractOperation(query)
 
-	ctx, span := tx.cfg.Tracer.Start(ctx, sqlxSpanName("sqlx.Tx.QueryRowx", query),
-		trace.WithSpanKind(trace.SpanKindClient),
-		trace.WithAttributes(tx.cfg.queryAttributes(query)...),
+	ctx, span := tx.cfg.Tracer.Start(ctx, tx.cfg.sqlxSpanName("sqlx.Tx.QueryRowx", query),
+		trace.WithSpanKind(tx.cfg.SpanKind),
+		trace.WithAttributes(tx.cfg.queryAttributes(ctx, query)...),
+		trace.WithAttributes(txAttributes(tx.begin)...),
+		trace.WithLinks(txLinks(tx.begin)...),
 	)
 	defer span.End()
+	tx.cfg.recordArgs(span, args)
 
-	row := tx.Tx.QueryRowxContext(ctx, query, args...)
+	row := tx.Tx.QueryRowxContext(ctx, tx.cfg.comment(ctx, query), args...)
 
-	tx.cfg.Metrics.recordQueryDuration(
-		ctx,
-		time.Since(start),
-		operation,
-		tx.cfg.baseAttributes(),
-		nil,
-	)
+	tx.cfg.recordQuery(ctx, time.Since(start), query, operation, nil)
 
 	return row
 }
 
+// InContext expands slice arguments for an IN clause with sqlx.In, rebinds
+// the query for the driver, and executes it through QueryxContext, so the
+// whole call is traced as a single instrumented query.
+//
+// The recorded db.statement is the expanded, rebound query.
+//
+// Example:
+//
+//	rows, err := tx.InContext(ctx,
+//	    "SELECT id, name FROM users WHERE id IN (?) AND active = ?",
+//	    []int{1, 2, 3}, true,
+//	)
+func (tx *Tx) InContext(
+	ctx context.Context,
+	query string,
+	args ...interface{},
+) (*sqlx.Rows, error) {
+	expanded, expandedArgs, err := sqlx.In(query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return tx.QueryxContext(ctx, tx.Rebind(expanded), expandedArgs...)
+}
+
 // ExecContext executes a query without returning rows.
 func (tx *Tx) ExecContext(
 	ctx context.Context,
@@ -214,25 +259,25 @@ func (tx *Tx) ExecContext(
 	start := time.Now()
 	operation := extractOperation(query)
 
-	ctx, span := tx.cfg.Tracer.Start(ctx, spanName(query),
-		trace.WithSpanKind(trace.SpanKindClient),
-		trace.WithAttributes(tx.cfg.queryAttributes(query)...),
+	ctx, span := tx.cfg.Tracer.Start(ctx, tx.cfg.spanName(query),
+		trace.WithSpanKind(tx.cfg.SpanKind),
+		trace.WithAttributes(tx.cfg.queryAttributes(ctx, query)...),
+		trace.WithAttributes(txAttributes(tx.begin)...),
+		trace.WithLinks(txLinks(tx.begin)...),
 	)
 	defer span.End()
+	tx.cfg.recordArgs(span, args)
 
-	result, err := tx.Tx.ExecContext(ctx, query, args...)
+	ctx, cancel, timeout := tx.cfg.withQueryTimeout(ctx)
+	defer cancel()
 
-	tx.cfg.Metrics.recordQueryDuration(
-		ctx,
-		time.Since(start),
-		operation,
-		tx.cfg.baseAttributes(),
-		err,
-	)
+	result, err := tx.Tx.ExecContext(ctx, tx.cfg.comment(ctx, query), args...)
+
+	tx.cfg.recordExec(ctx, span, time.Since(start), query, operation, result, err)
 
 	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, err.Error())
+		recordError(span, err)
+		tx.cfg.recordTimeout(ctx, span, timeout, operation, err)
 	}
 
 	return result, err
@@ -247,28 +292,32 @@ func (tx *Tx) QueryContext(
 	start := time.Now()
 	operation := extractOperation(query)
 
-	ctx, span := tx.cfg.Tracer.Start(ctx, spanName(query),
-		trace.WithSpanKind(trace.SpanKindClient),
-		trace.WithAttributes(tx.cfg.queryAttributes(query)...),
+	ctx, span := tx.cfg.Tracer.Start(ctx, tx.cfg.spanName(query),
+		trace.WithSpanKind(tx.cfg.SpanKind),
+		trace.WithAttributes(tx.cfg.queryAttributes(ctx, query)...),
+		trace.WithAttributes(txAttributes(tx.begin)...),
+		trace.WithLinks(txLinks(tx.begin)...),
 	)
 	defer span.End()
+	tx.cfg.recordArgs(span, args)
 
-	rows, err := tx.Tx.QueryContext(ctx, query, args...)
+	// The timeout also bounds reading the rows, so on success it is
+	// released with them.
+	ctx, cancel, timeout := tx.cfg.withQueryTimeout(ctx)
 
-	tx.cfg.Metrics.recordQueryDuration(
-		ctx,
-		time.Since(start),
-		operation,
-		tx.cfg.baseAttributes(),
-		err,
-	)
+	rows, err := tx.Tx.QueryContext(ctx, tx.cfg.comment(ctx, query), args...)
+
+	tx.cfg.recordQuery(ctx, time.Since(start), query, operation, err)
 
 	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, err.Error())
+		recordError(span, err)
+		tx.cfg.recordTimeout(ctx, span, timeout, operation, err)
+		cancel()
+		return nil, err
 	}
 
-	return rows, err
+	releaseWithRows(rows, cancel, timeout)
+	return rows, nil
 }
 
 // QueryRowContext executes a query and returns a single row.
@@ -276,32 +325,92 @@ func (tx *Tx) QueryRowContext(ctx context.Context, query string, args ...interfa
 	start := time.Now()
 	operation := extractOperation(query)
 
-	ctx, span := tx.cfg.Tracer.Start(ctx, spanName(query),
-		trace.WithSpanKind(trace.SpanKindClient),
-		trace.WithAttributes(tx.cfg.queryAttributes(query)...),
+	ctx, span := tx.cfg.Tracer.Start(ctx, tx.cfg.spanName(query),
+		trace.WithSpanKind(tx.cfg.SpanKind),
+		trace.WithAttributes(tx.cfg.queryAttributes(ctx, query)...),
+		trace.WithAttributes(txAttributes(tx.begin)...),
+		trace.WithLinks(txLinks(tx.begin)...),
 	)
 	defer span.End()
+	tx.cfg.recordArgs(span, args)
 
-	row := tx.Tx.QueryRowContext(ctx, query, args...)
+	row := tx.Tx.QueryRowContext(ctx, tx.cfg.comment(ctx, query), args...)
 
-	tx.cfg.Metrics.recordQueryDuration(
-		ctx,
-		time.Since(start),
-		operation,
-		tx.cfg.baseAttributes(),
-		nil,
-	)
+	tx.cfg.recordQuery(ctx, time.Since(start), query, operation, nil)
 
 	return row
 }
 
+// MustExecContext executes a query and panics on error.
+//
+// It panics on error, so use it only in init paths such as startup and
+// migration code, where failing fast is desired. The query is instrumented
+// like ExecContext.
+//
+// Example:
+//
+//	tx.MustExecContext(ctx, "CREATE TABLE IF NOT EXISTS users (id BIGINT PRIMARY KEY)")
+func (tx *Tx) MustExecContext(
+	ctx context.Context,
+	query string,
+	args ...interface{},
+) sql.Result {
+	result, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// MustNamedExecContext executes a named query and panics on error.
+// Use it only in init paths; see MustExecContext.
+func (tx *Tx) MustNamedExecContext(
+	ctx context.Context,
+	query string,
+	arg interface{},
+) sql.Result {
+	result, err := tx.NamedExecContext(ctx, query, arg)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// MustGetContext gets a single row into dest and panics on error.
+// Use it only in init paths; see MustExecContext.
+func (tx *Tx) MustGetContext(
+	ctx context.Context,
+	dest interface{},
+	query string,
+	args ...interface{},
+) {
+	if err := tx.GetContext(ctx, dest, query, args...); err != nil {
+		panic(err)
+	}
+}
+
+// MustSelectContext selects rows into dest and panics on error.
+// Use it only in init paths; see MustExecContext.
+func (tx *Tx) MustSelectContext(
+	ctx context.Context,
+	dest interface{},
+	query string,
+	args ...interface{},
+) {
+	if err := tx.SelectContext(ctx, dest, query, args...); err != nil {
+		panic(err)
+	}
+}
+
 // PrepareNamedContext prepares a named statement within the transaction.
 func (tx *Tx) PrepareNamedContext(ctx context.Context, query string) (*NamedStmt, error) {
 	start := time.Now()
 
 	ctx, span := tx.cfg.Tracer.Start(ctx, "sqlx.Tx.PrepareNamed",
-		trace.WithSpanKind(trace.SpanKindClient),
-		trace.WithAttributes(tx.cfg.queryAttributes(query)...),
+		trace.WithSpanKind(tx.cfg.SpanKind),
+		trace.WithAttributes(tx.cfg.queryAttributes(ctx, query)...),
+		trace.WithAttributes(txAttributes(tx.begin)...),
+		trace.WithLinks(txLinks(tx.begin)...),
 	)
 	defer span.End()
 
@@ -316,8 +425,7 @@ func (tx *Tx) PrepareNamedContext(ctx context.Context, query string) (*NamedStmt
 	)
 
 	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, err.Error())
+		recordError(span, err)
 		return nil, err
 	}
 
@@ -334,8 +442,10 @@ func (tx *Tx) PreparexContext(ctx context.Context, query string) (*Stmt, error)
 	start := time.Now()
 
 	ctx, span := tx.cfg.Tracer.Start(ctx, "sqlx.Tx.Preparex",
-		trace.WithSpanKind(trace.SpanKindClient),
-		trace.WithAttributes(tx.cfg.queryAttributes(query)...),
+		trace.WithSpanKind(tx.cfg.SpanKind),
+		trace.WithAttributes(tx.cfg.queryAttributes(ctx, query)...),
+		trace.WithAttributes(txAttributes(tx.begin)...),
+		trace.WithLinks(txLinks(tx.begin)...),
 	)
 	defer span.End()
 
@@ -350,8 +460,7 @@ func (tx *Tx) PreparexContext(ctx context.Context, query string) (*Stmt, error)
 	)
 
 	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, err.Error())
+		recordError(span, err)
 		return nil, err
 	}
 
@@ -397,8 +506,10 @@ func (tx *Tx) Commit() error {
 	ctx := context.Background()
 
 	ctx, span := tx.cfg.Tracer.Start(ctx, "COMMIT",
-		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithSpanKind(tx.cfg.SpanKind),
 		trace.WithAttributes(tx.cfg.baseAttributes()...),
+		trace.WithAttributes(txAttributes(tx.begin)...),
+		trace.WithLinks(txLinks(tx.begin)...),
 	)
 	defer span.End()
 
@@ -411,10 +522,10 @@ func (tx *Tx) Commit() error {
 		tx.cfg.baseAttributes(),
 		err,
 	)
+	tx.cfg.Metrics.recordTx(ctx, time.Since(tx.started), "commit", tx.cfg.baseAttributes(), err)
 
 	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, err.Error())
+		recordError(span, err)
 	}
 
 	return err
@@ -426,8 +537,10 @@ func (tx *Tx) Rollback() error {
 	ctx := context.Background()
 
 	ctx, span := tx.cfg.Tracer.Start(ctx, "ROLLBACK",
-		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithSpanKind(tx.cfg.SpanKind),
 		trace.WithAttributes(tx.cfg.baseAttributes()...),
+		trace.WithAttributes(txAttributes(tx.begin)...),
+		trace.WithLinks(txLinks(tx.begin)...),
 	)
 	defer span.End()
 
@@ -440,10 +553,10 @@ func (tx *Tx) Rollback() error {
 		tx.cfg.baseAttributes(),
 		err,
 	)
+	tx.cfg.Metrics.recordTx(ctx, time.Since(tx.started), "rollback", tx.cfg.baseAttributes(), err)
 
 	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, err.Error())
+		recordError(span, err)
 	}
 
 	return err
@@ -467,7 +580,28 @@ func (tx *Tx) DriverName() string {
 // Unsafe returns a version of Tx that silently ignores missing destination fields.
 func (tx *Tx) Unsafe() *Tx {
 	return &Tx{
-		Tx:  tx.Tx.Unsafe(),
-		cfg: tx.cfg,
+		Tx:      tx.Tx.Unsafe(),
+		cfg:     tx.cfg,
+		begin:   tx.begin,
+		started: tx.started,
+	}
+}
+
+// txLinks returns a link to begin, the span context of the BEGIN span of
+// the transaction a span belongs to, or nil outside a transaction.
+func txLinks(begin trace.SpanContext) []trace.Link {
+	if !begin.IsValid() {
+		return nil
+	}
+	return []trace.Link{{SpanContext: begin}}
+}
+
+// txAttributes returns the db.transaction.id attribute shared by every span
+// of the transaction whose BEGIN span has span context begin, or nil outside
+// a transaction. The ID is the span ID of the BEGIN span.
+func txAttributes(begin trace.SpanContext) []attribute.KeyValue {
+	if !begin.IsValid() {
+		return nil
 	}
+	return []attribute.KeyValue{attribute.String("db.transaction.id", begin.SpanID().String())}
 }
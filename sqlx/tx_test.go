@@ -8,6 +8,9 @@ import (
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 )
 
 func TestTx_GetContext(t *testing.T) {
@@ -400,3 +403,94 @@ func TestTx_Unsafe(t *testing.T) {
 	assert.Equal(t, tx.cfg, unsafeTx.cfg)
 	require.NoError(t, mock.ExpectationsWereMet())
 }
+
+func TestTx_InContext(t *testing.T) {
+	t.Run("given slice argument, then expands and rebinds within tx", func(t *testing.T) {
+		mockDB, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+		defer mockDB.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT id FROM users WHERE id IN ($1, $2)").
+			WithArgs(1, 2).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+
+		db := NewDB(mockDB, "postgres")
+		tx, err := db.BeginTxx(context.Background(), nil)
+		require.NoError(t, err)
+
+		rows, err := tx.InContext(
+			context.Background(),
+			"SELECT id FROM users WHERE id IN (?)",
+			[]int{1, 2},
+		)
+		require.NoError(t, err)
+		defer rows.Close()
+
+		var count int
+		for rows.Next() {
+			count++
+		}
+		assert.Equal(t, 2, count)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestTx_MustExecContext(t *testing.T) {
+	t.Run("given failing Exec within tx, then panics", func(t *testing.T) {
+		mockDB, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer mockDB.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec("ALTER TABLE users").WillReturnError(assert.AnError)
+
+		db := NewDB(mockDB, "postgres")
+		tx, err := db.BeginTxx(context.Background(), nil)
+		require.NoError(t, err)
+
+		assert.PanicsWithValue(t, assert.AnError, func() {
+			tx.MustExecContext(context.Background(), "ALTER TABLE users ADD COLUMN age INT")
+		})
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestTx_SpanLinks(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer mockDB.Close()
+
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	db := NewDB(mockDB, "postgres", WithTracerProvider(tp))
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE users").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("SELECT id FROM users").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectCommit()
+
+	ctx := context.Background()
+	tx, err := db.BeginTxx(ctx, nil)
+	require.NoError(t, err)
+	_, err = tx.ExecContext(ctx, "UPDATE users SET active = true")
+	require.NoError(t, err)
+	var id int
+	require.NoError(t, tx.GetContext(ctx, &id, "SELECT id FROM users"))
+	require.NoError(t, tx.Commit())
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 4)
+	begin := spans[0].SpanContext
+	assert.Equal(t, "BEGIN", spans[0].Name)
+	for _, span := range spans[1:] {
+		require.Len(t, span.Links, 1, span.Name)
+		assert.Equal(t, begin, span.Links[0].SpanContext)
+		set := attribute.NewSet(span.Attributes...)
+		id, ok := set.Value("db.transaction.id")
+		assert.True(t, ok, span.Name)
+		assert.Equal(t, begin.SpanID().String(), id.AsString())
+	}
+}